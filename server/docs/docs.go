@@ -23,6 +23,67 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/platform/reconcile": {
+            "post": {
+                "description": "Compare every job with its temporal schedule across all projects and repair the drift unless dry_run is set.",
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "Reconcile jobs and schedules",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "only report drift",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ReconcileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "409": {
+                        "description": "reconciliation is already in progress",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to reconcile schedules",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/platform/releases": {
             "get": {
                 "description": "Retrieve the latest platform release updates and metadata.",
@@ -72,6 +133,44 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/platform/worker-pools": {
+            "get": {
+                "description": "List the worker pools configured on the server with TEMPORAL_WORKER_POOLS, with the task queue of each and how many workers poll it. The default pool polls the default task queue. A job runs on the pool of its advanced settings, else on the pool of its source, else on the default pool.",
+                "tags": [
+                    "Platform"
+                ],
+                "summary": "List worker pools",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.WorkerPoolItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/project/{projectid}/check-unique": {
             "post": {
                 "description": "Verify if a given name is unique within the project for a specific entity type.",
@@ -149,13 +248,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/destinations": {
+        "/api/v1/project/{projectid}/concurrency": {
             "get": {
-                "description": "Retrieve a list of all configured destinations within a specific project.",
+                "description": "Show the sync and discover caps of a project and of its capped sources and destinations, how many syncs and discovers run and wait against each of them, and the run queue: the syncs waiting for a slot, the discovers waiting for one and the discovers holding one. A cap of 0 is no cap. Running syncs are read from temporal, clear-destination runs do not count.",
                 "tags": [
-                    "Destinations"
+                    "Concurrency"
                 ],
-                "summary": "List all destinations",
+                "summary": "Get concurrency usage",
                 "parameters": [
                     {
                         "type": "string",
@@ -177,10 +276,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.DestinationDataItem"
-                                            }
+                                            "$ref": "#/definitions/dto.ConcurrencyResponse"
                                         }
                                     }
                                 }
@@ -200,19 +296,19 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "failed to get destinations",
+                        "description": "failed to get concurrency usage",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Create a new destination for a project",
+            "put": {
+                "description": "Set the maximum concurrent syncs and discovers of the project, a source or a destination; 0 removes a cap.",
                 "tags": [
-                    "Destinations"
+                    "Concurrency"
                 ],
-                "summary": "Create a new destination",
+                "summary": "Set concurrency limits",
                 "parameters": [
                     {
                         "type": "string",
@@ -222,32 +318,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "destination data",
+                        "description": "concurrency limits",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateDestinationRequest"
+                            "$ref": "#/definitions/dto.ConcurrencyLimitRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "concurrency limits updated",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.JSONResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.CreateDestinationRequest"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dto.JSONResponse"
                         }
                     },
                     "400": {
@@ -262,14 +346,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
+                    "404": {
+                        "description": "source or destination not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
+                            "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
-                        "description": "failed to create destination",
+                        "description": "failed to set concurrency limits",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -277,13 +361,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/destinations/spec": {
-            "post": {
-                "description": "Retrieve the UI spec for a specific destination type/version.",
+        "/api/v1/project/{projectid}/concurrency/queue/{id}": {
+            "delete": {
+                "description": "Remove a sync from the run queue so it does not start. Discovers leave the queue with their request.",
                 "tags": [
-                    "Destinations"
+                    "Concurrency"
                 ],
-                "summary": "Get destination UI spec",
+                "summary": "Remove a queued sync",
                 "parameters": [
                     {
                         "type": "string",
@@ -293,32 +377,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "spec request data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SpecRequest"
-                        }
+                        "type": "integer",
+                        "description": "run queue entry id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "queued sync removed",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.JSONResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.SpecResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dto.JSONResponse"
                         }
                     },
                     "400": {
@@ -333,14 +403,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
+                    "404": {
+                        "description": "queued run not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
+                            "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
-                        "description": "failed to get spec",
+                        "description": "failed to remove queued sync",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -348,13 +418,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/destinations/test": {
-            "post": {
-                "description": "Validate the connection to a destination using the provided configuration details.",
+        "/api/v1/project/{projectid}/destinations": {
+            "get": {
+                "description": "Retrieve a page of configured destinations within a specific project. Results can be filtered, sorted and paged using an opaque cursor.",
                 "tags": [
                     "Destinations"
                 ],
-                "summary": "Test destination connection",
+                "summary": "List all destinations",
                 "parameters": [
                     {
                         "type": "string",
@@ -364,13 +434,52 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "test connection data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.DestinationTestConnectionRequest"
-                        }
+                        "type": "string",
+                        "description": "cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, all destinations are returned when omitted",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "destination name substring (case-insensitive)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "destination type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username of the creator",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "label selector, e.g. tier=critical,team!=growth",
+                        "name": "selector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort key: name, created_at, updated_at (default updated_at)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort order: asc, desc",
+                        "name": "sort_order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -385,7 +494,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.TestConnectionResponse"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/dto.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/dto.DestinationDataItem"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -404,22 +528,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
+                    "500": {
+                        "description": "failed to get destinations",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
+                            "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/project/{projectid}/destinations/versions": {
-            "get": {
-                "description": "Retrieve the list of available versions for a specific destination connector type.",
+            },
+            "post": {
+                "description": "Create a new destination for a project",
                 "tags": [
                     "Destinations"
                 ],
-                "summary": "Get available destination versions",
+                "summary": "Create a new destination",
                 "parameters": [
                     {
                         "type": "string",
@@ -429,11 +551,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "destination type",
-                        "name": "type",
-                        "in": "query",
-                        "required": true
+                        "description": "destination data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateDestinationRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -448,7 +572,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.VersionsResponse"
+                                            "$ref": "#/definitions/dto.CreateDestinationRequest"
                                         }
                                     }
                                 }
@@ -467,8 +591,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
                     "500": {
-                        "description": "failed to get versions",
+                        "description": "failed to create destination",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -476,13 +606,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/destinations/{id}": {
-            "get": {
-                "description": "Retrieve details of a specific destination identified by its unique ID.",
+        "/api/v1/project/{projectid}/destinations/spec": {
+            "post": {
+                "description": "Retrieve the UI spec for a specific destination type/version.",
                 "tags": [
                     "Destinations"
                 ],
-                "summary": "Get destination details",
+                "summary": "Get destination UI spec",
                 "parameters": [
                     {
                         "type": "string",
@@ -492,11 +622,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "destination id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "spec request data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpecRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -511,7 +643,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.DestinationDataItem"
+                                            "$ref": "#/definitions/dto.SpecResponse"
                                         }
                                     }
                                 }
@@ -530,26 +662,34 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "404": {
-                        "description": "destination not found",
+                    "413": {
+                        "description": "payload too large",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error404Response"
+                            "$ref": "#/definitions/dto.Error413Response"
                         }
                     },
                     "500": {
-                        "description": "failed to get destination",
+                        "description": "failed to get spec",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
+                    },
+                    "503": {
+                        "description": "no workers poll the task queue of the worker pool",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error503Response"
+                        }
                     }
                 }
-            },
-            "put": {
-                "description": "Update the configuration details of an existing destination.",
+            }
+        },
+        "/api/v1/project/{projectid}/destinations/test": {
+            "post": {
+                "description": "Validate the connection to a destination using the provided configuration details.",
                 "tags": [
                     "Destinations"
                 ],
-                "summary": "Update a destination",
+                "summary": "Test destination connection",
                 "parameters": [
                     {
                         "type": "string",
@@ -559,19 +699,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "destination id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "destination data",
+                        "description": "test connection data",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateDestinationRequest"
+                            "$ref": "#/definitions/dto.DestinationTestConnectionRequest"
                         }
                     }
                 ],
@@ -587,7 +720,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.UpdateDestinationRequest"
+                                            "$ref": "#/definitions/dto.TestConnectionResponse"
                                         }
                                     }
                                 }
@@ -606,32 +739,28 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "404": {
-                        "description": "destination not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error404Response"
-                        }
-                    },
                     "413": {
                         "description": "payload too large",
                         "schema": {
                             "$ref": "#/definitions/dto.Error413Response"
                         }
                     },
-                    "500": {
-                        "description": "failed to update destination",
+                    "503": {
+                        "description": "no workers poll the task queue of the worker pool",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error500Response"
+                            "$ref": "#/definitions/dto.Error503Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Permanently delete a specified destination.",
+            }
+        },
+        "/api/v1/project/{projectid}/destinations/versions": {
+            "get": {
+                "description": "Retrieve the list of available versions for a specific destination connector type.",
                 "tags": [
                     "Destinations"
                 ],
-                "summary": "Delete a destination",
+                "summary": "Get available destination versions",
                 "parameters": [
                     {
                         "type": "string",
@@ -641,10 +770,10 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "destination id",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "destination type",
+                        "name": "type",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -660,7 +789,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.DeleteDestinationResponse"
+                                            "$ref": "#/definitions/dto.VersionsResponse"
                                         }
                                     }
                                 }
@@ -679,14 +808,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "404": {
-                        "description": "destination not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error404Response"
-                        }
-                    },
                     "500": {
-                        "description": "failed to delete destination",
+                        "description": "failed to get versions",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -694,13 +817,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/jobs": {
+        "/api/v1/project/{projectid}/destinations/{id}": {
             "get": {
-                "description": "Retrieve a list of all jobs associated with a specific project.",
+                "description": "Retrieve details of a specific destination identified by its unique ID.",
                 "tags": [
-                    "Jobs"
+                    "Destinations"
                 ],
-                "summary": "List all jobs",
+                "summary": "Get destination details",
                 "parameters": [
                     {
                         "type": "string",
@@ -708,6 +831,13 @@ const docTemplate = `{
                         "name": "projectid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "destination id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -722,10 +852,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.JobResponse"
-                                            }
+                                            "$ref": "#/definitions/dto.DestinationDataItem"
                                         }
                                     }
                                 }
@@ -744,20 +871,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
+                    "404": {
+                        "description": "destination not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
                     "500": {
-                        "description": "failed to retrieve jobs",
+                        "description": "failed to get destination",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Create a new job within a specific project.",
+            "put": {
+                "description": "Update the configuration details of an existing destination.",
                 "tags": [
-                    "Jobs"
+                    "Destinations"
                 ],
-                "summary": "Create a new job",
+                "summary": "Update a destination",
                 "parameters": [
                     {
                         "type": "string",
@@ -767,20 +900,39 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "job data",
+                        "type": "integer",
+                        "description": "destination id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "destination data",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateJobRequest"
+                            "$ref": "#/definitions/dto.UpdateDestinationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "job created successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UpdateDestinationRequest"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -795,6 +947,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
+                    "404": {
+                        "description": "destination not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
                     "413": {
                         "description": "payload too large",
                         "schema": {
@@ -802,21 +960,19 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "failed to create job",
+                        "description": "failed to update destination",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/project/{projectid}/jobs/{id}": {
-            "get": {
-                "description": "Retrieve details of a specific job identified by its unique ID.",
+            },
+            "delete": {
+                "description": "Move a specified destination to the trash, where it can be restored until it is purged. A destination used by jobs cannot be deleted.",
                 "tags": [
-                    "Jobs"
+                    "Destinations"
                 ],
-                "summary": "Get job details",
+                "summary": "Delete a destination",
                 "parameters": [
                     {
                         "type": "string",
@@ -827,7 +983,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "job id",
+                        "description": "destination id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -845,7 +1001,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.JobResponse"
+                                            "$ref": "#/definitions/dto.DeleteDestinationResponse"
                                         }
                                     }
                                 }
@@ -865,25 +1021,27 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "job not found",
+                        "description": "destination not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
-                        "description": "failed to get job",
+                        "description": "failed to delete destination",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update the configuration details of an existing job.",
+            }
+        },
+        "/api/v1/project/{projectid}/destinations/{id}/labels": {
+            "get": {
+                "description": "Retrieve the key/value labels attached to a job, source or destination.",
                 "tags": [
-                    "Jobs"
+                    "Labels"
                 ],
-                "summary": "Update a job",
+                "summary": "Get labels",
                 "parameters": [
                     {
                         "type": "string",
@@ -894,26 +1052,29 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "job id",
+                        "description": "entity id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "job data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateJobRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "job updated successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LabelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -929,31 +1090,25 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "job not found",
+                        "description": "entity not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
-                        }
-                    },
                     "500": {
-                        "description": "failed to update job",
+                        "description": "failed to get labels",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Permanently delete a specified job.",
+            "put": {
+                "description": "Replace every label of a job, source or destination with the provided set.",
                 "tags": [
-                    "Jobs"
+                    "Labels"
                 ],
-                "summary": "Delete a job",
+                "summary": "Replace labels",
                 "parameters": [
                     {
                         "type": "string",
@@ -964,17 +1119,38 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "job id",
+                        "description": "entity id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "labels",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReplaceLabelsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "job deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LabelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -990,27 +1166,25 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "job not found",
+                        "description": "entity not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
-                        "description": "failed to delete job",
+                        "description": "failed to update labels",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/project/{projectid}/jobs/{id}/activate": {
-            "post": {
-                "description": "Pause or resume a job.",
+            },
+            "patch": {
+                "description": "Add or overwrite the labels in \"set\" and remove the keys in \"remove\", leaving other labels untouched.",
                 "tags": [
-                    "Jobs"
+                    "Labels"
                 ],
-                "summary": "Pause or resume job",
+                "summary": "Patch labels",
                 "parameters": [
                     {
                         "type": "string",
@@ -1021,30 +1195,42 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "job id",
+                        "description": "entity id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "activation data",
+                        "description": "label changes",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.JobStatusRequest"
+                            "$ref": "#/definitions/dto.PatchLabelsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "job activated/deactivated successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "failed to validate request",
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LabelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
                         "schema": {
                             "$ref": "#/definitions/dto.Error400Response"
                         }
@@ -1056,19 +1242,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "job not found",
+                        "description": "entity not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
-                        }
-                    },
                     "500": {
-                        "description": "failed to activate job",
+                        "description": "failed to update labels",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -1076,13 +1256,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/jobs/{id}/cancel": {
-            "get": {
-                "description": "Request cancellation of a currently running job execution.",
+        "/api/v1/project/{projectid}/destinations/{id}/labels/{key}": {
+            "delete": {
+                "description": "Remove a single label key from a job, source or destination.",
                 "tags": [
-                    "Jobs"
+                    "Labels"
                 ],
-                "summary": "Cancel running job",
+                "summary": "Delete a label",
                 "parameters": [
                     {
                         "type": "string",
@@ -1093,17 +1273,36 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "job id",
+                        "description": "entity id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "label key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "job cancel requested successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LabelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1119,13 +1318,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "job not found",
+                        "description": "entity or label not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
-                        "description": "failed to cancel job run",
+                        "description": "failed to delete label",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -1133,13 +1332,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/jobs/{id}/clear-destination": {
+        "/api/v1/project/{projectid}/jobs": {
             "get": {
-                "description": "Retrieve the current status of an ongoing clear destination job.",
+                "description": "Retrieve a page of jobs associated with a specific project. Results can be filtered, sorted and paged using an opaque cursor.",
                 "tags": [
                     "Jobs"
                 ],
-                "summary": "Get clear destination status",
+                "summary": "List all jobs",
                 "parameters": [
                     {
                         "type": "string",
@@ -1148,12 +1347,65 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "job id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "page size, all jobs are returned when omitted",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "job name substring (case-insensitive)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "source or destination type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "active state",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "status of the latest run (e.g. Completed, Failed, Running)",
+                        "name": "last_run_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username of the creator",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "label selector, e.g. tier=critical,team!=growth",
+                        "name": "selector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort key: name, created_at, updated_at (default updated_at)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort order: asc, desc",
+                        "name": "sort_order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1168,7 +1420,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.ClearDestinationStatusResponse"
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/dto.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/dto.JobResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
                                         }
                                     }
                                 }
@@ -1187,14 +1454,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "404": {
-                        "description": "job not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error404Response"
-                        }
-                    },
                     "500": {
-                        "description": "failed to get status",
+                        "description": "failed to retrieve jobs",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -1202,11 +1463,11 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Initiate job to clear data in the destination associated with a job.",
+                "description": "Create a new job within a specific project.",
                 "tags": [
                     "Jobs"
                 ],
-                "summary": "Clear destination data",
+                "summary": "Create a new job",
                 "parameters": [
                     {
                         "type": "string",
@@ -1216,16 +1477,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "job id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "job data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateJobRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "clear destination triggered successfully",
+                        "description": "job created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "job created, schedule change is pending",
                         "schema": {
                             "$ref": "#/definitions/dto.JSONResponse"
                         }
@@ -1242,28 +1511,34 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "404": {
-                        "description": "job not found",
+                    "413": {
+                        "description": "payload too large",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error404Response"
+                            "$ref": "#/definitions/dto.Error413Response"
                         }
                     },
                     "500": {
-                        "description": "failed to trigger clear destination",
+                        "description": "failed to create job",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
+                    },
+                    "503": {
+                        "description": "no workers poll the task queue of the worker pool",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error503Response"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/project/{projectid}/jobs/{id}/logs/download": {
-            "get": {
-                "description": "Downloads the log file for a specific task. The file path required for the download must be obtained from the [Get Job Tasks](#/Jobs/get_api_v1_project__projectid__jobs__id__tasks) endpoint.",
+        "/api/v1/project/{projectid}/jobs/backfill": {
+            "post": {
+                "description": "List the fire times that the schedules of the selected jobs missed in a time range and backfill them.",
                 "tags": [
                     "Jobs"
                 ],
-                "summary": "Download task logs",
+                "summary": "Backfill missed schedule runs",
                 "parameters": [
                     {
                         "type": "string",
@@ -1273,25 +1548,32 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "job id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "log file path",
-                        "name": "file_path",
-                        "in": "query",
-                        "required": true
+                        "description": "backfill",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BackfillRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "file"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.BackfillResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1306,14 +1588,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "404": {
-                        "description": "failed to prepare log archive",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error404Response"
-                        }
-                    },
                     "500": {
-                        "description": "internal server error",
+                        "description": "failed to backfill jobs",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -1321,13 +1597,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/jobs/{id}/stream-difference": {
+        "/api/v1/project/{projectid}/jobs/bulk": {
             "post": {
-                "description": "Get difference between current streams.json and existing streams.json.",
+                "description": "Apply pause, resume, trigger, cancel, change_frequency, change_version or delete to the jobs listed in job_ids or matched by filter. Each job reports its own result; change_version sets the version on the job's source and therefore applies to every job sharing that source. trigger fails for jobs in a maintenance window unless override_maintenance_window is set.",
                 "tags": [
                     "Jobs"
                 ],
-                "summary": "Get stream differences",
+                "summary": "Bulk job operation",
                 "parameters": [
                     {
                         "type": "string",
@@ -1337,19 +1613,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "job id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "stream difference data",
+                        "description": "bulk operation",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.StreamDifferenceRequest"
+                            "$ref": "#/definitions/dto.BulkJobRequest"
                         }
                     }
                 ],
@@ -1365,7 +1634,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.StreamDifferenceResponse"
+                                            "$ref": "#/definitions/dto.BulkJobResponse"
                                         }
                                     }
                                 }
@@ -1384,12 +1653,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "404": {
-                        "description": "job not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error404Response"
-                        }
-                    },
                     "413": {
                         "description": "payload too large",
                         "schema": {
@@ -1397,7 +1660,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "failed to get stream difference",
+                        "description": "failed to run bulk operation",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -1405,13 +1668,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/jobs/{id}/sync": {
-            "post": {
-                "description": "Trigger a manual sync for a job",
+        "/api/v1/project/{projectid}/jobs/calendar": {
+            "get": {
+                "description": "Get the scheduled runs of the active jobs of a project in the coming window, grouped by source, with the spans in which they overlap.",
                 "tags": [
                     "Jobs"
                 ],
-                "summary": "Trigger job sync",
+                "summary": "Get the project run calendar",
                 "parameters": [
                     {
                         "type": "string",
@@ -1421,18 +1684,29 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "job id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "how far ahead to look, e.g. 24h",
+                        "name": "window",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "sync triggered successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RunCalendarResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1447,14 +1721,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "404": {
-                        "description": "job not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error404Response"
-                        }
-                    },
                     "500": {
-                        "description": "failed to trigger sync",
+                        "description": "failed to get run calendar",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -1462,13 +1730,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/jobs/{id}/tasks": {
+        "/api/v1/project/{projectid}/jobs/dependencies": {
             "get": {
-                "description": "Retrieve a list of execution tasks associated with a specific job.",
+                "description": "Get the dependency graph of the jobs of a project: every job with upstream or downstream jobs, an edge from each job to each of its upstream jobs with the status of the latest upstream sync since the job was last triggered by them, and the upstream jobs each job is still waiting on.",
                 "tags": [
                     "Jobs"
                 ],
-                "summary": "List job tasks",
+                "summary": "Get the job dependency graph",
                 "parameters": [
                     {
                         "type": "string",
@@ -1476,13 +1744,6 @@ const docTemplate = `{
                         "name": "projectid",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "job id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -1497,10 +1758,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.JobTask"
-                                            }
+                                            "$ref": "#/definitions/dto.DependencyGraphResponse"
                                         }
                                     }
                                 }
@@ -1519,28 +1777,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "404": {
-                        "description": "job not found",
+                    "500": {
+                        "description": "failed to get dependency graph",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error404Response"
-                        }
-                    },
-                    "500": {
-                        "description": "failed to get job tasks",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error500Response"
+                            "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
             }
         },
-        "/api/v1/project/{projectid}/jobs/{id}/tasks/{taskid}/logs": {
-            "post": {
-                "description": "Retrieves the execution logs for a specific task. The file path for the log must be obtained from the [Get Job Tasks](#/Jobs/get_api_v1_project__projectid__jobs__id__tasks) endpoint.",
+        "/api/v1/project/{projectid}/jobs/export": {
+            "get": {
+                "description": "Download a self-contained bundle of jobs with their sources, destinations, streams config and advanced settings. Jobs are selected by job_ids or by a label selector; without either every job of the project is exported. Secret config values are replaced by \"${secret:\u003ckey\u003e}\" placeholders whose keys are listed in the bundle. The bundle is returned as a JSON or YAML attachment.",
+                "produces": [
+                    "application/json",
+                    "application/yaml"
+                ],
                 "tags": [
                     "Jobs"
                 ],
-                "summary": "Get task logs",
+                "summary": "Export jobs as a bundle",
                 "parameters": [
                     {
                         "type": "string",
@@ -1550,44 +1806,25 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "job id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "task id (defaults to 1)",
-                        "name": "taskid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "task log data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.JobTaskRequest"
-                        }
-                    },
-                    {
-                        "type": "integer",
-                        "description": "log cursor",
-                        "name": "cursor",
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "job ids to export",
+                        "name": "job_ids",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "log limit",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "label selector, e.g. tier=critical",
+                        "name": "selector",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "log direction",
-                        "name": "direction",
+                        "description": "bundle format: json (default) or yaml",
+                        "name": "format",
                         "in": "query"
                     }
                 ],
@@ -1595,19 +1832,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.JSONResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.TaskLogsResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dto.JobBundle"
                         }
                     },
                     "400": {
@@ -1628,14 +1853,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
-                        }
-                    },
                     "500": {
-                        "description": "failed to get task logs",
+                        "description": "failed to export jobs",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -1643,13 +1862,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/settings": {
-            "get": {
-                "description": "Retrieve the settings for a specific project.",
+        "/api/v1/project/{projectid}/jobs/import": {
+            "post": {
+                "description": "Import a job bundle into the project, adapted by an optional overlay, once every entity in it has been planned without conflicts.",
+                "consumes": [
+                    "application/json",
+                    "application/yaml"
+                ],
                 "tags": [
-                    "Project Settings"
+                    "Jobs"
                 ],
-                "summary": "Get project settings",
+                "summary": "Import a job bundle",
                 "parameters": [
                     {
                         "type": "string",
@@ -1657,11 +1880,20 @@ const docTemplate = `{
                         "name": "projectid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "bundle, overlay and import options",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportJobsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Project Settings fetched successfully",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1671,7 +1903,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.ProjectSettingsResponse"
+                                            "$ref": "#/definitions/dto.ImportJobsResponse"
                                         }
                                     }
                                 }
@@ -1690,20 +1922,40 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
+                    "409": {
+                        "description": "bundle import has conflicts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImportJobsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
-                        "description": "failed to retrieve project settings",
+                        "description": "failed to import bundle",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Create or update the settings for a project.",
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/schedule-preview": {
+            "post": {
+                "description": "Compute the next fire times of a frequency before it is saved on a job, in time_zone or, without one, in the project default time zone. The times are computed from the frequency without jitter; with jitter every run may start up to the jitter later. Times in a maintenance window of the project, or of source_id when it is set, are skipped. count defaults to 10 and is at most 100.",
                 "tags": [
-                    "Project Settings"
+                    "Jobs"
                 ],
-                "summary": "Update project settings",
+                "summary": "Preview a schedule",
                 "parameters": [
                     {
                         "type": "string",
@@ -1713,20 +1965,32 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "project settings data",
+                        "description": "frequency, time zone, source and count",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpsertProjectSettingsRequest"
+                            "$ref": "#/definitions/dto.SchedulePreviewRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Project Settings updated successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SchedulePreviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1741,14 +2005,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
-                        }
-                    },
                     "500": {
-                        "description": "failed to update project settings",
+                        "description": "failed to preview schedule",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -1756,13 +2014,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/sources": {
+        "/api/v1/project/{projectid}/jobs/{id}": {
             "get": {
-                "description": "Retrieve a list of all configured sources within a specific project.",
+                "description": "Retrieve details of a specific job identified by its unique ID.",
                 "tags": [
-                    "Sources"
+                    "Jobs"
                 ],
-                "summary": "List all sources",
+                "summary": "Get job details",
                 "parameters": [
                     {
                         "type": "string",
@@ -1770,6 +2028,13 @@ const docTemplate = `{
                         "name": "projectid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1784,10 +2049,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.SourceDataItem"
-                                            }
+                                            "$ref": "#/definitions/dto.JobResponse"
                                         }
                                     }
                                 }
@@ -1806,20 +2068,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
                     "500": {
-                        "description": "failed to retrieve sources",
+                        "description": "failed to get job",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Create a new source within a project.",
+            "put": {
+                "description": "Update the configuration details of an existing job. An omitted time_zone keeps the job's time zone and an empty one makes it follow the project default. Frequency, time zone and activation changes are applied to the temporal schedule after the job is saved; if that cannot be done right away it is retried in the background and status 202 is returned.",
                 "tags": [
-                    "Sources"
+                    "Jobs"
                 ],
-                "summary": "Create a new source",
+                "summary": "Update a job",
                 "parameters": [
                     {
                         "type": "string",
@@ -1829,32 +2097,33 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "source data",
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "job data",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateSourceRequest"
+                            "$ref": "#/definitions/dto.UpdateJobRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "job updated successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.JSONResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.CreateSourceRequest"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "job updated, schedule change is pending",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
                         }
                     },
                     "400": {
@@ -1869,6 +2138,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
                     "413": {
                         "description": "payload too large",
                         "schema": {
@@ -1876,21 +2151,25 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "failed to create source",
+                        "description": "failed to update job",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
+                    },
+                    "503": {
+                        "description": "no workers poll the task queue of the worker pool",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error503Response"
+                        }
                     }
                 }
-            }
-        },
-        "/api/v1/project/{projectid}/sources/spec": {
-            "post": {
-                "description": "Retrieve the UI spec for a specific source type/version.",
+            },
+            "delete": {
+                "description": "Move a specified job to the trash, where it can be restored until it is purged. Running syncs of the job are cancelled; while a clear-destination is running the job cannot be deleted and 409 is returned. Its temporal schedule is paused rather than deleted; if that cannot be done right away it is retried in the background and status 202 is returned.",
                 "tags": [
-                    "Sources"
+                    "Jobs"
                 ],
-                "summary": "Get source UI spec",
+                "summary": "Delete a job",
                 "parameters": [
                     {
                         "type": "string",
@@ -1900,32 +2179,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "spec request data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SpecRequest"
-                        }
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "job deleted successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.JSONResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.SpecResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "job deleted, schedule change is pending",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
                         }
                     },
                     "400": {
@@ -1940,14 +2211,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
+                    "404": {
+                        "description": "job not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
+                            "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
-                    "500": {
-                        "description": "failed to get spec",
+                    "409": {
+                        "description": "clear-destination is in progress",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error409Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to delete job",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -1955,13 +2232,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/sources/streams": {
+        "/api/v1/project/{projectid}/jobs/{id}/activate": {
             "post": {
-                "description": "Discover and list available data streams from a source.",
+                "description": "Pause or resume a job. If its temporal schedule cannot be paused or resumed right away it is retried in the background and status 202 is returned.",
                 "tags": [
-                    "Sources"
+                    "Jobs"
                 ],
-                "summary": "Get source stream catalog",
+                "summary": "Pause or resume job",
                 "parameters": [
                     {
                         "type": "string",
@@ -1971,32 +2248,33 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "streams request data",
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "activation data",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.StreamsRequest"
+                            "$ref": "#/definitions/dto.JobStatusRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "job activated/deactivated successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.JSONResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "job activated/deactivated, schedule change is pending",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
                         }
                     },
                     "400": {
@@ -2011,6 +2289,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
                     "413": {
                         "description": "payload too large",
                         "schema": {
@@ -2018,7 +2302,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "failed to get source catalog",
+                        "description": "failed to activate job",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -2026,13 +2310,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/sources/test": {
+        "/api/v1/project/{projectid}/jobs/{id}/backfill": {
             "post": {
-                "description": "Validate the connection to a source using the provided configuration details.",
+                "description": "Backfill the fire times from from to to that the schedule of a job missed, as the project backfill does for a single job. job_ids and selector are not allowed.",
                 "tags": [
-                    "Sources"
+                    "Jobs"
                 ],
-                "summary": "Test source connection",
+                "summary": "Backfill missed schedule runs of a job",
                 "parameters": [
                     {
                         "type": "string",
@@ -2042,12 +2326,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "test connection data",
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "backfill",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.SourceTestConnectionRequest"
+                            "$ref": "#/definitions/dto.BackfillRequest"
                         }
                     }
                 ],
@@ -2063,7 +2354,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.TestConnectionResponse"
+                                            "$ref": "#/definitions/dto.BackfillResponse"
                                         }
                                     }
                                 }
@@ -2082,14 +2373,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
+                    "404": {
+                        "description": "job not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
+                            "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
-                        "description": "failed to test connection",
+                        "description": "failed to backfill jobs",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -2097,13 +2388,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/sources/versions": {
+        "/api/v1/project/{projectid}/jobs/{id}/cancel": {
             "get": {
-                "description": "Retrieve the list of available versions for a specific source connector type.",
+                "description": "Request cancellation of a currently running job execution.",
                 "tags": [
-                    "Sources"
+                    "Jobs"
                 ],
-                "summary": "Get available source versions",
+                "summary": "Cancel running job",
                 "parameters": [
                     {
                         "type": "string",
@@ -2113,30 +2404,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "source type",
-                        "name": "type",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "job cancel requested successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.JSONResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.VersionsResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dto.JSONResponse"
                         }
                     },
                     "400": {
@@ -2151,8 +2430,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
                     "500": {
-                        "description": "failed to get versions",
+                        "description": "failed to cancel job run",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -2160,13 +2445,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/project/{projectid}/sources/{id}": {
+        "/api/v1/project/{projectid}/jobs/{id}/clear-destination": {
             "get": {
-                "description": "Retrieve details of a specific source identified by its unique ID.",
+                "description": "Retrieve the current status of an ongoing clear destination job.",
                 "tags": [
-                    "Sources"
+                    "Jobs"
                 ],
-                "summary": "Get source details",
+                "summary": "Get clear destination status",
                 "parameters": [
                     {
                         "type": "string",
@@ -2177,7 +2462,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "source id",
+                        "description": "job id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2195,7 +2480,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.SourceDataItem"
+                                            "$ref": "#/definitions/dto.ClearDestinationStatusResponse"
                                         }
                                     }
                                 }
@@ -2215,25 +2500,25 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "source not found",
+                        "description": "job not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
-                        "description": "failed to get source",
+                        "description": "failed to get status",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
             },
-            "put": {
-                "description": "Update the configuration details of an existing source.",
+            "post": {
+                "description": "Initiate job to clear data in the destination associated with a job.",
                 "tags": [
-                    "Sources"
+                    "Jobs"
                 ],
-                "summary": "Update a source",
+                "summary": "Clear destination data",
                 "parameters": [
                     {
                         "type": "string",
@@ -2244,38 +2529,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "source id",
+                        "description": "job id",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "source data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateSourceRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "clear destination triggered successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.JSONResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.UpdateSourceRequest"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/dto.JSONResponse"
                         }
                     },
                     "400": {
@@ -2291,31 +2555,27 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "source not found",
+                        "description": "job not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
-                        }
-                    },
                     "500": {
-                        "description": "failed to update source",
+                        "description": "failed to trigger clear destination",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Permanently delete a specified source.",
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/clone": {
+            "post": {
+                "description": "Create a new job with the streams config, frequency, advanced settings and labels of an existing job. The clone can be pointed at another source of the same type or at another destination; it starts with an empty state and its own schedule.",
                 "tags": [
-                    "Sources"
+                    "Jobs"
                 ],
-                "summary": "Delete a source",
+                "summary": "Clone a job",
                 "parameters": [
                     {
                         "type": "string",
@@ -2326,10 +2586,19 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "source id",
+                        "description": "job id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "clone data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CloneJobRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -2344,7 +2613,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.DeleteSourceResponse"
+                                            "$ref": "#/definitions/dto.CloneJobResponse"
                                         }
                                     }
                                 }
@@ -2364,13 +2633,19 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "source not found",
+                        "description": "job, source or destination not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
+                    "409": {
+                        "description": "job name is not unique",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error409Response"
+                        }
+                    },
                     "500": {
-                        "description": "failed to delete source",
+                        "description": "failed to clone job",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -2378,33 +2653,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users": {
+        "/api/v1/project/{projectid}/jobs/{id}/dependencies": {
             "get": {
-                "description": "Retrieve a list of all registered users.",
+                "description": "Get the upstream jobs of a job, with the status of their latest sync since the job was last triggered by them (pending, completed or failed), its downstream jobs, and the upstream jobs it is still waiting on.",
                 "tags": [
-                    "Users"
+                    "Jobs"
+                ],
+                "summary": "Get job dependencies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List all users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "allOf": [
-                                    {
-                                        "$ref": "#/definitions/dto.JSONResponse"
-                                    },
-                                    {
-                                        "type": "object",
-                                        "properties": {
-                                            "data": {
-                                                "$ref": "#/definitions/dto.UserResponse"
-                                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobDependenciesResponse"
                                         }
                                     }
-                                ]
-                            }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2419,34 +2707,54 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
                     "500": {
-                        "description": "failed to get users",
+                        "description": "failed to get job dependencies",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Create a new user record with the provided details.",
+            "put": {
+                "description": "Set the upstream jobs of a job and the policy by which their syncs trigger it.",
                 "tags": [
-                    "Users"
+                    "Jobs"
                 ],
-                "summary": "Create a new user",
+                "summary": "Set job dependencies",
                 "parameters": [
                     {
-                        "description": "user info",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateUserRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "user created successfully",
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "upstream jobs and policy",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.JobDependenciesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -2456,7 +2764,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.UserResponse"
+                                            "$ref": "#/definitions/dto.JobDependenciesResponse"
                                         }
                                     }
                                 }
@@ -2475,20 +2783,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "409": {
-                        "description": "user already exists",
+                    "404": {
+                        "description": "job not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error409Response"
+                            "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
+                    "409": {
+                        "description": "dependencies form a cycle",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
+                            "$ref": "#/definitions/dto.Error409Response"
                         }
                     },
                     "500": {
-                        "description": "failed to create user",
+                        "description": "failed to set job dependencies",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -2496,29 +2804,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/users/{id}": {
-            "put": {
-                "description": "Update the details of an existing user identified by their unique ID.",
+        "/api/v1/project/{projectid}/jobs/{id}/labels": {
+            "get": {
+                "description": "Retrieve the key/value labels attached to a job, source or destination.",
                 "tags": [
-                    "Users"
+                    "Labels"
                 ],
-                "summary": "Update user details",
+                "summary": "Get labels",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "user id",
-                        "name": "id",
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "user info",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateUserRequest"
-                        }
+                        "type": "integer",
+                        "description": "entity id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2533,7 +2839,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.UserResponse"
+                                            "$ref": "#/definitions/dto.LabelsResponse"
                                         }
                                     }
                                 }
@@ -2553,45 +2859,67 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "user not found",
+                        "description": "entity not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
-                        "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
-                        }
-                    },
                     "500": {
-                        "description": "failed to update user",
+                        "description": "failed to get labels",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Permanently remove a user record identified by their unique ID.",
+            "put": {
+                "description": "Replace every label of a job, source or destination with the provided set.",
                 "tags": [
-                    "Users"
+                    "Labels"
                 ],
-                "summary": "Delete a user",
+                "summary": "Replace labels",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "user id",
+                        "description": "entity id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "labels",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReplaceLabelsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "user deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LabelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2607,50 +2935,103 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "user not found",
+                        "description": "entity not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
-                        "description": "failed to delete user",
+                        "description": "failed to update labels",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
-            }
-        },
-        "/auth/check": {
-            "get": {
-                "description": "Verify if the current user session is active and valid.",
+            },
+            "patch": {
+                "description": "Add or overwrite the labels in \"set\" and remove the keys in \"remove\", leaving other labels untouched.",
                 "tags": [
-                    "Authentication"
+                    "Labels"
+                ],
+                "summary": "Patch labels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "entity id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "label changes",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatchLabelsRequest"
+                        }
+                    }
                 ],
-                "summary": "Check authentication status",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LabelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
                         }
                     },
                     "401": {
-                        "description": "Not authenticated",
+                        "description": "unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.Error401Response"
                         }
+                    },
+                    "404": {
+                        "description": "entity not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to update labels",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
                     }
                 }
             }
         },
-        "/internal/project/{projectid}/jobs/{id}/clear-destination/recover": {
-            "post": {
-                "description": "Internal recovery endpoint to cancel stuck clear-destination workflows and restore sync schedules.",
+        "/api/v1/project/{projectid}/jobs/{id}/labels/{key}": {
+            "delete": {
+                "description": "Remove a single label key from a job, source or destination.",
                 "tags": [
-                    "Internal"
+                    "Labels"
                 ],
-                "summary": "(Internal) Recover clear determination",
+                "summary": "Delete a label",
                 "parameters": [
                     {
                         "type": "string",
@@ -2661,17 +3042,36 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "job id",
+                        "description": "entity id",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "label key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "successfully recovered",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LabelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2680,14 +3080,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error400Response"
                         }
                     },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
                     "404": {
-                        "description": "job not found",
+                        "description": "entity or label not found",
                         "schema": {
                             "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "failed to delete label",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -2695,13 +3101,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/internal/project/{projectid}/jobs/{id}/statefile": {
-            "put": {
-                "description": "Internal endpoint to update the state file associated with a job.",
+        "/api/v1/project/{projectid}/jobs/{id}/logs/download": {
+            "get": {
+                "description": "Downloads the log file for a specific task. The file path required for the download must be obtained from the [Get Job Tasks](#/Jobs/get_api_v1_project__projectid__jobs__id__tasks) endpoint.",
                 "tags": [
-                    "Internal"
+                    "Jobs"
                 ],
-                "summary": "(Internal) Update state file",
+                "summary": "Download task logs",
                 "parameters": [
                     {
                         "type": "string",
@@ -2718,20 +3124,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "state file data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateStateFileRequest"
-                        }
+                        "type": "string",
+                        "description": "log file path",
+                        "name": "file_path",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "state file updated successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -2740,16 +3144,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error400Response"
                         }
                     },
-                    "404": {
-                        "description": "job not found",
+                    "401": {
+                        "description": "unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error404Response"
+                            "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
+                    "404": {
+                        "description": "failed to prepare log archive",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
+                            "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
@@ -2761,29 +3165,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/internal/worker/callback/sync-telemetry": {
+        "/api/v1/project/{projectid}/jobs/{id}/resync": {
             "post": {
-                "description": "Internal callback to update sync telemetry data.",
+                "description": "Sync some streams of a job again from scratch, optionally truncating their destination tables first.",
                 "tags": [
-                    "Internal"
+                    "Jobs"
                 ],
-                "summary": "(Internal) Update sync telemetry",
+                "summary": "Resync job streams",
                 "parameters": [
                     {
-                        "description": "telemetry data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateSyncTelemetryRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "sync telemetry updated successfully",
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "streams to resync",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "$ref": "#/definitions/dto.ResyncStreamsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ResyncStreamsResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2792,14 +3222,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error400Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
+                    "401": {
+                        "description": "unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "409": {
+                        "description": "job is paused or not idle",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "failed to resync streams",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -2807,22 +3249,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Authenticate a user and create a new session.",
+        "/api/v1/project/{projectid}/jobs/{id}/revisions": {
+            "get": {
+                "description": "Retrieve the revisions of a job, newest first. A revision is an immutable copy of the streams config, frequency, source, destination and advanced settings of the job, saved with its author every time one of them changes. Jobs created before revisions were recorded get their configuration as of their last update saved as a \"baseline\" revision on their first change.",
                 "tags": [
-                    "Authentication"
+                    "Jobs"
                 ],
-                "summary": "User login",
+                "summary": "List job revisions",
                 "parameters": [
                     {
-                        "description": "login credentials",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2837,7 +3284,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.LoginResponse"
+                                            "$ref": "#/definitions/dto.JobRevisionsResponse"
                                         }
                                     }
                                 }
@@ -2845,25 +3292,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "invalid request",
+                        "description": "failed to validate request",
                         "schema": {
                             "$ref": "#/definitions/dto.Error400Response"
                         }
                     },
                     "401": {
-                        "description": "invalid credentials",
+                        "description": "unauthorized",
                         "schema": {
                             "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
+                    "404": {
+                        "description": "job not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
+                            "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
-                        "description": "internal server error",
+                        "description": "failed to list job revisions",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -2871,29 +3318,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/signup": {
-            "post": {
-                "description": "Register a new user account with the provided details.",
+        "/api/v1/project/{projectid}/jobs/{id}/revisions/diff": {
+            "get": {
+                "description": "Compare two revisions of a job: the job settings that differ and, stream by stream, the streams added or removed and the settings changed on the others, with their sync mode and column selection changes. Without to, from is compared with the latest revision.",
                 "tags": [
-                    "Authentication"
+                    "Jobs"
                 ],
-                "summary": "User signup",
+                "summary": "Diff two job revisions",
                 "parameters": [
                     {
-                        "description": "user info",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "revision to compare from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "revision to compare to, the latest by default",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "user created successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.JSONResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobRevisionDiffResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2902,20 +3379,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.Error400Response"
                         }
                     },
-                    "409": {
-                        "description": "user already exists",
+                    "401": {
+                        "description": "unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error409Response"
+                            "$ref": "#/definitions/dto.Error401Response"
                         }
                     },
-                    "413": {
-                        "description": "payload too large",
+                    "404": {
+                        "description": "job or revision not found",
                         "schema": {
-                            "$ref": "#/definitions/dto.Error413Response"
+                            "$ref": "#/definitions/dto.Error404Response"
                         }
                     },
                     "500": {
-                        "description": "failed to create user",
+                        "description": "failed to diff job revisions",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
@@ -2923,13 +3400,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/telemetry-id": {
-            "get": {
-                "description": "Retrieve the unique telemetry identifier and current UI version.",
+        "/api/v1/project/{projectid}/jobs/{id}/revisions/{revision}/rollback": {
+            "post": {
+                "description": "Restore the configuration of a revision of a job and save it as a new rollback revision.",
                 "tags": [
-                    "Internal"
+                    "Jobs"
+                ],
+                "summary": "Roll back a job to a revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "revision to roll back to",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get telemetry ID",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2942,431 +3442,6356 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.TelemetryIDResponse"
+                                            "$ref": "#/definitions/dto.JobRollbackResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "202": {
+                        "description": "job rolled back, schedule change is pending",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job or revision not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot roll back job",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
                     "500": {
-                        "description": "internal server error",
+                        "description": "failed to roll back job",
                         "schema": {
                             "$ref": "#/definitions/dto.Error500Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AdvancedSettings": {
-            "type": "object",
-            "properties": {
-                "max_discover_threads": {
-                    "type": "integer",
-                    "example": 50
-                }
-            }
-        },
-        "dto.CheckUniqueJobNameResponse": {
-            "type": "object",
-            "properties": {
-                "unique": {
-                    "type": "boolean",
-                    "example": true
-                }
-            }
-        },
-        "dto.CheckUniqueNameRequest": {
-            "type": "object",
-            "required": [
-                "entity_type",
-                "name"
-            ],
-            "properties": {
-                "entity_type": {
-                    "type": "string",
-                    "enum": [
-                        "job",
-                        "source",
-                        "destination"
-                    ],
-                    "example": "job"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "my-sync-job"
-                }
-            }
         },
-        "dto.ClearDestinationStatusResponse": {
-            "type": "object",
-            "properties": {
+        "/api/v1/project/{projectid}/jobs/{id}/runs/{runid}": {
+            "get": {
+                "description": "Get the status of a run of a job by the run id a sync trigger returned, optionally waiting for the run to close.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get job run status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "run id",
+                        "name": "runid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "wait for the run to close",
+                        "name": "wait",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how long to wait, e.g. 5m",
+                        "name": "timeout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer trigger token of the job, on the /trigger/v1 route",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobRunResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job or run not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get job run",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/schedule-operations": {
+            "get": {
+                "description": "Retrieve the latest changes to the temporal schedule of a job, newest first.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "List schedule operations of a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ScheduleOperationsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to list schedule operations",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/schedule-operations/retry": {
+            "post": {
+                "description": "Queue the schedule operations of a job that ran out of attempts again and apply them right away. The resulting operations are returned.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Retry failed schedule operations of a job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ScheduleOperationsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to retry schedule operations",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/schedule-preview": {
+            "get": {
+                "description": "Get the next fire times of the schedule of a job in its time zone.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Preview a job schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "number of fire times",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SchedulePreviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to preview job schedule",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/state": {
+            "get": {
+                "description": "Retrieve the state of a job broken down by stream: the cursor field and value of incremental streams, the chunks left of an initial load and whether the stream follows the CDC position, which is returned once for the job. With snapshot_id the state of that snapshot is shown instead of the current one.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get job state",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "state snapshot to show",
+                        "name": "snapshot_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobStateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job or snapshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get job state",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/state/history": {
+            "get": {
+                "description": "Retrieve the latest state snapshots of a job, newest first, with the run that wrote each of them. A snapshot is kept every time the state is written by a sync, reset by a manual clear-destination or restored; only the most recent ones are kept. A job whose state was written before the history was kept gets that state saved as a \"baseline\" snapshot.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "List job state history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobStateHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to list job state history",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/state/history/{snapshotid}/restore": {
+            "post": {
+                "description": "Write the state of a snapshot back to the job; the restore is kept as a new snapshot. The job has to be idle: the request fails with 409 while a sync or clear-destination is running, and the schedule is paused while the state is written so that no sync starts in between.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Restore a job state snapshot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "state snapshot id",
+                        "name": "snapshotid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobStateSnapshotItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job or snapshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "409": {
+                        "description": "job is not idle or in a maintenance window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to restore job state",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/stream-difference": {
+            "post": {
+                "description": "Get difference between current streams.json and existing streams.json.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get stream differences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "stream difference data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StreamDifferenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.StreamDifferenceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get stream difference",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/sync": {
+            "post": {
+                "description": "Trigger a sync of a job through its schedule, or a one-off sync of some of its streams or with run overrides.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Trigger job sync",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "streams and run overrides of a one-off sync",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SyncJobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "sync triggered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SyncJobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "409": {
+                        "description": "job is not idle or in a maintenance window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "429": {
+                        "description": "one-off sync over a concurrency cap",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error429Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to trigger sync",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    },
+                    "503": {
+                        "description": "no workers poll the task queue of the worker pool",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error503Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/tasks": {
+            "get": {
+                "description": "Retrieve a page of execution tasks associated with a specific job, newest first.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "List job tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, all tasks are returned when omitted",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "execution status (Running, Completed, Failed, Canceled, Terminated, ContinuedAsNew, TimedOut)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "task type: sync, clear",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/dto.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/dto.JobTask"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get job tasks",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/tasks/{taskid}/logs": {
+            "post": {
+                "description": "Retrieves the execution logs for a specific task. The file path for the log must be obtained from the [Get Job Tasks](#/Jobs/get_api_v1_project__projectid__jobs__id__tasks) endpoint.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get task logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "task id (defaults to 1)",
+                        "name": "taskid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "task log data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.JobTaskRequest"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "log cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "log limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "log direction",
+                        "name": "direction",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TaskLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get task logs",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/template": {
+            "post": {
+                "description": "Create one job per source from a base job, copying its streams config, frequency, advanced settings and labels. Sources must be of the same type as the base job's source. Job names come from name_pattern (default \"{job}-{source}\") which supports {job}, {source}, {source_id} and {index}. Each source reports its own result.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Create jobs from a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "base job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "template data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.JobTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobTemplateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job or destination not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to create jobs from template",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/trigger-tokens": {
+            "get": {
+                "description": "List the trigger tokens of a job, newest first, with their prefix and when they were last used. The tokens themselves are not returned.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "List job trigger tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TriggerTokenItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to list trigger tokens",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a token with which an external scheduler such as Airflow or Dagster can trigger syncs of this job and follow their runs on the /trigger/v1 routes, without a user account. The token is returned only in this response; only its prefix is shown afterwards. A job has at most 10 tokens.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Create a job trigger token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "token name",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTriggerTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreateTriggerTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "409": {
+                        "description": "trigger token limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error409Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to create trigger token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/jobs/{id}/trigger-tokens/{tokenid}": {
+            "delete": {
+                "description": "Delete a trigger token of a job; requests made with it are rejected from then on.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Revoke a job trigger token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "trigger token id",
+                        "name": "tokenid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "trigger token revoked",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job or trigger token not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to revoke trigger token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/maintenance-windows": {
+            "get": {
+                "description": "List the maintenance windows of a project, of its sources and of its jobs. active_until is set on a window that is in effect, to the end of the current occurrence; a window without a time zone is reported on the project default time zone here and follows the time zone of each job's schedule otherwise.",
+                "tags": [
+                    "Maintenance Windows"
+                ],
+                "summary": "List maintenance windows",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.MaintenanceWindowItem"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to list maintenance windows",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a maintenance window in which scheduled syncs of the project, of a source or of a job do not start.",
+                "tags": [
+                    "Maintenance Windows"
+                ],
+                "summary": "Create a maintenance window",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "maintenance window",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MaintenanceWindowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MaintenanceWindowItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "source or job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "409": {
+                        "description": "maintenance window limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error409Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to create maintenance window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/maintenance-windows/{id}": {
+            "put": {
+                "description": "Replace a maintenance window, including its scope. The schedules of the jobs it applied to before and of those it applies to now are updated.",
+                "tags": [
+                    "Maintenance Windows"
+                ],
+                "summary": "Update a maintenance window",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maintenance window id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "maintenance window",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MaintenanceWindowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MaintenanceWindowItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "maintenance window, source or job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to update maintenance window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a maintenance window and remove it from the schedules of the jobs it applied to.",
+                "tags": [
+                    "Maintenance Windows"
+                ],
+                "summary": "Delete a maintenance window",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maintenance window id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "maintenance window deleted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "maintenance window not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to delete maintenance window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/settings": {
+            "get": {
+                "description": "Retrieve the settings for a specific project.",
+                "tags": [
+                    "Project Settings"
+                ],
+                "summary": "Get project settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project Settings fetched successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProjectSettingsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to retrieve project settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Create or update the settings for a project. default_time_zone, an IANA time zone name, is the schedule time zone of the jobs without one of their own; changing it moves their schedules to the new zone. It is left as it is when omitted and an empty string resets it to UTC.",
+                "tags": [
+                    "Project Settings"
+                ],
+                "summary": "Update project settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "project settings data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpsertProjectSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Project Settings updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to update project settings",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/sources": {
+            "get": {
+                "description": "Retrieve a page of configured sources within a specific project. Results can be filtered, sorted and paged using an opaque cursor.",
+                "tags": [
+                    "Sources"
+                ],
+                "summary": "List all sources",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, all sources are returned when omitted",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "source name substring (case-insensitive)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "source type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username of the creator",
+                        "name": "created_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "label selector, e.g. tier=critical,team!=growth",
+                        "name": "selector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort key: name, created_at, updated_at (default updated_at)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort order: asc, desc",
+                        "name": "sort_order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/dto.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/dto.SourceDataItem"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to retrieve sources",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new source within a project.",
+                "tags": [
+                    "Sources"
+                ],
+                "summary": "Create a new source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "source data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSourceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreateSourceRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to create source",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    },
+                    "503": {
+                        "description": "no workers poll the task queue of the worker pool",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error503Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/sources/spec": {
+            "post": {
+                "description": "Retrieve the UI spec for a specific source type/version.",
+                "tags": [
+                    "Sources"
+                ],
+                "summary": "Get source UI spec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "spec request data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpecRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SpecResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get spec",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    },
+                    "503": {
+                        "description": "no workers poll the task queue of the worker pool",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error503Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/sources/streams": {
+            "post": {
+                "description": "Discover and list available data streams from a source. The discover counts against the discover caps of the project, and of the source and destination of the job in job_id or of the source in source_id; it waits up to 10 minutes for a slot, then fails with 429.",
+                "tags": [
+                    "Sources"
+                ],
+                "summary": "Get source stream catalog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "streams request data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StreamsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "429": {
+                        "description": "concurrency limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error429Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get source catalog",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    },
+                    "503": {
+                        "description": "no workers poll the task queue of the worker pool",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error503Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/sources/test": {
+            "post": {
+                "description": "Validate the connection to a source using the provided configuration details.",
+                "tags": [
+                    "Sources"
+                ],
+                "summary": "Test source connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "test connection data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SourceTestConnectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TestConnectionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to test connection",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    },
+                    "503": {
+                        "description": "no workers poll the task queue of the worker pool",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error503Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/sources/versions": {
+            "get": {
+                "description": "Retrieve the list of available versions for a specific source connector type.",
+                "tags": [
+                    "Sources"
+                ],
+                "summary": "Get available source versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "source type",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.VersionsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get versions",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/sources/{id}": {
+            "get": {
+                "description": "Retrieve details of a specific source identified by its unique ID.",
+                "tags": [
+                    "Sources"
+                ],
+                "summary": "Get source details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "source id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SourceDataItem"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "source not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get source",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update the configuration details of an existing source.",
+                "tags": [
+                    "Sources"
+                ],
+                "summary": "Update a source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "source id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "source data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateSourceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UpdateSourceRequest"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "source not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to update source",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    },
+                    "503": {
+                        "description": "no workers poll the task queue of the worker pool",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error503Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move a specified source to the trash, where it can be restored until it is purged. A source used by jobs cannot be deleted.",
+                "tags": [
+                    "Sources"
+                ],
+                "summary": "Delete a source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "source id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.DeleteSourceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "source not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to delete source",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/sources/{id}/labels": {
+            "get": {
+                "description": "Retrieve the key/value labels attached to a job, source or destination.",
+                "tags": [
+                    "Labels"
+                ],
+                "summary": "Get labels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "entity id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LabelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "entity not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get labels",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace every label of a job, source or destination with the provided set.",
+                "tags": [
+                    "Labels"
+                ],
+                "summary": "Replace labels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "entity id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "labels",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReplaceLabelsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LabelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "entity not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to update labels",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Add or overwrite the labels in \"set\" and remove the keys in \"remove\", leaving other labels untouched.",
+                "tags": [
+                    "Labels"
+                ],
+                "summary": "Patch labels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "entity id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "label changes",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatchLabelsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LabelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "entity not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to update labels",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/sources/{id}/labels/{key}": {
+            "delete": {
+                "description": "Remove a single label key from a job, source or destination.",
+                "tags": [
+                    "Labels"
+                ],
+                "summary": "Delete a label",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "entity id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "label key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LabelsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "entity or label not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to delete label",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/spec/apply": {
+            "post": {
+                "description": "Plan a declarative spec and apply it when the plan has no errors and no destructive changes that are not allowed.",
+                "consumes": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "tags": [
+                    "Spec"
+                ],
+                "summary": "Apply a declarative spec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "declarative spec and apply options",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpecPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SpecPlanResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "409": {
+                        "description": "spec is invalid or has destructive changes",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SpecPlanResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "failed to apply spec",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/spec/plan": {
+            "post": {
+                "description": "Compare a declarative spec of projects, sources, destinations and jobs with the current state, without changing anything.",
+                "consumes": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "tags": [
+                    "Spec"
+                ],
+                "summary": "Plan a declarative spec",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "declarative spec",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SpecPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SpecPlanResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to plan spec",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/trash": {
+            "get": {
+                "description": "Retrieve the deleted jobs, sources and destinations of a project. Deleted entities are hidden from every other listing and can be restored until they are purged, TRASH_RETENTION after they were deleted. The schedule of a deleted job is paused, not deleted.",
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TrashResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to list trash",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/trash/{kind}/{id}": {
+            "delete": {
+                "description": "Permanently delete a deleted job, source or destination before its retention runs out. A purged job has its schedule deleted. Sources and destinations still used by a job, deleted or not, cannot be purged.",
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Purge from the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "job, source or destination",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id of the deleted entity",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "purged successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "purged, schedule change is pending",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found in trash or still used by a job",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to purge from trash",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/project/{projectid}/trash/{kind}/{id}/restore": {
+            "post": {
+                "description": "Restore a deleted job, source or destination. A job can only be restored once its source and destination are restored; its schedule is recreated if it is gone and paused or unpaused as the job was before it was deleted. If the schedule cannot be changed right away it is retried in the background and status 202 is returned. An entity whose name was taken in the meantime cannot be restored.",
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore from the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "job, source or destination",
+                        "name": "kind",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "id of the deleted entity",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "restored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "restored, schedule change is pending",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "not found in trash",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "409": {
+                        "description": "cannot restore from trash",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to restore from trash",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users": {
+            "get": {
+                "description": "Retrieve a page of registered users. Results can be filtered, sorted and paged using an opaque cursor.",
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List all users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "cursor returned as next_cursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, all users are returned when omitted",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "username substring (case-insensitive)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort key: name, created_at, updated_at (default updated_at)",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort order: asc, desc",
+                        "name": "sort_order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "allOf": [
+                                                {
+                                                    "$ref": "#/definitions/dto.PaginatedResponse"
+                                                },
+                                                {
+                                                    "type": "object",
+                                                    "properties": {
+                                                        "items": {
+                                                            "type": "array",
+                                                            "items": {
+                                                                "$ref": "#/definitions/dto.UserResponse"
+                                                            }
+                                                        }
+                                                    }
+                                                }
+                                            ]
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get users",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new user record with the provided details.",
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "user info",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "user created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "409": {
+                        "description": "user already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error409Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to create user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/{id}": {
+            "put": {
+                "description": "Update the details of an existing user identified by their unique ID.",
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user details",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "user info",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "user not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to update user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Permanently remove a user record identified by their unique ID.",
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "user deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "user not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to delete user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/check": {
+            "get": {
+                "description": "Verify if the current user session is active and valid.",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Check authentication status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/internal/project/{projectid}/jobs/{id}/clear-destination/recover": {
+            "post": {
+                "description": "Internal recovery endpoint to cancel stuck clear-destination workflows and restore sync schedules.",
+                "tags": [
+                    "Internal"
+                ],
+                "summary": "(Internal) Recover clear determination",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "successfully recovered",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/internal/project/{projectid}/jobs/{id}/statefile": {
+            "put": {
+                "description": "Internal endpoint to update the state file associated with a job. The state is kept in the state history of the job, tied to the run given by workflow_id and run_id or else to the sync running at the time.",
+                "tags": [
+                    "Internal"
+                ],
+                "summary": "(Internal) Update state file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "state file data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateStateFileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "state file updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/internal/worker/callback/sync-telemetry": {
+            "post": {
+                "description": "Internal callback to update sync telemetry data.",
+                "tags": [
+                    "Internal"
+                ],
+                "summary": "(Internal) Update sync telemetry",
+                "parameters": [
+                    {
+                        "description": "telemetry data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateSyncTelemetryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "sync telemetry updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Authenticate a user and create a new session.",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "login credentials",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/signup": {
+            "post": {
+                "description": "Register a new user account with the provided details.",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User signup",
+                "parameters": [
+                    {
+                        "description": "user info",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "user created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "409": {
+                        "description": "user already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error409Response"
+                        }
+                    },
+                    "413": {
+                        "description": "payload too large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error413Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to create user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/telemetry-id": {
+            "get": {
+                "description": "Retrieve the unique telemetry identifier and current UI version.",
+                "tags": [
+                    "Internal"
+                ],
+                "summary": "Get telemetry ID",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TelemetryIDResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trigger/v1/project/{projectid}/jobs/{id}/runs/{runid}": {
+            "get": {
+                "description": "Get the status of a run of a job by the run id a sync trigger returned, optionally waiting for the run to close.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get job run status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "run id",
+                        "name": "runid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "wait for the run to close",
+                        "name": "wait",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how long to wait, e.g. 5m",
+                        "name": "timeout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer trigger token of the job, on the /trigger/v1 route",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.JobRunResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "failed to validate request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job or run not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to get job run",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trigger/v1/project/{projectid}/jobs/{id}/sync": {
+            "post": {
+                "description": "Trigger a sync of the job through its schedule, authenticated with a trigger token of the job; takes no body.",
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Trigger job sync with a trigger token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "project id (default is 123)",
+                        "name": "projectid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer trigger token of the job",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "sync triggered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.JSONResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SyncJobResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "the request has a body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid trigger token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error401Response"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error404Response"
+                        }
+                    },
+                    "409": {
+                        "description": "job is not idle or in a maintenance window",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error400Response"
+                        }
+                    },
+                    "500": {
+                        "description": "failed to trigger sync",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error500Response"
+                        }
+                    },
+                    "503": {
+                        "description": "no workers poll the task queue of the worker pool",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Error503Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AdvancedSettings": {
+            "type": "object",
+            "properties": {
+                "catchup_window": {
+                    "description": "CatchupWindow is how late a sync missed while temporal was down may still start",
+                    "type": "string",
+                    "example": "1h"
+                },
+                "env": {
+                    "description": "Env are extra environment variables of the job's driver container",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DriverEnvVar"
+                    }
+                },
+                "max_discover_threads": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "overlap_policy": {
+                    "description": "OverlapPolicy decides what a scheduled sync does while the previous one still runs",
+                    "type": "string",
+                    "example": "skip"
+                },
+                "resources": {
+                    "description": "Resources are the cpu and memory of the job's driver container, the server defaults\nfor those left out",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.DriverResources"
+                        }
+                    ]
+                },
+                "retry": {
+                    "description": "Retry retries a failed scheduled sync",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.RetryPolicy"
+                        }
+                    ]
+                },
+                "run_timeout": {
+                    "description": "RunTimeout is how long a sync may run, at most the default of 720h",
+                    "type": "string",
+                    "example": "12h"
+                },
+                "worker_pool": {
+                    "description": "WorkerPool is the worker pool the job's runs go to instead of the pool of its source",
+                    "type": "string",
+                    "example": "cdc-large"
+                }
+            }
+        },
+        "dto.BackfillJobResult": {
+            "type": "object",
+            "properties": {
+                "enqueued": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2024-01-09T06:00:00+01:00"
+                    ]
+                },
+                "error": {
+                    "type": "string",
+                    "example": "failed to backfill schedule"
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "job_name": {
+                    "type": "string",
+                    "example": "my-sync-job"
+                },
+                "missed_windows": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2024-01-09T01:00:00+01:00"
+                    ]
+                },
+                "skipped": {
+                    "type": "string",
+                    "example": "job is paused"
+                }
+            }
+        },
+        "dto.BackfillRequest": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-09T00:00:00Z"
+                },
+                "job_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "mode": {
+                    "description": "\"catch_up\" | \"per_window\"",
+                    "type": "string",
+                    "example": "catch_up"
+                },
+                "override_maintenance_window": {
+                    "description": "OverrideMaintenanceWindow backfills jobs that are in a maintenance window",
+                    "type": "boolean",
+                    "example": false
+                },
+                "selector": {
+                    "type": "string",
+                    "example": "tier=critical"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-09T06:00:00Z"
+                }
+            }
+        },
+        "dto.BackfillResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "enqueued": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2024-01-09T00:00:00Z"
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BackfillJobResult"
+                    }
+                },
+                "missed": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "catch_up"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-09T06:00:00Z"
+                }
+            }
+        },
+        "dto.BulkJobFilter": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "orders"
+                },
+                "selector": {
+                    "type": "string",
+                    "example": "tier=critical,team!=growth"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "postgres"
+                }
+            }
+        },
+        "dto.BulkJobRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "description": "enum: pause,resume,trigger,cancel,change_frequency,change_version,delete",
+                    "type": "string",
+                    "example": "pause"
+                },
+                "filter": {
+                    "$ref": "#/definitions/dto.BulkJobFilter"
+                },
+                "frequency": {
+                    "description": "change_frequency only",
+                    "type": "string",
+                    "example": "0 */6 * * *"
+                },
+                "job_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "override_maintenance_window": {
+                    "description": "OverrideMaintenanceWindow triggers jobs that are in a maintenance window, trigger only",
+                    "type": "boolean",
+                    "example": false
+                },
+                "version": {
+                    "description": "change_version only",
+                    "type": "string",
+                    "example": "v0.3.15"
+                }
+            }
+        },
+        "dto.BulkJobResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "pause"
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BulkJobResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 78
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 80
+                }
+            }
+        },
+        "dto.BulkJobResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "failed to pause schedule"
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "job_name": {
+                    "type": "string",
+                    "example": "my-sync-job"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "schedule paused"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.BundleDestination": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "iceberg-staging"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "iceberg"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v0.3.15"
+                }
+            }
+        },
+        "dto.BundleJob": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "advanced_settings": {
+                    "$ref": "#/definitions/dto.AdvancedSettings"
+                },
+                "destination": {
+                    "type": "string",
+                    "example": "iceberg-staging"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "0 * * * *"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "orders-sync"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "pg-staging"
+                },
+                "streams_config": {
+                    "type": "object"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                }
+            }
+        },
+        "dto.BundleOverlay": {
+            "type": "object",
+            "properties": {
+                "destinations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {}
+                    }
+                },
+                "hosts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name_prefix": {
+                    "type": "string",
+                    "example": "prod-"
+                },
+                "secrets": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "sources": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {}
+                    }
+                }
+            }
+        },
+        "dto.BundleSource": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "pg-staging"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "postgres"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v0.3.15"
+                }
+            }
+        },
+        "dto.CheckUniqueJobNameResponse": {
+            "type": "object",
+            "properties": {
+                "unique": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.CheckUniqueNameRequest": {
+            "type": "object",
+            "required": [
+                "entity_type",
+                "name"
+            ],
+            "properties": {
+                "entity_type": {
+                    "type": "string",
+                    "enum": [
+                        "job",
+                        "source",
+                        "destination"
+                    ],
+                    "example": "job"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-sync-job"
+                }
+            }
+        },
+        "dto.ClearDestinationStatusResponse": {
+            "type": "object",
+            "properties": {
                 "running": {
                     "type": "boolean",
                     "example": false
                 }
             }
         },
-        "dto.CreateDestinationRequest": {
+        "dto.CloneJobRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "destination_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-sync-job-copy"
+                },
+                "source_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.CloneJobResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-sync-job-copy"
+                }
+            }
+        },
+        "dto.ConcurrencyLimitRequest": {
+            "type": "object",
+            "required": [
+                "scope"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_concurrent_discovers": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_concurrent_syncs": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "scope": {
+                    "description": "\"project\" | \"source\" | \"destination\"",
+                    "type": "string",
+                    "example": "source"
+                }
+            }
+        },
+        "dto.ConcurrencyResponse": {
+            "type": "object",
+            "properties": {
+                "destinations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ConcurrencyUsage"
+                    }
+                },
+                "project": {
+                    "$ref": "#/definitions/dto.ConcurrencyUsage"
+                },
+                "queue": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RunQueueItem"
+                    }
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ConcurrencyUsage"
+                    }
+                }
+            }
+        },
+        "dto.ConcurrencyUsage": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_concurrent_discovers": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_concurrent_syncs": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "orders-db"
+                },
+                "queued_discovers": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "queued_syncs": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "running_discovers": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "running_syncs": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.CreateDestinationRequest": {
+            "type": "object",
+            "required": [
+                "config",
+                "name",
+                "type",
+                "version"
+            ],
+            "properties": {
+                "config": {
+                    "type": "string",
+                    "example": "{\"catalog_type\":\"glue\",\"warehouse\":\"s3://my-bucket/warehouse\"}"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-iceberg-destination"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "iceberg"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v0.2.7"
+                }
+            }
+        },
+        "dto.CreateJobRequest": {
+            "type": "object",
+            "required": [
+                "destination",
+                "frequency",
+                "name",
+                "source",
+                "streams_config"
+            ],
+            "properties": {
+                "activate": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "advanced_settings": {
+                    "$ref": "#/definitions/dto.AdvancedSettings"
+                },
+                "destination": {
+                    "$ref": "#/definitions/dto.DriverConfig"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "0 */6 * * *"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-sync-job"
+                },
+                "source": {
+                    "$ref": "#/definitions/dto.DriverConfig"
+                },
+                "streams_config": {
+                    "type": "string"
+                },
+                "time_zone": {
+                    "description": "empty follows the project default",
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                }
+            }
+        },
+        "dto.CreateSourceRequest": {
+            "type": "object",
+            "required": [
+                "config",
+                "name",
+                "type",
+                "version"
+            ],
+            "properties": {
+                "config": {
+                    "type": "string",
+                    "example": "{\"host\":\"localhost\",\"port\":5432,\"database\":\"mydb\",\"user\":\"postgres\",\"password\":\"secret\"}"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-postgres-source"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "postgres"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v0.2.7"
+                },
+                "worker_pool": {
+                    "description": "WorkerPool is the worker pool the runs of the source go to, empty for the default pool",
+                    "type": "string",
+                    "example": "vpc-orders"
+                }
+            }
+        },
+        "dto.CreateTriggerTokenRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "airflow"
+                }
+            }
+        },
+        "dto.CreateTriggerTokenResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-01-10T06:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "airflow"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "olt_3fa9c1"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "olt_3fa9c1..."
+                }
+            }
+        },
+        "dto.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "admin@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "dto.DeleteDestinationResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "my-iceberg-destination"
+                }
+            }
+        },
+        "dto.DeleteSourceResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "my-postgres-source"
+                }
+            }
+        },
+        "dto.DependencyGraphEdge": {
+            "type": "object",
+            "properties": {
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "\"pending\" | \"completed\" | \"failed\"",
+                    "type": "string",
+                    "example": "pending"
+                },
+                "upstream_job_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "dto.DependencyGraphNode": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "deleted": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "raw-orders"
+                },
+                "policy": {
+                    "type": "string",
+                    "example": "all_succeeded"
+                },
+                "waiting_on": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2
+                    ]
+                }
+            }
+        },
+        "dto.DependencyGraphResponse": {
+            "type": "object",
+            "properties": {
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DependencyGraphEdge"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DependencyGraphNode"
+                    }
+                }
+            }
+        },
+        "dto.DestinationDataItem": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "string",
+                    "example": "{\"catalog_type\":\"glue\",\"warehouse\":\"s3://bucket/warehouse\"}"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JobDataItem"
+                    }
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-iceberg-destination"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "iceberg"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "updated_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v0.2.7"
+                }
+            }
+        },
+        "dto.DestinationTestConnectionRequest": {
+            "type": "object",
+            "required": [
+                "config",
+                "type",
+                "version"
+            ],
+            "properties": {
+                "config": {
+                    "type": "string",
+                    "example": "{\"catalog_type\":\"glue\",\"warehouse\":\"s3://my-bucket/warehouse\"}"
+                },
+                "source_type": {
+                    "type": "string",
+                    "example": "postgres"
+                },
+                "source_version": {
+                    "type": "string",
+                    "example": "v0.2.7"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "iceberg"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v0.2.7"
+                },
+                "worker_pool": {
+                    "type": "string",
+                    "example": "default"
+                }
+            }
+        },
+        "dto.DriverConfig": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "type": "string",
+                    "example": "{\"host\":\"localhost\",\"port\":5432}"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-postgres-source"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "postgres"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v0.2.7"
+                }
+            }
+        },
+        "dto.DriverEnvVar": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "GOMEMLIMIT"
+                },
+                "secret": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "value": {
+                    "type": "string",
+                    "example": "3GiB"
+                }
+            }
+        },
+        "dto.DriverResources": {
+            "type": "object",
+            "properties": {
+                "limits": {
+                    "$ref": "#/definitions/dto.ResourceQuantities"
+                },
+                "requests": {
+                    "$ref": "#/definitions/dto.ResourceQuantities"
+                }
+            }
+        },
+        "dto.Error400Response": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Bad request"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.Error401Response": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Authentication required"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.Error404Response": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Resource not found"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.Error409Response": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Resource already exists"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.Error413Response": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Payload too large"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.Error429Response": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Concurrency limit reached"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.Error500Response": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Internal server error"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.Error503Response": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "no workers are polling the task queue"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dto.ExecutionPolicy": {
+            "type": "object",
+            "properties": {
+                "backoff_coefficient": {
+                    "type": "number",
+                    "example": 2
+                },
+                "catchup_window": {
+                    "type": "string",
+                    "example": "8760h0m0s"
+                },
+                "initial_interval": {
+                    "type": "string",
+                    "example": "1m0s"
+                },
+                "max_attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "max_interval": {
+                    "type": "string",
+                    "example": "1h0m0s"
+                },
+                "overlap_policy": {
+                    "type": "string",
+                    "example": "skip"
+                },
+                "run_timeout": {
+                    "type": "string",
+                    "example": "720h0m0s"
+                }
+            }
+        },
+        "dto.ImportItem": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "create"
+                },
+                "conflict": {
+                    "type": "string",
+                    "example": "source 'prod-pg-staging' already exists"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "failed to create source"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "source"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "prod-pg-staging"
+                }
+            }
+        },
+        "dto.ImportJobsRequest": {
+            "type": "object",
+            "required": [
+                "bundle"
+            ],
+            "properties": {
+                "bundle": {
+                    "$ref": "#/definitions/dto.JobBundle"
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "on_conflict": {
+                    "type": "string",
+                    "example": "fail"
+                },
+                "overlay": {
+                    "$ref": "#/definitions/dto.BundleOverlay"
+                }
+            }
+        },
+        "dto.ImportJobsResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "conflicts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportItem"
+                    }
+                }
+            }
+        },
+        "dto.JSONResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
+                    "type": "string",
+                    "example": "operation completed successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.JobBundle": {
+            "type": "object",
+            "properties": {
+                "destinations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleDestination"
+                    }
+                },
+                "exported_at": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleJob"
+                    }
+                },
+                "project_id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "secrets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "source/pg-staging/password"
+                    ]
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleSource"
+                    }
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v1"
+                }
+            }
+        },
+        "dto.JobDataItem": {
+            "type": "object",
+            "properties": {
+                "activate": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "destination_name": {
+                    "type": "string",
+                    "example": "my-iceberg-destination"
+                },
+                "destination_type": {
+                    "type": "string",
+                    "example": "iceberg"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_run_state": {
+                    "type": "string",
+                    "example": "completed"
+                },
+                "last_run_time": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-sync-job"
+                },
+                "source_name": {
+                    "type": "string",
+                    "example": "my-postgres-source"
+                },
+                "source_type": {
+                    "type": "string",
+                    "example": "postgres"
+                }
+            }
+        },
+        "dto.JobDependenciesRequest": {
+            "type": "object",
+            "properties": {
+                "policy": {
+                    "description": "\"all_succeeded\" | \"any_completed\"",
+                    "type": "string",
+                    "example": "all_succeeded"
+                },
+                "upstream_job_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2
+                    ]
+                }
+            }
+        },
+        "dto.JobDependenciesResponse": {
+            "type": "object",
+            "properties": {
+                "downstreams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JobDependencyRef"
+                    }
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "policy": {
+                    "type": "string",
+                    "example": "all_succeeded"
+                },
+                "upstreams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JobUpstream"
+                    }
+                },
+                "waiting_on": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.JobDependencyRef": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "deleted": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "raw-orders"
+                }
+            }
+        },
+        "dto.JobResponse": {
+            "type": "object",
+            "properties": {
+                "activate": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "advanced_settings": {
+                    "$ref": "#/definitions/dto.AdvancedSettings"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "destination": {
+                    "$ref": "#/definitions/dto.DriverConfig"
+                },
+                "driver_resources": {
+                    "description": "DriverResources are the resources the job's driver container runs with, server\ndefaults filled in",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.DriverResources"
+                        }
+                    ]
+                },
+                "effective_time_zone": {
+                    "description": "the job's, else the project default, else UTC",
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "execution_policy": {
+                    "description": "ExecutionPolicy is the policy the job's syncs run with, defaults filled in",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ExecutionPolicy"
+                        }
+                    ]
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "0 */6 * * *"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "last_run_state": {
+                    "type": "string",
+                    "example": "completed"
+                },
+                "last_run_time": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "last_run_type": {
+                    "description": "\"sync\" | \"clear-destination\"",
+                    "type": "string",
+                    "example": "sync"
+                },
+                "maintenance_windows": {
+                    "description": "MaintenanceWindows are the windows of the job's project, source and the job itself",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MaintenanceWindowItem"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-sync-job"
+                },
+                "schedule_error": {
+                    "type": "string",
+                    "example": "failed to update schedule: context deadline exceeded"
+                },
+                "schedule_mode": {
+                    "description": "\"cron\" | \"interval\" | \"calendar\" | \"manual\" | \"once\"",
+                    "type": "string",
+                    "example": "cron"
+                },
+                "schedule_status": {
+                    "description": "ScheduleStatus is set while a change to the job's temporal schedule is not applied yet",
+                    "type": "string",
+                    "example": "pending"
+                },
+                "source": {
+                    "$ref": "#/definitions/dto.DriverConfig"
+                },
+                "streams_config": {
+                    "type": "string"
+                },
+                "task_queue": {
+                    "type": "string",
+                    "example": "OLAKE_CDC_QUEUE"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "trigger_deferred_until": {
+                    "type": "string",
+                    "example": "2024-01-10T03:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "updated_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "worker_pool": {
+                    "description": "WorkerPool and TaskQueue are where the job's runs go: the pool of its advanced settings,\nelse the pool of its source, else the default pool",
+                    "type": "string",
+                    "example": "cdc-large"
+                }
+            }
+        },
+        "dto.JobRevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "description": "\"source\" | \"destination\" | \"frequency\" | \"streams_config\" | \"advanced_settings\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "streams_config"
+                    ]
+                },
+                "from": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "stream_changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JobRevisionStreamChange"
+                    }
+                },
+                "to": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "dto.JobRevisionItem": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "description": "\"baseline\" | \"create\" | \"update\" | \"rollback\"",
+                    "type": "string",
+                    "example": "update"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "destination_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "0 */6 * * *"
+                },
+                "restored_from": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "revision": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "source_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dto.JobRevisionStreamChange": {
+            "type": "object",
+            "properties": {
+                "added_columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "discount"
+                    ]
+                },
+                "change": {
+                    "type": "string",
+                    "example": "modified"
+                },
+                "destructive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "partition_regex"
+                    ]
+                },
+                "removed_columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "notes"
+                    ]
+                },
+                "stream": {
+                    "type": "string",
+                    "example": "public.orders"
+                },
+                "sync_mode": {
+                    "$ref": "#/definitions/dto.SyncModeChange"
+                }
+            }
+        },
+        "dto.JobRevisionsResponse": {
+            "type": "object",
+            "properties": {
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JobRevisionItem"
+                    }
+                }
+            }
+        },
+        "dto.JobRollbackResponse": {
+            "type": "object",
+            "properties": {
+                "cleared_streams": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "public.orders"
+                    ]
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "restored_from": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "revision": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "dto.JobRunResponse": {
+            "type": "object",
+            "properties": {
+                "ad_hoc": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "close_time": {
+                    "type": "string",
+                    "example": "2024-01-09T12:01:30Z"
+                },
+                "done": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "duration": {
+                    "type": "string",
+                    "example": "1m30s"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "sync failed: connection refused"
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "job_type": {
+                    "description": "\"sync\" | \"clear\"",
+                    "type": "string",
+                    "example": "sync"
+                },
+                "run_id": {
+                    "type": "string",
+                    "example": "0b6a2f9c-5a4e-4f0e-9a57-5d7c1c2b4e11"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Completed"
+                },
+                "succeeded": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "workflow_id": {
+                    "type": "string",
+                    "example": "sync-123-1-2024-01-09T12:00:00Z"
+                }
+            }
+        },
+        "dto.JobStateHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "snapshots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JobStateSnapshotItem"
+                    }
+                }
+            }
+        },
+        "dto.JobStateResponse": {
+            "type": "object",
+            "properties": {
+                "cdc_position": {},
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "snapshot_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "streams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StreamState"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "GLOBAL"
+                }
+            }
+        },
+        "dto.JobStateSnapshotItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "reason": {
+                    "description": "\"baseline\" | \"sync\" | \"clear_destination\" | \"restore\"",
+                    "type": "string",
+                    "example": "sync"
+                },
+                "restored_from": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "run_id": {
+                    "type": "string",
+                    "example": "0b6a2f9c-5a4e-4f0e-9a57-5d7c1c2b4e11"
+                },
+                "workflow_id": {
+                    "type": "string",
+                    "example": "sync-123-1-1704800000"
+                }
+            }
+        },
+        "dto.JobStatusRequest": {
+            "type": "object",
+            "properties": {
+                "activate": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dto.JobTask": {
+            "type": "object",
+            "properties": {
+                "ad_hoc": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "file_path": {
+                    "type": "string",
+                    "example": "sync-123-2-2026-01-19T13:45:09Z"
+                },
+                "job_type": {
+                    "description": "\"sync\" | \"clear-destination\"",
+                    "type": "string",
+                    "example": "sync"
+                },
+                "runtime": {
+                    "type": "string",
+                    "example": "1m30s"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                }
+            }
+        },
+        "dto.JobTaskRequest": {
+            "type": "object",
+            "required": [
+                "file_path"
+            ],
+            "properties": {
+                "file_path": {
+                    "type": "string",
+                    "example": "sync-123-2-2026-01-19T13:45:09Z"
+                }
+            }
+        },
+        "dto.JobTemplateRequest": {
             "type": "object",
             "required": [
-                "config",
-                "name",
-                "type",
-                "version"
+                "source_ids"
             ],
             "properties": {
-                "config": {
-                    "type": "string",
-                    "example": "{\"catalog_type\":\"glue\",\"warehouse\":\"s3://my-bucket/warehouse\"}"
+                "destination_id": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "name": {
+                "name_pattern": {
                     "type": "string",
-                    "example": "my-iceberg-destination"
+                    "example": "{job}-{source}"
                 },
-                "type": {
+                "source_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        3,
+                        4
+                    ]
+                }
+            }
+        },
+        "dto.JobTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JobTemplateResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 39
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "dto.JobTemplateResult": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string",
-                    "example": "iceberg"
+                    "example": "job name 'my-sync-job-tenant-a' is not unique"
                 },
-                "version": {
+                "job_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "job_name": {
                     "type": "string",
-                    "example": "v0.2.7"
+                    "example": "my-sync-job-tenant-a"
+                },
+                "source_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "dto.CreateJobRequest": {
+        "dto.JobUpstream": {
             "type": "object",
-            "required": [
-                "destination",
-                "frequency",
-                "name",
-                "source",
-                "streams_config"
-            ],
             "properties": {
-                "activate": {
+                "active": {
                     "type": "boolean",
                     "example": true
                 },
-                "advanced_settings": {
-                    "$ref": "#/definitions/dto.AdvancedSettings"
+                "deleted": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "destination": {
-                    "$ref": "#/definitions/dto.DriverConfig"
+                "job_id": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "frequency": {
+                "last_closed_at": {
                     "type": "string",
-                    "example": "0 */6 * * *"
+                    "example": "2024-01-09T12:01:30Z"
                 },
-                "name": {
+                "last_workflow_id": {
                     "type": "string",
-                    "example": "my-sync-job"
+                    "example": "sync-123-2-2024-01-09T12:00:00Z"
                 },
-                "source": {
-                    "$ref": "#/definitions/dto.DriverConfig"
+                "name": {
+                    "type": "string",
+                    "example": "raw-orders"
                 },
-                "streams_config": {
-                    "type": "string"
+                "status": {
+                    "description": "\"pending\" | \"completed\" | \"failed\"",
+                    "type": "string",
+                    "example": "completed"
                 }
             }
         },
-        "dto.CreateSourceRequest": {
+        "dto.LabelsResponse": {
+            "type": "object",
+            "properties": {
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.LoginRequest": {
             "type": "object",
             "required": [
-                "config",
-                "name",
-                "type",
-                "version"
+                "password",
+                "username"
             ],
             "properties": {
-                "config": {
+                "password": {
                     "type": "string",
-                    "example": "{\"host\":\"localhost\",\"port\":5432,\"database\":\"mydb\",\"user\":\"postgres\",\"password\":\"secret\"}"
+                    "example": "password"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "dto.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "dto.MaintenanceWindowItem": {
+            "type": "object",
+            "properties": {
+                "active_until": {
+                    "type": "string",
+                    "example": "2024-01-10T03:00:00+01:00"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "days": {
+                    "type": "string",
+                    "example": "mon-fri"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "03:00"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2024-01-21T04:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 2
                 },
                 "name": {
                     "type": "string",
-                    "example": "my-postgres-source"
+                    "example": "nightly backup"
                 },
-                "type": {
+                "recurring": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "scope": {
+                    "description": "\"project\" | \"source\" | \"job\"",
                     "type": "string",
-                    "example": "postgres"
+                    "example": "source"
                 },
-                "version": {
+                "source_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_time": {
                     "type": "string",
-                    "example": "v0.2.7"
+                    "example": "01:00"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2024-01-20T22:00:00Z"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
                 }
             }
         },
-        "dto.CreateUserRequest": {
+        "dto.MaintenanceWindowRequest": {
             "type": "object",
             "required": [
-                "email",
-                "password",
-                "username"
+                "name"
             ],
             "properties": {
-                "email": {
+                "days": {
+                    "description": "\"daily\" | \"weekdays\" | \"weekends\" | days such as \"mon,wed\" or \"mon-fri\"",
                     "type": "string",
-                    "example": "admin@example.com"
+                    "example": "mon-fri"
                 },
-                "password": {
+                "end_time": {
                     "type": "string",
-                    "example": "password"
+                    "example": "03:00"
                 },
-                "username": {
+                "ends_at": {
                     "type": "string",
-                    "example": "admin"
+                    "example": "2024-01-21T04:00:00Z"
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "nightly backup"
+                },
+                "source_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "01:00"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2024-01-20T22:00:00Z"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
                 }
             }
         },
-        "dto.DeleteDestinationResponse": {
+        "dto.PaginatedResponse": {
             "type": "object",
             "properties": {
-                "name": {
+                "items": {},
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "next_cursor": {
                     "type": "string",
-                    "example": "my-iceberg-destination"
+                    "example": "eyJ2IjoiMjAyNC0wMS0wOVQxMjowMDowMFoiLCJpZCI6NDJ9"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "dto.PatchLabelsRequest": {
+            "type": "object",
+            "properties": {
+                "remove": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "team"
+                    ]
+                },
+                "set": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.ProjectSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "default_time_zone": {
+                    "type": "string",
+                    "example": "Asia/Kolkata"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "project_id": {
+                    "type": "string",
+                    "example": "project-123"
+                },
+                "webhook_alert_url": {
+                    "type": "string",
+                    "example": "https://hooks.slack.com/services/xxx/yyy/zzz"
+                }
+            }
+        },
+        "dto.ProjectSettingsSpec": {
+            "type": "object",
+            "properties": {
+                "webhook_alert_url": {
+                    "type": "string",
+                    "example": "https://hooks.slack.com/services/T000/B000/XXXX"
+                }
+            }
+        },
+        "dto.ProjectSpec": {
+            "type": "object",
+            "properties": {
+                "destinations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleDestination"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleJob"
+                    }
+                },
+                "prune": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "settings": {
+                    "$ref": "#/definitions/dto.ProjectSettingsSpec"
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BundleSource"
+                    }
                 }
             }
         },
-        "dto.DeleteSourceResponse": {
+        "dto.ReconcileItem": {
             "type": "object",
             "properties": {
-                "name": {
+                "drift": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "schedule_paused"
+                    ]
+                },
+                "error": {
                     "type": "string",
-                    "example": "my-postgres-source"
+                    "example": "failed to describe schedule"
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "job_name": {
+                    "type": "string",
+                    "example": "orders-sync"
+                },
+                "project_id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "repaired": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "schedule_id": {
+                    "type": "string",
+                    "example": "schedule-sync-123-7"
                 }
             }
         },
-        "dto.DestinationDataItem": {
+        "dto.ReconcileResponse": {
             "type": "object",
             "properties": {
-                "config": {
-                    "type": "string",
-                    "example": "{\"catalog_type\":\"glue\",\"warehouse\":\"s3://bucket/warehouse\"}"
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "drifted": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "created_by": {
-                    "type": "string",
-                    "example": "admin"
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "id": {
+                "failed": {
                     "type": "integer",
-                    "example": 1
+                    "example": 0
                 },
-                "jobs": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.JobDataItem"
+                        "$ref": "#/definitions/dto.ReconcileItem"
                     }
                 },
-                "name": {
-                    "type": "string",
-                    "example": "my-iceberg-destination"
-                },
-                "type": {
-                    "type": "string",
-                    "example": "iceberg"
+                "jobs": {
+                    "type": "integer",
+                    "example": 40
                 },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2024-01-09T12:00:00Z"
+                "repaired": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "updated_by": {
-                    "type": "string",
-                    "example": "admin"
+                "schedules": {
+                    "type": "integer",
+                    "example": 41
                 },
-                "version": {
-                    "type": "string",
-                    "example": "v0.2.7"
+                "skipped": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "dto.DestinationTestConnectionRequest": {
+        "dto.ReleaseMetadataResponse": {
             "type": "object",
-            "required": [
-                "config",
-                "type",
-                "version"
-            ],
             "properties": {
-                "config": {
-                    "type": "string",
-                    "example": "{\"catalog_type\":\"glue\",\"warehouse\":\"s3://my-bucket/warehouse\"}"
+                "date": {
+                    "type": "string"
                 },
-                "source_type": {
-                    "type": "string",
-                    "example": "postgres"
+                "description": {
+                    "type": "string"
                 },
-                "source_version": {
-                    "type": "string",
-                    "example": "v0.2.7"
+                "link": {
+                    "type": "string"
                 },
-                "type": {
-                    "type": "string",
-                    "example": "iceberg"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
                 },
                 "version": {
-                    "type": "string",
-                    "example": "v0.2.7"
+                    "type": "string"
                 }
             }
         },
-        "dto.DriverConfig": {
+        "dto.ReleaseTypeData": {
             "type": "object",
             "properties": {
-                "config": {
-                    "type": "string",
-                    "example": "{\"host\":\"localhost\",\"port\":5432}"
+                "current_version": {
+                    "type": "string"
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "releases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ReleaseMetadataResponse"
+                    }
+                }
+            }
+        },
+        "dto.ReleasesResponse": {
+            "type": "object",
+            "properties": {
+                "features": {
+                    "$ref": "#/definitions/dto.ReleaseTypeData"
                 },
-                "name": {
-                    "type": "string",
-                    "example": "my-postgres-source"
+                "olake": {
+                    "$ref": "#/definitions/dto.ReleaseTypeData"
                 },
-                "type": {
-                    "type": "string",
-                    "example": "postgres"
+                "olake_helm": {
+                    "$ref": "#/definitions/dto.ReleaseTypeData"
                 },
-                "version": {
-                    "type": "string",
-                    "example": "v0.2.7"
+                "olake_ui_worker": {
+                    "$ref": "#/definitions/dto.ReleaseTypeData"
                 }
             }
         },
-        "dto.Error400Response": {
+        "dto.ReplaceLabelsRequest": {
             "type": "object",
+            "required": [
+                "labels"
+            ],
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Bad request"
-                },
-                "success": {
-                    "type": "boolean",
-                    "example": false
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.Error401Response": {
+        "dto.ResourceQuantities": {
             "type": "object",
             "properties": {
-                "message": {
+                "cpu": {
                     "type": "string",
-                    "example": "Authentication required"
+                    "example": "500m"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": false
+                "memory": {
+                    "type": "string",
+                    "example": "2Gi"
                 }
             }
         },
-        "dto.Error404Response": {
+        "dto.ResyncStreamsRequest": {
             "type": "object",
+            "required": [
+                "streams"
+            ],
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Resource not found"
-                },
-                "success": {
+                "clear_destination": {
                     "type": "boolean",
-                    "example": false
+                    "example": true
+                },
+                "streams": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "public.orders"
+                    ]
                 }
             }
         },
-        "dto.Error409Response": {
+        "dto.ResyncStreamsResponse": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Resource already exists"
-                },
-                "success": {
+                "cleared_destination": {
                     "type": "boolean",
-                    "example": false
+                    "example": true
+                },
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "snapshot_id": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "streams": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "public.orders"
+                    ]
                 }
             }
         },
-        "dto.Error413Response": {
+        "dto.RetryPolicy": {
             "type": "object",
             "properties": {
-                "message": {
+                "backoff_coefficient": {
+                    "type": "number",
+                    "example": 2
+                },
+                "initial_interval": {
                     "type": "string",
-                    "example": "Payload too large"
+                    "example": "1m"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": false
+                "max_attempts": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "max_interval": {
+                    "type": "string",
+                    "example": "1h"
                 }
             }
         },
-        "dto.Error500Response": {
+        "dto.RunCalendarOverlap": {
             "type": "object",
             "properties": {
-                "message": {
+                "end_time": {
                     "type": "string",
-                    "example": "Internal server error"
+                    "example": "2024-01-09T13:12:00Z"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": false
+                "job_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "2024-01-09T13:00:00Z"
                 }
             }
         },
-        "dto.JSONResponse": {
+        "dto.RunCalendarResponse": {
             "type": "object",
             "properties": {
-                "data": {},
-                "message": {
+                "from": {
                     "type": "string",
-                    "example": "operation completed successfully"
+                    "example": "2024-01-09T12:00:00Z"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RunCalendarSource"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2024-01-10T12:00:00Z"
                 }
             }
         },
-        "dto.JobDataItem": {
+        "dto.RunCalendarRun": {
             "type": "object",
             "properties": {
-                "activate": {
-                    "type": "boolean",
-                    "example": true
+                "end_time": {
+                    "type": "string",
+                    "example": "2024-01-09T13:12:00Z"
                 },
-                "destination_name": {
+                "estimated_duration": {
                     "type": "string",
-                    "example": "my-iceberg-destination"
+                    "example": "2m0s"
                 },
-                "destination_type": {
+                "jitter": {
                     "type": "string",
-                    "example": "iceberg"
+                    "example": "10m0s"
                 },
-                "id": {
+                "job_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "last_run_state": {
+                "job_name": {
                     "type": "string",
-                    "example": "completed"
+                    "example": "orders-sync"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "2024-01-09T13:00:00Z"
+                }
+            }
+        },
+        "dto.RunCalendarSource": {
+            "type": "object",
+            "properties": {
+                "overlaps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RunCalendarOverlap"
+                    }
                 },
-                "last_run_time": {
-                    "type": "string",
-                    "example": "2024-01-09T12:00:00Z"
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RunCalendarRun"
+                    }
                 },
-                "name": {
-                    "type": "string",
-                    "example": "my-sync-job"
+                "source_id": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "source_name": {
                     "type": "string",
-                    "example": "my-postgres-source"
+                    "example": "orders-db"
                 },
                 "source_type": {
                     "type": "string",
@@ -3374,281 +9799,383 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.JobResponse": {
+        "dto.RunQueueItem": {
             "type": "object",
             "properties": {
-                "activate": {
-                    "type": "boolean",
-                    "example": true
+                "destination_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "advanced_settings": {
-                    "$ref": "#/definitions/dto.AdvancedSettings"
+                "id": {
+                    "type": "integer",
+                    "example": 7
                 },
-                "created_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "job_id": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "created_by": {
+                "job_name": {
                     "type": "string",
-                    "example": "admin"
-                },
-                "destination": {
-                    "$ref": "#/definitions/dto.DriverConfig"
+                    "example": "orders-sync"
                 },
-                "frequency": {
+                "kind": {
+                    "description": "\"sync\" | \"discover\"",
                     "type": "string",
-                    "example": "0 */6 * * *"
+                    "example": "sync"
                 },
-                "id": {
+                "position": {
                     "type": "integer",
                     "example": 1
                 },
-                "last_run_state": {
-                    "type": "string",
-                    "example": "completed"
-                },
-                "last_run_time": {
+                "queued_at": {
                     "type": "string",
                     "example": "2024-01-09T12:00:00Z"
                 },
-                "last_run_type": {
-                    "description": "\"sync\" | \"clear-destination\"",
-                    "type": "string",
-                    "example": "sync"
-                },
-                "name": {
+                "reason": {
                     "type": "string",
-                    "example": "my-sync-job"
-                },
-                "source": {
-                    "$ref": "#/definitions/dto.DriverConfig"
-                },
-                "streams_config": {
-                    "type": "string"
+                    "example": "source 'orders-db' runs 3 of 3 syncs"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2024-01-09T12:00:00Z"
+                "source_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "updated_by": {
+                "status": {
+                    "description": "\"queued\" | \"running\"",
                     "type": "string",
-                    "example": "admin"
+                    "example": "queued"
                 }
             }
         },
-        "dto.JobStatusRequest": {
-            "type": "object",
-            "properties": {
-                "activate": {
-                    "type": "boolean",
-                    "example": true
-                }
-            }
-        },
-        "dto.JobTask": {
+        "dto.ScheduleOperationItem": {
             "type": "object",
             "properties": {
-                "file_path": {
+                "applied_at": {
                     "type": "string",
-                    "example": "sync-123-2-2026-01-19T13:45:09Z"
+                    "example": "2024-01-09T12:00:21Z"
                 },
-                "job_type": {
-                    "description": "\"sync\" | \"clear-destination\"",
+                "attempts": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
                     "type": "string",
-                    "example": "sync"
+                    "example": "2024-01-09T12:00:00Z"
                 },
-                "runtime": {
+                "id": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "last_error": {
                     "type": "string",
-                    "example": "1m30s"
+                    "example": "failed to update schedule: context deadline exceeded"
                 },
-                "start_time": {
+                "next_attempt_at": {
                     "type": "string",
-                    "example": "2024-01-09T12:00:00Z"
+                    "example": "2024-01-09T12:00:20Z"
+                },
+                "operation": {
+                    "type": "string",
+                    "example": "update_schedule"
                 },
                 "status": {
+                    "description": "\"pending\" | \"applied\" | \"failed\" | \"superseded\"",
                     "type": "string",
-                    "example": "completed"
+                    "example": "pending"
                 }
             }
         },
-        "dto.JobTaskRequest": {
+        "dto.ScheduleOperationsResponse": {
             "type": "object",
-            "required": [
-                "file_path"
-            ],
             "properties": {
-                "file_path": {
+                "job_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ScheduleOperationItem"
+                    }
+                },
+                "schedule_status": {
+                    "description": "\"applied\" | \"pending\" | \"failed\"",
                     "type": "string",
-                    "example": "sync-123-2-2026-01-19T13:45:09Z"
+                    "example": "pending"
                 }
             }
         },
-        "dto.LoginRequest": {
+        "dto.SchedulePreviewRequest": {
             "type": "object",
             "required": [
-                "password",
-                "username"
+                "frequency"
             ],
             "properties": {
-                "password": {
+                "count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "frequency": {
                     "type": "string",
-                    "example": "password"
+                    "example": "weekdays at 02:30 jitter 5m"
                 },
-                "username": {
+                "source_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "time_zone": {
                     "type": "string",
-                    "example": "admin"
+                    "example": "Europe/Berlin"
                 }
             }
         },
-        "dto.LoginResponse": {
+        "dto.SchedulePreviewResponse": {
             "type": "object",
             "properties": {
-                "username": {
+                "fire_times": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2024-01-09T02:30:00+01:00"
+                    ]
+                },
+                "frequency": {
                     "type": "string",
-                    "example": "admin"
-                }
-            }
-        },
-        "dto.ProjectSettingsResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
+                    "example": "weekdays at 02:30 jitter 5m"
+                },
+                "jitter": {
+                    "type": "string",
+                    "example": "5m0s"
+                },
+                "job_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "project_id": {
+                "paused": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "schedule_mode": {
+                    "description": "\"cron\" | \"interval\" | \"calendar\" | \"manual\" | \"once\"",
                     "type": "string",
-                    "example": "project-123"
+                    "example": "calendar"
                 },
-                "webhook_alert_url": {
+                "source": {
+                    "description": "\"temporal\" | \"local\"",
                     "type": "string",
-                    "example": "https://hooks.slack.com/services/xxx/yyy/zzz"
+                    "example": "local"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
                 }
             }
         },
-        "dto.ReleaseMetadataResponse": {
+        "dto.SourceDataItem": {
             "type": "object",
             "properties": {
-                "date": {
-                    "type": "string"
+                "config": {
+                    "type": "string",
+                    "example": "{\"host\":\"localhost\",\"port\":5432}"
                 },
-                "description": {
-                    "type": "string"
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "link": {
-                    "type": "string"
+                "created_by": {
+                    "type": "string",
+                    "example": "admin"
                 },
-                "tags": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "jobs": {
                     "type": "array",
                     "items": {
+                        "$ref": "#/definitions/dto.JobDataItem"
+                    }
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
                         "type": "string"
                     }
                 },
-                "title": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "my-postgres-source"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "postgres"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "updated_by": {
+                    "type": "string",
+                    "example": "admin"
                 },
                 "version": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "v0.2.7"
+                },
+                "worker_pool": {
+                    "description": "WorkerPool is the worker pool of the source, empty for the default pool",
+                    "type": "string",
+                    "example": "vpc-orders"
                 }
             }
         },
-        "dto.ReleaseTypeData": {
+        "dto.SourceTestConnectionRequest": {
             "type": "object",
+            "required": [
+                "config",
+                "type",
+                "version"
+            ],
             "properties": {
-                "current_version": {
-                    "type": "string"
+                "config": {
+                    "type": "string",
+                    "example": "{\"host\":\"localhost\",\"port\":5432,\"database\":\"mydb\",\"user\":\"postgres\",\"password\":\"secret\"}"
                 },
-                "releases": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.ReleaseMetadataResponse"
-                    }
+                "type": {
+                    "type": "string",
+                    "example": "postgres"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v0.2.7"
+                },
+                "worker_pool": {
+                    "description": "WorkerPool runs the check on the workers of a pool, e.g. those that can reach the source",
+                    "type": "string",
+                    "example": "vpc-orders"
                 }
             }
         },
-        "dto.ReleasesResponse": {
+        "dto.Spec": {
             "type": "object",
             "properties": {
-                "features": {
-                    "$ref": "#/definitions/dto.ReleaseTypeData"
-                },
-                "olake": {
-                    "$ref": "#/definitions/dto.ReleaseTypeData"
-                },
-                "olake_helm": {
-                    "$ref": "#/definitions/dto.ReleaseTypeData"
+                "projects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProjectSpec"
+                    }
                 },
-                "olake_ui_worker": {
-                    "$ref": "#/definitions/dto.ReleaseTypeData"
+                "version": {
+                    "type": "string",
+                    "example": "v1"
                 }
             }
         },
-        "dto.SourceDataItem": {
+        "dto.SpecChange": {
             "type": "object",
             "properties": {
-                "config": {
+                "action": {
                     "type": "string",
-                    "example": "{\"host\":\"localhost\",\"port\":5432}"
+                    "example": "update"
                 },
-                "created_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "destructive": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "created_by": {
+                "error": {
                     "type": "string",
-                    "example": "admin"
-                },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                    "example": "failed to update job"
                 },
-                "jobs": {
+                "fields": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.JobDataItem"
-                    }
+                        "type": "string"
+                    },
+                    "example": [
+                        "streams_config"
+                    ]
                 },
-                "name": {
-                    "type": "string",
-                    "example": "my-postgres-source"
+                "id": {
+                    "type": "integer",
+                    "example": 7
                 },
-                "type": {
+                "kind": {
                     "type": "string",
-                    "example": "postgres"
+                    "example": "job"
                 },
-                "updated_at": {
+                "name": {
                     "type": "string",
-                    "example": "2024-01-09T12:00:00Z"
+                    "example": "orders-sync"
                 },
-                "updated_by": {
+                "project_id": {
                     "type": "string",
-                    "example": "admin"
+                    "example": "123"
                 },
-                "version": {
-                    "type": "string",
-                    "example": "v0.2.7"
+                "stream_changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StreamChange"
+                    }
                 }
             }
         },
-        "dto.SourceTestConnectionRequest": {
+        "dto.SpecPlanRequest": {
             "type": "object",
             "required": [
-                "config",
-                "type",
-                "version"
+                "spec"
             ],
             "properties": {
-                "config": {
-                    "type": "string",
-                    "example": "{\"host\":\"localhost\",\"port\":5432,\"database\":\"mydb\",\"user\":\"postgres\",\"password\":\"secret\"}"
+                "allow_destructive": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "type": {
-                    "type": "string",
-                    "example": "postgres"
+                "spec": {
+                    "$ref": "#/definitions/dto.Spec"
+                }
+            }
+        },
+        "dto.SpecPlanResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "version": {
-                    "type": "string",
-                    "example": "v0.2.7"
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SpecChange"
+                    }
+                },
+                "creates": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "deletes": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "destructive": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "job 'orders-sync' references source 'pg' that is not in the spec"
+                    ]
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "updates": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
@@ -3667,6 +10194,10 @@ const docTemplate = `{
                 "version": {
                     "type": "string",
                     "example": "v0.2.7"
+                },
+                "worker_pool": {
+                    "type": "string",
+                    "example": "default"
                 }
             }
         },
@@ -3686,6 +10217,32 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.StreamChange": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "string",
+                    "example": "modified"
+                },
+                "destructive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "partition_regex"
+                    ]
+                },
+                "stream": {
+                    "type": "string",
+                    "example": "public.orders"
+                }
+            }
+        },
         "dto.StreamDifferenceRequest": {
             "type": "object",
             "required": [
@@ -3705,6 +10262,36 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.StreamState": {
+            "type": "object",
+            "properties": {
+                "cdc": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "cursor_field": {
+                    "type": "string",
+                    "example": "updated_at"
+                },
+                "cursor_value": {},
+                "pending_chunks": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "state": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "stream": {
+                    "type": "string",
+                    "example": "public.orders"
+                },
+                "sync_mode": {
+                    "type": "string",
+                    "example": "incremental"
+                }
+            }
+        },
         "dto.StreamsRequest": {
             "type": "object",
             "required": [
@@ -3736,6 +10323,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "my-postgres-source"
                 },
+                "source_id": {
+                    "description": "SourceID is the saved source being discovered, counted against its discover cap when\njob_id does not name a job",
+                    "type": "integer",
+                    "example": 1
+                },
                 "type": {
                     "type": "string",
                     "example": "postgres"
@@ -3743,6 +10335,98 @@ const docTemplate = `{
                 "version": {
                     "type": "string",
                     "example": "v0.2.7"
+                },
+                "worker_pool": {
+                    "description": "WorkerPool runs the discover on the workers of a pool instead of those of the job or source",
+                    "type": "string",
+                    "example": "vpc-orders"
+                }
+            }
+        },
+        "dto.SyncJobRequest": {
+            "type": "object",
+            "properties": {
+                "override_maintenance_window": {
+                    "description": "OverrideMaintenanceWindow runs the sync even while the job is in a maintenance window",
+                    "type": "boolean",
+                    "example": false
+                },
+                "overrides": {
+                    "$ref": "#/definitions/dto.SyncRunOverrides"
+                },
+                "streams": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "public.orders"
+                    ]
+                }
+            }
+        },
+        "dto.SyncJobResponse": {
+            "type": "object",
+            "properties": {
+                "ad_hoc": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "already_running": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "sync triggered successfully"
+                },
+                "queued": {
+                    "description": "Queued is set when a concurrency cap held the sync back, it starts once a slot is free",
+                    "type": "boolean",
+                    "example": false
+                },
+                "run_id": {
+                    "type": "string",
+                    "example": "0b6a2f9c-5a4e-4f0e-9a57-5d7c1c2b4e11"
+                },
+                "streams": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "public.orders"
+                    ]
+                },
+                "timeout": {
+                    "type": "string",
+                    "example": "2h0m0s"
+                },
+                "workflow_id": {
+                    "type": "string",
+                    "example": "sync-123-1-adhoc-1704800000"
+                }
+            }
+        },
+        "dto.SyncModeChange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "full_refresh"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "cdc"
+                }
+            }
+        },
+        "dto.SyncRunOverrides": {
+            "type": "object",
+            "properties": {
+                "timeout": {
+                    "type": "string",
+                    "example": "2h"
                 }
             }
         },
@@ -3800,6 +10484,76 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TrashItem": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "description": "\"job\" | \"source\" | \"destination\"",
+                    "type": "string",
+                    "example": "job"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-sync-job"
+                },
+                "purge_at": {
+                    "type": "string",
+                    "example": "2024-02-08T12:00:00Z"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "postgres"
+                }
+            }
+        },
+        "dto.TrashResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TrashItem"
+                    }
+                }
+            }
+        },
+        "dto.TriggerTokenItem": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-09T12:00:00Z"
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-01-10T06:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "airflow"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "olt_3fa9c1"
+                }
+            }
+        },
         "dto.UpdateDestinationRequest": {
             "type": "object",
             "required": [
@@ -3864,6 +10618,11 @@ const docTemplate = `{
                 },
                 "streams_config": {
                     "type": "string"
+                },
+                "time_zone": {
+                    "description": "omitted keeps the zone, empty follows the project default",
+                    "type": "string",
+                    "example": "Europe/Berlin"
                 }
             }
         },
@@ -3891,6 +10650,11 @@ const docTemplate = `{
                 "version": {
                     "type": "string",
                     "example": "v0.2.7"
+                },
+                "worker_pool": {
+                    "description": "WorkerPool changes the worker pool of the source when set, \"\" being the default pool",
+                    "type": "string",
+                    "example": "vpc-orders"
                 }
             }
         },
@@ -3900,8 +10664,16 @@ const docTemplate = `{
                 "state_file"
             ],
             "properties": {
+                "run_id": {
+                    "type": "string",
+                    "example": "0b6a2f9c-5a4e-4f0e-9a57-5d7c1c2b4e11"
+                },
                 "state_file": {
                     "type": "string"
+                },
+                "workflow_id": {
+                    "type": "string",
+                    "example": "sync-123-1-1704800000"
                 }
             }
         },
@@ -3941,6 +10713,11 @@ const docTemplate = `{
                 "project_id"
             ],
             "properties": {
+                "default_time_zone": {
+                    "description": "DefaultTimeZone is left as it is when omitted; an empty string resets it to UTC",
+                    "type": "string",
+                    "example": "Asia/Kolkata"
+                },
                 "id": {
                     "type": "integer",
                     "example": 1
@@ -3988,6 +10765,31 @@ const docTemplate = `{
                     ]
                 }
             }
+        },
+        "dto.WorkerPoolItem": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "error": {
+                    "type": "string",
+                    "example": "failed to describe task queue"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "cdc-large"
+                },
+                "pollers": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "task_queue": {
+                    "type": "string",
+                    "example": "OLAKE_CDC_QUEUE"
+                }
+            }
         }
     },
     "tags": [
@@ -4011,6 +10813,10 @@ const docTemplate = `{
             "description": "Project configuration endpoints",
             "name": "Project Settings"
         },
+        {
+            "description": "Declarative configuration endpoints",
+            "name": "Spec"
+        },
         {
             "description": "Platform-level operations",
             "name": "Platform"
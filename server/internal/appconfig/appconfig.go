@@ -3,6 +3,7 @@ package appconfig
 import (
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/viper"
@@ -34,17 +35,49 @@ type Config struct {
 	TemporalAPIKey        string
 	TemporalExternal      bool
 	TemporalTaskQueue     string
+	// TemporalWorkerPools names the task queues of extra worker pools as name=queue pairs
+	TemporalWorkerPools   string
 	ContainerRegistryBase string
 	EnableOptimization    bool
 	OptimizationGroup     string
 	OptimizationBaseURL   string
 	OptimizationUsername  string
 	OptimizationPassword  string
+	ProvisioningDir       string
+	ProvisioningUser      string
+	// ProvisioningAllowDestructive lets provisioning apply stream changes that clear destination data
+	ProvisioningAllowDestructive bool
+	// SpecEnvPrefix is the prefix of the environment variables that specs received over the API
+	// may reference; they may reference none when it is empty
+	SpecEnvPrefix            string
+	ReconcileInterval        time.Duration
+	ReconcileDryRun          bool
+	ScheduleDispatchInterval time.Duration
+	// TrashRetention is how long deleted jobs, sources and destinations stay restorable
+	TrashRetention time.Duration
+	// DependencyWatchInterval is how often closed syncs of upstream jobs are looked for
+	DependencyWatchInterval time.Duration
+	// ConcurrencyCheckInterval is how often queued runs are started and concurrency caps enforced
+	ConcurrencyCheckInterval time.Duration
+	// DriverCPURequest and the other driver settings are the default resources of the driver
+	// containers and the most a job may set, as kubernetes quantities
+	DriverCPURequest    string
+	DriverCPULimit      string
+	DriverMemoryRequest string
+	DriverMemoryLimit   string
+	DriverMaxCPU        string
+	DriverMaxMemory     string
 }
 
-var cfg = loadConfig()
+var (
+	cfg      Config
+	loadOnce sync.Once
+)
 
+// Load returns the configuration, read on first use so that packages depending on it can be
+// loaded without a config file, as in unit tests
 func Load() Config {
+	loadOnce.Do(func() { cfg = loadConfig() })
 	return cfg
 }
 
@@ -85,6 +118,7 @@ func loadConfig() Config {
 		TemporalAPIKey:        strings.TrimSpace(v.GetString("TEMPORAL_API_KEY")),
 		TemporalExternal:      v.GetBool("TEMPORAL_EXTERNAL"),
 		TemporalTaskQueue:     strings.TrimSpace(v.GetString("TEMPORAL_TASK_QUEUE")),
+		TemporalWorkerPools:   strings.TrimSpace(v.GetString("TEMPORAL_WORKER_POOLS")),
 		MaxMemory:             v.GetInt64("MAX_MEMORY"),
 		MaxUploadSize:         v.GetInt64("MAX_UPLOAD_SIZE"),
 		SessionOn:             v.GetBool("SESSION_ON"),
@@ -103,5 +137,25 @@ func loadConfig() Config {
 		OptimizationBaseURL:  strings.TrimSpace(v.GetString("OPTIMIZATION_BASE_URL")),
 		OptimizationUsername: strings.TrimSpace(v.GetString("USERNAME")),
 		OptimizationPassword: strings.TrimSpace(v.GetString("PASSWORD")),
+
+		ProvisioningDir:              strings.TrimSpace(v.GetString("PROVISIONING_DIR")),
+		ProvisioningUser:             strings.TrimSpace(v.GetString("PROVISIONING_USER")),
+		ProvisioningAllowDestructive: v.GetBool("PROVISIONING_ALLOW_DESTRUCTIVE"),
+		SpecEnvPrefix:                strings.TrimSpace(v.GetString("SPEC_ENV_PREFIX")),
+
+		ReconcileInterval: v.GetDuration("RECONCILE_INTERVAL"),
+		ReconcileDryRun:   v.GetBool("RECONCILE_DRY_RUN"),
+
+		ScheduleDispatchInterval: v.GetDuration("SCHEDULE_DISPATCH_INTERVAL"),
+		TrashRetention:           v.GetDuration("TRASH_RETENTION"),
+		DependencyWatchInterval:  v.GetDuration("DEPENDENCY_WATCH_INTERVAL"),
+		ConcurrencyCheckInterval: v.GetDuration("CONCURRENCY_CHECK_INTERVAL"),
+
+		DriverCPURequest:    strings.TrimSpace(v.GetString("DRIVER_CPU_REQUEST")),
+		DriverCPULimit:      strings.TrimSpace(v.GetString("DRIVER_CPU_LIMIT")),
+		DriverMemoryRequest: strings.TrimSpace(v.GetString("DRIVER_MEMORY_REQUEST")),
+		DriverMemoryLimit:   strings.TrimSpace(v.GetString("DRIVER_MEMORY_LIMIT")),
+		DriverMaxCPU:        strings.TrimSpace(v.GetString("DRIVER_MAX_CPU")),
+		DriverMaxMemory:     strings.TrimSpace(v.GetString("DRIVER_MAX_MEMORY")),
 	}
 }
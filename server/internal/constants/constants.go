@@ -15,7 +15,7 @@ var (
 	commitsha        = "Not Set"
 	releasechannel   = "Not Set"
 	defaultBaseHost  = "localhost"
-	DefaultTimeZone  = "Asia/Kolkata"
+	DefaultTimeZone  = "UTC" // schedule time zone of jobs without one of their own or a project default
 	DefaultUsername  = "olake"
 	DefaultPassword  = "password"
 	EncryptionKey    = "OLAKE_SECRET_KEY"
@@ -26,6 +26,207 @@ var (
 	DefaultCancelSyncWaitTime   = 30 * time.Second
 	DefaultListWorkflowPageSize = 500
 
+	// list endpoints
+	MaxListPageSize  = 500
+	SortKeyName      = "name"
+	SortKeyCreatedAt = "created_at"
+	SortKeyUpdatedAt = "updated_at"
+	SortOrderAsc     = "asc"
+	SortOrderDesc    = "desc"
+
+	// bulk job operations
+	MaxBulkJobs           = 500
+	BulkJobOperationLimit = 10 // maximum temporal calls in flight for a bulk operation
+
+	// job templates
+	DefaultJobTemplateNamePattern = "{job}-{source}"
+
+	// job bundles
+	BundleVersion           = "v1"
+	BundleFormatJSON        = "json"
+	BundleFormatYAML        = "yaml"
+	ImportOnConflictFail    = "fail"
+	ImportOnConflictUpdate  = "update"
+	ImportActionCreate      = "create"
+	ImportActionUpdate      = "update"
+	BundleKindSource        = "source"
+	BundleKindDestination   = "destination"
+	BundleKindJob           = "job"
+	SecretPlaceholderPrefix = "${secret:"
+	SecretPlaceholderSuffix = "}"
+
+	// declarative specs
+	SpecVersion                  = "v1"
+	SpecKindProjectSettings      = "project_settings"
+	SpecActionCreate             = "create"
+	SpecActionUpdate             = "update"
+	SpecActionDelete             = "delete"
+	StreamChangeAdded            = "added"
+	StreamChangeRemoved          = "removed"
+	StreamChangeModified         = "modified"
+	EnvReferencePrefix           = "${env:"
+	EnvReferenceSuffix           = "}"
+	ProvisioningUserWaitTimeout  = 10 * time.Minute
+	ProvisioningUserPollInterval = 5 * time.Second
+
+	// schedule drift, as reported by spec plans and the reconciler
+	DriftScheduleMissing   = "schedule"
+	DriftScheduleFrequency = "schedule_frequency"
+	DriftSchedulePaused    = "schedule_paused"
+	DriftScheduleAction    = "schedule_action"
+	DriftOrphanSchedule    = "orphan_schedule"
+	// jobs and schedules changed more recently than this may be mid-update and are not reconciled
+	ReconcileGracePeriod = 2 * time.Minute
+
+	// schedule outbox
+	ScheduleOpCreate           = "create_schedule"
+	ScheduleOpUpdate           = "update_schedule"
+	ScheduleOpPause            = "pause_schedule"
+	ScheduleOpResume           = "resume_schedule"
+	ScheduleOpDelete           = "delete_schedule"
+	ScheduleOpTrigger          = "trigger_schedule"
+	ScheduleOpStatusPending    = "pending"
+	ScheduleOpStatusApplied    = "applied"
+	ScheduleOpStatusFailed     = "failed"
+	ScheduleOpStatusSuperseded = "superseded"
+	ScheduleOpMaxAttempts      = 10
+	ScheduleOpBatchSize        = 50
+	ScheduleOpLease            = time.Minute
+	ScheduleOpRetryBaseDelay   = 5 * time.Second
+	ScheduleOpRetryMaxDelay    = 10 * time.Minute
+	// a trigger waiting for its schedule to be free is tried again after this, without
+	// counting as an attempt
+	ScheduleOpWaitInterval = 30 * time.Second
+	ScheduleOpRetention    = 7 * 24 * time.Hour
+	MaxScheduleOpsListed   = 50
+
+	// job frequencies without a schedule spec: manual jobs only run when triggered, once jobs
+	// run right away and pause themselves after a successful run
+	FrequencyManual  = "manual"
+	FrequencyOnce    = "once"
+	ScheduleModeCron = "cron"
+	// interval schedules fire every N minutes, hours, days or weeks, calendar schedules on
+	// given days of the week at given times
+	ScheduleModeInterval = "interval"
+	ScheduleModeCalendar = "calendar"
+	MaxScheduleJitter    = time.Hour
+	MaxScheduleTimes     = 24 // times of day in one calendar schedule
+	MaxScheduleEvery     = 1000
+
+	// schedule previews list the next fire times of a schedule, taken from its temporal
+	// schedule or computed locally from the frequency; the run calendar lists the runs of
+	// the active jobs of a project within a window
+	SchedulePreviewDefaultCount   = 10
+	SchedulePreviewMaxCount       = 100
+	SchedulePreviewSourceTemporal = "temporal"
+	SchedulePreviewSourceLocal    = "local"
+	RunCalendarDefaultWindow      = 24 * time.Hour
+	RunCalendarMaxWindow          = 7 * 24 * time.Hour
+	RunCalendarMaxRunsPerJob      = 500
+	RunCalendarHistoryPages       = 5 // pages of completed syncs read to estimate run durations
+
+	// backfills run the fire times of a schedule that no run covered, e.g. during an outage:
+	// once for the latest of them, or once for each in order
+	BackfillModeCatchUp    = "catch_up"
+	BackfillModePerWindow  = "per_window"
+	MaxBackfillRange       = 7 * 24 * time.Hour
+	MaxBackfillWindows     = 100             // per job
+	BackfillMatchTolerance = 2 * time.Minute // how late a run may start and still cover a fire time
+	BackfillHistoryPages   = 5               // pages of runs read to find the missed fire times of a job
+
+	// maintenance windows keep the schedules of a project, a source or a job from firing
+	MaintenanceScopeProject  = "project"
+	MaintenanceScopeSource   = "source"
+	MaintenanceScopeJob      = "job"
+	MaxMaintenanceWindows    = 100 // per project
+	MaxMaintenanceWindowSpan = 31 * 24 * time.Hour
+	MaxBlackoutSkips         = 1000 // blackouts skipped when looking for the next fire time
+
+	// trash
+	TrashPurgeInterval = time.Hour
+
+	// job revisions
+	JobRevisionChangeBaseline = "baseline"
+	JobRevisionChangeCreate   = "create"
+	JobRevisionChangeUpdate   = "update"
+	JobRevisionChangeRollback = "rollback"
+
+	// job state history
+	StateSnapshotReasonBaseline         = "baseline"
+	StateSnapshotReasonSync             = "sync"
+	StateSnapshotReasonClearDestination = "clear_destination"
+	StateSnapshotReasonRestore          = "restore"
+	StateSnapshotReasonResync           = "resync"
+	StateHistoryLimit                   = 30
+
+	// stream resync: how often and how long to wait for clear-destination before the sync
+	ResyncPollInterval = 15 * time.Second
+	ResyncWaitTimeout  = 24 * time.Hour
+
+	// sync runs: how long a trigger looks for the run it started, and how long a status
+	// request may wait for a run to close. A wait also ends RunWaitWriteMargin before the
+	// HTTP write timeout, so that the response is still written.
+	SyncTriggerPollInterval = 500 * time.Millisecond
+	SyncTriggerWaitTimeout  = 10 * time.Second
+	RunWaitDefaultTimeout   = time.Minute
+	RunWaitMaxTimeout       = 30 * time.Minute
+	RunWaitWriteMargin      = 30 * time.Second
+
+	// execution policy of a job, see dto.AdvancedSettings. A sync without a run timeout of
+	// its own may run for DefaultSyncTimeout, which is also the longest run timeout.
+	DefaultSyncTimeout          = 30 * 24 * time.Hour
+	MinRunTimeout               = time.Minute
+	MaxRetryAttempts            = 10
+	DefaultRetryInitialInterval = time.Minute
+	DefaultRetryBackoff         = 2.0
+	DefaultRetryMaxInterval     = time.Hour
+	DefaultCatchupWindow        = 365 * 24 * time.Hour // temporal's default
+	MinCatchupWindow            = 10 * time.Second     // temporal's minimum
+	OverlapPolicySkip           = "skip"
+	OverlapPolicyBufferOne      = "buffer_one"
+	OverlapPolicyCancelOther    = "cancel_other"
+
+	// driver container of a job, see dto.AdvancedSettings. Resource maximums and defaults
+	// are server settings, see appconfig.
+	MaxDriverEnvVars = 50
+
+	// trigger tokens let external schedulers run and watch a single job
+	TriggerTokenPrefix     = "olt_"
+	TriggerTokenBytes      = 32
+	MaxTriggerTokensPerJob = 10
+
+	// job dependencies: a downstream job is triggered once all of its upstream jobs have
+	// completed a sync, or once a sync of any of them has closed, failed or not, since it was
+	// last triggered by them
+	DependencyPolicyAllSucceeded = "all_succeeded"
+	DependencyPolicyAnyCompleted = "any_completed"
+	DependencyRunPending         = "pending"
+	DependencyRunCompleted       = "completed"
+	DependencyRunFailed          = "failed"
+	MaxUpstreamJobs              = 20
+	DependencyWatchPageSize      = 5
+
+	// concurrency caps limit the syncs and discovers running at once on a project, a source
+	// or a destination, 0 being no cap. Runs held back by a cap wait in the run queue.
+	ConcurrencyScopeProject     = "project"
+	ConcurrencyScopeSource      = "source"
+	ConcurrencyScopeDestination = "destination"
+	MaxConcurrencyCap           = 1000
+	RunKindSync                 = "sync"
+	RunKindDiscover             = "discover"
+	RunQueueStatusQueued        = "queued"
+	RunQueueStatusRunning       = "running"
+	ConcurrencyAdmissionGrace   = 2 * time.Minute  // scheduled syncs started over a cap this recently are cancelled and queued
+	ConcurrencyHistoryPages     = 5                // pages of running syncs read per project
+	DiscoverQueuePollInterval   = 2 * time.Second  // how often a queued discover checks for a free slot
+	DiscoverQueueTimeout        = 10 * time.Minute // how long a discover waits for a slot
+	DiscoverSlotLease           = 15 * time.Minute // when the slot of a discover is freed if its server stops
+
+	// labels
+	MaxLabelsPerEntity  = 64
+	MaxLabelKeyLength   = 63
+	MaxLabelValueLength = 63
+
 	// versions
 	DefaultSpecVersion               = "v0.2.0"
 	DefaultClearDestinationVersion   = "v0.3.0"
@@ -38,6 +239,8 @@ var (
 	// Frontend index path key
 	FrontendIndexPath = "FRONTEND_INDEX_PATH"
 	TemporalTaskQueue = "OLAKE_DOCKER_TASK_QUEUE"
+	// DefaultWorkerPool is the name of the workers polling the default task queue
+	DefaultWorkerPool = "default"
 
 	// command flags
 	MaxDiscoverThreadsFlag    = "--max-discover-threads"
@@ -126,6 +329,28 @@ var (
 	ProjectIDParam   = "projectid"
 )
 
+// WorkflowExecutionStatuses are the temporal execution statuses accepted by task filters
+var WorkflowExecutionStatuses = []string{
+	"Running",
+	"Completed",
+	"Failed",
+	"Canceled",
+	"Terminated",
+	"ContinuedAsNew",
+	"TimedOut",
+}
+
+// Actions supported by the bulk job operations endpoint
+const (
+	BulkActionPause           = "pause"
+	BulkActionResume          = "resume"
+	BulkActionTrigger         = "trigger"
+	BulkActionCancel          = "cancel"
+	BulkActionChangeFrequency = "change_frequency"
+	BulkActionChangeVersion   = "change_version"
+	BulkActionDelete          = "delete"
+)
+
 // Supported database/source types
 var SupportedSourceTypes = []string{
 	"mysql",
@@ -144,6 +369,32 @@ var SupportedDestinationTypes = []string{
 	"iceberg",
 }
 
+// SecretConfigKeys are the key fragments that mark a driver config value as a secret.
+// Matching values are replaced by placeholders when jobs are exported.
+var SecretConfigKeys = []string{
+	"password",
+	"secret",
+	"token",
+	"private_key",
+	"access_key",
+	"account_key",
+	"api_key",
+	"credential",
+	"passphrase",
+}
+
+// DestructiveStreamFields are the stream settings whose change alters the layout of the data
+// already written to the destination, so the stream has to be cleared and re-synced.
+var DestructiveStreamFields = []string{
+	"normalization",
+	"partition_regex",
+	"append_mode",
+	"sync_mode",
+	"cursor_field",
+	"destination_database",
+	"destination_table",
+}
+
 var AppVersion string
 
 func Init() {
@@ -170,13 +421,20 @@ func Init() {
 
 	// init table names
 	TableNameMap = map[TableType]string{
-		UserTable:            "olake-$$-user",
-		SourceTable:          "olake-$$-source",
-		DestinationTable:     "olake-$$-destination",
-		JobTable:             "olake-$$-job",
-		CatalogTable:         "olake-$$-catalog",
-		SessionTable:         "session",
-		ProjectSettingsTable: "olake-$$-project-settings",
+		UserTable:              "olake-$$-user",
+		SourceTable:            "olake-$$-source",
+		DestinationTable:       "olake-$$-destination",
+		JobTable:               "olake-$$-job",
+		CatalogTable:           "olake-$$-catalog",
+		SessionTable:           "session",
+		ProjectSettingsTable:   "olake-$$-project-settings",
+		ScheduleOutboxTable:    "olake-$$-schedule-outbox",
+		JobRevisionTable:       "olake-$$-job-revision",
+		JobStateSnapshotTable:  "olake-$$-job-state-snapshot",
+		JobTriggerTokenTable:   "olake-$$-job-trigger-token",
+		JobDependencyTable:     "olake-$$-job-dependency",
+		MaintenanceWindowTable: "olake-$$-maintenance-window",
+		RunQueueTable:          "olake-$$-run-queue",
 	}
 
 	// replace $$ with the environment
@@ -14,6 +14,78 @@ var (
 	ErrSourceNotFound      = errors.New("source not found")
 	ErrDestinationNotFound = errors.New("destination not found")
 	ErrJobNotFound         = errors.New("job not found")
+	ErrJobNameNotUnique    = errors.New("job name is not unique")
+	ErrSourceTypeMismatch  = errors.New("source type mismatch")
+
+	// List query related errors
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// Bulk operation related errors
+	ErrBulkLimitExceeded = errors.New("bulk job limit exceeded")
+
+	// Bundle import related errors
+	ErrImportConflict = errors.New("bundle import has conflicts")
+
+	// Declarative spec related errors
+	ErrInvalidSpec        = errors.New("spec is invalid")
+	ErrDestructiveChanges = errors.New("plan contains destructive stream changes")
+
+	// Reconciler related errors
+	ErrReconcileInProgress = errors.New("reconciliation is already in progress")
+
+	// Schedule outbox related errors
+	ErrSchedulePending = errors.New("schedule change is pending")
+	ErrScheduleBusy    = errors.New("schedule cannot be triggered yet")
+
+	// Schedule related errors
+	ErrInvalidTimeZone  = errors.New("invalid time zone")
+	ErrInvalidFrequency = errors.New("invalid frequency")
+
+	// Maintenance window related errors
+	ErrMaintenanceWindowNotFound = errors.New("maintenance window not found")
+	ErrMaintenanceWindowLimit    = errors.New("maintenance window limit reached")
+	ErrInMaintenanceWindow       = errors.New("job is in a maintenance window")
+
+	// Concurrency related errors
+	ErrConcurrencyLimit      = errors.New("concurrency limit reached")
+	ErrRunQueueEntryNotFound = errors.New("queued run not found")
+
+	// Worker pool related errors
+	ErrUnknownWorkerPool = errors.New("unknown worker pool")
+	ErrNoWorkerPollers   = errors.New("no workers are polling the task queue")
+
+	// Driver container related errors
+	ErrInvalidDriverResources = errors.New("invalid driver resources")
+	ErrMissingSecretValue     = errors.New("secret environment variable has no value")
+
+	// Job revision related errors
+	ErrJobRevisionNotFound = errors.New("job revision not found")
+	ErrRollbackConflict    = errors.New("cannot roll back job")
+
+	// Job state related errors
+	ErrStateSnapshotNotFound = errors.New("state snapshot not found")
+	ErrJobNotIdle            = errors.New("job is not idle")
+	ErrJobPaused             = errors.New("job is paused")
+	ErrStreamNotSelected     = errors.New("stream is not selected in the job")
+
+	// Sync run related errors
+	ErrInvalidSyncRun       = errors.New("invalid sync run")
+	ErrRunNotFound          = errors.New("run not found")
+	ErrInvalidTriggerToken  = errors.New("invalid trigger token")
+	ErrTriggerTokenLimit    = errors.New("trigger token limit reached")
+	ErrTriggerTokenNotFound = errors.New("trigger token not found")
+
+	// Job dependency related errors
+	ErrInvalidDependency = errors.New("invalid job dependency")
+	ErrDependencyCycle   = errors.New("job dependencies form a cycle")
+
+	// Trash related errors
+	ErrRestoreConflict = errors.New("cannot restore from trash")
+
+	// Label related errors
+	ErrInvalidLabel         = errors.New("invalid label")
+	ErrInvalidLabelSelector = errors.New("invalid label selector")
+	ErrLabelNotFound        = errors.New("label not found")
 )
 
 // Validation messages
@@ -11,4 +11,11 @@ const (
 	CatalogTable
 	SessionTable
 	ProjectSettingsTable
+	ScheduleOutboxTable
+	JobRevisionTable
+	JobStateSnapshotTable
+	JobTriggerTokenTable
+	JobDependencyTable
+	MaintenanceWindowTable
+	RunQueueTable
 )
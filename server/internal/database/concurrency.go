@@ -0,0 +1,263 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+// DiscoverLimits are the discover caps that apply to a discover, 0 being no cap
+type DiscoverLimits struct {
+	Project     int
+	Source      int
+	Destination int
+}
+
+// ListCappedSources retrieves the sources of a project with a sync or discover cap
+func (db *Database) ListCappedSources(projectID string) ([]*models.Source, error) {
+	sources := []*models.Source{}
+	err := db.conn.
+		Scopes(notDeleted).
+		Select("id", "name", "project_id", "max_concurrent_syncs", "max_concurrent_discovers").
+		Where("project_id = ? AND (max_concurrent_syncs > 0 OR max_concurrent_discovers > 0)", projectID).
+		Order("id ASC").
+		Find(&sources).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list capped sources project_id[%s]: %s", projectID, err)
+	}
+	return sources, nil
+}
+
+// ListCappedDestinations retrieves the destinations of a project with a sync or discover cap
+func (db *Database) ListCappedDestinations(projectID string) ([]*models.Destination, error) {
+	destinations := []*models.Destination{}
+	err := db.conn.
+		Scopes(notDeleted).
+		Select("id", "name", "project_id", "max_concurrent_syncs", "max_concurrent_discovers").
+		Where("project_id = ? AND (max_concurrent_syncs > 0 OR max_concurrent_discovers > 0)", projectID).
+		Order("id ASC").
+		Find(&destinations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list capped destinations project_id[%s]: %s", projectID, err)
+	}
+	return destinations, nil
+}
+
+// ListConcurrencyProjectIDs retrieves the projects that have a sync cap on the project, a
+// source or a destination, or queued syncs
+func (db *Database) ListConcurrencyProjectIDs() ([]string, error) {
+	var projectIDs []string
+	err := db.conn.Raw(fmt.Sprintf(`
+		SELECT project_id FROM %q WHERE max_concurrent_syncs > 0
+		UNION SELECT project_id FROM %q WHERE max_concurrent_syncs > 0 AND deleted_at IS NULL
+		UNION SELECT project_id FROM %q WHERE max_concurrent_syncs > 0 AND deleted_at IS NULL
+		UNION SELECT project_id FROM %q WHERE kind = ?`,
+		constants.TableNameMap[constants.ProjectSettingsTable],
+		constants.TableNameMap[constants.SourceTable],
+		constants.TableNameMap[constants.DestinationTable],
+		constants.TableNameMap[constants.RunQueueTable],
+	), constants.RunKindSync).Scan(&projectIDs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects with concurrency caps: %s", err)
+	}
+	return projectIDs, nil
+}
+
+// SetProjectConcurrencyLimits sets the caps of a project, creating its settings if it has
+// none yet
+func (db *Database) SetProjectConcurrencyLimits(projectID string, maxSyncs, maxDiscovers int) error {
+	row := &models.ProjectSettings{ProjectID: projectID, MaxConcurrentSyncs: maxSyncs, MaxConcurrentDiscovers: maxDiscovers}
+	if err := db.conn.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "project_id"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"max_concurrent_syncs":     maxSyncs,
+				"max_concurrent_discovers": maxDiscovers,
+			}),
+		}).
+		Create(row).Error; err != nil {
+		return fmt.Errorf("failed to set concurrency limits of project_id[%s]: %s", projectID, err)
+	}
+	return nil
+}
+
+// SetSourceConcurrencyLimits sets the caps of a source of a project
+func (db *Database) SetSourceConcurrencyLimits(projectID string, id, maxSyncs, maxDiscovers int) error {
+	result := db.conn.Model(&models.Source{}).
+		Scopes(notDeleted).
+		Where("project_id = ? AND id = ?", projectID, id).
+		UpdateColumns(map[string]any{"max_concurrent_syncs": maxSyncs, "max_concurrent_discovers": maxDiscovers})
+	if result.Error != nil {
+		return fmt.Errorf("failed to set concurrency limits of source[%d]: %s", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: id[%d] project_id[%s]", constants.ErrSourceNotFound, id, projectID)
+	}
+	return nil
+}
+
+// SetDestinationConcurrencyLimits sets the caps of a destination of a project
+func (db *Database) SetDestinationConcurrencyLimits(projectID string, id, maxSyncs, maxDiscovers int) error {
+	result := db.conn.Model(&models.Destination{}).
+		Scopes(notDeleted).
+		Where("project_id = ? AND id = ?", projectID, id).
+		UpdateColumns(map[string]any{"max_concurrent_syncs": maxSyncs, "max_concurrent_discovers": maxDiscovers})
+	if result.Error != nil {
+		return fmt.Errorf("failed to set concurrency limits of destination[%d]: %s", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: id[%d] project_id[%s]", constants.ErrDestinationNotFound, id, projectID)
+	}
+	return nil
+}
+
+// EnqueueSyncRun queues a sync of a job. A job has at most one queued sync, a sync queued
+// again keeps its place and takes the new reason.
+func (db *Database) EnqueueSyncRun(entry *models.RunQueueEntry) error {
+	return db.conn.Transaction(func(tx *gorm.DB) error {
+		existing := &models.RunQueueEntry{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("kind = ? AND job_id = ?", constants.RunKindSync, entry.JobID).
+			First(existing).Error
+		switch {
+		case err == nil:
+			*entry = *existing
+			return tx.Model(existing).UpdateColumn("reason", entry.Reason).Error
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return fmt.Errorf("failed to find queued sync of job_id[%d]: %s", *entry.JobID, err)
+		}
+		entry.Kind = constants.RunKindSync
+		entry.Status = constants.RunQueueStatusQueued
+		if err := tx.Create(entry).Error; err != nil {
+			return fmt.Errorf("failed to queue sync of job_id[%d]: %s", *entry.JobID, err)
+		}
+		return nil
+	})
+}
+
+// ListRunQueue retrieves the queued and running entries of the run queue of a project in
+// queue order, leaving out discovers whose lease ran out
+func (db *Database) ListRunQueue(projectID string) ([]*models.RunQueueEntry, error) {
+	entries := []*models.RunQueueEntry{}
+	err := db.conn.
+		Where("project_id = ? AND (lease_until IS NULL OR lease_until > ?)", projectID, time.Now()).
+		Order("id ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list run queue project_id[%s]: %s", projectID, err)
+	}
+	return entries, nil
+}
+
+// UpdateRunQueueReason records why a queued run is still waiting
+func (db *Database) UpdateRunQueueReason(id int, reason string) error {
+	if err := db.conn.Model(&models.RunQueueEntry{}).Where("id = ?", id).UpdateColumn("reason", reason).Error; err != nil {
+		return fmt.Errorf("failed to update run queue entry[%d]: %s", id, err)
+	}
+	return nil
+}
+
+// DeleteRunQueueEntry removes an entry from the run queue of a project
+func (db *Database) DeleteRunQueueEntry(projectID string, id int) error {
+	result := db.conn.Where("project_id = ? AND id = ?", projectID, id).Delete(&models.RunQueueEntry{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete run queue entry[%d]: %s", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: id[%d] project_id[%s]", constants.ErrRunQueueEntryNotFound, id, projectID)
+	}
+	return nil
+}
+
+// DeleteExpiredRunQueueEntries removes the discovers whose lease ran out before the given time
+func (db *Database) DeleteExpiredRunQueueEntries(before time.Time) (int64, error) {
+	result := db.conn.Where("lease_until IS NOT NULL AND lease_until < ?", before).Delete(&models.RunQueueEntry{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired run queue entries: %s", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// EnqueueDiscoverRun queues a discover, leased until it would have timed out waiting
+func (db *Database) EnqueueDiscoverRun(entry *models.RunQueueEntry) error {
+	leaseUntil := time.Now().Add(constants.DiscoverQueueTimeout + constants.DiscoverQueuePollInterval)
+	entry.Kind = constants.RunKindDiscover
+	entry.Status = constants.RunQueueStatusQueued
+	entry.LeaseUntil = &leaseUntil
+	if err := db.conn.Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to queue discover project_id[%s]: %s", entry.ProjectID, err)
+	}
+	return nil
+}
+
+// TryStartDiscover starts a queued discover when every cap it counts against has room and no
+// discover queued before it shares a capped scope with it. It returns why the discover has to
+// keep waiting, empty once it is started. Discovers of a project are started one at a time.
+func (db *Database) TryStartDiscover(entry *models.RunQueueEntry, limits DiscoverLimits) (string, error) {
+	var reason string
+	err := db.conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "run-queue-"+entry.ProjectID).Error; err != nil {
+			return fmt.Errorf("failed to lock run queue project_id[%s]: %s", entry.ProjectID, err)
+		}
+		now := time.Now()
+		live := func() *gorm.DB {
+			return tx.Model(&models.RunQueueEntry{}).
+				Where("project_id = ? AND kind = ? AND lease_until > ?", entry.ProjectID, constants.RunKindDiscover, now)
+		}
+
+		scopes := []struct {
+			name   string
+			limit  int
+			column string
+			id     *int
+		}{
+			{constants.ConcurrencyScopeProject, limits.Project, "", nil},
+			{constants.ConcurrencyScopeSource, limits.Source, "source_id", entry.SourceID},
+			{constants.ConcurrencyScopeDestination, limits.Destination, "destination_id", entry.DestinationID},
+		}
+		for _, scope := range scopes {
+			if scope.limit <= 0 || (scope.column != "" && scope.id == nil) {
+				continue
+			}
+			scoped := func() *gorm.DB {
+				query := live()
+				if scope.column != "" {
+					query = query.Where(scope.column+" = ?", *scope.id)
+				}
+				return query
+			}
+			var running, ahead int64
+			if err := scoped().Where("status = ?", constants.RunQueueStatusRunning).Count(&running).Error; err != nil {
+				return fmt.Errorf("failed to count running discovers: %s", err)
+			}
+			if running >= int64(scope.limit) {
+				reason = fmt.Sprintf("%s runs %d of %d discovers", scope.name, running, scope.limit)
+				break
+			}
+			if err := scoped().Where("status = ? AND id < ?", constants.RunQueueStatusQueued, entry.ID).Count(&ahead).Error; err != nil {
+				return fmt.Errorf("failed to count queued discovers: %s", err)
+			}
+			if ahead > 0 {
+				reason = fmt.Sprintf("%d earlier discovers of the %s are queued", ahead, scope.name)
+				break
+			}
+		}
+
+		params := map[string]any{"reason": reason, "lease_until": now.Add(constants.DiscoverQueueTimeout + constants.DiscoverQueuePollInterval)}
+		if reason == "" {
+			params["status"] = constants.RunQueueStatusRunning
+			params["lease_until"] = now.Add(constants.DiscoverSlotLease)
+		}
+		if err := tx.Model(&models.RunQueueEntry{}).Where("id = ?", entry.ID).UpdateColumns(params).Error; err != nil {
+			return fmt.Errorf("failed to update run queue entry[%d]: %s", entry.ID, err)
+		}
+		return nil
+	})
+	return reason, err
+}
@@ -45,6 +45,13 @@ func Init() (*Database, error) {
 		new(models.Job),
 		new(models.User),
 		new(models.Catalog),
+		new(models.ScheduleOperation),
+		new(models.JobRevision),
+		new(models.JobStateSnapshot),
+		new(models.JobTriggerToken),
+		new(models.JobDependency),
+		new(models.MaintenanceWindow),
+		new(models.RunQueueEntry),
 	); err != nil {
 		return nil, fmt.Errorf("failed to run automigrate: %s", err)
 	}
@@ -67,6 +74,14 @@ func Init() (*Database, error) {
 	return &Database{conn: conn}, nil
 }
 
+// Transaction runs fn in a database transaction. The Database passed to fn writes through
+// the transaction, which is committed when fn returns nil and rolled back otherwise.
+func (db *Database) Transaction(fn func(tx *Database) error) error {
+	return db.conn.Transaction(func(tx *gorm.DB) error {
+		return fn(&Database{conn: tx})
+	})
+}
+
 // BuildPostgresURIFromConfig reads POSTGRES_DB_HOST, POSTGRES_DB_PORT, etc. from app.conf
 // and constructs the Postgres connection URI.
 func BuildPostgresURIFromConfig() (string, error) {
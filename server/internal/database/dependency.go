@@ -0,0 +1,143 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+// LockProjectDependencies serialises dependency changes within a project until the
+// transaction ends, so that two changes cannot form a cycle together. Call it within a
+// transaction.
+func (db *Database) LockProjectDependencies(projectID string) error {
+	if err := db.conn.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "job-dependencies-"+projectID).Error; err != nil {
+		return fmt.Errorf("failed to lock job dependencies of project_id[%s]: %s", projectID, err)
+	}
+	return nil
+}
+
+// ListProjectJobDependencies retrieves every job dependency of a project
+func (db *Database) ListProjectJobDependencies(projectID string) ([]*models.JobDependency, error) {
+	deps := []*models.JobDependency{}
+	if err := db.conn.Where("project_id = ?", projectID).Order("job_id ASC, upstream_job_id ASC").Find(&deps).Error; err != nil {
+		return nil, fmt.Errorf("failed to list job dependencies of project_id[%s]: %s", projectID, err)
+	}
+	return deps, nil
+}
+
+// ListAllJobDependencies retrieves the job dependencies of every project
+func (db *Database) ListAllJobDependencies() ([]*models.JobDependency, error) {
+	deps := []*models.JobDependency{}
+	if err := db.conn.Order("job_id ASC, upstream_job_id ASC").Find(&deps).Error; err != nil {
+		return nil, fmt.Errorf("failed to list job dependencies: %s", err)
+	}
+	return deps, nil
+}
+
+// SetJobDependencies replaces the upstream jobs and dependency policy of a job. Dependencies
+// that are kept keep the upstream syncs recorded for them. Call it within a transaction.
+func (db *Database) SetJobDependencies(jobID int, projectID, policy string, upstreamIDs []int) error {
+	if err := db.conn.Model(&models.Job{}).Where("id = ?", jobID).Update("dependency_policy", policy).Error; err != nil {
+		return fmt.Errorf("failed to update dependency policy of job_id[%d]: %s", jobID, err)
+	}
+
+	existing := []*models.JobDependency{}
+	if err := db.conn.Where("job_id = ?", jobID).Find(&existing).Error; err != nil {
+		return fmt.Errorf("failed to get dependencies of job_id[%d]: %s", jobID, err)
+	}
+	kept := make(map[int]bool, len(existing))
+	for _, dep := range existing {
+		if !slices.Contains(upstreamIDs, dep.UpstreamJobID) {
+			if err := db.conn.Delete(dep).Error; err != nil {
+				return fmt.Errorf("failed to delete dependency of job_id[%d] on job_id[%d]: %s", jobID, dep.UpstreamJobID, err)
+			}
+			continue
+		}
+		kept[dep.UpstreamJobID] = true
+	}
+
+	for _, upstreamID := range upstreamIDs {
+		if kept[upstreamID] {
+			continue
+		}
+		dep := &models.JobDependency{JobID: jobID, UpstreamJobID: upstreamID, ProjectID: projectID}
+		if err := db.conn.Create(dep).Error; err != nil {
+			return fmt.Errorf("failed to save dependency of job_id[%d] on job_id[%d]: %s", jobID, upstreamID, err)
+		}
+	}
+	return nil
+}
+
+// RecordUpstreamRun records a sync of an upstream job that closed with the given status on
+// the jobs depending on it, and returns those jobs. A sync recorded already, or older than
+// the latest one recorded or than the dependency itself, is left out.
+func (db *Database) RecordUpstreamRun(upstreamJobID int, workflowID, status string, closedAt time.Time) ([]int, error) {
+	updated := []*models.JobDependency{}
+	err := db.conn.Model(&updated).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "job_id"}}}).
+		Where("upstream_job_id = ? AND upstream_workflow_id <> ? AND created_at <= ?", upstreamJobID, workflowID, closedAt).
+		Where("upstream_closed_at IS NULL OR upstream_closed_at < ?", closedAt).
+		Updates(map[string]any{
+			"upstream_status":      status,
+			"upstream_workflow_id": workflowID,
+			"upstream_closed_at":   closedAt,
+		}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to record sync %s of upstream job_id[%d]: %s", workflowID, upstreamJobID, err)
+	}
+
+	jobIDs := make([]int, 0, len(updated))
+	for _, dep := range updated {
+		jobIDs = append(jobIDs, dep.JobID)
+	}
+	return jobIDs, nil
+}
+
+// LockJobDependencies reads a live job and its dependencies and locks the job row until the
+// transaction ends, so that its dependencies are evaluated one upstream sync at a time. A job
+// in the trash is returned as nil. Call it within a transaction.
+func (db *Database) LockJobDependencies(jobID int) (*models.Job, []*models.JobDependency, error) {
+	job := &models.Job{}
+	err := db.conn.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scopes(notDeleted).
+		Select("id", "project_id", "active", "dependency_policy").
+		Where("id = ?", jobID).
+		First(job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get job_id[%d] for dependencies: %s", jobID, err)
+	}
+
+	deps := []*models.JobDependency{}
+	if err := db.conn.Where("job_id = ?", jobID).Order("upstream_job_id ASC").Find(&deps).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to get dependencies of job_id[%d]: %s", jobID, err)
+	}
+	return job, deps, nil
+}
+
+// ResetJobDependencies forgets the upstream syncs recorded for a job, once they have
+// triggered it
+func (db *Database) ResetJobDependencies(jobID int) error {
+	if err := db.conn.Model(&models.JobDependency{}).Where("job_id = ?", jobID).Update("upstream_status", "").Error; err != nil {
+		return fmt.Errorf("failed to reset dependencies of job_id[%d]: %s", jobID, err)
+	}
+	return nil
+}
+
+// DeleteJobDependencies removes the dependencies of a job and on it, once the job itself is
+// purged
+func (db *Database) DeleteJobDependencies(jobID int) error {
+	if err := db.conn.Where("job_id = ? OR upstream_job_id = ?", jobID, jobID).Delete(&models.JobDependency{}).Error; err != nil {
+		return fmt.Errorf("failed to delete dependencies of job_id[%d]: %s", jobID, err)
+	}
+	return nil
+}
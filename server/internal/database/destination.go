@@ -37,6 +37,7 @@ func (db *Database) CreateDestination(destination *models.Destination) error {
 func (db *Database) ListDestinations() ([]*models.Destination, error) {
 	var destinations []*models.Destination
 	err := db.conn.
+		Scopes(notDeleted).
 		Preload("CreatedBy").
 		Preload("UpdatedBy").
 		Order("updated_at DESC").
@@ -53,29 +54,57 @@ func (db *Database) ListDestinations() ([]*models.Destination, error) {
 	return destinations, nil
 }
 
-func (db *Database) ListDestinationsByProjectID(projectID string) ([]*models.Destination, error) {
-	var destinations []*models.Destination
-	err := db.conn.
-		Where("project_id = ?", projectID).
+// ListDestinationsByProjectID retrieves one page of destinations belonging to a project
+// along with the total number of destinations matching the filters.
+func (db *Database) ListDestinationsByProjectID(projectID string, opts ListOptions) ([]*models.Destination, int64, error) {
+	query := db.conn.
+		Model(&models.Destination{}).
+		Scopes(notDeleted).
+		Where("project_id = ?", projectID)
+
+	if opts.Name != "" {
+		query = query.Where("name ILIKE ?", nameContains(opts.Name))
+	}
+	if opts.Type != "" {
+		query = query.Where("dest_type = ?", opts.Type)
+	}
+	if opts.CreatedBy != "" {
+		query = query.Where("created_by_id IN (?)", db.createdBySubQuery(opts.CreatedBy))
+	}
+	query = matchLabels(query, opts.Selector)
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count destinations project_id[%s]: %s", projectID, err)
+	}
+
+	query, err := paginate(query, opts, "name")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	destinations := []*models.Destination{}
+	err = query.
 		Preload("CreatedBy").
 		Preload("UpdatedBy").
-		Order("updated_at DESC").
 		Find(&destinations).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to list destinations project_id[%s]: %s", projectID, err)
+		return nil, 0, fmt.Errorf("failed to list destinations project_id[%s]: %s", projectID, err)
 	}
 
 	// Decrypt config after reading
 	if err := db.decryptDestinationSliceConfigs(destinations); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return destinations, nil
+	return destinations, total, nil
 }
 
 func (db *Database) GetDestinationByID(id int) (*models.Destination, error) {
 	var destination models.Destination
 	err := db.conn.
+		Scopes(notDeleted).
 		Where("id = ?", id).
 		Preload("CreatedBy").
 		Preload("UpdatedBy").
@@ -110,8 +139,9 @@ func (db *Database) UpdateDestination(destination *models.Destination) error {
 		Updates(destination).Error
 }
 
+// DeleteDestination moves a destination to the trash
 func (db *Database) DeleteDestination(id int) error {
-	result := db.conn.Delete(&models.Destination{}, "id = ?", id)
+	result := moveToTrash(db.conn.Model(&models.Destination{}), id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -18,6 +18,7 @@ var jobListColumns = []string{
 	"id",
 	"name",
 	"frequency",
+	"time_zone",
 	"active",
 	"created_at",
 	"updated_at",
@@ -27,6 +28,8 @@ var jobListColumns = []string{
 	"updated_by_id",
 	"project_id",
 	"advanced_settings",
+	"labels",
+	"trigger_deferred_until",
 }
 
 // decryptJobConfig decrypts Config fields in related Source and Destination
@@ -58,39 +61,66 @@ func (db *Database) CreateJob(job *models.Job) error {
 	return db.conn.Create(job).Error
 }
 
-// GetAllJobsByProjectID retrieves all jobs belonging to a specific project,
-// including related Source and Destination, sorted by latest update time.
+// ListJobsByProjectID retrieves one page of jobs belonging to a specific project,
+// including related Source and Destination, along with the total number of jobs
+// matching the filters.
 // Only fetches columns needed for JobResponse
 // Excludes: streams_config, state (not needed for JobResponse).
-func (db *Database) ListJobsByProjectID(projectID string) ([]*models.Job, error) {
-	var jobs []*models.Job
-
-	err := db.conn.
+func (db *Database) ListJobsByProjectID(projectID string, opts ListOptions) ([]*models.Job, int64, error) {
+	query := db.conn.
 		Model(&models.Job{}).
+		Scopes(notDeleted).
+		Where("project_id = ?", projectID)
+
+	if opts.Name != "" {
+		query = query.Where("name ILIKE ?", nameContains(opts.Name))
+	}
+	if opts.Type != "" {
+		query = query.Where(
+			"(source_id IN (?) OR dest_id IN (?))",
+			db.conn.Model(&models.Source{}).Select("id").Where("type = ?", opts.Type),
+			db.conn.Model(&models.Destination{}).Select("id").Where("dest_type = ?", opts.Type),
+		)
+	}
+	if opts.Active != nil {
+		query = query.Where("active = ?", *opts.Active)
+	}
+	if opts.CreatedBy != "" {
+		query = query.Where("created_by_id IN (?)", db.createdBySubQuery(opts.CreatedBy))
+	}
+	query = matchLabels(query, opts.Selector)
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count jobs project_id[%s]: %s", projectID, err)
+	}
+
+	query, err := paginate(query, opts, "name")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	jobs := []*models.Job{}
+	err = query.
 		Select(jobListColumns).
-		Where("project_id = ?", projectID).
 		Preload("Source").
 		Preload("Destination").
 		Preload("CreatedBy").
 		Preload("UpdatedBy").
-		Order("updated_at DESC").
 		Find(&jobs).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to list jobs project_id[%s]: %s", projectID, err)
+		return nil, 0, fmt.Errorf("failed to list jobs project_id[%s]: %s", projectID, err)
 	}
 
-	// If project has no jobs, return empty slice (not nil)
-	if len(jobs) == 0 {
-		return []*models.Job{}, nil
-	}
-
-	return jobs, nil
+	return jobs, total, nil
 }
 
 // GetByID retrieves a job by ID
 func (db *Database) GetJobByID(id int, decrypt bool) (*models.Job, error) {
 	job := &models.Job{}
 	err := db.conn.
+		Scopes(notDeleted).
 		Where("id = ?", id).
 		Preload("Source").
 		Preload("Destination").
@@ -122,6 +152,7 @@ func (db *Database) GetJobsBySourceID(sourceIDs []int) ([]*models.Job, error) {
 
 	// TODO: add context to all database queries
 	err := db.conn.
+		Scopes(notDeleted).
 		Where("source_id IN ?", sourceIDs).
 		Preload("Source").
 		Preload("Destination").
@@ -138,6 +169,7 @@ func (db *Database) GetJobsByDestinationID(destIDs []int) ([]*models.Job, error)
 		return jobs, nil
 	}
 	err := db.conn.
+		Scopes(notDeleted).
 		Where("dest_id IN ?", destIDs).
 		Preload("Source").
 		Preload("Destination").
@@ -148,6 +180,39 @@ func (db *Database) GetJobsByDestinationID(destIDs []int) ([]*models.Job, error)
 	return jobs, nil
 }
 
+// ListAllJobs retrieves the jobs of every project with their source, without
+// streams_config and state, including the jobs in the trash. It is used to reconcile
+// jobs with their schedules.
+func (db *Database) ListAllJobs() ([]*models.Job, error) {
+	jobs := []*models.Job{}
+	err := db.conn.
+		Select(jobListColumns).
+		Preload("Source").
+		Order("id ASC").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %s", err)
+	}
+	return jobs, nil
+}
+
+// ListActiveJobsByProjectID retrieves the active jobs of a project with their source, without
+// streams_config and state
+func (db *Database) ListActiveJobsByProjectID(projectID string) ([]*models.Job, error) {
+	jobs := []*models.Job{}
+	err := db.conn.
+		Scopes(notDeleted).
+		Select(jobListColumns).
+		Where("project_id = ? AND active = ?", projectID, true).
+		Preload("Source").
+		Order("id ASC").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active jobs project_id[%s]: %s", projectID, err)
+	}
+	return jobs, nil
+}
+
 // UpdateJob updates a job with the given params.
 func (db *Database) UpdateJob(jobID int, params map[string]any) error {
 	return db.conn.Model(&models.Job{}).
@@ -155,20 +220,51 @@ func (db *Database) UpdateJob(jobID int, params map[string]any) error {
 		Updates(params).Error
 }
 
-// BulkDeactivate deactivates multiple jobs by their IDs in a single query
-func (db *Database) DeactivateJobs(ids []int) error {
+// GetJobsByIDs retrieves the jobs of a project with the given IDs, including
+// related Source and Destination. IDs that do not exist are silently skipped.
+func (db *Database) GetJobsByIDs(projectID string, ids []int, decrypt bool) ([]*models.Job, error) {
+	jobs := []*models.Job{}
 	if len(ids) == 0 {
-		return nil
+		return jobs, nil
 	}
 
-	return db.conn.Model(&models.Job{}).
+	err := db.conn.
+		Scopes(notDeleted).
+		Where("project_id = ?", projectID).
 		Where("id IN ?", ids).
-		Updates(map[string]any{"active": false}).Error
+		Preload("Source").
+		Preload("Destination").
+		Order("id ASC").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs project_id[%s]: %s", projectID, err)
+	}
+
+	if decrypt {
+		// jobs sharing a source or destination may share the preloaded struct, decrypt each one once
+		var sources []*models.Source
+		var destinations []*models.Destination
+		for _, job := range jobs {
+			if job.Source != nil && !utils.ExistsInArray(sources, job.Source) {
+				sources = append(sources, job.Source)
+			}
+			if job.Destination != nil && !utils.ExistsInArray(destinations, job.Destination) {
+				destinations = append(destinations, job.Destination)
+			}
+		}
+		if err := db.decryptSourceSliceConfigs(sources); err != nil {
+			return nil, err
+		}
+		if err := db.decryptDestinationSliceConfigs(destinations); err != nil {
+			return nil, err
+		}
+	}
+	return jobs, nil
 }
 
-// Delete a job
+// DeleteJob moves a job to the trash
 func (db *Database) DeleteJob(id int) error {
-	result := db.conn.Delete(&models.Job{}, "id = ?", id)
+	result := moveToTrash(db.conn.Model(&models.Job{}), id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -187,6 +283,7 @@ func (db *Database) IsNameUniqueInProject(ctx context.Context, projectID, name s
 
 	var count int64
 	err := db.conn.Table(tableName).WithContext(ctx).
+		Scopes(notDeleted).
 		Where("name = ?", name).
 		Where("project_id = ?", projectID).
 		Count(&count).Error
@@ -200,3 +297,18 @@ func (db *Database) IsNameUniqueInProject(ctx context.Context, projectID, name s
 func (db *Database) IsJobNameUniqueInProject(ctx context.Context, projectID, jobName string) (bool, error) {
 	return db.IsNameUniqueInProject(ctx, projectID, jobName, constants.JobTable)
 }
+
+// ListJobIDsWithoutTimeZone retrieves the IDs of the live jobs of a project that have no
+// time zone of their own and so follow the project default
+func (db *Database) ListJobIDsWithoutTimeZone(projectID string) ([]int, error) {
+	var ids []int
+	err := db.conn.Model(&models.Job{}).
+		Scopes(notDeleted).
+		Where("project_id = ? AND (time_zone = '' OR time_zone IS NULL)", projectID).
+		Order("id ASC").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs without time zone project_id[%s]: %s", projectID, err)
+	}
+	return ids, nil
+}
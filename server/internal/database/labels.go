@@ -0,0 +1,88 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+// labelEntities maps the tables that carry labels to their not-found error.
+var labelEntities = map[constants.TableType]error{
+	constants.JobTable:         constants.ErrJobNotFound,
+	constants.SourceTable:      constants.ErrSourceNotFound,
+	constants.DestinationTable: constants.ErrDestinationNotFound,
+}
+
+// matchLabels restricts a query to rows whose labels column satisfies the selector.
+// A key that is absent satisfies "!=" and "notin", mirroring LabelSelector.Matches.
+func matchLabels(query *gorm.DB, selector models.LabelSelector) *gorm.DB {
+	for _, r := range selector.Requirements {
+		switch r.Operator {
+		case models.SelectorEquals:
+			query = query.Where("labels ->> ? = ?", r.Key, r.Values[0])
+		case models.SelectorNotEquals:
+			query = query.Where("labels ->> ? IS DISTINCT FROM ?", r.Key, r.Values[0])
+		case models.SelectorIn:
+			query = query.Where("labels ->> ? IN ?", r.Key, r.Values)
+		case models.SelectorNotIn:
+			query = query.Where("(labels ->> ? IS NULL OR labels ->> ? NOT IN ?)", r.Key, r.Key, r.Values)
+		case models.SelectorExists:
+			query = query.Where("labels ->> ? IS NOT NULL", r.Key)
+		case models.SelectorDoesNotExist:
+			query = query.Where("labels ->> ? IS NULL", r.Key)
+		}
+	}
+	return query
+}
+
+// GetLabels returns the labels of a job, source or destination within a project.
+func (db *Database) GetLabels(tableType constants.TableType, projectID string, id int) (models.Labels, error) {
+	query, err := db.labelQuery(tableType, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels models.Labels
+	if err := query.Select("labels").Row().Scan(&labels); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: id[%d]", labelEntities[tableType], id)
+		}
+		return nil, fmt.Errorf("failed to get labels id[%d]: %s", id, err)
+	}
+	if labels == nil {
+		labels = models.Labels{}
+	}
+	return labels, nil
+}
+
+// SetLabels replaces the labels of a job, source or destination within a project.
+func (db *Database) SetLabels(tableType constants.TableType, projectID string, id int, labels models.Labels) error {
+	query, err := db.labelQuery(tableType, projectID, id)
+	if err != nil {
+		return err
+	}
+
+	result := query.Update("labels", labels)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update labels id[%d]: %s", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: id[%d]", labelEntities[tableType], id)
+	}
+	return nil
+}
+
+func (db *Database) labelQuery(tableType constants.TableType, projectID string, id int) (*gorm.DB, error) {
+	if _, ok := labelEntities[tableType]; !ok {
+		return nil, fmt.Errorf("labels are not supported for table type: %v", tableType)
+	}
+	return db.conn.Table(constants.TableNameMap[tableType]).
+		Scopes(notDeleted).
+		Where("id = ?", id).
+		Where("project_id = ?", projectID), nil
+}
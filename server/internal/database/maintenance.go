@@ -0,0 +1,143 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+// CreateMaintenanceWindow stores a maintenance window, unless its project has reached the
+// window limit already
+func (db *Database) CreateMaintenanceWindow(window *models.MaintenanceWindow) error {
+	var count int64
+	if err := db.conn.Model(&models.MaintenanceWindow{}).Where("project_id = ?", window.ProjectID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to count maintenance windows project_id[%s]: %s", window.ProjectID, err)
+	}
+	if count >= int64(constants.MaxMaintenanceWindows) {
+		return fmt.Errorf("%w: project_id[%s] has %d maintenance windows", constants.ErrMaintenanceWindowLimit, window.ProjectID, count)
+	}
+	if err := db.conn.Create(window).Error; err != nil {
+		return fmt.Errorf("failed to save maintenance window project_id[%s]: %s", window.ProjectID, err)
+	}
+	return nil
+}
+
+// ListMaintenanceWindows retrieves the maintenance windows of a project with their authors
+func (db *Database) ListMaintenanceWindows(projectID string) ([]*models.MaintenanceWindow, error) {
+	windows := []*models.MaintenanceWindow{}
+	err := db.conn.
+		Where("project_id = ?", projectID).
+		Preload("CreatedBy").
+		Order("id ASC").
+		Find(&windows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list maintenance windows project_id[%s]: %s", projectID, err)
+	}
+	return windows, nil
+}
+
+// ListJobMaintenanceWindows retrieves the maintenance windows that apply to a job: those of
+// its project, of its source and of the job itself
+func (db *Database) ListJobMaintenanceWindows(projectID string, sourceID, jobID int) ([]*models.MaintenanceWindow, error) {
+	windows := []*models.MaintenanceWindow{}
+	err := db.conn.
+		Where("project_id = ?", projectID).
+		Where("(source_id IS NULL AND job_id IS NULL) OR source_id = ? OR job_id = ?", sourceID, jobID).
+		Preload("CreatedBy").
+		Order("id ASC").
+		Find(&windows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list maintenance windows of job_id[%d]: %s", jobID, err)
+	}
+	return windows, nil
+}
+
+// GetMaintenanceWindow retrieves a maintenance window of a project
+func (db *Database) GetMaintenanceWindow(projectID string, id int) (*models.MaintenanceWindow, error) {
+	window := &models.MaintenanceWindow{}
+	err := db.conn.Where("project_id = ? AND id = ?", projectID, id).Preload("CreatedBy").First(window).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: id[%d] project_id[%s]", constants.ErrMaintenanceWindowNotFound, id, projectID)
+		}
+		return nil, fmt.Errorf("failed to get maintenance window[%d]: %s", id, err)
+	}
+	return window, nil
+}
+
+// UpdateMaintenanceWindow saves every field of a maintenance window
+func (db *Database) UpdateMaintenanceWindow(window *models.MaintenanceWindow) error {
+	if err := db.conn.Omit("CreatedBy").Save(window).Error; err != nil {
+		return fmt.Errorf("failed to update maintenance window[%d]: %s", window.ID, err)
+	}
+	return nil
+}
+
+// DeleteMaintenanceWindow removes a maintenance window of a project
+func (db *Database) DeleteMaintenanceWindow(projectID string, id int) error {
+	result := db.conn.Where("project_id = ? AND id = ?", projectID, id).Delete(&models.MaintenanceWindow{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete maintenance window[%d]: %s", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: id[%d] project_id[%s]", constants.ErrMaintenanceWindowNotFound, id, projectID)
+	}
+	return nil
+}
+
+// DeleteJobMaintenanceWindows removes the maintenance windows of a job, once the job itself
+// is purged
+func (db *Database) DeleteJobMaintenanceWindows(jobID int) error {
+	if err := db.conn.Where("job_id = ?", jobID).Delete(&models.MaintenanceWindow{}).Error; err != nil {
+		return fmt.Errorf("failed to delete maintenance windows of job_id[%d]: %s", jobID, err)
+	}
+	return nil
+}
+
+// DeleteSourceMaintenanceWindows removes the maintenance windows of a source, once the source
+// itself is purged
+func (db *Database) DeleteSourceMaintenanceWindows(sourceID int) error {
+	if err := db.conn.Where("source_id = ?", sourceID).Delete(&models.MaintenanceWindow{}).Error; err != nil {
+		return fmt.Errorf("failed to delete maintenance windows of source_id[%d]: %s", sourceID, err)
+	}
+	return nil
+}
+
+// ListMaintenanceJobIDs retrieves the IDs of the live jobs a maintenance window of the given
+// scope applies to: every job of the project, the jobs of a source or a single job
+func (db *Database) ListMaintenanceJobIDs(projectID string, sourceID, jobID *int) ([]int, error) {
+	var ids []int
+	query := db.conn.Model(&models.Job{}).
+		Scopes(notDeleted).
+		Where("project_id = ?", projectID)
+	switch {
+	case jobID != nil:
+		query = query.Where("id = ?", *jobID)
+	case sourceID != nil:
+		query = query.Where("source_id = ?", *sourceID)
+	}
+	if err := query.Order("id ASC").Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to list jobs of maintenance window project_id[%s]: %s", projectID, err)
+	}
+	return ids, nil
+}
+
+// ListDeferredTriggerJobs retrieves the jobs whose deferred trigger is due
+func (db *Database) ListDeferredTriggerJobs(now time.Time) ([]*models.Job, error) {
+	jobs := []*models.Job{}
+	err := db.conn.
+		Scopes(notDeleted).
+		Select(jobListColumns).
+		Where("trigger_deferred_until <= ?", now).
+		Order("id ASC").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs with deferred triggers: %s", err)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+// EnqueueScheduleOperations adds schedule operations for a job to the outbox, in the given
+// order. Call it within the transaction of the job change that needs them.
+func (db *Database) EnqueueScheduleOperations(projectID string, jobID int, operations ...string) error {
+	if len(operations) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	ops := make([]*models.ScheduleOperation, 0, len(operations))
+	for _, operation := range operations {
+		ops = append(ops, &models.ScheduleOperation{
+			JobID:         jobID,
+			ProjectID:     projectID,
+			Operation:     operation,
+			Status:        constants.ScheduleOpStatusPending,
+			NextAttemptAt: now,
+		})
+	}
+	if err := db.conn.Create(&ops).Error; err != nil {
+		return fmt.Errorf("failed to enqueue schedule operations job_id[%d]: %s", jobID, err)
+	}
+	return nil
+}
+
+// ClaimScheduleOperations picks the due pending operations, the oldest pending one of each
+// job so that the operations of a job are applied in order, and leases them so that other
+// dispatchers skip them until the lease runs out. A jobID of 0 claims across all jobs.
+func (db *Database) ClaimScheduleOperations(jobID int, limit int) ([]*models.ScheduleOperation, error) {
+	ops := []*models.ScheduleOperation{}
+	err := db.conn.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		oldest := tx.Model(&models.ScheduleOperation{}).
+			Select("MIN(id)").
+			Where("status = ?", constants.ScheduleOpStatusPending).
+			Group("job_id")
+
+		query := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("id IN (?)", oldest).
+			Where("next_attempt_at <= ?", now)
+		if jobID > 0 {
+			query = query.Where("job_id = ?", jobID)
+		}
+		if err := query.Order("id ASC").Limit(limit).Find(&ops).Error; err != nil {
+			return err
+		}
+		if len(ops) == 0 {
+			return nil
+		}
+
+		ids := make([]int, 0, len(ops))
+		for _, op := range ops {
+			ids = append(ids, op.ID)
+		}
+		return tx.Model(&models.ScheduleOperation{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(constants.ScheduleOpLease)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim schedule operations: %s", err)
+	}
+	return ops, nil
+}
+
+// UpdateScheduleOperation updates a schedule operation with the given params.
+func (db *Database) UpdateScheduleOperation(id int, params map[string]any) error {
+	return db.conn.Model(&models.ScheduleOperation{}).
+		Where("id = ?", id).
+		Updates(params).Error
+}
+
+// HasPendingScheduleOperations reports whether a job has operations that are not applied yet.
+func (db *Database) HasPendingScheduleOperations(jobID int) (bool, error) {
+	var count int64
+	err := db.conn.Model(&models.ScheduleOperation{}).
+		Where("job_id = ? AND status = ?", jobID, constants.ScheduleOpStatusPending).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to count pending schedule operations job_id[%d]: %s", jobID, err)
+	}
+	return count > 0, nil
+}
+
+// ListScheduleOperations retrieves the latest schedule operations of a job, newest first.
+func (db *Database) ListScheduleOperations(jobID int, limit int) ([]*models.ScheduleOperation, error) {
+	ops := []*models.ScheduleOperation{}
+	err := db.conn.
+		Where("job_id = ?", jobID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&ops).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedule operations job_id[%d]: %s", jobID, err)
+	}
+	return ops, nil
+}
+
+// GetUnappliedScheduleOperations returns, per job, the operation that keeps its schedule out
+// of date: the oldest pending operation, or else the latest failed one. Jobs whose operations
+// are all applied are left out.
+func (db *Database) GetUnappliedScheduleOperations(jobIDs []int) (map[int]*models.ScheduleOperation, error) {
+	result := make(map[int]*models.ScheduleOperation)
+	if len(jobIDs) == 0 {
+		return result, nil
+	}
+
+	ops := []*models.ScheduleOperation{}
+	err := db.conn.
+		Where("job_id IN ?", jobIDs).
+		Where("status IN ?", []string{constants.ScheduleOpStatusPending, constants.ScheduleOpStatusFailed}).
+		Order("id ASC").
+		Find(&ops).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unapplied schedule operations: %s", err)
+	}
+
+	for _, op := range ops {
+		// operations come oldest first, so a pending one is only replaced by nothing and a
+		// failed one by anything after it
+		if current, ok := result[op.JobID]; !ok || current.Status == constants.ScheduleOpStatusFailed {
+			result[op.JobID] = op
+		}
+	}
+	return result, nil
+}
+
+// PendingScheduleOperationJobIDs returns the IDs of the jobs that have pending operations.
+func (db *Database) PendingScheduleOperationJobIDs() (map[int]bool, error) {
+	var ids []int
+	err := db.conn.Model(&models.ScheduleOperation{}).
+		Where("status = ?", constants.ScheduleOpStatusPending).
+		Distinct().
+		Pluck("job_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs with pending schedule operations: %s", err)
+	}
+
+	pending := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		pending[id] = true
+	}
+	return pending, nil
+}
+
+// RetryFailedScheduleOperations puts the failed operations of a job back in the queue with a
+// fresh set of attempts.
+func (db *Database) RetryFailedScheduleOperations(jobID int) (int64, error) {
+	result := db.conn.Model(&models.ScheduleOperation{}).
+		Where("job_id = ? AND status = ?", jobID, constants.ScheduleOpStatusFailed).
+		Updates(map[string]any{
+			"status":          constants.ScheduleOpStatusPending,
+			"attempts":        0,
+			"next_attempt_at": time.Now(),
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to retry schedule operations job_id[%d]: %s", jobID, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// SupersedeFailedScheduleOperations marks the failed operations of a job as superseded, once
+// its schedule is known to match the job again.
+func (db *Database) SupersedeFailedScheduleOperations(jobID int) error {
+	return db.conn.Model(&models.ScheduleOperation{}).
+		Where("job_id = ? AND status = ?", jobID, constants.ScheduleOpStatusFailed).
+		Update("status", constants.ScheduleOpStatusSuperseded).Error
+}
+
+// DeleteFinishedScheduleOperations removes applied and superseded operations last updated
+// before the given time.
+func (db *Database) DeleteFinishedScheduleOperations(before time.Time) (int64, error) {
+	result := db.conn.
+		Where("status IN ?", []string{constants.ScheduleOpStatusApplied, constants.ScheduleOpStatusSuperseded}).
+		Where("updated_at < ?", before).
+		Delete(&models.ScheduleOperation{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete finished schedule operations: %s", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+)
+
+// ListOptions carries the filters, sort order and keyset cursor for list queries.
+// Filters that do not apply to an entity are ignored by its list method.
+type ListOptions struct {
+	Name      string
+	Type      string
+	Active    *bool
+	CreatedBy string
+	Selector  models.LabelSelector
+	SortBy    string
+	Desc      bool
+	Limit     int
+	Cursor    *Cursor
+}
+
+// Cursor points at the last row of the previous page as (sort value, id).
+type Cursor struct {
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+// timeSortKeys are sort keys whose cursor value is an RFC3339 timestamp.
+var timeSortKeys = []string{constants.SortKeyCreatedAt, constants.SortKeyUpdatedAt}
+
+// EncodeCursor serialises a cursor into an opaque, URL-safe token.
+func EncodeCursor(cursor Cursor) string {
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a token produced by EncodeCursor. An empty token yields a nil cursor.
+func DecodeCursor(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", constants.ErrInvalidCursor, err)
+	}
+	cursor := &Cursor{}
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return nil, fmt.Errorf("%w: %s", constants.ErrInvalidCursor, err)
+	}
+	return cursor, nil
+}
+
+// NewCursor builds the cursor for a row given the sort key used for the listing.
+func NewCursor(sortBy string, id int, name string, base models.BaseModel) Cursor {
+	switch sortBy {
+	case constants.SortKeyName:
+		return Cursor{Value: name, ID: id}
+	case constants.SortKeyCreatedAt:
+		return Cursor{Value: base.CreatedAt.UTC().Format(time.RFC3339Nano), ID: id}
+	case constants.SortKeyUpdatedAt:
+		return Cursor{Value: base.UpdatedAt.UTC().Format(time.RFC3339Nano), ID: id}
+	default:
+		return Cursor{ID: id}
+	}
+}
+
+// nameContains returns an ILIKE pattern matching the given substring literally.
+func nameContains(name string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(name)
+	return "%" + escaped + "%"
+}
+
+// createdBySubQuery selects the ids of users matching the given username.
+func (db *Database) createdBySubQuery(username string) *gorm.DB {
+	return db.conn.Model(&models.User{}).Select("id").Where("username = ?", username)
+}
+
+// paginate applies the keyset cursor, ordering and limit to a query.
+// nameColumn is the column that backs the "name" sort key for the entity.
+// One extra row is fetched when a limit is set so callers can tell whether another page exists.
+func paginate(query *gorm.DB, opts ListOptions, nameColumn string) (*gorm.DB, error) {
+	column := "id"
+	switch opts.SortBy {
+	case constants.SortKeyName:
+		column = nameColumn
+	case constants.SortKeyCreatedAt, constants.SortKeyUpdatedAt:
+		column = opts.SortBy
+	}
+
+	direction, comparator := "ASC", ">"
+	if opts.Desc {
+		direction, comparator = "DESC", "<"
+	}
+
+	if opts.Cursor != nil {
+		if column == "id" {
+			query = query.Where(fmt.Sprintf("id %s ?", comparator), opts.Cursor.ID)
+		} else {
+			var value any = opts.Cursor.Value
+			if utils.ExistsInArray(timeSortKeys, opts.SortBy) {
+				t, err := time.Parse(time.RFC3339Nano, opts.Cursor.Value)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s", constants.ErrInvalidCursor, err)
+				}
+				value = t
+			}
+			query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, comparator), value, opts.Cursor.ID)
+		}
+	}
+
+	query = query.Order(fmt.Sprintf("%s %s, id %s", column, direction, direction))
+	if opts.Limit > 0 {
+		query = query.Limit(opts.Limit + 1)
+	}
+	return query, nil
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []Cursor{
+		{ID: 42},
+		{Value: "orders-sync", ID: 7},
+		{Value: "2025-03-10T12:00:00.123456789Z", ID: 1},
+		// values that are not URL safe in plain base64
+		{Value: "a/b+c?d=é", ID: 3},
+	}
+	for _, cursor := range tests {
+		t.Run(cursor.Value, func(t *testing.T) {
+			token := EncodeCursor(cursor)
+			require.NotContains(t, token, "=")
+			require.NotContains(t, token, "/")
+			require.NotContains(t, token, "+")
+
+			decoded, err := DecodeCursor(token)
+			require.NoError(t, err)
+			require.Equal(t, &cursor, decoded)
+		})
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	cursor, err := DecodeCursor("")
+	require.NoError(t, err)
+	require.Nil(t, cursor)
+
+	for _, token := range []string{"not base64!", "bm90IGpzb24", "eyJpZCI6ImEifQ"} {
+		_, err := DecodeCursor(token)
+		require.Error(t, err, token)
+		require.True(t, errors.Is(err, constants.ErrInvalidCursor), token)
+	}
+}
+
+func TestNewCursor(t *testing.T) {
+	created := time.Date(2025, time.March, 10, 12, 0, 0, 500, time.FixedZone("IST", 5*3600+1800))
+	updated := created.Add(time.Hour)
+	base := models.BaseModel{CreatedAt: created, UpdatedAt: updated}
+	tests := []struct {
+		sortBy string
+		want   Cursor
+	}{
+		{constants.SortKeyName, Cursor{Value: "orders", ID: 7}},
+		{constants.SortKeyCreatedAt, Cursor{Value: "2025-03-10T06:30:00.0000005Z", ID: 7}},
+		{constants.SortKeyUpdatedAt, Cursor{Value: "2025-03-10T07:30:00.0000005Z", ID: 7}},
+		{"", Cursor{ID: 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			require.Equal(t, tt.want, NewCursor(tt.sortBy, 7, "orders", base))
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	conn, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	createdAt := time.Date(2025, time.March, 10, 12, 0, 0, 123456789, time.UTC)
+	tests := []struct {
+		name string
+		opts ListOptions
+		sql  string
+		vars []any
+	}{
+		{
+			name: "id ascending without a limit",
+			opts: ListOptions{},
+			sql:  `SELECT * FROM "jobs" ORDER BY id ASC, id ASC`,
+			vars: []any{},
+		},
+		{
+			name: "limit fetches one extra row",
+			opts: ListOptions{SortBy: constants.SortKeyName, Limit: 20},
+			sql:  `SELECT * FROM "jobs" ORDER BY name ASC, id ASC LIMIT $1`,
+			vars: []any{21},
+		},
+		{
+			name: "name cursor compares the tuple",
+			opts: ListOptions{SortBy: constants.SortKeyName, Cursor: &Cursor{Value: "orders", ID: 7}},
+			sql:  `SELECT * FROM "jobs" WHERE (name, id) > ($1, $2) ORDER BY name ASC, id ASC`,
+			vars: []any{"orders", 7},
+		},
+		{
+			name: "descending order compares downwards",
+			opts: ListOptions{SortBy: constants.SortKeyName, Desc: true, Limit: 5, Cursor: &Cursor{Value: "orders", ID: 7}},
+			sql:  `SELECT * FROM "jobs" WHERE (name, id) < ($1, $2) ORDER BY name DESC, id DESC LIMIT $3`,
+			vars: []any{"orders", 7, 6},
+		},
+		{
+			name: "time cursor is parsed",
+			opts: ListOptions{SortBy: constants.SortKeyCreatedAt, Desc: true, Cursor: &Cursor{Value: createdAt.Format(time.RFC3339Nano), ID: 3}},
+			sql:  `SELECT * FROM "jobs" WHERE (created_at, id) < ($1, $2) ORDER BY created_at DESC, id DESC`,
+			vars: []any{createdAt, 3},
+		},
+		{
+			name: "id cursor compares the id only",
+			opts: ListOptions{Desc: true, Cursor: &Cursor{Value: "ignored", ID: 9}},
+			sql:  `SELECT * FROM "jobs" WHERE id < $1 ORDER BY id DESC, id DESC`,
+			vars: []any{9},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := paginate(conn.Table("jobs"), tt.opts, "name")
+			require.NoError(t, err)
+			stmt := query.Find(&[]map[string]any{}).Statement
+			require.Equal(t, tt.sql, stmt.SQL.String())
+			require.Equal(t, tt.vars, stmt.Vars)
+		})
+	}
+
+	_, err = paginate(conn.Table("jobs"), ListOptions{SortBy: constants.SortKeyUpdatedAt, Cursor: &Cursor{Value: "yesterday", ID: 3}}, "name")
+	require.True(t, errors.Is(err, constants.ErrInvalidCursor))
+}
@@ -52,3 +52,18 @@ func (db *Database) UpsertProjectSettingsModel(settings *models.ProjectSettings)
 	}
 	return nil
 }
+
+// SetProjectDefaultTimeZone sets the default schedule time zone of a project, creating its
+// settings if it has none yet
+func (db *Database) SetProjectDefaultTimeZone(projectID, timeZone string) error {
+	row := &models.ProjectSettings{ProjectID: projectID, DefaultTimeZone: timeZone}
+	if err := db.conn.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "project_id"}},
+			DoUpdates: clause.Assignments(map[string]any{"default_time_zone": timeZone}),
+		}).
+		Create(row).Error; err != nil {
+		return fmt.Errorf("failed to set default time zone of project_id[%s]: %s", projectID, err)
+	}
+	return nil
+}
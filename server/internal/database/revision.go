@@ -0,0 +1,157 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+// SaveJobRevision stores the configuration a job has now as its next revision, unless it is
+// the same as the latest revision. The job, change, author and restored revision are taken
+// from rev. Call it within the transaction of the job change.
+func (db *Database) SaveJobRevision(rev *models.JobRevision) error {
+	job, err := db.lockJobForRevision(rev.JobID)
+	if err != nil {
+		return err
+	}
+	return db.saveJobRevision(job, rev)
+}
+
+// SaveJobBaselineRevision stores the configuration of a job that has no revisions yet, as
+// last saved by its last author, so that its first recorded change can be rolled back.
+func (db *Database) SaveJobBaselineRevision(jobID int) error {
+	job, err := db.lockJobForRevision(jobID)
+	if err != nil {
+		return err
+	}
+	var count int64
+	if err := db.conn.Model(&models.JobRevision{}).Where("job_id = ?", jobID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to count revisions of job_id[%d]: %s", jobID, err)
+	}
+	if count > 0 {
+		return nil
+	}
+	return db.saveJobRevision(job, &models.JobRevision{
+		BaseModel:   models.BaseModel{CreatedAt: job.UpdatedAt},
+		JobID:       jobID,
+		Change:      constants.JobRevisionChangeBaseline,
+		CreatedByID: job.UpdatedByID,
+	})
+}
+
+func (db *Database) saveJobRevision(job *models.Job, rev *models.JobRevision) error {
+	latest, err := db.getLatestJobRevision(rev.JobID)
+	if err != nil {
+		return err
+	}
+	if latest != nil && sameJobConfig(latest, job) {
+		return nil
+	}
+
+	rev.Revision = 1
+	if latest != nil {
+		rev.Revision = latest.Revision + 1
+	}
+	rev.ProjectID = job.ProjectID
+	rev.SourceID = job.SourceID
+	rev.DestID = job.DestID
+	rev.Frequency = job.Frequency
+	rev.StreamsConfig = job.StreamsConfig
+	rev.AdvancedSettings = job.AdvancedSettings
+	if err := db.conn.Create(rev).Error; err != nil {
+		return fmt.Errorf("failed to save revision of job_id[%d]: %s", rev.JobID, err)
+	}
+	return nil
+}
+
+// ListJobRevisions retrieves the revisions of a job with their authors, newest first and
+// without the streams config and advanced settings.
+func (db *Database) ListJobRevisions(jobID int) ([]*models.JobRevision, error) {
+	revisions := []*models.JobRevision{}
+	err := db.conn.
+		Where("job_id = ?", jobID).
+		Omit("streams_config", "advanced_settings").
+		Preload("CreatedBy").
+		Order("revision DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions of job_id[%d]: %s", jobID, err)
+	}
+	return revisions, nil
+}
+
+// GetJobRevision retrieves a revision of a job with its full configuration. A revision of 0
+// is the latest one.
+func (db *Database) GetJobRevision(jobID, revision int) (*models.JobRevision, error) {
+	if revision == 0 {
+		latest, err := db.getLatestJobRevision(jobID)
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil {
+			return nil, fmt.Errorf("%w: job_id[%d] has no revisions", constants.ErrJobRevisionNotFound, jobID)
+		}
+		return latest, nil
+	}
+
+	rev := &models.JobRevision{}
+	err := db.conn.Where("job_id = ? AND revision = ?", jobID, revision).First(rev).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: job_id[%d] revision[%d]", constants.ErrJobRevisionNotFound, jobID, revision)
+		}
+		return nil, fmt.Errorf("failed to get revision[%d] of job_id[%d]: %s", revision, jobID, err)
+	}
+	return rev, nil
+}
+
+// DeleteJobRevisions removes the revisions of a job, once the job itself is purged
+func (db *Database) DeleteJobRevisions(jobID int) error {
+	if err := db.conn.Where("job_id = ?", jobID).Delete(&models.JobRevision{}).Error; err != nil {
+		return fmt.Errorf("failed to delete revisions of job_id[%d]: %s", jobID, err)
+	}
+	return nil
+}
+
+func (db *Database) getLatestJobRevision(jobID int) (*models.JobRevision, error) {
+	rev := &models.JobRevision{}
+	err := db.conn.Where("job_id = ?", jobID).Order("revision DESC").First(rev).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest revision of job_id[%d]: %s", jobID, err)
+	}
+	return rev, nil
+}
+
+// lockJobForRevision reads a job row and locks it until the transaction ends, so that the
+// revisions of a job are numbered one at a time
+func (db *Database) lockJobForRevision(jobID int) (*models.Job, error) {
+	job := &models.Job{}
+	err := db.conn.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", jobID).
+		First(job).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job_id[%d] for revision: %s", jobID, err)
+	}
+	return job, nil
+}
+
+// sameJobConfig reports whether a revision holds the configuration a job has now. Both are
+// read back from jsonb columns, so equal configs have equal text.
+func sameJobConfig(rev *models.JobRevision, job *models.Job) bool {
+	sameSettings := rev.AdvancedSettings == nil && job.AdvancedSettings == nil ||
+		rev.AdvancedSettings != nil && job.AdvancedSettings != nil && *rev.AdvancedSettings == *job.AdvancedSettings
+	return sameSettings &&
+		rev.SourceID == job.SourceID &&
+		rev.DestID == job.DestID &&
+		rev.Frequency == job.Frequency &&
+		rev.StreamsConfig == job.StreamsConfig
+}
@@ -38,6 +38,7 @@ func (db *Database) CreateSource(source *models.Source) error {
 func (db *Database) ListSources() ([]*models.Source, error) {
 	var sources []*models.Source
 	err := db.conn.
+		Scopes(notDeleted).
 		Preload("CreatedBy").
 		Preload("UpdatedBy").
 		Order("updated_at DESC").
@@ -54,29 +55,57 @@ func (db *Database) ListSources() ([]*models.Source, error) {
 	return sources, nil
 }
 
-func (db *Database) ListSourcesByProjectID(projectID string) ([]*models.Source, error) {
-	var sources []*models.Source
-	err := db.conn.
-		Where("project_id = ?", projectID).
+// ListSourcesByProjectID retrieves one page of sources belonging to a project along
+// with the total number of sources matching the filters.
+func (db *Database) ListSourcesByProjectID(projectID string, opts ListOptions) ([]*models.Source, int64, error) {
+	query := db.conn.
+		Model(&models.Source{}).
+		Scopes(notDeleted).
+		Where("project_id = ?", projectID)
+
+	if opts.Name != "" {
+		query = query.Where("name ILIKE ?", nameContains(opts.Name))
+	}
+	if opts.Type != "" {
+		query = query.Where("type = ?", opts.Type)
+	}
+	if opts.CreatedBy != "" {
+		query = query.Where("created_by_id IN (?)", db.createdBySubQuery(opts.CreatedBy))
+	}
+	query = matchLabels(query, opts.Selector)
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count sources project_id[%s]: %s", projectID, err)
+	}
+
+	query, err := paginate(query, opts, "name")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sources := []*models.Source{}
+	err = query.
 		Preload("CreatedBy").
 		Preload("UpdatedBy").
-		Order("updated_at DESC").
 		Find(&sources).Error
 	if err != nil {
-		return nil, fmt.Errorf("failed to list sources project_id[%s]: %s", projectID, err)
+		return nil, 0, fmt.Errorf("failed to list sources project_id[%s]: %s", projectID, err)
 	}
 
 	// Decrypt config after reading
 	if err := db.decryptSourceSliceConfigs(sources); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return sources, nil
+	return sources, total, nil
 }
 
 func (db *Database) GetSourceByID(id int) (*models.Source, error) {
 	var source models.Source
 	err := db.conn.
+		Scopes(notDeleted).
 		Where("id = ?", id).
 		Preload("CreatedBy").
 		Preload("UpdatedBy").
@@ -107,12 +136,21 @@ func (db *Database) UpdateSource(source *models.Source) error {
 	return db.conn.
 		Model(&models.Source{}).
 		Where("id = ?", source.ID).
-		Select("name", "type", "version", "config", "updated_by_id").
+		Select("name", "type", "version", "config", "worker_pool", "updated_by_id").
 		Updates(source).Error
 }
 
+// UpdateSourceVersion sets the driver version of a source.
+func (db *Database) UpdateSourceVersion(id int, version string, updatedByID int) error {
+	return db.conn.
+		Model(&models.Source{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"version": version, "updated_by_id": updatedByID}).Error
+}
+
+// DeleteSource moves a source to the trash
 func (db *Database) DeleteSource(id int) error {
-	result := db.conn.Delete(&models.Source{}, "id = ?", id)
+	result := moveToTrash(db.conn.Model(&models.Source{}), id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -134,6 +172,7 @@ func (db *Database) GetMinimumSourceVersion() (string, error) {
 	var versions []string
 	err := db.conn.
 		Model(&models.Source{}).
+		Scopes(notDeleted).
 		Distinct().
 		Pluck("version", &versions).Error
 	if err != nil {
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+// SaveJobState writes the state of a job and keeps it as a snapshot, dropping the oldest
+// snapshots past the history limit. A job without snapshots first gets the state it had
+// kept as its baseline. The job and the run that wrote the state are taken from snapshot.
+func (db *Database) SaveJobState(state string, snapshot *models.JobStateSnapshot) error {
+	return db.conn.Transaction(func(tx *gorm.DB) error {
+		job := &models.Job{}
+		if err := tx.Select("id", "project_id", "state", "updated_at").Where("id = ?", snapshot.JobID).First(job).Error; err != nil {
+			return fmt.Errorf("failed to get job_id[%d]: %s", snapshot.JobID, err)
+		}
+
+		var count int64
+		if err := tx.Model(&models.JobStateSnapshot{}).Where("job_id = ?", job.ID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count state snapshots of job_id[%d]: %s", job.ID, err)
+		}
+		if count == 0 && job.State != "" {
+			baseline := &models.JobStateSnapshot{
+				BaseModel: models.BaseModel{CreatedAt: job.UpdatedAt},
+				JobID:     job.ID,
+				ProjectID: job.ProjectID,
+				State:     job.State,
+				Reason:    constants.StateSnapshotReasonBaseline,
+			}
+			if err := tx.Create(baseline).Error; err != nil {
+				return fmt.Errorf("failed to save baseline state of job_id[%d]: %s", job.ID, err)
+			}
+		}
+
+		if err := tx.Model(&models.Job{}).Where("id = ?", job.ID).Update("state", state).Error; err != nil {
+			return fmt.Errorf("failed to update state of job_id[%d]: %s", job.ID, err)
+		}
+		snapshot.ProjectID = job.ProjectID
+		snapshot.State = state
+		if err := tx.Create(snapshot).Error; err != nil {
+			return fmt.Errorf("failed to save state snapshot of job_id[%d]: %s", job.ID, err)
+		}
+
+		kept := tx.Model(&models.JobStateSnapshot{}).
+			Select("id").
+			Where("job_id = ?", job.ID).
+			Order("id DESC").
+			Limit(constants.StateHistoryLimit)
+		err := tx.Where("job_id = ? AND id NOT IN (?)", job.ID, kept).Delete(&models.JobStateSnapshot{}).Error
+		if err != nil {
+			return fmt.Errorf("failed to trim state snapshots of job_id[%d]: %s", job.ID, err)
+		}
+		return nil
+	})
+}
+
+// ListJobStateSnapshots retrieves the state snapshots of a job, newest first, without their
+// state.
+func (db *Database) ListJobStateSnapshots(jobID int) ([]*models.JobStateSnapshot, error) {
+	snapshots := []*models.JobStateSnapshot{}
+	err := db.conn.
+		Where("job_id = ?", jobID).
+		Omit("state").
+		Order("id DESC").
+		Find(&snapshots).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list state snapshots of job_id[%d]: %s", jobID, err)
+	}
+	return snapshots, nil
+}
+
+// GetJobStateSnapshot retrieves a state snapshot of a job
+func (db *Database) GetJobStateSnapshot(jobID, id int) (*models.JobStateSnapshot, error) {
+	snapshot := &models.JobStateSnapshot{}
+	err := db.conn.Where("job_id = ? AND id = ?", jobID, id).First(snapshot).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: job_id[%d] snapshot_id[%d]", constants.ErrStateSnapshotNotFound, jobID, id)
+		}
+		return nil, fmt.Errorf("failed to get state snapshot[%d] of job_id[%d]: %s", id, jobID, err)
+	}
+	return snapshot, nil
+}
+
+// DeleteJobStateSnapshots removes the state snapshots of a job, once the job itself is purged
+func (db *Database) DeleteJobStateSnapshots(jobID int) error {
+	if err := db.conn.Where("job_id = ?", jobID).Delete(&models.JobStateSnapshot{}).Error; err != nil {
+		return fmt.Errorf("failed to delete state snapshots of job_id[%d]: %s", jobID, err)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+// notDeleted leaves out the rows that were moved to the trash
+func notDeleted(query *gorm.DB) *gorm.DB {
+	return query.Where("deleted_at IS NULL")
+}
+
+// inTrash keeps only the rows that were moved to the trash
+func inTrash(query *gorm.DB) *gorm.DB {
+	return query.Where("deleted_at IS NOT NULL")
+}
+
+// moveToTrash marks the row with the given id of the query's model as deleted
+func moveToTrash(query *gorm.DB, id int) *gorm.DB {
+	return query.Scopes(notDeleted).Where("id = ?", id).Update("deleted_at", time.Now())
+}
+
+// ListTrashedJobs retrieves the jobs in the trash of a project, or of every project when
+// projectID is empty, that were deleted before the given time. A zero time matches all.
+func (db *Database) ListTrashedJobs(projectID string, deletedBefore time.Time) ([]*models.Job, error) {
+	jobs := []*models.Job{}
+	err := trashQuery(db.conn.Model(&models.Job{}), projectID, deletedBefore).
+		Select(append(slices.Clone(jobListColumns), "deleted_at")).
+		Preload("Source").
+		Preload("Destination").
+		Find(&jobs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trashed jobs: %s", err)
+	}
+	return jobs, nil
+}
+
+// ListTrashedSources retrieves the sources in the trash of a project, or of every project
+// when projectID is empty, that were deleted before the given time. A zero time matches all.
+func (db *Database) ListTrashedSources(projectID string, deletedBefore time.Time) ([]*models.Source, error) {
+	sources := []*models.Source{}
+	err := trashQuery(db.conn.Model(&models.Source{}), projectID, deletedBefore).
+		Omit("config").
+		Find(&sources).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trashed sources: %s", err)
+	}
+	return sources, nil
+}
+
+// ListTrashedDestinations retrieves the destinations in the trash of a project, or of every
+// project when projectID is empty, that were deleted before the given time. A zero time
+// matches all.
+func (db *Database) ListTrashedDestinations(projectID string, deletedBefore time.Time) ([]*models.Destination, error) {
+	destinations := []*models.Destination{}
+	err := trashQuery(db.conn.Model(&models.Destination{}), projectID, deletedBefore).
+		Omit("config").
+		Find(&destinations).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trashed destinations: %s", err)
+	}
+	return destinations, nil
+}
+
+func trashQuery(query *gorm.DB, projectID string, deletedBefore time.Time) *gorm.DB {
+	query = query.Scopes(inTrash)
+	if projectID != "" {
+		query = query.Where("project_id = ?", projectID)
+	}
+	if !deletedBefore.IsZero() {
+		query = query.Where("deleted_at < ?", deletedBefore)
+	}
+	return query.Order("deleted_at DESC")
+}
+
+// GetTrashedJob retrieves a job in the trash with its source and destination
+func (db *Database) GetTrashedJob(id int) (*models.Job, error) {
+	job := &models.Job{}
+	err := db.conn.
+		Scopes(inTrash).
+		Where("id = ?", id).
+		Preload("Source").
+		Preload("Destination").
+		First(job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: job not found in trash id[%d]", constants.ErrJobNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get trashed job id[%d]: %s", id, err)
+	}
+	return job, nil
+}
+
+// GetTrashedSource retrieves a source in the trash, without its config
+func (db *Database) GetTrashedSource(id int) (*models.Source, error) {
+	source := &models.Source{}
+	err := db.conn.Scopes(inTrash).Where("id = ?", id).Omit("config").First(source).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: source not found in trash id[%d]", constants.ErrSourceNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get trashed source id[%d]: %s", id, err)
+	}
+	return source, nil
+}
+
+// GetTrashedDestination retrieves a destination in the trash, without its config
+func (db *Database) GetTrashedDestination(id int) (*models.Destination, error) {
+	destination := &models.Destination{}
+	err := db.conn.Scopes(inTrash).Where("id = ?", id).Omit("config").First(destination).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: destination not found in trash id[%d]", constants.ErrDestinationNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to get trashed destination id[%d]: %s", id, err)
+	}
+	return destination, nil
+}
+
+// RestoreFromTrash takes a job, source or destination out of the trash
+func (db *Database) RestoreFromTrash(tableType constants.TableType, id int, updatedByID int) error {
+	model, err := trashModel(tableType)
+	if err != nil {
+		return err
+	}
+	notFound := trashEntities[tableType]
+
+	result := db.conn.Model(model).
+		Scopes(inTrash).
+		Where("id = ?", id).
+		Updates(map[string]any{"deleted_at": nil, "updated_by_id": updatedByID})
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore id[%d]: %s", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: not found in trash id[%d]", notFound, id)
+	}
+	return nil
+}
+
+// PurgeFromTrash permanently deletes a job, source or destination in the trash. Sources and
+// destinations still used by a job, in the trash or not, are kept.
+func (db *Database) PurgeFromTrash(tableType constants.TableType, id int) error {
+	model, err := trashModel(tableType)
+	if err != nil {
+		return err
+	}
+	notFound := trashEntities[tableType]
+
+	query := db.conn.Scopes(inTrash).Where("id = ?", id)
+	switch tableType {
+	case constants.SourceTable:
+		query = query.Where("id NOT IN (?)", db.conn.Model(&models.Job{}).Select("source_id"))
+	case constants.DestinationTable:
+		query = query.Where("id NOT IN (?)", db.conn.Model(&models.Job{}).Select("dest_id"))
+	}
+	result := query.Delete(model)
+	if result.Error != nil {
+		return fmt.Errorf("failed to purge id[%d]: %s", id, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: not found in trash or still used by a job id[%d]", notFound, id)
+	}
+	return nil
+}
+
+// trashEntities maps the tables that support the trash to their not-found error.
+var trashEntities = map[constants.TableType]error{
+	constants.JobTable:         constants.ErrJobNotFound,
+	constants.SourceTable:      constants.ErrSourceNotFound,
+	constants.DestinationTable: constants.ErrDestinationNotFound,
+}
+
+// trashModel returns the model of a table that supports the trash
+func trashModel(tableType constants.TableType) (any, error) {
+	switch tableType {
+	case constants.JobTable:
+		return &models.Job{}, nil
+	case constants.SourceTable:
+		return &models.Source{}, nil
+	case constants.DestinationTable:
+		return &models.Destination{}, nil
+	default:
+		return nil, fmt.Errorf("trash is not supported for table type: %v", tableType)
+	}
+}
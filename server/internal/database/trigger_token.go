@@ -0,0 +1,85 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+// CreateJobTriggerToken stores a trigger token of a job, unless the job has reached the
+// token limit already
+func (db *Database) CreateJobTriggerToken(token *models.JobTriggerToken) error {
+	return db.conn.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.JobTriggerToken{}).Where("job_id = ?", token.JobID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count trigger tokens of job_id[%d]: %s", token.JobID, err)
+		}
+		if count >= int64(constants.MaxTriggerTokensPerJob) {
+			return fmt.Errorf("%w: job_id[%d] has %d trigger tokens", constants.ErrTriggerTokenLimit, token.JobID, count)
+		}
+		if err := tx.Create(token).Error; err != nil {
+			return fmt.Errorf("failed to save trigger token of job_id[%d]: %s", token.JobID, err)
+		}
+		return nil
+	})
+}
+
+// ListJobTriggerTokens retrieves the trigger tokens of a job with their authors, newest first
+func (db *Database) ListJobTriggerTokens(jobID int) ([]*models.JobTriggerToken, error) {
+	tokens := []*models.JobTriggerToken{}
+	err := db.conn.
+		Where("job_id = ?", jobID).
+		Preload("CreatedBy").
+		Order("id DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trigger tokens of job_id[%d]: %s", jobID, err)
+	}
+	return tokens, nil
+}
+
+// GetJobTriggerTokenByHash retrieves the trigger token with the given hash
+func (db *Database) GetJobTriggerTokenByHash(hash string) (*models.JobTriggerToken, error) {
+	token := &models.JobTriggerToken{}
+	err := db.conn.Where("token_hash = ?", hash).First(token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: unknown token", constants.ErrInvalidTriggerToken)
+		}
+		return nil, fmt.Errorf("failed to get trigger token: %s", err)
+	}
+	return token, nil
+}
+
+// TouchJobTriggerToken records that a trigger token was used
+func (db *Database) TouchJobTriggerToken(id int) error {
+	if err := db.conn.Model(&models.JobTriggerToken{}).Where("id = ?", id).UpdateColumn("last_used_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to update trigger token[%d]: %s", id, err)
+	}
+	return nil
+}
+
+// DeleteJobTriggerToken revokes a trigger token of a job
+func (db *Database) DeleteJobTriggerToken(jobID, id int) error {
+	result := db.conn.Where("job_id = ? AND id = ?", jobID, id).Delete(&models.JobTriggerToken{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete trigger token[%d] of job_id[%d]: %s", id, jobID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: job_id[%d] token_id[%d]", constants.ErrTriggerTokenNotFound, jobID, id)
+	}
+	return nil
+}
+
+// DeleteJobTriggerTokens removes the trigger tokens of a job, once the job itself is purged
+func (db *Database) DeleteJobTriggerTokens(jobID int) error {
+	if err := db.conn.Where("job_id = ?", jobID).Delete(&models.JobTriggerToken{}).Error; err != nil {
+		return fmt.Errorf("failed to delete trigger tokens of job_id[%d]: %s", jobID, err)
+	}
+	return nil
+}
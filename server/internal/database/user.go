@@ -42,10 +42,30 @@ func (db *Database) CreateUser(user *models.User) error {
 	return nil
 }
 
-func (db *Database) ListUsers() ([]*models.User, error) {
-	var users []*models.User
-	err := db.conn.Find(&users).Error
-	return users, err
+// ListUsers retrieves one page of users along with the total number of users
+// matching the filters. The name filter matches against the username.
+func (db *Database) ListUsers(opts ListOptions) ([]*models.User, int64, error) {
+	query := db.conn.Model(&models.User{})
+	if opts.Name != "" {
+		query = query.Where("username ILIKE ?", nameContains(opts.Name))
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %s", err)
+	}
+
+	query, err := paginate(query, opts, "username")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users := []*models.User{}
+	if err := query.Find(&users).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %s", err)
+	}
+	return users, total, nil
 }
 
 func (db *Database) GetUserByID(id int) (*models.User, error) {
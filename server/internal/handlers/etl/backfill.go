@@ -0,0 +1,99 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Backfill missed schedule runs
+// @Tags Jobs
+// @Description List the fire times that the schedules of the selected jobs missed in a time range and backfill them.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   body          body    dto.BackfillRequest true "backfill"
+// @Success 200 {object} dto.JSONResponse{data=dto.BackfillResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 500 {object} dto.Error500Response "failed to backfill jobs"
+// @Router /api/v1/project/{projectid}/jobs/backfill [post]
+func (h *Handler) BackfillJobs(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.BackfillRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	h.backfill(c, projectID, &req, false)
+}
+
+// @Summary Backfill missed schedule runs of a job
+// @Tags Jobs
+// @Description Backfill the fire times from from to to that the schedule of a job missed, as the project backfill does for a single job. job_ids and selector are not allowed.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   body          body    dto.BackfillRequest true "backfill"
+// @Success 200 {object} dto.JSONResponse{data=dto.BackfillResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 500 {object} dto.Error500Response "failed to backfill jobs"
+// @Router /api/v1/project/{projectid}/jobs/{id}/backfill [post]
+func (h *Handler) BackfillJob(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.BackfillRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if len(req.JobIDs) > 0 || req.Selector != "" {
+		err := fmt.Errorf("job_ids and selector are not allowed when backfilling a single job")
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	req.JobIDs = []int{jobID}
+	h.backfill(c, projectID, &req, true)
+}
+
+// backfill validates and runs a backfill and writes its response. With single the backfill
+// is of one job, which is reported as not found if it does not exist.
+func (h *Handler) backfill(c *gin.Context, projectID string, req *dto.BackfillRequest, single bool) {
+	if err := req.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Backfill initiated project_id[%s] mode[%s] job_ids%v selector[%s]", projectID, req.Mode, req.JobIDs, req.Selector)
+	resp, err := h.etl.BackfillJobs(c.Request.Context(), projectID, req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrInvalidLabelSelector) || errors.Is(err, constants.ErrBulkLimitExceeded) {
+			status = http.StatusBadRequest
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to backfill jobs: %s", err), err)
+		return
+	}
+	if single && len(resp.Jobs) == 1 && resp.Jobs[0].Error == constants.ErrJobNotFound.Error() {
+		err := fmt.Errorf("%w: id[%d] project_id[%s]", constants.ErrJobNotFound, req.JobIDs[0], projectID)
+		utils.ErrorResponse(c, http.StatusNotFound, fmt.Sprintf("failed to backfill jobs: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("%d missed fire times of %d jobs, %d runs enqueued", resp.Missed, len(resp.Jobs), resp.Enqueued), resp)
+}
@@ -0,0 +1,133 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+)
+
+// @Summary Export jobs as a bundle
+// @Tags Jobs
+// @Description Download a self-contained bundle of jobs with their sources, destinations, streams config and advanced settings. Jobs are selected by job_ids or by a label selector; without either every job of the project is exported. Secret config values are replaced by "${secret:<key>}" placeholders whose keys are listed in the bundle. The bundle is returned as a JSON or YAML attachment.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   job_ids       query   []int   false   "job ids to export" collectionFormat(multi)
+// @Param   selector      query   string  false   "label selector, e.g. tier=critical"
+// @Param   format        query   string  false   "bundle format: json (default) or yaml"
+// @Produce json
+// @Produce application/yaml
+// @Success 200 {object} dto.JobBundle
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 500 {object} dto.Error500Response "failed to export jobs"
+// @Router /api/v1/project/{projectid}/jobs/export [get]
+func (h *Handler) ExportJobs(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var query dto.ExportJobsQuery
+	if err := utils.BindQuery(c, &query); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := query.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Export jobs initiated project_id[%s] jobs[%d] selector[%s]", projectID, len(query.JobIDs), query.Selector)
+	bundle, err := h.etl.ExportJobs(c.Request.Context(), projectID, &query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, constants.ErrInvalidLabelSelector), errors.Is(err, constants.ErrBulkLimitExceeded):
+			status = http.StatusBadRequest
+		case errors.Is(err, constants.ErrJobNotFound):
+			status = http.StatusNotFound
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to export jobs: %s", err), err)
+		return
+	}
+
+	format := query.Format
+	if format == "" {
+		format = constants.BundleFormatJSON
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("olake-jobs-%s.%s", projectID, format)))
+	c.Header("Access-Control-Expose-Headers", "Content-Disposition")
+	if format == constants.BundleFormatYAML {
+		c.YAML(http.StatusOK, bundle)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, bundle)
+}
+
+// @Summary Import a job bundle
+// @Tags Jobs
+// @Description Import a job bundle into the project, adapted by an optional overlay, once every entity in it has been planned without conflicts.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   body          body    dto.ImportJobsRequest true "bundle, overlay and import options"
+// @Accept  json
+// @Accept  application/yaml
+// @Success 200 {object} dto.JSONResponse{data=dto.ImportJobsResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 409 {object} dto.JSONResponse{data=dto.ImportJobsResponse} "bundle import has conflicts"
+// @Failure 500 {object} dto.Error500Response "failed to import bundle"
+// @Router /api/v1/project/{projectid}/jobs/import [post]
+func (h *Handler) ImportJobs(c *gin.Context) {
+	userID := utils.GetCurrentUserID(c)
+	if userID == nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Not authenticated", fmt.Errorf("not authenticated"))
+		return
+	}
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.ImportJobsRequest
+	if c.ContentType() == binding.MIMEYAML || c.ContentType() == binding.MIMEYAML2 {
+		err = c.ShouldBindYAML(&req)
+	} else {
+		err = utils.BindAndValidate(c, &req)
+	}
+	if err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Import bundle initiated project_id[%s] jobs[%d] on_conflict[%s] dry_run[%t] user_id[%v]", projectID, len(req.Bundle.Jobs), req.OnConflict, req.DryRun, userID)
+	resp, err := h.etl.ImportJobs(c.Request.Context(), projectID, &req, userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to import bundle: %s", err), err)
+		return
+	}
+
+	switch {
+	case resp.Conflicts > 0:
+		// the plan is the useful part of a rejected import, so it is returned with the error
+		logger.Errorf("error in request %s: %s: %d conflicts", c.Request.URL.Path, constants.ErrImportConflict, resp.Conflicts)
+		c.JSON(http.StatusConflict, dto.JSONResponse{
+			Success: false,
+			Message: fmt.Sprintf("%s: %d conflicts, nothing was applied", constants.ErrImportConflict, resp.Conflicts),
+			Data:    resp,
+		})
+	case !resp.Applied:
+		utils.SuccessResponse(c, "import plan has no conflicts, nothing was applied (dry run)", resp)
+	default:
+		utils.SuccessResponse(c, fmt.Sprintf("bundle imported: %d entities, %d failed", len(resp.Items), resp.Failed), resp)
+	}
+}
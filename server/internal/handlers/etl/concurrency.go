@@ -0,0 +1,112 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get concurrency usage
+// @Tags Concurrency
+// @Description Show the sync and discover caps of a project and of its capped sources and destinations, how many syncs and discovers run and wait against each of them, and the run queue: the syncs waiting for a slot, the discovers waiting for one and the discovers holding one. A cap of 0 is no cap. Running syncs are read from temporal, clear-destination runs do not count.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Success 200 {object} dto.JSONResponse{data=dto.ConcurrencyResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 500 {object} dto.Error500Response "failed to get concurrency usage"
+// @Router /api/v1/project/{projectid}/concurrency [get]
+func (h *Handler) GetConcurrency(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Get concurrency usage initiated project_id[%s]", projectID)
+	usage, err := h.etl.GetConcurrency(c.Request.Context(), projectID)
+	if err != nil {
+		utils.ErrorResponse(c, concurrencyErrorStatus(err), fmt.Sprintf("failed to get concurrency usage: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("%d runs queued or holding a slot in project_id[%s]", len(usage.Queue), projectID), usage)
+}
+
+// @Summary Set concurrency limits
+// @Tags Concurrency
+// @Description Set the maximum concurrent syncs and discovers of the project, a source or a destination; 0 removes a cap.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   body          body    dto.ConcurrencyLimitRequest true "concurrency limits"
+// @Success 200 {object} dto.JSONResponse "concurrency limits updated"
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "source or destination not found"
+// @Failure 500 {object} dto.Error500Response "failed to set concurrency limits"
+// @Router /api/v1/project/{projectid}/concurrency [put]
+func (h *Handler) SetConcurrencyLimits(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.ConcurrencyLimitRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Set concurrency limits initiated project_id[%s] scope[%s] id[%d]", projectID, req.Scope, req.ID)
+	if err := h.etl.SetConcurrencyLimits(c.Request.Context(), projectID, &req); err != nil {
+		utils.ErrorResponse(c, concurrencyErrorStatus(err), fmt.Sprintf("failed to set concurrency limits: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("concurrency limits of %s updated", req.Scope), nil)
+}
+
+// @Summary Remove a queued sync
+// @Tags Concurrency
+// @Description Remove a sync from the run queue so it does not start. Discovers leave the queue with their request.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "run queue entry id"
+// @Success 200 {object} dto.JSONResponse "queued sync removed"
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "queued run not found"
+// @Failure 500 {object} dto.Error500Response "failed to remove queued sync"
+// @Router /api/v1/project/{projectid}/concurrency/queue/{id} [delete]
+func (h *Handler) DeleteRunQueueEntry(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	id, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Remove queued sync initiated project_id[%s] id[%d]", projectID, id)
+	if err := h.etl.DeleteRunQueueEntry(c.Request.Context(), projectID, id); err != nil {
+		utils.ErrorResponse(c, concurrencyErrorStatus(err), fmt.Sprintf("failed to remove queued sync: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("queued sync %d removed", id), nil)
+}
+
+func concurrencyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrRunQueueEntryNotFound),
+		errors.Is(err, constants.ErrSourceNotFound),
+		errors.Is(err, constants.ErrDestinationNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
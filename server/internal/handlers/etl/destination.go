@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
 	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
 	"github.com/datazip-inc/olake-ui/server/internal/utils"
 	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
@@ -15,9 +16,17 @@ import (
 
 // @Summary List all destinations
 // @Tags Destinations
-// @Description Retrieve a list of all configured destinations within a specific project.
+// @Description Retrieve a page of configured destinations within a specific project. Results can be filtered, sorted and paged using an opaque cursor.
 // @Param   projectid     path    string  true    "project id (default is 123)"
-// @Success 200 {object} dto.JSONResponse{data=[]dto.DestinationDataItem}
+// @Param   cursor        query   string  false   "cursor returned as next_cursor by the previous page"
+// @Param   limit         query   int     false   "page size, all destinations are returned when omitted"
+// @Param   name          query   string  false   "destination name substring (case-insensitive)"
+// @Param   type          query   string  false   "destination type"
+// @Param   created_by    query   string  false   "username of the creator"
+// @Param   selector      query   string  false   "label selector, e.g. tier=critical,team!=growth"
+// @Param   sort_by       query   string  false   "sort key: name, created_at, updated_at (default updated_at)"
+// @Param   sort_order    query   string  false   "sort order: asc, desc"
+// @Success 200 {object} dto.JSONResponse{data=dto.PaginatedResponse{items=[]dto.DestinationDataItem}}
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 500 {object} dto.Error500Response "failed to get destinations"
@@ -28,10 +37,19 @@ func (h *Handler) ListDestinations(c *gin.Context) {
 		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
+	var query dto.ListQuery
+	if err := bindListQuery(c, &query, constants.SortKeyName, constants.SortKeyCreatedAt, constants.SortKeyUpdatedAt); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
 	logger.Debugf("Get all destinations initiated project_id[%s]", projectID)
-	items, err := h.etl.ListDestinations(c.Request.Context(), projectID)
+	items, err := h.etl.ListDestinations(c.Request.Context(), projectID, query)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to get destinations: %s", err), err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrInvalidCursor) || errors.Is(err, constants.ErrInvalidLabelSelector) {
+			status = http.StatusBadRequest
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to get destinations: %s", err), err)
 		return
 	}
 	utils.SuccessResponse(c, "destinations listed successfully", items)
@@ -107,6 +125,11 @@ func (h *Handler) CreateDestination(c *gin.Context) {
 		return
 	}
 
+	if err := models.ValidateLabels(req.Labels); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+
 	logger.Debugf("Create destination initiated project_id[%s] destination_type[%s] destination_name[%s] user_id[%v]",
 		projectID, req.Type, req.Name, userID)
 	if err := h.etl.CreateDestination(c.Request.Context(), &req, projectID, userID); err != nil {
@@ -172,7 +195,7 @@ func (h *Handler) UpdateDestination(c *gin.Context) {
 
 // @Summary Delete a destination
 // @Tags Destinations
-// @Description Permanently delete a specified destination.
+// @Description Move a specified destination to the trash, where it can be restored until it is purged. A destination used by jobs cannot be deleted.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   id            path    int     true    "destination id"
 // @Success 200 {object} dto.JSONResponse{data=dto.DeleteDestinationResponse}
@@ -209,6 +232,7 @@ func (h *Handler) DeleteDestination(c *gin.Context) {
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 413 {object} dto.Error413Response "payload too large"
+// @Failure 503 {object} dto.Error503Response "no workers poll the task queue of the worker pool"
 // @Router /api/v1/project/{projectid}/destinations/test [post]
 func (h *Handler) TestDestinationConnection(c *gin.Context) {
 	// need to remove sourceVersion from request
@@ -222,7 +246,11 @@ func (h *Handler) TestDestinationConnection(c *gin.Context) {
 
 	result, logs, err := h.etl.TestDestinationConnection(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to verify driver credentials: %s", err), err)
+		status := workerPoolErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			status = http.StatusBadRequest
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to verify driver credentials: %s", err), err)
 		return
 	}
 	utils.SuccessResponse(c, fmt.Sprintf("destination %s connection tested successfully", req.Type), dto.TestConnectionResponse{
@@ -273,6 +301,7 @@ func (h *Handler) GetDestinationVersions(c *gin.Context) {
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 413 {object} dto.Error413Response "payload too large"
 // @Failure 500 {object} dto.Error500Response "failed to get spec"
+// @Failure 503 {object} dto.Error503Response "no workers poll the task queue of the worker pool"
 // @Router /api/v1/project/{projectid}/destinations/spec [post]
 func (h *Handler) GetDestinationSpec(c *gin.Context) {
 	projectID, err := utils.GetProjectID(c)
@@ -289,7 +318,7 @@ func (h *Handler) GetDestinationSpec(c *gin.Context) {
 		projectID, req.Type, req.Version)
 	resp, err := h.etl.GetDestinationSpec(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to get destination spec: %s", err), err)
+		utils.ErrorResponse(c, workerPoolErrorStatus(err), fmt.Sprintf("failed to get destination spec: %s", err), err)
 		return
 	}
 	utils.SuccessResponse(c, fmt.Sprintf("destination %s spec fetched successfully", req.Type), resp)
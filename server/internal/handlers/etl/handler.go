@@ -1,7 +1,11 @@
 package etl
 
 import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
 	services "github.com/datazip-inc/olake-ui/server/internal/services/etl"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
 )
 
 // encapsulates ETL-specific request handling and business logic.
@@ -13,3 +17,12 @@ type Handler struct {
 func NewHandler(s *services.Service) *Handler {
 	return &Handler{etl: s}
 }
+
+// bindListQuery binds and validates the pagination, filter and sort query parameters
+// of a list endpoint against the sort keys it supports.
+func bindListQuery(c *gin.Context, query *dto.ListQuery, sortKeys ...string) error {
+	if err := utils.BindQuery(c, query); err != nil {
+		return err
+	}
+	return query.Validate(sortKeys...)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
 	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
 	"github.com/datazip-inc/olake-ui/server/internal/utils"
 	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
@@ -16,9 +17,19 @@ import (
 
 // @Summary List all jobs
 // @Tags Jobs
-// @Description Retrieve a list of all jobs associated with a specific project.
-// @Param   projectid     path    string  true    "project id (default is 123)"
-// @Success 200 {object} dto.JSONResponse{data=[]dto.JobResponse}
+// @Description Retrieve a page of jobs associated with a specific project. Results can be filtered, sorted and paged using an opaque cursor.
+// @Param   projectid        path    string  true    "project id (default is 123)"
+// @Param   cursor           query   string  false   "cursor returned as next_cursor by the previous page"
+// @Param   limit            query   int     false   "page size, all jobs are returned when omitted"
+// @Param   name             query   string  false   "job name substring (case-insensitive)"
+// @Param   type             query   string  false   "source or destination type"
+// @Param   active           query   bool    false   "active state"
+// @Param   last_run_status  query   string  false   "status of the latest run (e.g. Completed, Failed, Running)"
+// @Param   created_by       query   string  false   "username of the creator"
+// @Param   selector         query   string  false   "label selector, e.g. tier=critical,team!=growth"
+// @Param   sort_by          query   string  false   "sort key: name, created_at, updated_at (default updated_at)"
+// @Param   sort_order       query   string  false   "sort order: asc, desc"
+// @Success 200 {object} dto.JSONResponse{data=dto.PaginatedResponse{items=[]dto.JobResponse}}
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 500 {object} dto.Error500Response "failed to retrieve jobs"
@@ -29,10 +40,19 @@ func (h *Handler) ListJobs(c *gin.Context) {
 		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
+	var query dto.ListQuery
+	if err := bindListQuery(c, &query, constants.SortKeyName, constants.SortKeyCreatedAt, constants.SortKeyUpdatedAt); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
 	logger.Debugf("List jobs initiated project_id[%s]", projectID)
-	jobs, err := h.etl.ListJobs(c.Request.Context(), projectID)
+	jobs, err := h.etl.ListJobs(c.Request.Context(), projectID, query)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to retrieve jobs by project ID: %s", err), err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrInvalidCursor) || errors.Is(err, constants.ErrInvalidLabelSelector) {
+			status = http.StatusBadRequest
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to retrieve jobs by project ID: %s", err), err)
 		return
 	}
 	utils.SuccessResponse(c, "jobs listed successfully", jobs)
@@ -79,10 +99,12 @@ func (h *Handler) GetJob(c *gin.Context) {
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   body          body    dto.CreateJobRequest true "job data"
 // @Success 200 {object} dto.JSONResponse "job created successfully"
+// @Success 202 {object} dto.JSONResponse "job created, schedule change is pending"
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 413 {object} dto.Error413Response "payload too large"
 // @Failure 500 {object} dto.Error500Response "failed to create job"
+// @Failure 503 {object} dto.Error503Response "no workers poll the task queue of the worker pool"
 // @Router /api/v1/project/{projectid}/jobs [post]
 func (h *Handler) CreateJob(c *gin.Context) {
 	userID := utils.GetCurrentUserID(c)
@@ -104,9 +126,28 @@ func (h *Handler) CreateJob(c *gin.Context) {
 		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
+	if err := models.ValidateLabels(req.Labels); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := dto.ValidateTimeZone(req.TimeZone); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := utils.ValidateFrequency(req.Frequency); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := req.AdvancedSettings.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
 	logger.Debugf("Create job initiated project_id[%s] user_id[%v] job_name[%s]", projectID, userID, req.Name)
 	if err := h.etl.CreateJob(c.Request.Context(), &req, projectID, userID); err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to create job: %s", err), err)
+		if schedulePendingResponse(c, fmt.Sprintf("job '%s' created", req.Name), err) {
+			return
+		}
+		utils.ErrorResponse(c, jobSettingsErrorStatus(err), fmt.Sprintf("failed to create job: %s", err), err)
 		return
 	}
 	utils.SuccessResponse(c, fmt.Sprintf("job '%s' created successfully", req.Name), nil)
@@ -114,16 +155,18 @@ func (h *Handler) CreateJob(c *gin.Context) {
 
 // @Summary Update a job
 // @Tags Jobs
-// @Description Update the configuration details of an existing job.
+// @Description Update the configuration details of an existing job. An omitted time_zone keeps the job's time zone and an empty one makes it follow the project default. Frequency, time zone and activation changes are applied to the temporal schedule after the job is saved; if that cannot be done right away it is retried in the background and status 202 is returned.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   id            path    int     true    "job id"
 // @Param   body          body    dto.UpdateJobRequest true "job data"
 // @Success 200 {object} dto.JSONResponse "job updated successfully"
+// @Success 202 {object} dto.JSONResponse "job updated, schedule change is pending"
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 404 {object} dto.Error404Response "job not found"
 // @Failure 413 {object} dto.Error413Response "payload too large"
 // @Failure 500 {object} dto.Error500Response "failed to update job"
+// @Failure 503 {object} dto.Error503Response "no workers poll the task queue of the worker pool"
 // @Router /api/v1/project/{projectid}/jobs/{id} [put]
 func (h *Handler) UpdateJob(c *gin.Context) {
 	userID := utils.GetCurrentUserID(c)
@@ -150,10 +193,28 @@ func (h *Handler) UpdateJob(c *gin.Context) {
 		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
+	if req.TimeZone != nil {
+		if err := dto.ValidateTimeZone(*req.TimeZone); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+			return
+		}
+	}
+	// a changed frequency is checked against deprecated forms by the update itself
+	if _, err := utils.ParseFrequency(req.Frequency, ""); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := req.AdvancedSettings.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
 
 	logger.Debugf("Update job initiated project_id[%s] job_id[%d] user_id[%v]", projectID, jobID, userID)
 	if err := h.etl.UpdateJob(c.Request.Context(), &req, projectID, jobID, userID); err != nil {
-		status := http.StatusInternalServerError
+		if schedulePendingResponse(c, fmt.Sprintf("job '%s' updated", req.Name), err) {
+			return
+		}
+		status := jobSettingsErrorStatus(err)
 		if errors.Is(err, constants.ErrJobNotFound) {
 			status = http.StatusNotFound
 		}
@@ -165,13 +226,15 @@ func (h *Handler) UpdateJob(c *gin.Context) {
 
 // @Summary Delete a job
 // @Tags Jobs
-// @Description Permanently delete a specified job.
+// @Description Move a specified job to the trash, where it can be restored until it is purged. Running syncs of the job are cancelled; while a clear-destination is running the job cannot be deleted and 409 is returned. Its temporal schedule is paused rather than deleted; if that cannot be done right away it is retried in the background and status 202 is returned.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   id            path    int     true    "job id"
 // @Success 200 {object} dto.JSONResponse "job deleted successfully"
+// @Success 202 {object} dto.JSONResponse "job deleted, schedule change is pending"
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 409 {object} dto.Error409Response "clear-destination is in progress"
 // @Failure 500 {object} dto.Error500Response "failed to delete job"
 // @Router /api/v1/project/{projectid}/jobs/{id} [delete]
 func (h *Handler) DeleteJob(c *gin.Context) {
@@ -183,9 +246,15 @@ func (h *Handler) DeleteJob(c *gin.Context) {
 	logger.Debugf("Delete job initiated job_id[%d]", id)
 	jobName, err := h.etl.DeleteJob(c.Request.Context(), id)
 	if err != nil {
+		if schedulePendingResponse(c, fmt.Sprintf("job '%s' deleted", jobName), err) {
+			return
+		}
 		status := http.StatusInternalServerError
-		if errors.Is(err, constants.ErrJobNotFound) {
+		switch {
+		case errors.Is(err, constants.ErrJobNotFound):
 			status = http.StatusNotFound
+		case errors.Is(err, constants.ErrJobNotIdle):
+			status = http.StatusConflict
 		}
 		utils.ErrorResponse(c, status, fmt.Sprintf("failed to delete job: %s", err), err)
 		return
@@ -231,14 +300,18 @@ func (h *Handler) CheckUniqueName(c *gin.Context) {
 
 // @Summary Trigger job sync
 // @Tags Jobs
-// @Description Trigger a manual sync for a job
+// @Description Trigger a sync of a job through its schedule, or a one-off sync of some of its streams or with run overrides.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   id            path    int     true    "job id"
-// @Success 200 {object} dto.JSONResponse "sync triggered successfully"
+// @Param   body          body    dto.SyncJobRequest false "streams and run overrides of a one-off sync"
+// @Success 200 {object} dto.JSONResponse{data=dto.SyncJobResponse} "sync triggered successfully"
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 409 {object} dto.Error400Response "job is not idle or in a maintenance window"
+// @Failure 429 {object} dto.Error429Response "one-off sync over a concurrency cap"
 // @Failure 500 {object} dto.Error500Response "failed to trigger sync"
+// @Failure 503 {object} dto.Error503Response "no workers poll the task queue of the worker pool"
 // @Router /api/v1/project/{projectid}/jobs/{id}/sync [post]
 func (h *Handler) SyncJob(c *gin.Context) {
 	projectID, err := utils.GetProjectID(c)
@@ -251,26 +324,47 @@ func (h *Handler) SyncJob(c *gin.Context) {
 		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
+	var req *dto.SyncJobRequest
+	if c.Request.ContentLength != 0 {
+		req = &dto.SyncJobRequest{}
+		if err := utils.BindAndValidate(c, req); err != nil {
+			utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+			return
+		}
+	}
 	logger.Debugf("Sync job initiated project_id[%s] job_id[%d]", projectID, id)
-	result, err := h.etl.SyncJob(c.Request.Context(), projectID, id)
+	result, err := h.etl.SyncJob(c.Request.Context(), projectID, id, req)
 	if err != nil {
-		status := http.StatusInternalServerError
-		if errors.Is(err, constants.ErrJobNotFound) {
-			status = http.StatusNotFound
-		}
-		utils.ErrorResponse(c, status, fmt.Sprintf("failed to trigger sync: %s", err), err)
+		utils.ErrorResponse(c, syncJobErrorStatus(err), fmt.Sprintf("failed to trigger sync: %s", err), err)
 		return
 	}
 	utils.SuccessResponse(c, fmt.Sprintf("sync triggered successfully for job_id[%d]", id), result)
 }
 
+func syncJobErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrStreamNotSelected), errors.Is(err, constants.ErrInvalidSyncRun):
+		return http.StatusBadRequest
+	case errors.Is(err, constants.ErrJobNotIdle), errors.Is(err, constants.ErrInMaintenanceWindow):
+		return http.StatusConflict
+	case errors.Is(err, constants.ErrConcurrencyLimit):
+		return http.StatusTooManyRequests
+	case errors.Is(err, constants.ErrUnknownWorkerPool), errors.Is(err, constants.ErrNoWorkerPollers):
+		return workerPoolErrorStatus(err)
+	}
+	return http.StatusInternalServerError
+}
+
 // @Summary Pause or resume job
 // @Tags Jobs
-// @Description Pause or resume a job.
+// @Description Pause or resume a job. If its temporal schedule cannot be paused or resumed right away it is retried in the background and status 202 is returned.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   id            path    int     true    "job id"
 // @Param   body          body    dto.JobStatusRequest true "activation data"
 // @Success 200 {object} dto.JSONResponse "job activated/deactivated successfully"
+// @Success 202 {object} dto.JSONResponse "job activated/deactivated, schedule change is pending"
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 404 {object} dto.Error404Response "job not found"
@@ -294,7 +388,14 @@ func (h *Handler) ActivateJob(c *gin.Context) {
 		return
 	}
 	logger.Debugf("Activate job initiated job_id[%d] activate[%t] user_id[%v]", id, req.Activate, userID)
+	action := "paused"
+	if req.Activate {
+		action = "resumed"
+	}
 	if err := h.etl.ActivateJob(c.Request.Context(), id, req, userID); err != nil {
+		if schedulePendingResponse(c, fmt.Sprintf("job %d %s", id, action), err) {
+			return
+		}
 		status := http.StatusInternalServerError
 		if errors.Is(err, constants.ErrJobNotFound) {
 			status = http.StatusNotFound
@@ -302,13 +403,172 @@ func (h *Handler) ActivateJob(c *gin.Context) {
 		utils.ErrorResponse(c, status, fmt.Sprintf("failed to activate job: %s", err), err)
 		return
 	}
-	action := "paused"
-	if req.Activate {
-		action = "resumed"
-	}
 	utils.SuccessResponse(c, fmt.Sprintf("job %d %s successfully", id, action), nil)
 }
 
+// @Summary Clone a job
+// @Tags Jobs
+// @Description Create a new job with the streams config, frequency, advanced settings and labels of an existing job. The clone can be pointed at another source of the same type or at another destination; it starts with an empty state and its own schedule.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   body          body    dto.CloneJobRequest true "clone data"
+// @Success 200 {object} dto.JSONResponse{data=dto.CloneJobResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job, source or destination not found"
+// @Failure 409 {object} dto.Error409Response "job name is not unique"
+// @Failure 500 {object} dto.Error500Response "failed to clone job"
+// @Router /api/v1/project/{projectid}/jobs/{id}/clone [post]
+func (h *Handler) CloneJob(c *gin.Context) {
+	userID := utils.GetCurrentUserID(c)
+	if userID == nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Not authenticated", fmt.Errorf("not authenticated"))
+		return
+	}
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	id, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.CloneJobRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Clone job initiated project_id[%s] job_id[%d] name[%s] user_id[%v]", projectID, id, req.Name, userID)
+	resp, err := h.etl.CloneJob(c.Request.Context(), projectID, id, &req, userID)
+	if err != nil {
+		utils.ErrorResponse(c, cloneErrorStatus(err), fmt.Sprintf("failed to clone job: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("job '%s' created successfully", resp.Name), resp)
+}
+
+// @Summary Create jobs from a template
+// @Tags Jobs
+// @Description Create one job per source from a base job, copying its streams config, frequency, advanced settings and labels. Sources must be of the same type as the base job's source. Job names come from name_pattern (default "{job}-{source}") which supports {job}, {source}, {source_id} and {index}. Each source reports its own result.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "base job id"
+// @Param   body          body    dto.JobTemplateRequest true "template data"
+// @Success 200 {object} dto.JSONResponse{data=dto.JobTemplateResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job or destination not found"
+// @Failure 500 {object} dto.Error500Response "failed to create jobs from template"
+// @Router /api/v1/project/{projectid}/jobs/{id}/template [post]
+func (h *Handler) CreateJobsFromTemplate(c *gin.Context) {
+	userID := utils.GetCurrentUserID(c)
+	if userID == nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Not authenticated", fmt.Errorf("not authenticated"))
+		return
+	}
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	id, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.JobTemplateRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Create jobs from template initiated project_id[%s] job_id[%d] sources[%d] user_id[%v]", projectID, id, len(req.SourceIDs), userID)
+	resp, err := h.etl.CreateJobsFromTemplate(c.Request.Context(), projectID, id, &req, userID)
+	if err != nil {
+		utils.ErrorResponse(c, cloneErrorStatus(err), fmt.Sprintf("failed to create jobs from template: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("jobs created from template: %d succeeded, %d failed", resp.Succeeded, resp.Failed), resp)
+}
+
+// jobSettingsErrorStatus maps invalid driver resources and secrets without a value to 400, and
+// worker pool errors as workerPoolErrorStatus
+func jobSettingsErrorStatus(err error) int {
+	if errors.Is(err, constants.ErrInvalidDriverResources) || errors.Is(err, constants.ErrMissingSecretValue) || errors.Is(err, constants.ErrInvalidFrequency) {
+		return http.StatusBadRequest
+	}
+	return workerPoolErrorStatus(err)
+}
+
+func cloneErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrJobNotFound),
+		errors.Is(err, constants.ErrSourceNotFound),
+		errors.Is(err, constants.ErrDestinationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrJobNameNotUnique):
+		return http.StatusConflict
+	case errors.Is(err, constants.ErrSourceTypeMismatch):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// @Summary Bulk job operation
+// @Tags Jobs
+// @Description Apply pause, resume, trigger, cancel, change_frequency, change_version or delete to the jobs listed in job_ids or matched by filter. Each job reports its own result; change_version sets the version on the job's source and therefore applies to every job sharing that source. trigger fails for jobs in a maintenance window unless override_maintenance_window is set.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   body          body    dto.BulkJobRequest true "bulk operation"
+// @Success 200 {object} dto.JSONResponse{data=dto.BulkJobResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 413 {object} dto.Error413Response "payload too large"
+// @Failure 500 {object} dto.Error500Response "failed to run bulk operation"
+// @Router /api/v1/project/{projectid}/jobs/bulk [post]
+func (h *Handler) BulkJobOperation(c *gin.Context) {
+	userID := utils.GetCurrentUserID(c)
+	if userID == nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Not authenticated", fmt.Errorf("not authenticated"))
+		return
+	}
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.BulkJobRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if req.Action == constants.BulkActionChangeFrequency {
+		if err := utils.ValidateFrequency(req.Frequency); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+			return
+		}
+	}
+	logger.Debugf("Bulk job operation initiated project_id[%s] action[%s] user_id[%v]", projectID, req.Action, userID)
+	resp, err := h.etl.BulkJobOperation(c.Request.Context(), projectID, &req, userID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrInvalidLabelSelector) || errors.Is(err, constants.ErrBulkLimitExceeded) {
+			status = http.StatusBadRequest
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to run bulk operation: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("bulk %s completed: %d succeeded, %d failed", req.Action, resp.Succeeded, resp.Failed), resp)
+}
+
 // @Summary Cancel running job
 // @Tags Jobs
 // @Description Request cancellation of a currently running job execution.
@@ -456,162 +716,779 @@ func (h *Handler) GetClearDestinationStatus(c *gin.Context) {
 	utils.SuccessResponse(c, fmt.Sprintf("clear destination status retrieved successfully for job_id[%d]", jobID), dto.ClearDestinationStatusResponse{Running: status})
 }
 
-// @Summary List job tasks
+// @Summary List schedule operations of a job
 // @Tags Jobs
-// @Description Retrieve a list of execution tasks associated with a specific job.
+// @Description Retrieve the latest changes to the temporal schedule of a job, newest first.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   id            path    int     true    "job id"
-// @Success 200 {object} dto.JSONResponse{data=[]dto.JobTask}
+// @Success 200 {object} dto.JSONResponse{data=dto.ScheduleOperationsResponse}
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 404 {object} dto.Error404Response "job not found"
-// @Failure 500 {object} dto.Error500Response "failed to get job tasks"
-// @Router /api/v1/project/{projectid}/jobs/{id}/tasks [get]
-func (h *Handler) GetJobTasks(c *gin.Context) {
+// @Failure 500 {object} dto.Error500Response "failed to list schedule operations"
+// @Router /api/v1/project/{projectid}/jobs/{id}/schedule-operations [get]
+func (h *Handler) ListScheduleOperations(c *gin.Context) {
 	projectID, err := utils.GetProjectID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
-	id, err := utils.GetIDParam(c)
+	jobID, err := utils.GetIDParam(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
-	logger.Debugf("Get job tasks initiated project_id[%s] job_id[%d]", projectID, id)
-	tasks, err := h.etl.GetJobTasks(c.Request.Context(), projectID, id)
+	logger.Debugf("List schedule operations initiated project_id[%s] job_id[%d]", projectID, jobID)
+	resp, err := h.etl.ListScheduleOperations(c.Request.Context(), projectID, jobID)
 	if err != nil {
 		status := http.StatusInternalServerError
 		if errors.Is(err, constants.ErrJobNotFound) {
 			status = http.StatusNotFound
 		}
-		utils.ErrorResponse(c, status, fmt.Sprintf("failed to get job tasks: %s", err), err)
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to list schedule operations: %s", err), err)
 		return
 	}
-	utils.SuccessResponse(c, fmt.Sprintf("job tasks listed successfully for job_id[%d]", id), tasks)
+	utils.SuccessResponse(c, fmt.Sprintf("schedule of job_id[%d] is %s", jobID, resp.ScheduleStatus), resp)
 }
 
-// @Summary Get task logs
+// @Summary Retry failed schedule operations of a job
 // @Tags Jobs
-// @Description Retrieves the execution logs for a specific task. The file path for the log must be obtained from the [Get Job Tasks](#/Jobs/get_api_v1_project__projectid__jobs__id__tasks) endpoint.
+// @Description Queue the schedule operations of a job that ran out of attempts again and apply them right away. The resulting operations are returned.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   id            path    int     true    "job id"
-// @Param   taskid        path    string  true    "task id (defaults to 1)"
-// @Param   body          body    dto.JobTaskRequest true "task log data"
-// @Param   cursor        query   int     false   "log cursor"
-// @Param   limit         query   int     false   "log limit"
-// @Param   direction     query   string  false   "log direction"
-// @Success 200 {object} dto.JSONResponse{data=dto.TaskLogsResponse}
+// @Success 200 {object} dto.JSONResponse{data=dto.ScheduleOperationsResponse}
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 404 {object} dto.Error404Response "job not found"
-// @Failure 413 {object} dto.Error413Response "payload too large"
-// @Failure 500 {object} dto.Error500Response "failed to get task logs"
-// @Router /api/v1/project/{projectid}/jobs/{id}/tasks/{taskid}/logs [post]
-func (h *Handler) GetTaskLogs(c *gin.Context) {
-	id, err := utils.GetIDParam(c)
+// @Failure 500 {object} dto.Error500Response "failed to retry schedule operations"
+// @Router /api/v1/project/{projectid}/jobs/{id}/schedule-operations/retry [post]
+func (h *Handler) RetryScheduleOperations(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
-	var req dto.JobTaskRequest
-	if err := utils.BindAndValidate(c, &req); err != nil {
-		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
-	logger.Debugf("Get task logs initiated job_id[%d] file_path[%s]", id, req.FilePath)
-
-	cursor := constants.DefaultLogsCursor
-	if raw := c.Query("cursor"); raw != "" {
-		if parsed, err := strconv.ParseInt(raw, 10, 64); err == nil {
-			cursor = parsed
-		}
-	}
-	limit := constants.DefaultLogsLimit
-	if raw := c.Query("limit"); raw != "" {
-		if parsed, err := strconv.Atoi(raw); err == nil {
-			limit = parsed
-		}
-	}
-	direction := c.DefaultQuery("direction", constants.DefaultLogsDirection)
-
-	logs, err := h.etl.GetTaskLogs(c.Request.Context(), id, req.FilePath, cursor, limit, direction)
+	logger.Debugf("Retry schedule operations initiated project_id[%s] job_id[%d]", projectID, jobID)
+	resp, err := h.etl.RetryScheduleOperations(c.Request.Context(), projectID, jobID)
 	if err != nil {
 		status := http.StatusInternalServerError
 		if errors.Is(err, constants.ErrJobNotFound) {
 			status = http.StatusNotFound
 		}
-		utils.ErrorResponse(c, status, fmt.Sprintf("failed to get task logs: %s", err), err)
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to retry schedule operations: %s", err), err)
 		return
 	}
-	utils.SuccessResponse(c, fmt.Sprintf("task logs retrieved successfully for job_id[%d]", id), logs)
+	utils.SuccessResponse(c, fmt.Sprintf("schedule of job_id[%d] is %s", jobID, resp.ScheduleStatus), resp)
 }
 
-// @Summary Download task logs
+// @Summary List job revisions
 // @Tags Jobs
-// @Description Downloads the log file for a specific task. The file path required for the download must be obtained from the [Get Job Tasks](#/Jobs/get_api_v1_project__projectid__jobs__id__tasks) endpoint.
+// @Description Retrieve the revisions of a job, newest first. A revision is an immutable copy of the streams config, frequency, source, destination and advanced settings of the job, saved with its author every time one of them changes. Jobs created before revisions were recorded get their configuration as of their last update saved as a "baseline" revision on their first change.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   id            path    int     true    "job id"
-// @Param   file_path     query   string  true    "log file path"
-// @Success 200 {file} file
+// @Success 200 {object} dto.JSONResponse{data=dto.JobRevisionsResponse}
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
-// @Failure 404 {object} dto.Error404Response "failed to prepare log archive"
-// @Failure 500 {object} dto.Error500Response "internal server error"
-// @Router /api/v1/project/{projectid}/jobs/{id}/logs/download [get]
-func (h *Handler) DownloadTaskLogs(c *gin.Context) {
-	id, err := utils.GetIDParam(c)
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 500 {object} dto.Error500Response "failed to list job revisions"
+// @Router /api/v1/project/{projectid}/jobs/{id}/revisions [get]
+func (h *Handler) ListJobRevisions(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
-	filePath := c.Query("file_path")
-	if filePath == "" {
-		utils.ErrorResponse(c, http.StatusBadRequest, "file_path query parameter is required", nil)
-		return
-	}
-	logger.Debugf("Download task logs initiated job_id[%d] file_path[%s]", id, filePath)
-	filename, err := utils.GetLogArchiveFilename(id, filePath)
+	jobID, err := utils.GetIDParam(c)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusNotFound, fmt.Sprintf("failed to prepare log archive: %s", err), err)
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
-
-	c.Header("Content-Type", "application/gzip")
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
-	c.Header("Cache-Control", "no-cache")
-	c.Header("X-Content-Type-Options", "nosniff")
-	// Expose Content-Disposition header so browser JS can access filename for download
-	c.Header("Access-Control-Expose-Headers", "Content-Disposition")
-
-	if err := h.etl.StreamLogArchive(id, filePath, c.Writer); err != nil {
-		logger.Errorf("failed to stream log archive job_id[%d]: %s", id, err)
+	logger.Debugf("List job revisions initiated project_id[%s] job_id[%d]", projectID, jobID)
+	resp, err := h.etl.ListJobRevisions(c.Request.Context(), projectID, jobID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrJobNotFound) {
+			status = http.StatusNotFound
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to list job revisions: %s", err), err)
 		return
 	}
-
-	logger.Infof("successfully streamed log archive job_id[%d] filename[%s]", id, filename)
+	utils.SuccessResponse(c, fmt.Sprintf("%d revisions of job_id[%d]", len(resp.Revisions), jobID), resp)
 }
 
-// @Summary (Internal) Update sync telemetry
-// @Tags Internal
-// @Description Internal callback to update sync telemetry data.
-// @Param   body          body    dto.UpdateSyncTelemetryRequest true "telemetry data"
-// @Success 200 {object} dto.JSONResponse "sync telemetry updated successfully"
+// @Summary Diff two job revisions
+// @Tags Jobs
+// @Description Compare two revisions of a job: the job settings that differ and, stream by stream, the streams added or removed and the settings changed on the others, with their sync mode and column selection changes. Without to, from is compared with the latest revision.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   from          query   int     true    "revision to compare from"
+// @Param   to            query   int     false   "revision to compare to, the latest by default"
+// @Success 200 {object} dto.JSONResponse{data=dto.JobRevisionDiffResponse}
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
-// @Failure 413 {object} dto.Error413Response "payload too large"
-// @Failure 500 {object} dto.Error500Response "internal server error"
-// @Router /internal/worker/callback/sync-telemetry [post]
-func (h *Handler) UpdateSyncTelemetry(c *gin.Context) {
-	var req dto.UpdateSyncTelemetryRequest
-	if err := utils.BindAndValidate(c, &req); err != nil {
-		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job or revision not found"
+// @Failure 500 {object} dto.Error500Response "failed to diff job revisions"
+// @Router /api/v1/project/{projectid}/jobs/{id}/revisions/diff [get]
+func (h *Handler) DiffJobRevisions(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
-	if req.JobID == 0 || req.WorkflowID == "" {
-		utils.ErrorResponse(c, http.StatusBadRequest, "job_id and workflow_id are required", nil)
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
-	logger.Debugf("Update sync telemetry callback initiated job_id[%d] workflow_id[%s] event[%s]", req.JobID, req.WorkflowID, req.Event)
+	var query dto.JobRevisionDiffQuery
+	if err := utils.BindQuery(c, &query); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Diff job revisions initiated project_id[%s] job_id[%d] from[%d] to[%d]", projectID, jobID, query.From, query.To)
+	resp, err := h.etl.DiffJobRevisions(c.Request.Context(), projectID, jobID, query.From, query.To)
+	if err != nil {
+		utils.ErrorResponse(c, revisionErrorStatus(err), fmt.Sprintf("failed to diff job revisions: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("%d streams changed from revision %d to %d", len(resp.StreamChanges), resp.From, resp.To), resp)
+}
+
+// @Summary Roll back a job to a revision
+// @Tags Jobs
+// @Description Restore the configuration of a revision of a job and save it as a new rollback revision.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   revision      path    int     true    "revision to roll back to"
+// @Success 200 {object} dto.JSONResponse{data=dto.JobRollbackResponse}
+// @Success 202 {object} dto.JSONResponse "job rolled back, schedule change is pending"
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job or revision not found"
+// @Failure 409 {object} dto.Error400Response "cannot roll back job"
+// @Failure 500 {object} dto.Error500Response "failed to roll back job"
+// @Router /api/v1/project/{projectid}/jobs/{id}/revisions/{revision}/rollback [post]
+func (h *Handler) RollbackJob(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision < 1 {
+		err = fmt.Errorf("invalid revision '%s'", c.Param("revision"))
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	userID := utils.GetCurrentUserID(c)
+	if userID == nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Not authenticated", fmt.Errorf("not authenticated"))
+		return
+	}
+	logger.Debugf("Rollback job initiated project_id[%s] job_id[%d] revision[%d]", projectID, jobID, revision)
+	resp, err := h.etl.RollbackJob(c.Request.Context(), projectID, jobID, revision, userID)
+	if err != nil {
+		if schedulePendingResponse(c, fmt.Sprintf("job_id[%d] rolled back to revision %d", jobID, revision), err) {
+			return
+		}
+		utils.ErrorResponse(c, revisionErrorStatus(err), fmt.Sprintf("failed to roll back job: %s", err), err)
+		return
+	}
+	if resp.Revision == 0 {
+		utils.SuccessResponse(c, fmt.Sprintf("job_id[%d] already has the configuration of revision %d", jobID, revision), resp)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("job_id[%d] rolled back to revision %d", jobID, revision), resp)
+}
+
+// @Summary Get job state
+// @Tags Jobs
+// @Description Retrieve the state of a job broken down by stream: the cursor field and value of incremental streams, the chunks left of an initial load and whether the stream follows the CDC position, which is returned once for the job. With snapshot_id the state of that snapshot is shown instead of the current one.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   snapshot_id   query   int     false   "state snapshot to show"
+// @Success 200 {object} dto.JSONResponse{data=dto.JobStateResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job or snapshot not found"
+// @Failure 500 {object} dto.Error500Response "failed to get job state"
+// @Router /api/v1/project/{projectid}/jobs/{id}/state [get]
+func (h *Handler) GetJobState(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var query dto.JobStateQuery
+	if err := utils.BindQuery(c, &query); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Get job state initiated project_id[%s] job_id[%d] snapshot_id[%d]", projectID, jobID, query.SnapshotID)
+	resp, err := h.etl.GetJobState(c.Request.Context(), projectID, jobID, query.SnapshotID)
+	if err != nil {
+		utils.ErrorResponse(c, stateErrorStatus(err), fmt.Sprintf("failed to get job state: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("state of job_id[%d] has %d streams", jobID, len(resp.Streams)), resp)
+}
+
+// @Summary List job state history
+// @Tags Jobs
+// @Description Retrieve the latest state snapshots of a job, newest first, with the run that wrote each of them. A snapshot is kept every time the state is written by a sync, reset by a manual clear-destination or restored; only the most recent ones are kept. A job whose state was written before the history was kept gets that state saved as a "baseline" snapshot.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Success 200 {object} dto.JSONResponse{data=dto.JobStateHistoryResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 500 {object} dto.Error500Response "failed to list job state history"
+// @Router /api/v1/project/{projectid}/jobs/{id}/state/history [get]
+func (h *Handler) ListJobStateHistory(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("List job state history initiated project_id[%s] job_id[%d]", projectID, jobID)
+	resp, err := h.etl.ListJobStateHistory(c.Request.Context(), projectID, jobID)
+	if err != nil {
+		utils.ErrorResponse(c, stateErrorStatus(err), fmt.Sprintf("failed to list job state history: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("%d state snapshots of job_id[%d]", len(resp.Snapshots), jobID), resp)
+}
+
+// @Summary Restore a job state snapshot
+// @Tags Jobs
+// @Description Write the state of a snapshot back to the job; the restore is kept as a new snapshot. The job has to be idle: the request fails with 409 while a sync or clear-destination is running, and the schedule is paused while the state is written so that no sync starts in between.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   snapshotid    path    int     true    "state snapshot id"
+// @Success 200 {object} dto.JSONResponse{data=dto.JobStateSnapshotItem}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job or snapshot not found"
+// @Failure 409 {object} dto.Error400Response "job is not idle or in a maintenance window"
+// @Failure 500 {object} dto.Error500Response "failed to restore job state"
+// @Router /api/v1/project/{projectid}/jobs/{id}/state/history/{snapshotid}/restore [post]
+func (h *Handler) RestoreJobState(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	snapshotID, err := strconv.Atoi(c.Param("snapshotid"))
+	if err != nil || snapshotID < 1 {
+		err = fmt.Errorf("invalid snapshot id '%s'", c.Param("snapshotid"))
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Restore job state initiated project_id[%s] job_id[%d] snapshot_id[%d]", projectID, jobID, snapshotID)
+	resp, err := h.etl.RestoreJobState(c.Request.Context(), projectID, jobID, snapshotID)
+	if err != nil {
+		utils.ErrorResponse(c, stateErrorStatus(err), fmt.Sprintf("failed to restore job state: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("state of job_id[%d] restored from snapshot %d", jobID, snapshotID), resp)
+}
+
+// @Summary Resync job streams
+// @Tags Jobs
+// @Description Sync some streams of a job again from scratch, optionally truncating their destination tables first.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   body          body    dto.ResyncStreamsRequest true "streams to resync"
+// @Success 200 {object} dto.JSONResponse{data=dto.ResyncStreamsResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 409 {object} dto.Error400Response "job is paused or not idle"
+// @Failure 500 {object} dto.Error500Response "failed to resync streams"
+// @Router /api/v1/project/{projectid}/jobs/{id}/resync [post]
+func (h *Handler) ResyncStreams(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.ResyncStreamsRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Resync streams initiated project_id[%s] job_id[%d] streams[%v] clear_destination[%t]", projectID, jobID, req.Streams, req.ClearDestination)
+	resp, err := h.etl.ResyncStreams(c.Request.Context(), projectID, jobID, &req)
+	if err != nil {
+		utils.ErrorResponse(c, stateErrorStatus(err), fmt.Sprintf("failed to resync streams: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("resync of %d streams of job_id[%d] started", len(resp.Streams), jobID), resp)
+}
+
+// @Summary Get the job dependency graph
+// @Tags Jobs
+// @Description Get the dependency graph of the jobs of a project: every job with upstream or downstream jobs, an edge from each job to each of its upstream jobs with the status of the latest upstream sync since the job was last triggered by them, and the upstream jobs each job is still waiting on.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Success 200 {object} dto.JSONResponse{data=dto.DependencyGraphResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 500 {object} dto.Error500Response "failed to get dependency graph"
+// @Router /api/v1/project/{projectid}/jobs/dependencies [get]
+func (h *Handler) GetDependencyGraph(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Get dependency graph initiated project_id[%s]", projectID)
+	graph, err := h.etl.GetDependencyGraph(c.Request.Context(), projectID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to get dependency graph: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("dependency graph of %d jobs", len(graph.Nodes)), graph)
+}
+
+// @Summary Preview a schedule
+// @Tags Jobs
+// @Description Compute the next fire times of a frequency before it is saved on a job, in time_zone or, without one, in the project default time zone. The times are computed from the frequency without jitter; with jitter every run may start up to the jitter later. Times in a maintenance window of the project, or of source_id when it is set, are skipped. count defaults to 10 and is at most 100.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   body          body    dto.SchedulePreviewRequest true "frequency, time zone, source and count"
+// @Success 200 {object} dto.JSONResponse{data=dto.SchedulePreviewResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 500 {object} dto.Error500Response "failed to preview schedule"
+// @Router /api/v1/project/{projectid}/jobs/schedule-preview [post]
+func (h *Handler) PreviewSchedule(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.SchedulePreviewRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := utils.ValidateFrequency(req.Frequency); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Preview schedule initiated project_id[%s] frequency[%s] time_zone[%s]", projectID, req.Frequency, req.TimeZone)
+	resp, err := h.etl.PreviewSchedule(c.Request.Context(), projectID, &req)
+	if err != nil {
+		utils.ErrorResponse(c, scheduleErrorStatus(err), fmt.Sprintf("failed to preview schedule: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("next %d fire times of '%s'", len(resp.FireTimes), req.Frequency), resp)
+}
+
+// @Summary Preview a job schedule
+// @Tags Jobs
+// @Description Get the next fire times of the schedule of a job in its time zone.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   count         query   int     false   "number of fire times"
+// @Success 200 {object} dto.JSONResponse{data=dto.SchedulePreviewResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 500 {object} dto.Error500Response "failed to preview job schedule"
+// @Router /api/v1/project/{projectid}/jobs/{id}/schedule-preview [get]
+func (h *Handler) GetJobSchedulePreview(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var query dto.SchedulePreviewQuery
+	if err := utils.BindQuery(c, &query); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := query.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Preview job schedule initiated project_id[%s] job_id[%d]", projectID, jobID)
+	resp, err := h.etl.GetJobSchedulePreview(c.Request.Context(), projectID, jobID, query.Count)
+	if err != nil {
+		utils.ErrorResponse(c, scheduleErrorStatus(err), fmt.Sprintf("failed to preview job schedule: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("next %d fire times of job_id[%d]", len(resp.FireTimes), jobID), resp)
+}
+
+// @Summary Get the project run calendar
+// @Tags Jobs
+// @Description Get the scheduled runs of the active jobs of a project in the coming window, grouped by source, with the spans in which they overlap.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   window        query   string  false   "how far ahead to look, e.g. 24h"
+// @Success 200 {object} dto.JSONResponse{data=dto.RunCalendarResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 500 {object} dto.Error500Response "failed to get run calendar"
+// @Router /api/v1/project/{projectid}/jobs/calendar [get]
+func (h *Handler) GetRunCalendar(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var query dto.RunCalendarQuery
+	if err := utils.BindQuery(c, &query); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	window, err := query.WindowDuration()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Get run calendar initiated project_id[%s] window[%s]", projectID, window)
+	resp, err := h.etl.GetRunCalendar(c.Request.Context(), projectID, window)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to get run calendar: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("run calendar of %d sources", len(resp.Sources)), resp)
+}
+
+// @Summary Get job dependencies
+// @Tags Jobs
+// @Description Get the upstream jobs of a job, with the status of their latest sync since the job was last triggered by them (pending, completed or failed), its downstream jobs, and the upstream jobs it is still waiting on.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Success 200 {object} dto.JSONResponse{data=dto.JobDependenciesResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 500 {object} dto.Error500Response "failed to get job dependencies"
+// @Router /api/v1/project/{projectid}/jobs/{id}/dependencies [get]
+func (h *Handler) GetJobDependencies(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Get job dependencies initiated project_id[%s] job_id[%d]", projectID, jobID)
+	resp, err := h.etl.GetJobDependencies(c.Request.Context(), projectID, jobID)
+	if err != nil {
+		utils.ErrorResponse(c, dependencyErrorStatus(err), fmt.Sprintf("failed to get job dependencies: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("dependencies of job_id[%d]", jobID), resp)
+}
+
+// @Summary Set job dependencies
+// @Tags Jobs
+// @Description Set the upstream jobs of a job and the policy by which their syncs trigger it.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   body          body    dto.JobDependenciesRequest true "upstream jobs and policy"
+// @Success 200 {object} dto.JSONResponse{data=dto.JobDependenciesResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 409 {object} dto.Error409Response "dependencies form a cycle"
+// @Failure 500 {object} dto.Error500Response "failed to set job dependencies"
+// @Router /api/v1/project/{projectid}/jobs/{id}/dependencies [put]
+func (h *Handler) SetJobDependencies(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.JobDependenciesRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Set job dependencies initiated project_id[%s] job_id[%d] upstreams%v", projectID, jobID, req.UpstreamJobIDs)
+	resp, err := h.etl.SetJobDependencies(c.Request.Context(), projectID, jobID, &req)
+	if err != nil {
+		utils.ErrorResponse(c, dependencyErrorStatus(err), fmt.Sprintf("failed to set job dependencies: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("dependencies of job_id[%d] updated", jobID), resp)
+}
+
+// @Summary Get job run status
+// @Tags Jobs
+// @Description Get the status of a run of a job by the run id a sync trigger returned, optionally waiting for the run to close.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   runid         path    string  true    "run id"
+// @Param   wait          query   bool    false   "wait for the run to close"
+// @Param   timeout       query   string  false   "how long to wait, e.g. 5m"
+// @Param   Authorization header  string  false   "Bearer trigger token of the job, on the /trigger/v1 route"
+// @Success 200 {object} dto.JSONResponse{data=dto.JobRunResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job or run not found"
+// @Failure 500 {object} dto.Error500Response "failed to get job run"
+// @Router /api/v1/project/{projectid}/jobs/{id}/runs/{runid} [get]
+// @Router /trigger/v1/project/{projectid}/jobs/{id}/runs/{runid} [get]
+func (h *Handler) GetJobRun(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	runID := c.Param("runid")
+	var query dto.JobRunQuery
+	if err := utils.BindQuery(c, &query); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	wait, err := query.WaitTimeout()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Get job run initiated project_id[%s] job_id[%d] run_id[%s] wait[%s]", projectID, jobID, runID, wait)
+	resp, err := h.etl.GetJobRun(c.Request.Context(), projectID, jobID, runID, wait)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrJobNotFound) || errors.Is(err, constants.ErrRunNotFound) {
+			status = http.StatusNotFound
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to get job run: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("run %s of job_id[%d] is %s", runID, jobID, resp.Status), resp)
+}
+
+// @Summary List job tasks
+// @Tags Jobs
+// @Description Retrieve a page of execution tasks associated with a specific job, newest first.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   cursor        query   string  false   "cursor returned as next_cursor by the previous page"
+// @Param   limit         query   int     false   "page size, all tasks are returned when omitted"
+// @Param   status        query   string  false   "execution status (Running, Completed, Failed, Canceled, Terminated, ContinuedAsNew, TimedOut)"
+// @Param   type          query   string  false   "task type: sync, clear"
+// @Success 200 {object} dto.JSONResponse{data=dto.PaginatedResponse{items=[]dto.JobTask}}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 500 {object} dto.Error500Response "failed to get job tasks"
+// @Router /api/v1/project/{projectid}/jobs/{id}/tasks [get]
+func (h *Handler) GetJobTasks(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	id, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var query dto.JobTaskListQuery
+	if err := utils.BindQuery(c, &query); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if err := query.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Get job tasks initiated project_id[%s] job_id[%d]", projectID, id)
+	tasks, err := h.etl.GetJobTasks(c.Request.Context(), projectID, id, query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrJobNotFound) {
+			status = http.StatusNotFound
+		} else if errors.Is(err, constants.ErrInvalidCursor) {
+			status = http.StatusBadRequest
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to get job tasks: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("job tasks listed successfully for job_id[%d]", id), tasks)
+}
+
+// @Summary Get task logs
+// @Tags Jobs
+// @Description Retrieves the execution logs for a specific task. The file path for the log must be obtained from the [Get Job Tasks](#/Jobs/get_api_v1_project__projectid__jobs__id__tasks) endpoint.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   taskid        path    string  true    "task id (defaults to 1)"
+// @Param   body          body    dto.JobTaskRequest true "task log data"
+// @Param   cursor        query   int     false   "log cursor"
+// @Param   limit         query   int     false   "log limit"
+// @Param   direction     query   string  false   "log direction"
+// @Success 200 {object} dto.JSONResponse{data=dto.TaskLogsResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 413 {object} dto.Error413Response "payload too large"
+// @Failure 500 {object} dto.Error500Response "failed to get task logs"
+// @Router /api/v1/project/{projectid}/jobs/{id}/tasks/{taskid}/logs [post]
+func (h *Handler) GetTaskLogs(c *gin.Context) {
+	id, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.JobTaskRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Get task logs initiated job_id[%d] file_path[%s]", id, req.FilePath)
+
+	cursor := constants.DefaultLogsCursor
+	if raw := c.Query("cursor"); raw != "" {
+		if parsed, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			cursor = parsed
+		}
+	}
+	limit := constants.DefaultLogsLimit
+	if raw := c.Query("limit"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil {
+			limit = parsed
+		}
+	}
+	direction := c.DefaultQuery("direction", constants.DefaultLogsDirection)
+
+	logs, err := h.etl.GetTaskLogs(c.Request.Context(), id, req.FilePath, cursor, limit, direction)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrJobNotFound) {
+			status = http.StatusNotFound
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to get task logs: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("task logs retrieved successfully for job_id[%d]", id), logs)
+}
+
+// @Summary Download task logs
+// @Tags Jobs
+// @Description Downloads the log file for a specific task. The file path required for the download must be obtained from the [Get Job Tasks](#/Jobs/get_api_v1_project__projectid__jobs__id__tasks) endpoint.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   file_path     query   string  true    "log file path"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "failed to prepare log archive"
+// @Failure 500 {object} dto.Error500Response "internal server error"
+// @Router /api/v1/project/{projectid}/jobs/{id}/logs/download [get]
+func (h *Handler) DownloadTaskLogs(c *gin.Context) {
+	id, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	filePath := c.Query("file_path")
+	if filePath == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "file_path query parameter is required", nil)
+		return
+	}
+	logger.Debugf("Download task logs initiated job_id[%d] file_path[%s]", id, filePath)
+	filename, err := utils.GetLogArchiveFilename(id, filePath)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusNotFound, fmt.Sprintf("failed to prepare log archive: %s", err), err)
+		return
+	}
+
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Content-Type-Options", "nosniff")
+	// Expose Content-Disposition header so browser JS can access filename for download
+	c.Header("Access-Control-Expose-Headers", "Content-Disposition")
+
+	if err := h.etl.StreamLogArchive(id, filePath, c.Writer); err != nil {
+		logger.Errorf("failed to stream log archive job_id[%d]: %s", id, err)
+		return
+	}
+
+	logger.Infof("successfully streamed log archive job_id[%d] filename[%s]", id, filename)
+}
+
+// @Summary (Internal) Update sync telemetry
+// @Tags Internal
+// @Description Internal callback to update sync telemetry data.
+// @Param   body          body    dto.UpdateSyncTelemetryRequest true "telemetry data"
+// @Success 200 {object} dto.JSONResponse "sync telemetry updated successfully"
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 413 {object} dto.Error413Response "payload too large"
+// @Failure 500 {object} dto.Error500Response "internal server error"
+// @Router /internal/worker/callback/sync-telemetry [post]
+func (h *Handler) UpdateSyncTelemetry(c *gin.Context) {
+	var req dto.UpdateSyncTelemetryRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	if req.JobID == 0 || req.WorkflowID == "" {
+		utils.ErrorResponse(c, http.StatusBadRequest, "job_id and workflow_id are required", nil)
+		return
+	}
+	logger.Debugf("Update sync telemetry callback initiated job_id[%d] workflow_id[%s] event[%s]", req.JobID, req.WorkflowID, req.Event)
 	if err := h.etl.UpdateSyncTelemetry(c.Request.Context(), req); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update sync telemetry", err)
 		return
@@ -655,7 +1532,7 @@ func (h *Handler) RecoverClearDestination(c *gin.Context) {
 
 // @Summary (Internal) Update state file
 // @Tags Internal
-// @Description Internal endpoint to update the state file associated with a job.
+// @Description Internal endpoint to update the state file associated with a job. The state is kept in the state history of the job, tied to the run given by workflow_id and run_id or else to the sync running at the time.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   id            path    int     true    "job id"
 // @Param   body          body    dto.UpdateStateFileRequest true "state file data"
@@ -677,7 +1554,7 @@ func (h *Handler) UpdateStateFile(c *gin.Context) {
 		return
 	}
 	logger.Debugf("Update state file callback initiated job_id[%d]", jobID)
-	if err := h.etl.UpdateStateFile(jobID, req.StateFile); err != nil {
+	if err := h.etl.UpdateStateFile(c.Request.Context(), jobID, &req); err != nil {
 		status := http.StatusInternalServerError
 		if errors.Is(err, constants.ErrJobNotFound) {
 			status = http.StatusNotFound
@@ -707,3 +1584,65 @@ func validateJobDriverConfig(source, destination *dto.DriverConfig) error {
 	}
 	return nil
 }
+
+// schedulePendingResponse reports a job change that is saved while the change to its temporal
+// schedule is still queued, with status 202. It returns false for any other error.
+func schedulePendingResponse(c *gin.Context, message string, err error) bool {
+	if !errors.Is(err, constants.ErrSchedulePending) {
+		return false
+	}
+	logger.Warnf("request %s: %s", c.Request.URL.Path, err)
+	c.JSON(http.StatusAccepted, dto.JSONResponse{
+		Success: true,
+		Message: fmt.Sprintf("%s, %s", message, err),
+	})
+	return true
+}
+
+func revisionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrJobNotFound), errors.Is(err, constants.ErrJobRevisionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrRollbackConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func stateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrJobNotFound), errors.Is(err, constants.ErrStateSnapshotNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrStreamNotSelected):
+		return http.StatusBadRequest
+	case errors.Is(err, constants.ErrJobNotIdle), errors.Is(err, constants.ErrJobPaused):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func dependencyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrInvalidDependency):
+		return http.StatusBadRequest
+	case errors.Is(err, constants.ErrDependencyCycle):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func scheduleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrInvalidFrequency):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,176 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+)
+
+// Label handlers are shared by jobs, sources and destinations; the router binds
+// each route to the table type of the entity it serves.
+
+// @Summary Get labels
+// @Tags Labels
+// @Description Retrieve the key/value labels attached to a job, source or destination.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "entity id"
+// @Success 200 {object} dto.JSONResponse{data=dto.LabelsResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "entity not found"
+// @Failure 500 {object} dto.Error500Response "failed to get labels"
+// @Router /api/v1/project/{projectid}/jobs/{id}/labels [get]
+// @Router /api/v1/project/{projectid}/sources/{id}/labels [get]
+// @Router /api/v1/project/{projectid}/destinations/{id}/labels [get]
+func (h *Handler) GetLabels(entity constants.TableType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID, id, ok := labelTarget(c)
+		if !ok {
+			return
+		}
+		logger.Debugf("Get labels initiated project_id[%s] table[%s] id[%d]", projectID, constants.TableNameMap[entity], id)
+		resp, err := h.etl.GetLabels(c.Request.Context(), entity, projectID, id)
+		if err != nil {
+			utils.ErrorResponse(c, labelErrorStatus(err), fmt.Sprintf("failed to get labels: %s", err), err)
+			return
+		}
+		utils.SuccessResponse(c, "labels retrieved successfully", resp)
+	}
+}
+
+// @Summary Replace labels
+// @Tags Labels
+// @Description Replace every label of a job, source or destination with the provided set.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "entity id"
+// @Param   body          body    dto.ReplaceLabelsRequest true "labels"
+// @Success 200 {object} dto.JSONResponse{data=dto.LabelsResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "entity not found"
+// @Failure 500 {object} dto.Error500Response "failed to update labels"
+// @Router /api/v1/project/{projectid}/jobs/{id}/labels [put]
+// @Router /api/v1/project/{projectid}/sources/{id}/labels [put]
+// @Router /api/v1/project/{projectid}/destinations/{id}/labels [put]
+func (h *Handler) ReplaceLabels(entity constants.TableType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID, id, ok := labelTarget(c)
+		if !ok {
+			return
+		}
+		var req dto.ReplaceLabelsRequest
+		if err := utils.BindAndValidate(c, &req); err != nil {
+			utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+			return
+		}
+		logger.Debugf("Replace labels initiated project_id[%s] table[%s] id[%d]", projectID, constants.TableNameMap[entity], id)
+		resp, err := h.etl.ReplaceLabels(c.Request.Context(), entity, projectID, id, &req)
+		if err != nil {
+			utils.ErrorResponse(c, labelErrorStatus(err), fmt.Sprintf("failed to update labels: %s", err), err)
+			return
+		}
+		utils.SuccessResponse(c, "labels updated successfully", resp)
+	}
+}
+
+// @Summary Patch labels
+// @Tags Labels
+// @Description Add or overwrite the labels in "set" and remove the keys in "remove", leaving other labels untouched.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "entity id"
+// @Param   body          body    dto.PatchLabelsRequest true "label changes"
+// @Success 200 {object} dto.JSONResponse{data=dto.LabelsResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "entity not found"
+// @Failure 500 {object} dto.Error500Response "failed to update labels"
+// @Router /api/v1/project/{projectid}/jobs/{id}/labels [patch]
+// @Router /api/v1/project/{projectid}/sources/{id}/labels [patch]
+// @Router /api/v1/project/{projectid}/destinations/{id}/labels [patch]
+func (h *Handler) PatchLabels(entity constants.TableType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID, id, ok := labelTarget(c)
+		if !ok {
+			return
+		}
+		var req dto.PatchLabelsRequest
+		if err := utils.BindAndValidate(c, &req); err != nil {
+			utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+			return
+		}
+		logger.Debugf("Patch labels initiated project_id[%s] table[%s] id[%d]", projectID, constants.TableNameMap[entity], id)
+		resp, err := h.etl.PatchLabels(c.Request.Context(), entity, projectID, id, &req)
+		if err != nil {
+			utils.ErrorResponse(c, labelErrorStatus(err), fmt.Sprintf("failed to update labels: %s", err), err)
+			return
+		}
+		utils.SuccessResponse(c, "labels updated successfully", resp)
+	}
+}
+
+// @Summary Delete a label
+// @Tags Labels
+// @Description Remove a single label key from a job, source or destination.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "entity id"
+// @Param   key           path    string  true    "label key"
+// @Success 200 {object} dto.JSONResponse{data=dto.LabelsResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "entity or label not found"
+// @Failure 500 {object} dto.Error500Response "failed to delete label"
+// @Router /api/v1/project/{projectid}/jobs/{id}/labels/{key} [delete]
+// @Router /api/v1/project/{projectid}/sources/{id}/labels/{key} [delete]
+// @Router /api/v1/project/{projectid}/destinations/{id}/labels/{key} [delete]
+func (h *Handler) DeleteLabel(entity constants.TableType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID, id, ok := labelTarget(c)
+		if !ok {
+			return
+		}
+		key := c.Param("key")
+		logger.Debugf("Delete label initiated project_id[%s] table[%s] id[%d] key[%s]", projectID, constants.TableNameMap[entity], id, key)
+		resp, err := h.etl.DeleteLabel(c.Request.Context(), entity, projectID, id, key)
+		if err != nil {
+			utils.ErrorResponse(c, labelErrorStatus(err), fmt.Sprintf("failed to delete label: %s", err), err)
+			return
+		}
+		utils.SuccessResponse(c, fmt.Sprintf("label '%s' deleted successfully", key), resp)
+	}
+}
+
+// labelTarget reads the project and entity id of a label route, writing a 400 response on failure.
+func labelTarget(c *gin.Context) (string, int, bool) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return "", 0, false
+	}
+	id, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return "", 0, false
+	}
+	return projectID, id, true
+}
+
+func labelErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrInvalidLabel):
+		return http.StatusBadRequest
+	case errors.Is(err, constants.ErrJobNotFound),
+		errors.Is(err, constants.ErrSourceNotFound),
+		errors.Is(err, constants.ErrDestinationNotFound),
+		errors.Is(err, constants.ErrLabelNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
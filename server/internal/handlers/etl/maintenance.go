@@ -0,0 +1,169 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary List maintenance windows
+// @Tags Maintenance Windows
+// @Description List the maintenance windows of a project, of its sources and of its jobs. active_until is set on a window that is in effect, to the end of the current occurrence; a window without a time zone is reported on the project default time zone here and follows the time zone of each job's schedule otherwise.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Success 200 {object} dto.JSONResponse{data=[]dto.MaintenanceWindowItem}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 500 {object} dto.Error500Response "failed to list maintenance windows"
+// @Router /api/v1/project/{projectid}/maintenance-windows [get]
+func (h *Handler) ListMaintenanceWindows(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("List maintenance windows initiated project_id[%s]", projectID)
+	windows, err := h.etl.ListMaintenanceWindows(c.Request.Context(), projectID)
+	if err != nil {
+		utils.ErrorResponse(c, maintenanceWindowErrorStatus(err), fmt.Sprintf("failed to list maintenance windows: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("%d maintenance windows of project_id[%s]", len(windows), projectID), windows)
+}
+
+// @Summary Create a maintenance window
+// @Tags Maintenance Windows
+// @Description Create a maintenance window in which scheduled syncs of the project, of a source or of a job do not start.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   body          body    dto.MaintenanceWindowRequest true "maintenance window"
+// @Success 200 {object} dto.JSONResponse{data=dto.MaintenanceWindowItem}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "source or job not found"
+// @Failure 409 {object} dto.Error409Response "maintenance window limit reached"
+// @Failure 500 {object} dto.Error500Response "failed to create maintenance window"
+// @Router /api/v1/project/{projectid}/maintenance-windows [post]
+func (h *Handler) CreateMaintenanceWindow(c *gin.Context) {
+	userID := utils.GetCurrentUserID(c)
+	if userID == nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Not authenticated", fmt.Errorf("not authenticated"))
+		return
+	}
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	req, ok := bindMaintenanceWindowRequest(c)
+	if !ok {
+		return
+	}
+	logger.Debugf("Create maintenance window initiated project_id[%s] name[%s]", projectID, req.Name)
+	window, err := h.etl.CreateMaintenanceWindow(c.Request.Context(), projectID, req, userID)
+	if err != nil {
+		utils.ErrorResponse(c, maintenanceWindowErrorStatus(err), fmt.Sprintf("failed to create maintenance window: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("maintenance window '%s' created", req.Name), window)
+}
+
+// @Summary Update a maintenance window
+// @Tags Maintenance Windows
+// @Description Replace a maintenance window, including its scope. The schedules of the jobs it applied to before and of those it applies to now are updated.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "maintenance window id"
+// @Param   body          body    dto.MaintenanceWindowRequest true "maintenance window"
+// @Success 200 {object} dto.JSONResponse{data=dto.MaintenanceWindowItem}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "maintenance window, source or job not found"
+// @Failure 500 {object} dto.Error500Response "failed to update maintenance window"
+// @Router /api/v1/project/{projectid}/maintenance-windows/{id} [put]
+func (h *Handler) UpdateMaintenanceWindow(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	id, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	req, ok := bindMaintenanceWindowRequest(c)
+	if !ok {
+		return
+	}
+	logger.Debugf("Update maintenance window initiated project_id[%s] id[%d]", projectID, id)
+	window, err := h.etl.UpdateMaintenanceWindow(c.Request.Context(), projectID, id, req)
+	if err != nil {
+		utils.ErrorResponse(c, maintenanceWindowErrorStatus(err), fmt.Sprintf("failed to update maintenance window: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("maintenance window %d updated", id), window)
+}
+
+// @Summary Delete a maintenance window
+// @Tags Maintenance Windows
+// @Description Delete a maintenance window and remove it from the schedules of the jobs it applied to.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "maintenance window id"
+// @Success 200 {object} dto.JSONResponse "maintenance window deleted"
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "maintenance window not found"
+// @Failure 500 {object} dto.Error500Response "failed to delete maintenance window"
+// @Router /api/v1/project/{projectid}/maintenance-windows/{id} [delete]
+func (h *Handler) DeleteMaintenanceWindow(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	id, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Delete maintenance window initiated project_id[%s] id[%d]", projectID, id)
+	if err := h.etl.DeleteMaintenanceWindow(c.Request.Context(), projectID, id); err != nil {
+		utils.ErrorResponse(c, maintenanceWindowErrorStatus(err), fmt.Sprintf("failed to delete maintenance window: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("maintenance window %d deleted", id), nil)
+}
+
+// bindMaintenanceWindowRequest binds and validates a maintenance window, writing the error
+// response when it is invalid
+func bindMaintenanceWindowRequest(c *gin.Context) (*dto.MaintenanceWindowRequest, bool) {
+	var req dto.MaintenanceWindowRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return nil, false
+	}
+	err := req.Validate()
+	if err == nil {
+		_, err = utils.ParseWindow(req.Days, req.StartTime, req.EndTime, req.TimeZone, req.StartsAt, req.EndsAt)
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return nil, false
+	}
+	return &req, true
+}
+
+func maintenanceWindowErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrMaintenanceWindowNotFound), errors.Is(err, constants.ErrSourceNotFound), errors.Is(err, constants.ErrJobNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrMaintenanceWindowLimit):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
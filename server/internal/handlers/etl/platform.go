@@ -1,10 +1,13 @@
 package etl
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
 	"github.com/datazip-inc/olake-ui/server/internal/utils"
 	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
 	"github.com/gin-gonic/gin"
@@ -33,3 +36,60 @@ func (h *Handler) GetReleaseUpdates(c *gin.Context) {
 	}
 	utils.SuccessResponse(c, "release metadata fetched successfully", response)
 }
+
+// @Summary List worker pools
+// @Tags Platform
+// @Description List the worker pools configured on the server with TEMPORAL_WORKER_POOLS, with the task queue of each and how many workers poll it. The default pool polls the default task queue. A job runs on the pool of its advanced settings, else on the pool of its source, else on the default pool.
+// @Success 200 {object} dto.JSONResponse{data=[]dto.WorkerPoolItem}
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Router /api/v1/platform/worker-pools [get]
+func (h *Handler) ListWorkerPools(c *gin.Context) {
+	logger.Debugf("List worker pools initiated")
+	pools := h.etl.ListWorkerPools(c.Request.Context())
+	utils.SuccessResponse(c, fmt.Sprintf("%d worker pools configured", len(pools)), pools)
+}
+
+// @Summary Reconcile jobs and schedules
+// @Tags Platform
+// @Description Compare every job with its temporal schedule across all projects and repair the drift unless dry_run is set.
+// @Param   dry_run       query   bool    false   "only report drift"
+// @Success 200 {object} dto.JSONResponse{data=dto.ReconcileResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 409 {object} dto.Error400Response "reconciliation is already in progress"
+// @Failure 500 {object} dto.Error500Response "failed to reconcile schedules"
+// @Router /api/v1/platform/reconcile [post]
+func (h *Handler) ReconcileSchedules(c *gin.Context) {
+	var query dto.ReconcileQuery
+	if err := utils.BindQuery(c, &query); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Reconcile schedules initiated dry_run[%t]", query.DryRun)
+	resp, err := h.etl.ReconcileSchedules(c.Request.Context(), query.DryRun)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrReconcileInProgress) {
+			status = http.StatusConflict
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to reconcile schedules: %s", err), err)
+		return
+	}
+	if query.DryRun {
+		utils.SuccessResponse(c, fmt.Sprintf("%d drifted schedules found, nothing was repaired (dry run)", resp.Drifted), resp)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("%d drifted schedules found, %d repaired, %d failed", resp.Drifted, resp.Repaired, resp.Failed), resp)
+}
+
+// workerPoolErrorStatus maps an unknown worker pool to 400 and a pool without workers to 503
+func workerPoolErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrUnknownWorkerPool):
+		return http.StatusBadRequest
+	case errors.Is(err, constants.ErrNoWorkerPollers):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
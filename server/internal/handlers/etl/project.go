@@ -37,7 +37,7 @@ func (h *Handler) GetProjectSettings(c *gin.Context) {
 
 // @Summary Update project settings
 // @Tags Project Settings
-// @Description Create or update the settings for a project.
+// @Description Create or update the settings for a project. default_time_zone, an IANA time zone name, is the schedule time zone of the jobs without one of their own; changing it moves their schedules to the new zone. It is left as it is when omitted and an empty string resets it to UTC.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   body          body    dto.UpsertProjectSettingsRequest true "project settings data"
 // @Success 200 {object} dto.JSONResponse "Project Settings updated successfully"
@@ -65,8 +65,15 @@ func (h *Handler) UpsertProjectSettings(c *gin.Context) {
 		return
 	}
 
+	if req.DefaultTimeZone != nil {
+		if err := dto.ValidateTimeZone(*req.DefaultTimeZone); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+			return
+		}
+	}
+
 	logger.Debugf("Upsert project settings initiated project_id[%s]", projectID)
-	if err := h.etl.UpsertProjectSettings(req); err != nil {
+	if err := h.etl.UpsertProjectSettings(c.Request.Context(), req); err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to update project settings: %s", err), err)
 		return
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
 	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
 	"github.com/datazip-inc/olake-ui/server/internal/utils"
 	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
@@ -15,9 +16,17 @@ import (
 
 // @Summary List all sources
 // @Tags Sources
-// @Description Retrieve a list of all configured sources within a specific project.
+// @Description Retrieve a page of configured sources within a specific project. Results can be filtered, sorted and paged using an opaque cursor.
 // @Param   projectid     path    string  true    "project id (default is 123)"
-// @Success 200 {object} dto.JSONResponse{data=[]dto.SourceDataItem}
+// @Param   cursor        query   string  false   "cursor returned as next_cursor by the previous page"
+// @Param   limit         query   int     false   "page size, all sources are returned when omitted"
+// @Param   name          query   string  false   "source name substring (case-insensitive)"
+// @Param   type          query   string  false   "source type"
+// @Param   created_by    query   string  false   "username of the creator"
+// @Param   selector      query   string  false   "label selector, e.g. tier=critical,team!=growth"
+// @Param   sort_by       query   string  false   "sort key: name, created_at, updated_at (default updated_at)"
+// @Param   sort_order    query   string  false   "sort order: asc, desc"
+// @Success 200 {object} dto.JSONResponse{data=dto.PaginatedResponse{items=[]dto.SourceDataItem}}
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 500 {object} dto.Error500Response "failed to retrieve sources"
@@ -28,10 +37,19 @@ func (h *Handler) ListSources(c *gin.Context) {
 		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
+	var query dto.ListQuery
+	if err := bindListQuery(c, &query, constants.SortKeyName, constants.SortKeyCreatedAt, constants.SortKeyUpdatedAt); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
 	logger.Debugf("Get all sources initiated project_id[%s]", projectID)
-	sources, err := h.etl.ListSources(c.Request.Context(), projectID)
+	sources, err := h.etl.ListSources(c.Request.Context(), projectID, query)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to retrieve sources: %s", err), err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrInvalidCursor) || errors.Is(err, constants.ErrInvalidLabelSelector) {
+			status = http.StatusBadRequest
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to retrieve sources: %s", err), err)
 		return
 	}
 	utils.SuccessResponse(c, "sources listed successfully", sources)
@@ -82,6 +100,7 @@ func (h *Handler) GetSource(c *gin.Context) {
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 413 {object} dto.Error413Response "payload too large"
 // @Failure 500 {object} dto.Error500Response "failed to create source"
+// @Failure 503 {object} dto.Error503Response "no workers poll the task queue of the worker pool"
 // @Router /api/v1/project/{projectid}/sources [post]
 func (h *Handler) CreateSource(c *gin.Context) {
 	userID := utils.GetCurrentUserID(c)
@@ -103,9 +122,13 @@ func (h *Handler) CreateSource(c *gin.Context) {
 		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
 		return
 	}
+	if err := models.ValidateLabels(req.Labels); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
 	logger.Debugf("Create source initiated project_id[%s] source_type[%s] source_name[%s] user_id[%v]", projectID, req.Type, req.Name, userID)
 	if err := h.etl.CreateSource(c.Request.Context(), &req, projectID, userID); err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to create source: %s", err), err)
+		utils.ErrorResponse(c, workerPoolErrorStatus(err), fmt.Sprintf("failed to create source: %s", err), err)
 		return
 	}
 	utils.SuccessResponse(c, fmt.Sprintf("source %s created successfully", req.Name), req)
@@ -123,6 +146,7 @@ func (h *Handler) CreateSource(c *gin.Context) {
 // @Failure 404 {object} dto.Error404Response "source not found"
 // @Failure 413 {object} dto.Error413Response "payload too large"
 // @Failure 500 {object} dto.Error500Response "failed to update source"
+// @Failure 503 {object} dto.Error503Response "no workers poll the task queue of the worker pool"
 // @Router /api/v1/project/{projectid}/sources/{id} [put]
 func (h *Handler) UpdateSource(c *gin.Context) {
 	// TODO: on SESSION_ON=false we need to skip userID check
@@ -152,7 +176,7 @@ func (h *Handler) UpdateSource(c *gin.Context) {
 	}
 	logger.Debugf("Update source initiated project_id[%s] source_id[%d] source_type[%s] user_id[%v]", projectID, id, req.Type, userID)
 	if err := h.etl.UpdateSource(c.Request.Context(), projectID, id, &req, userID); err != nil {
-		status := http.StatusInternalServerError
+		status := workerPoolErrorStatus(err)
 		if errors.Is(err, constants.ErrSourceNotFound) {
 			status = http.StatusNotFound
 		}
@@ -164,7 +188,7 @@ func (h *Handler) UpdateSource(c *gin.Context) {
 
 // @Summary Delete a source
 // @Tags Sources
-// @Description Permanently delete a specified source.
+// @Description Move a specified source to the trash, where it can be restored until it is purged. A source used by jobs cannot be deleted.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   id            path    int     true    "source id"
 // @Success 200 {object} dto.JSONResponse{data=dto.DeleteSourceResponse}
@@ -202,6 +226,7 @@ func (h *Handler) DeleteSource(c *gin.Context) {
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 413 {object} dto.Error413Response "payload too large"
 // @Failure 500 {object} dto.Error500Response "failed to test connection"
+// @Failure 503 {object} dto.Error503Response "no workers poll the task queue of the worker pool"
 // @Router /api/v1/project/{projectid}/sources/test [post]
 func (h *Handler) TestSourceConnection(c *gin.Context) {
 	var req dto.SourceTestConnectionRequest
@@ -216,7 +241,7 @@ func (h *Handler) TestSourceConnection(c *gin.Context) {
 	logger.Infof("Test source connection initiated source_type[%s] source_version[%s]", req.Type, req.Version)
 	result, logs, err := h.etl.TestSourceConnection(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to verify credentials: %s", err), err)
+		utils.ErrorResponse(c, workerPoolErrorStatus(err), fmt.Sprintf("failed to verify credentials: %s", err), err)
 		return
 	}
 	utils.SuccessResponse(c, fmt.Sprintf("source %s connection tested successfully", req.Type), dto.TestConnectionResponse{
@@ -227,16 +252,23 @@ func (h *Handler) TestSourceConnection(c *gin.Context) {
 
 // @Summary Get source stream catalog
 // @Tags Sources
-// @Description Discover and list available data streams from a source.
+// @Description Discover and list available data streams from a source. The discover counts against the discover caps of the project, and of the source and destination of the job in job_id or of the source in source_id; it waits up to 10 minutes for a slot, then fails with 429.
 // @Param   projectid     path    string  true    "project id (default is 123)"
 // @Param   body          body    dto.StreamsRequest true "streams request data"
 // @Success 200 {object} dto.JSONResponse{data=object}
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 413 {object} dto.Error413Response "payload too large"
+// @Failure 429 {object} dto.Error429Response "concurrency limit reached"
 // @Failure 500 {object} dto.Error500Response "failed to get source catalog"
+// @Failure 503 {object} dto.Error503Response "no workers poll the task queue of the worker pool"
 // @Router /api/v1/project/{projectid}/sources/streams [post]
 func (h *Handler) GetSourceCatalog(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
 	var req dto.StreamsRequest
 	if err := utils.BindAndValidate(c, &req); err != nil {
 		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
@@ -247,9 +279,13 @@ func (h *Handler) GetSourceCatalog(c *gin.Context) {
 		return
 	}
 	logger.Debugf("Get source catalog initiated source_type[%s] source_version[%s] job_id[%d]", req.Type, req.Version, req.JobID)
-	catalog, err := h.etl.GetSourceCatalog(c.Request.Context(), &req)
+	catalog, err := h.etl.GetSourceCatalog(c.Request.Context(), projectID, &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to get source streams: %s", err), err)
+		status := workerPoolErrorStatus(err)
+		if errors.Is(err, constants.ErrConcurrencyLimit) {
+			status = http.StatusTooManyRequests
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to get source streams: %s", err), err)
 		return
 	}
 	utils.SuccessResponse(c, fmt.Sprintf("source %s catalog fetched successfully", req.Type), catalog)
@@ -295,6 +331,7 @@ func (h *Handler) GetSourceVersions(c *gin.Context) {
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 413 {object} dto.Error413Response "payload too large"
 // @Failure 500 {object} dto.Error500Response "failed to get spec"
+// @Failure 503 {object} dto.Error503Response "no workers poll the task queue of the worker pool"
 // @Router /api/v1/project/{projectid}/sources/spec [post]
 func (h *Handler) GetSourceSpec(c *gin.Context) {
 	projectID, err := utils.GetProjectID(c)
@@ -314,7 +351,7 @@ func (h *Handler) GetSourceSpec(c *gin.Context) {
 	logger.Debugf("Get source spec initiated project_id[%s] source_type[%s] source_version[%s]", projectID, req.Type, req.Version)
 	resp, err := h.etl.GetSourceSpec(c.Request.Context(), &req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to get source spec: %s", err), err)
+		utils.ErrorResponse(c, workerPoolErrorStatus(err), fmt.Sprintf("failed to get source spec: %s", err), err)
 		return
 	}
 	utils.SuccessResponse(c, fmt.Sprintf("source %s spec fetched successfully", req.Type), resp)
@@ -0,0 +1,112 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+)
+
+// @Summary Plan a declarative spec
+// @Tags Spec
+// @Description Compare a declarative spec of projects, sources, destinations and jobs with the current state, without changing anything.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   body          body    dto.SpecPlanRequest true "declarative spec"
+// @Accept  json
+// @Accept  application/yaml
+// @Success 200 {object} dto.JSONResponse{data=dto.SpecPlanResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 500 {object} dto.Error500Response "failed to plan spec"
+// @Router /api/v1/project/{projectid}/spec/plan [post]
+func (h *Handler) PlanSpec(c *gin.Context) {
+	req, ok := bindSpecRequest(c)
+	if !ok {
+		return
+	}
+	logger.Debugf("Plan spec initiated projects[%d]", len(req.Spec.Projects))
+	resp, err := h.etl.PlanSpec(c.Request.Context(), req)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to plan spec: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("plan: %d to create, %d to update, %d to delete, %d destructive, %d errors", resp.Creates, resp.Updates, resp.Deletes, resp.Destructive, len(resp.Errors)), resp)
+}
+
+// @Summary Apply a declarative spec
+// @Tags Spec
+// @Description Plan a declarative spec and apply it when the plan has no errors and no destructive changes that are not allowed.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   body          body    dto.SpecPlanRequest true "declarative spec and apply options"
+// @Accept  json
+// @Accept  application/yaml
+// @Success 200 {object} dto.JSONResponse{data=dto.SpecPlanResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 409 {object} dto.JSONResponse{data=dto.SpecPlanResponse} "spec is invalid or has destructive changes"
+// @Failure 500 {object} dto.Error500Response "failed to apply spec"
+// @Router /api/v1/project/{projectid}/spec/apply [post]
+func (h *Handler) ApplySpec(c *gin.Context) {
+	userID := utils.GetCurrentUserID(c)
+	if userID == nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Not authenticated", fmt.Errorf("not authenticated"))
+		return
+	}
+	req, ok := bindSpecRequest(c)
+	if !ok {
+		return
+	}
+	logger.Debugf("Apply spec initiated projects[%d] allow_destructive[%t] user_id[%v]", len(req.Spec.Projects), req.AllowDestructive, userID)
+	resp, err := h.etl.ApplySpec(c.Request.Context(), req, userID)
+	if err != nil {
+		if errors.Is(err, constants.ErrInvalidSpec) || errors.Is(err, constants.ErrDestructiveChanges) {
+			// the plan is the useful part of a refused apply, so it is returned with the error
+			logger.Errorf("error in request %s: %s", c.Request.URL.Path, err)
+			c.JSON(http.StatusConflict, dto.JSONResponse{
+				Success: false,
+				Message: err.Error(),
+				Data:    resp,
+			})
+			return
+		}
+		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to apply spec: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("spec applied: %d created, %d updated, %d deleted, %d failed", resp.Creates, resp.Updates, resp.Deletes, resp.Failed), resp)
+}
+
+// bindSpecRequest binds a JSON or YAML spec request of the project in the path and writes the
+// error response when it is invalid.
+func bindSpecRequest(c *gin.Context) (*dto.SpecPlanRequest, bool) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return nil, false
+	}
+	var req dto.SpecPlanRequest
+	if c.ContentType() == binding.MIMEYAML || c.ContentType() == binding.MIMEYAML2 {
+		err = c.ShouldBindYAML(&req)
+	} else {
+		err = utils.BindAndValidate(c, &req)
+	}
+	if err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return nil, false
+	}
+	if err := req.Validate(); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return nil, false
+	}
+	if err := req.ValidateProject(projectID); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return nil, false
+	}
+	return &req, true
+}
@@ -0,0 +1,134 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary List the trash
+// @Tags Trash
+// @Description Retrieve the deleted jobs, sources and destinations of a project. Deleted entities are hidden from every other listing and can be restored until they are purged, TRASH_RETENTION after they were deleted. The schedule of a deleted job is paused, not deleted.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Success 200 {object} dto.JSONResponse{data=dto.TrashResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 500 {object} dto.Error500Response "failed to list trash"
+// @Router /api/v1/project/{projectid}/trash [get]
+func (h *Handler) ListTrash(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("List trash initiated project_id[%s]", projectID)
+	resp, err := h.etl.ListTrash(c.Request.Context(), projectID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to list trash: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("%d items in the trash", len(resp.Items)), resp)
+}
+
+// @Summary Restore from the trash
+// @Tags Trash
+// @Description Restore a deleted job, source or destination. A job can only be restored once its source and destination are restored; its schedule is recreated if it is gone and paused or unpaused as the job was before it was deleted. If the schedule cannot be changed right away it is retried in the background and status 202 is returned. An entity whose name was taken in the meantime cannot be restored.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   kind          path    string  true    "job, source or destination"
+// @Param   id            path    int     true    "id of the deleted entity"
+// @Success 200 {object} dto.JSONResponse "restored successfully"
+// @Success 202 {object} dto.JSONResponse "restored, schedule change is pending"
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "not found in trash"
+// @Failure 409 {object} dto.Error400Response "cannot restore from trash"
+// @Failure 500 {object} dto.Error500Response "failed to restore from trash"
+// @Router /api/v1/project/{projectid}/trash/{kind}/{id}/restore [post]
+func (h *Handler) RestoreFromTrash(c *gin.Context) {
+	projectID, kind, id, err := trashParams(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	userID := utils.GetCurrentUserID(c)
+	if userID == nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Not authenticated", fmt.Errorf("not authenticated"))
+		return
+	}
+	logger.Debugf("Restore from trash initiated project_id[%s] %s_id[%d]", projectID, kind, id)
+	err = h.etl.RestoreFromTrash(c.Request.Context(), projectID, kind, id, userID)
+	if err != nil {
+		if schedulePendingResponse(c, fmt.Sprintf("%s %d restored", kind, id), err) {
+			return
+		}
+		utils.ErrorResponse(c, trashErrorStatus(err), fmt.Sprintf("failed to restore from trash: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("%s %d restored successfully", kind, id), nil)
+}
+
+// @Summary Purge from the trash
+// @Tags Trash
+// @Description Permanently delete a deleted job, source or destination before its retention runs out. A purged job has its schedule deleted. Sources and destinations still used by a job, deleted or not, cannot be purged.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   kind          path    string  true    "job, source or destination"
+// @Param   id            path    int     true    "id of the deleted entity"
+// @Success 200 {object} dto.JSONResponse "purged successfully"
+// @Success 202 {object} dto.JSONResponse "purged, schedule change is pending"
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "not found in trash or still used by a job"
+// @Failure 500 {object} dto.Error500Response "failed to purge from trash"
+// @Router /api/v1/project/{projectid}/trash/{kind}/{id} [delete]
+func (h *Handler) PurgeFromTrash(c *gin.Context) {
+	projectID, kind, id, err := trashParams(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Purge from trash initiated project_id[%s] %s_id[%d]", projectID, kind, id)
+	err = h.etl.PurgeFromTrash(c.Request.Context(), projectID, kind, id)
+	if err != nil {
+		if schedulePendingResponse(c, fmt.Sprintf("%s %d purged", kind, id), err) {
+			return
+		}
+		utils.ErrorResponse(c, trashErrorStatus(err), fmt.Sprintf("failed to purge from trash: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("%s %d purged successfully", kind, id), nil)
+}
+
+// trashParams reads the project, kind and id of a trash request
+func trashParams(c *gin.Context) (string, string, int, error) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		return "", "", 0, err
+	}
+	kind := c.Param("kind")
+	switch kind {
+	case constants.BundleKindJob, constants.BundleKindSource, constants.BundleKindDestination:
+	default:
+		return "", "", 0, fmt.Errorf("invalid kind '%s', expected job, source or destination", kind)
+	}
+	id, err := utils.GetIDParam(c)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return projectID, kind, id, nil
+}
+
+func trashErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrJobNotFound), errors.Is(err, constants.ErrSourceNotFound), errors.Is(err, constants.ErrDestinationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrRestoreConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
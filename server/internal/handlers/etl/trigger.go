@@ -0,0 +1,213 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// TriggerTokenAuth authenticates the /trigger/v1 routes with a trigger token of the job in the
+// path, sent as "Authorization: Bearer <token>", in place of a user session.
+func (h *Handler) TriggerTokenAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		projectID, err := utils.GetProjectID(c)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+			c.Abort()
+			return
+		}
+		jobID, err := utils.GetIDParam(c)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+			c.Abort()
+			return
+		}
+
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			err = fmt.Errorf("%w: bearer token is required", constants.ErrInvalidTriggerToken)
+		} else {
+			err = h.etl.AuthenticateTriggerToken(c.Request.Context(), projectID, jobID, strings.TrimSpace(token))
+		}
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, constants.ErrInvalidTriggerToken) {
+				status = http.StatusUnauthorized
+			}
+			utils.ErrorResponse(c, status, "Unauthorized, invalid trigger token", err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// @Summary Trigger job sync with a trigger token
+// @Tags Jobs
+// @Description Trigger a sync of the job through its schedule, authenticated with a trigger token of the job; takes no body.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   Authorization header  string  true    "Bearer trigger token of the job"
+// @Success 200 {object} dto.JSONResponse{data=dto.SyncJobResponse} "sync triggered successfully"
+// @Failure 400 {object} dto.Error400Response "the request has a body"
+// @Failure 401 {object} dto.Error401Response "invalid trigger token"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 409 {object} dto.Error400Response "job is not idle or in a maintenance window"
+// @Failure 500 {object} dto.Error500Response "failed to trigger sync"
+// @Failure 503 {object} dto.Error503Response "no workers poll the task queue of the worker pool"
+// @Router /trigger/v1/project/{projectid}/jobs/{id}/sync [post]
+func (h *Handler) TriggerSync(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	id, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	// stream subsets, run overrides and maintenance window overrides need a user session
+	if c.Request.ContentLength != 0 {
+		err := fmt.Errorf("a sync triggered with a trigger token takes no body")
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Trigger sync initiated project_id[%s] job_id[%d]", projectID, id)
+	result, err := h.etl.SyncJob(c.Request.Context(), projectID, id, nil)
+	if err != nil {
+		utils.ErrorResponse(c, syncJobErrorStatus(err), fmt.Sprintf("failed to trigger sync: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("sync triggered successfully for job_id[%d]", id), result)
+}
+
+// @Summary Create a job trigger token
+// @Tags Jobs
+// @Description Create a token with which an external scheduler such as Airflow or Dagster can trigger syncs of this job and follow their runs on the /trigger/v1 routes, without a user account. The token is returned only in this response; only its prefix is shown afterwards. A job has at most 10 tokens.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   body          body    dto.CreateTriggerTokenRequest true "token name"
+// @Success 200 {object} dto.JSONResponse{data=dto.CreateTriggerTokenResponse}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 409 {object} dto.Error409Response "trigger token limit reached"
+// @Failure 500 {object} dto.Error500Response "failed to create trigger token"
+// @Router /api/v1/project/{projectid}/jobs/{id}/trigger-tokens [post]
+func (h *Handler) CreateTriggerToken(c *gin.Context) {
+	userID := utils.GetCurrentUserID(c)
+	if userID == nil {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Not authenticated", fmt.Errorf("not authenticated"))
+		return
+	}
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	var req dto.CreateTriggerTokenRequest
+	if err := utils.BindAndValidate(c, &req); err != nil {
+		utils.ErrorResponse(c, utils.StatusFromBindError(err), fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Create trigger token initiated project_id[%s] job_id[%d]", projectID, jobID)
+	resp, err := h.etl.CreateTriggerToken(c.Request.Context(), projectID, jobID, &req, userID)
+	if err != nil {
+		utils.ErrorResponse(c, triggerTokenErrorStatus(err), fmt.Sprintf("failed to create trigger token: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("trigger token '%s' created for job_id[%d]", req.Name, jobID), resp)
+}
+
+// @Summary List job trigger tokens
+// @Tags Jobs
+// @Description List the trigger tokens of a job, newest first, with their prefix and when they were last used. The tokens themselves are not returned.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Success 200 {object} dto.JSONResponse{data=[]dto.TriggerTokenItem}
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job not found"
+// @Failure 500 {object} dto.Error500Response "failed to list trigger tokens"
+// @Router /api/v1/project/{projectid}/jobs/{id}/trigger-tokens [get]
+func (h *Handler) ListTriggerTokens(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("List trigger tokens initiated project_id[%s] job_id[%d]", projectID, jobID)
+	tokens, err := h.etl.ListTriggerTokens(c.Request.Context(), projectID, jobID)
+	if err != nil {
+		utils.ErrorResponse(c, triggerTokenErrorStatus(err), fmt.Sprintf("failed to list trigger tokens: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("%d trigger tokens of job_id[%d]", len(tokens), jobID), tokens)
+}
+
+// @Summary Revoke a job trigger token
+// @Tags Jobs
+// @Description Delete a trigger token of a job; requests made with it are rejected from then on.
+// @Param   projectid     path    string  true    "project id (default is 123)"
+// @Param   id            path    int     true    "job id"
+// @Param   tokenid       path    int     true    "trigger token id"
+// @Success 200 {object} dto.JSONResponse "trigger token revoked"
+// @Failure 400 {object} dto.Error400Response "failed to validate request"
+// @Failure 401 {object} dto.Error401Response "unauthorized"
+// @Failure 404 {object} dto.Error404Response "job or trigger token not found"
+// @Failure 500 {object} dto.Error500Response "failed to revoke trigger token"
+// @Router /api/v1/project/{projectid}/jobs/{id}/trigger-tokens/{tokenid} [delete]
+func (h *Handler) DeleteTriggerToken(c *gin.Context) {
+	projectID, err := utils.GetProjectID(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	jobID, err := utils.GetIDParam(c)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	tokenID, err := strconv.Atoi(c.Param("tokenid"))
+	if err != nil || tokenID < 1 {
+		err = fmt.Errorf("invalid token id '%s'", c.Param("tokenid"))
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
+	logger.Debugf("Delete trigger token initiated project_id[%s] job_id[%d] token_id[%d]", projectID, jobID, tokenID)
+	if err := h.etl.DeleteTriggerToken(c.Request.Context(), projectID, jobID, tokenID); err != nil {
+		utils.ErrorResponse(c, triggerTokenErrorStatus(err), fmt.Sprintf("failed to revoke trigger token: %s", err), err)
+		return
+	}
+	utils.SuccessResponse(c, fmt.Sprintf("trigger token %d of job_id[%d] revoked", tokenID, jobID), nil)
+}
+
+func triggerTokenErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrJobNotFound), errors.Is(err, constants.ErrTriggerTokenNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrTriggerTokenLimit):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
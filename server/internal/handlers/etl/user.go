@@ -55,17 +55,31 @@ func (h *Handler) CreateUser(c *gin.Context) {
 
 // @Summary List all users
 // @Tags Users
-// @Description Retrieve a list of all registered users.
-// @Success 200 {array}  dto.JSONResponse{data=dto.UserResponse}
+// @Description Retrieve a page of registered users. Results can be filtered, sorted and paged using an opaque cursor.
+// @Param   cursor        query   string  false   "cursor returned as next_cursor by the previous page"
+// @Param   limit         query   int     false   "page size, all users are returned when omitted"
+// @Param   name          query   string  false   "username substring (case-insensitive)"
+// @Param   sort_by       query   string  false   "sort key: name, created_at, updated_at (default updated_at)"
+// @Param   sort_order    query   string  false   "sort order: asc, desc"
+// @Success 200 {object} dto.JSONResponse{data=dto.PaginatedResponse{items=[]dto.UserResponse}}
 // @Failure 400 {object} dto.Error400Response "failed to validate request"
 // @Failure 401 {object} dto.Error401Response "unauthorized"
 // @Failure 500 {object} dto.Error500Response "failed to get users"
 // @Router /api/v1/users [get]
 func (h *Handler) GetAllUsers(c *gin.Context) {
+	var query dto.ListQuery
+	if err := bindListQuery(c, &query, constants.SortKeyName, constants.SortKeyCreatedAt, constants.SortKeyUpdatedAt); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, fmt.Sprintf("failed to validate request: %s", err), err)
+		return
+	}
 	logger.Info("Get all users initiated")
-	users, err := h.etl.GetAllUsers(c.Request.Context())
+	users, total, nextCursor, err := h.etl.GetAllUsers(c.Request.Context(), query)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, fmt.Sprintf("failed to get users: %s", err), err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrInvalidCursor) {
+			status = http.StatusBadRequest
+		}
+		utils.ErrorResponse(c, status, fmt.Sprintf("failed to get users: %s", err), err)
 		return
 	}
 	resp := make([]dto.UserResponse, 0, len(users))
@@ -76,7 +90,12 @@ func (h *Handler) GetAllUsers(c *gin.Context) {
 			Email:    user.Email,
 		})
 	}
-	utils.SuccessResponse(c, "users listed successfully", resp)
+	utils.SuccessResponse(c, "users listed successfully", dto.PaginatedResponse{
+		Items:      resp,
+		Total:      total,
+		Limit:      query.Limit,
+		NextCursor: nextCursor,
+	})
 }
 
 // @Summary Update user details
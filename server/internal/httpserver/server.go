@@ -127,8 +127,8 @@ func (s *Server) configureNoRoute(cfg *appconfig.Config, h *handlers.Handler) {
 		}
 
 		path := c.Request.URL.Path
-		// Never serve SPA HTML for API/internal paths; return proper JSON 404.
-		if strings.HasPrefix(path, "/api/") || strings.HasPrefix(path, "/internal/") {
+		// Never serve SPA HTML for API/internal/trigger paths; return proper JSON 404.
+		if strings.HasPrefix(path, "/api/") || strings.HasPrefix(path, "/internal/") || strings.HasPrefix(path, "/trigger/") {
 			c.JSON(http.StatusNotFound, gin.H{"message": "not found", "success": false})
 			return
 		}
@@ -32,6 +32,11 @@ type ProjectSettings struct {
 	ID              int    `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
 	ProjectID       string `json:"project_id" gorm:"column:project_id;size:255;unique"`
 	WebhookAlertURL string `json:"webhook_alert_url" gorm:"column:webhook_alert_url;size:512"`
+	// DefaultTimeZone is the IANA time zone of the schedules of jobs without one of their own
+	DefaultTimeZone string `json:"default_time_zone" gorm:"column:default_time_zone;size:64"`
+	// MaxConcurrentSyncs and MaxConcurrentDiscovers cap the runs of the project, 0 is no cap
+	MaxConcurrentSyncs     int `json:"max_concurrent_syncs" gorm:"column:max_concurrent_syncs;default:0"`
+	MaxConcurrentDiscovers int `json:"max_concurrent_discovers" gorm:"column:max_concurrent_discovers;default:0"`
 }
 
 func (s *ProjectSettings) TableName() string {
@@ -47,8 +52,15 @@ type Source struct {
 	Config      string `json:"config" gorm:"column:config;type:jsonb"`
 	Version     string `json:"version" gorm:"column:version;size:255"`
 	Type        string `json:"type" gorm:"column:type;size:255"`
+	Labels      Labels `json:"labels" gorm:"column:labels;type:jsonb;default:'{}'"`
 	CreatedByID int    `json:"-" gorm:"column:created_by_id"`
 	UpdatedByID int    `json:"-" gorm:"column:updated_by_id"`
+	// MaxConcurrentSyncs and MaxConcurrentDiscovers cap the runs of the jobs using it, 0 is no cap
+	MaxConcurrentSyncs     int `json:"max_concurrent_syncs" gorm:"column:max_concurrent_syncs;default:0"`
+	MaxConcurrentDiscovers int `json:"max_concurrent_discovers" gorm:"column:max_concurrent_discovers;default:0"`
+	// WorkerPool is the worker pool the runs of the source go to unless a job picks its own,
+	// empty for the default pool
+	WorkerPool string `json:"worker_pool,omitempty" gorm:"column:worker_pool;size:100"`
 
 	CreatedBy *User `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID;references:ID"`
 	UpdatedBy *User `json:"updated_by,omitempty" gorm:"foreignKey:UpdatedByID;references:ID"`
@@ -67,8 +79,12 @@ type Destination struct {
 	DestType    string `json:"type" gorm:"column:dest_type;size:255"`
 	Version     string `json:"version" gorm:"column:version;size:255"`
 	Config      string `json:"config" gorm:"column:config;type:jsonb"`
+	Labels      Labels `json:"labels" gorm:"column:labels;type:jsonb;default:'{}'"`
 	CreatedByID int    `json:"-" gorm:"column:created_by_id"`
 	UpdatedByID int    `json:"-" gorm:"column:updated_by_id"`
+	// MaxConcurrentSyncs and MaxConcurrentDiscovers cap the runs of the jobs using it, 0 is no cap
+	MaxConcurrentSyncs     int `json:"max_concurrent_syncs" gorm:"column:max_concurrent_syncs;default:0"`
+	MaxConcurrentDiscovers int `json:"max_concurrent_discovers" gorm:"column:max_concurrent_discovers;default:0"`
 
 	CreatedBy *User `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID;references:ID"`
 	UpdatedBy *User `json:"updated_by,omitempty" gorm:"foreignKey:UpdatedByID;references:ID"`
@@ -88,12 +104,19 @@ type Job struct {
 	DestID           int     `json:"dest_id" gorm:"column:dest_id"`
 	Active           bool    `json:"active" gorm:"column:active"`
 	Frequency        string  `json:"frequency" gorm:"column:frequency;size:255"`
+	TimeZone         string  `json:"time_zone" gorm:"column:time_zone;size:64"`
 	StreamsConfig    string  `json:"streams_config" gorm:"column:streams_config;type:jsonb"`
 	State            string  `json:"state" gorm:"column:state;type:jsonb"`
 	AdvancedSettings *string `json:"advanced_settings" gorm:"column:advanced_settings;type:jsonb"`
+	Labels           Labels  `json:"labels" gorm:"column:labels;type:jsonb;default:'{}'"`
 	CreatedByID      int     `json:"-" gorm:"column:created_by_id"`
 	UpdatedByID      int     `json:"-" gorm:"column:updated_by_id"`
 	ProjectID        string  `json:"project_id" gorm:"column:project_id;size:255"`
+	// DependencyPolicy is how the job is triggered by its upstream jobs, if it has any
+	DependencyPolicy string `json:"dependency_policy,omitempty" gorm:"column:dependency_policy;size:20"`
+	// TriggerDeferredUntil is set when a trigger of the job fell into a maintenance window; the
+	// job is triggered once the window is over
+	TriggerDeferredUntil *time.Time `json:"trigger_deferred_until,omitempty" gorm:"column:trigger_deferred_until"`
 
 	Source      *Source      `json:"source,omitempty" gorm:"foreignKey:SourceID;references:ID"`
 	Destination *Destination `json:"destination,omitempty" gorm:"foreignKey:DestID;references:ID"`
@@ -105,6 +128,157 @@ func (j *Job) TableName() string {
 	return constants.TableNameMap[constants.JobTable]
 }
 
+// ScheduleOperation is a pending change to the temporal schedule of a job. It is written in
+// the same transaction as the job change and applied by the schedule outbox dispatcher.
+type ScheduleOperation struct {
+	BaseModel
+	ID            int        `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	JobID         int        `json:"job_id" gorm:"column:job_id;index"`
+	ProjectID     string     `json:"project_id" gorm:"column:project_id;size:255"`
+	Operation     string     `json:"operation" gorm:"column:operation;size:50"`
+	Status        string     `json:"status" gorm:"column:status;size:50;index"`
+	Attempts      int        `json:"attempts" gorm:"column:attempts"`
+	LastError     string     `json:"last_error,omitempty" gorm:"column:last_error;type:text"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"column:next_attempt_at"`
+	AppliedAt     *time.Time `json:"applied_at,omitempty" gorm:"column:applied_at"`
+}
+
+func (o *ScheduleOperation) TableName() string {
+	return constants.TableNameMap[constants.ScheduleOutboxTable]
+}
+
+// JobRevision is an immutable copy of the configuration of a job, saved with every change to
+// it. RestoredFrom is the revision a rollback restored.
+type JobRevision struct {
+	BaseModel
+	ID               int     `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	JobID            int     `json:"job_id" gorm:"column:job_id;index"`
+	Revision         int     `json:"revision" gorm:"column:revision"`
+	ProjectID        string  `json:"project_id" gorm:"column:project_id;size:255"`
+	Change           string  `json:"change" gorm:"column:change;size:50"`
+	RestoredFrom     int     `json:"restored_from,omitempty" gorm:"column:restored_from"`
+	SourceID         int     `json:"source_id" gorm:"column:source_id"`
+	DestID           int     `json:"dest_id" gorm:"column:dest_id"`
+	Frequency        string  `json:"frequency" gorm:"column:frequency;size:255"`
+	TimeZone         string  `json:"time_zone" gorm:"column:time_zone;size:64"`
+	StreamsConfig    string  `json:"streams_config" gorm:"column:streams_config;type:jsonb"`
+	AdvancedSettings *string `json:"advanced_settings" gorm:"column:advanced_settings;type:jsonb"`
+	CreatedByID      int     `json:"-" gorm:"column:created_by_id"`
+
+	CreatedBy *User `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID;references:ID"`
+}
+
+func (r *JobRevision) TableName() string {
+	return constants.TableNameMap[constants.JobRevisionTable]
+}
+
+// JobStateSnapshot is a state a job had, kept so that state changes can be inspected and a
+// bad state write undone. WorkflowID and RunID are the run that wrote it, if any.
+type JobStateSnapshot struct {
+	BaseModel
+	ID           int    `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	JobID        int    `json:"job_id" gorm:"column:job_id;index"`
+	ProjectID    string `json:"project_id" gorm:"column:project_id;size:255"`
+	State        string `json:"state" gorm:"column:state;type:jsonb"`
+	Reason       string `json:"reason" gorm:"column:reason;size:50"`
+	WorkflowID   string `json:"workflow_id,omitempty" gorm:"column:workflow_id;size:255"`
+	RunID        string `json:"run_id,omitempty" gorm:"column:run_id;size:255"`
+	RestoredFrom int    `json:"restored_from,omitempty" gorm:"column:restored_from"`
+}
+
+func (s *JobStateSnapshot) TableName() string {
+	return constants.TableNameMap[constants.JobStateSnapshotTable]
+}
+
+// JobTriggerToken lets an external scheduler trigger and watch the syncs of a single job
+// without a user account. Only a hash of the token is kept; Prefix is its start, shown so
+// that tokens can be told apart.
+type JobTriggerToken struct {
+	BaseModel
+	ID          int        `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	JobID       int        `json:"job_id" gorm:"column:job_id;index"`
+	ProjectID   string     `json:"project_id" gorm:"column:project_id;size:255"`
+	Name        string     `json:"name" gorm:"column:name;size:100"`
+	TokenHash   string     `json:"-" gorm:"column:token_hash;size:64;uniqueIndex"`
+	Prefix      string     `json:"prefix" gorm:"column:prefix;size:20"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty" gorm:"column:last_used_at"`
+	CreatedByID int        `json:"-" gorm:"column:created_by_id"`
+
+	CreatedBy *User `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID;references:ID"`
+}
+
+func (t *JobTriggerToken) TableName() string {
+	return constants.TableNameMap[constants.JobTriggerTokenTable]
+}
+
+// JobDependency makes a job wait for a sync of an upstream job of the same project.
+// UpstreamStatus is how the latest upstream sync since the job was last triggered by its
+// dependencies ended, empty while there was none. UpstreamWorkflowID and UpstreamClosedAt are
+// the latest upstream sync seen at all, so that a sync reported by both the worker callback
+// and the watcher counts once.
+type JobDependency struct {
+	BaseModel
+	ID                 int        `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	JobID              int        `json:"job_id" gorm:"column:job_id;uniqueIndex:idx_job_dependency_edge"`
+	UpstreamJobID      int        `json:"upstream_job_id" gorm:"column:upstream_job_id;uniqueIndex:idx_job_dependency_edge;index"`
+	ProjectID          string     `json:"project_id" gorm:"column:project_id;size:255;index"`
+	UpstreamStatus     string     `json:"upstream_status" gorm:"column:upstream_status;size:20"`
+	UpstreamWorkflowID string     `json:"upstream_workflow_id,omitempty" gorm:"column:upstream_workflow_id;size:255"`
+	UpstreamClosedAt   *time.Time `json:"upstream_closed_at,omitempty" gorm:"column:upstream_closed_at"`
+}
+
+func (d *JobDependency) TableName() string {
+	return constants.TableNameMap[constants.JobDependencyTable]
+}
+
+// MaintenanceWindow is a span of time in which the schedules of the jobs of a project do not
+// fire, or of the jobs of a source when SourceID is set, or of one job when JobID is set. A
+// recurring window repeats every week on Days (every day when empty) from StartTime to
+// EndTime, past midnight when EndTime is not after StartTime, on the wall clock of TimeZone or,
+// without one, of the schedule of each job. A one-off window runs from StartsAt to EndsAt.
+type MaintenanceWindow struct {
+	BaseModel
+	ID          int        `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	ProjectID   string     `json:"project_id" gorm:"column:project_id;size:255;index"`
+	SourceID    *int       `json:"source_id,omitempty" gorm:"column:source_id;index"`
+	JobID       *int       `json:"job_id,omitempty" gorm:"column:job_id;index"`
+	Name        string     `json:"name" gorm:"column:name;size:100"`
+	Days        string     `json:"days" gorm:"column:days;size:100"`
+	StartTime   string     `json:"start_time" gorm:"column:start_time;size:5"`
+	EndTime     string     `json:"end_time" gorm:"column:end_time;size:5"`
+	TimeZone    string     `json:"time_zone" gorm:"column:time_zone;size:64"`
+	StartsAt    *time.Time `json:"starts_at,omitempty" gorm:"column:starts_at"`
+	EndsAt      *time.Time `json:"ends_at,omitempty" gorm:"column:ends_at"`
+	CreatedByID int        `json:"-" gorm:"column:created_by_id"`
+
+	CreatedBy *User `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID;references:ID"`
+}
+
+func (w *MaintenanceWindow) TableName() string {
+	return constants.TableNameMap[constants.MaintenanceWindowTable]
+}
+
+// RunQueueEntry is a sync or discover held back by a concurrency cap. A queued sync is
+// started through the schedule of its job once every cap it counts against has room. A
+// discover keeps its entry, running, until it is done; LeaseUntil frees the entry of a
+// discover whose server stopped.
+type RunQueueEntry struct {
+	BaseModel
+	ID            int        `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
+	ProjectID     string     `json:"project_id" gorm:"column:project_id;size:255;index"`
+	Kind          string     `json:"kind" gorm:"column:kind;size:20"`
+	Status        string     `json:"status" gorm:"column:status;size:20"`
+	JobID         *int       `json:"job_id,omitempty" gorm:"column:job_id;index"`
+	SourceID      *int       `json:"source_id,omitempty" gorm:"column:source_id"`
+	DestinationID *int       `json:"destination_id,omitempty" gorm:"column:destination_id"`
+	Reason        string     `json:"reason" gorm:"column:reason;size:255"`
+	LeaseUntil    *time.Time `json:"lease_until,omitempty" gorm:"column:lease_until"`
+}
+
+func (e *RunQueueEntry) TableName() string {
+	return constants.TableNameMap[constants.RunQueueTable]
+}
+
 type Catalog struct {
 	BaseModel
 	ID      int    `json:"id" gorm:"column:id;primaryKey;autoIncrement"`
@@ -0,0 +1,55 @@
+package dto
+
+// JobBundle is a portable export of jobs together with the sources and destinations they use.
+// Jobs refer to their source and destination by name, and secret config values are replaced
+// by "${secret:<key>}" placeholders whose keys are listed in Secrets.
+type JobBundle struct {
+	Version      string              `json:"version" example:"v1"`
+	ExportedAt   string              `json:"exported_at" example:"2024-01-09T12:00:00Z"`
+	ProjectID    string              `json:"project_id" example:"123"`
+	Secrets      []string            `json:"secrets,omitempty" example:"source/pg-staging/password"`
+	Sources      []BundleSource      `json:"sources"`
+	Destinations []BundleDestination `json:"destinations"`
+	Jobs         []BundleJob         `json:"jobs"`
+}
+
+type BundleSource struct {
+	Name    string            `json:"name" example:"pg-staging"`
+	Type    string            `json:"type" example:"postgres"`
+	Version string            `json:"version" example:"v0.3.15"`
+	Config  map[string]any    `json:"config"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+type BundleDestination struct {
+	Name    string            `json:"name" example:"iceberg-staging"`
+	Type    string            `json:"type" example:"iceberg"`
+	Version string            `json:"version" example:"v0.3.15"`
+	Config  map[string]any    `json:"config"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+type BundleJob struct {
+	Name             string            `json:"name" example:"orders-sync"`
+	Source           string            `json:"source" example:"pg-staging"`
+	Destination      string            `json:"destination" example:"iceberg-staging"`
+	Frequency        string            `json:"frequency" example:"0 * * * *"`
+	TimeZone         string            `json:"time_zone,omitempty" example:"Europe/Berlin"`
+	Active           bool              `json:"active" example:"true"`
+	StreamsConfig    any               `json:"streams_config" swaggertype:"object"`
+	AdvancedSettings *AdvancedSettings `json:"advanced_settings,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+}
+
+// BundleOverlay adapts a bundle to the target environment on import.
+//   - NamePrefix is prepended to every source, destination and job name.
+//   - Hosts replaces every occurrence of a hostname in the string values of driver configs.
+//   - Secrets supplies the values of the bundle's secret placeholders, keyed by placeholder key.
+//   - Sources and Destinations merge config values into the entity with that bundle name.
+type BundleOverlay struct {
+	NamePrefix   string                    `json:"name_prefix,omitempty" example:"prod-"`
+	Hosts        map[string]string         `json:"hosts,omitempty"`
+	Secrets      map[string]string         `json:"secrets,omitempty"`
+	Sources      map[string]map[string]any `json:"sources,omitempty"`
+	Destinations map[string]map[string]any `json:"destinations,omitempty"`
+}
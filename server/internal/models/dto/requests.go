@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // Common fields for source/destination config
 // source and destination are driver in olake cli
 type DriverConfig struct {
@@ -10,6 +12,29 @@ type DriverConfig struct {
 	Config  string `json:"config,omitempty" orm:"type(jsonb)" example:"{\"host\":\"localhost\",\"port\":5432}"`
 }
 
+// ListQuery holds the pagination, filter and sort query parameters accepted by list endpoints.
+// Filters that do not apply to an entity are ignored.
+type ListQuery struct {
+	Cursor        string `form:"cursor" example:"eyJ2IjoiMjAyNC0wMS0wOVQxMjowMDowMFoiLCJpZCI6NDJ9"`
+	Limit         int    `form:"limit" example:"50"`
+	Name          string `form:"name" example:"orders"`
+	Type          string `form:"type" example:"postgres"`
+	Active        *bool  `form:"active" example:"true"`
+	LastRunStatus string `form:"last_run_status" example:"Failed"`
+	CreatedBy     string `form:"created_by" example:"admin"`
+	Selector      string `form:"selector" example:"tier=critical,team!=growth"`
+	SortBy        string `form:"sort_by" example:"updated_at"`
+	SortOrder     string `form:"sort_order" example:"desc"`
+}
+
+// JobTaskListQuery holds the pagination and filter query parameters for job task listing.
+type JobTaskListQuery struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit" example:"50"`
+	Status string `form:"status" example:"Completed"`
+	Type   string `form:"type" example:"sync"` // "sync" | "clear"
+}
+
 type LoginRequest struct {
 	Username string `json:"username" binding:"required" example:"admin"`
 	Password string `json:"password" binding:"required" example:"password"`
@@ -28,8 +53,9 @@ type UpdateUserRequest struct {
 
 type SpecRequest struct {
 	// enum: postgres,mongodb,mysql,mssql,db2,s3,kafka,iceberg
-	Type    string `json:"type" binding:"required" example:"postgres"`
-	Version string `json:"version" binding:"required" example:"v0.2.7"`
+	Type       string `json:"type" binding:"required" example:"postgres"`
+	Version    string `json:"version" binding:"required" example:"v0.2.7"`
+	WorkerPool string `json:"worker_pool,omitempty" example:"default"`
 }
 
 // check unique job name request
@@ -43,6 +69,8 @@ type SourceTestConnectionRequest struct {
 	Type    string `json:"type" binding:"required" example:"postgres"`
 	Version string `json:"version" binding:"required" example:"v0.2.7"`
 	Config  string `json:"config" orm:"type(jsonb)" binding:"required" example:"{\"host\":\"localhost\",\"port\":5432,\"database\":\"mydb\",\"user\":\"postgres\",\"password\":\"secret\"}"`
+	// WorkerPool runs the check on the workers of a pool, e.g. those that can reach the source
+	WorkerPool string `json:"worker_pool,omitempty" example:"vpc-orders"`
 }
 type StreamsRequest struct {
 	Name               string `json:"name" binding:"required" example:"my-postgres-source"`
@@ -52,6 +80,11 @@ type StreamsRequest struct {
 	MaxDiscoverThreads *int   `json:"max_discover_threads,omitempty" example:"50"`
 	JobID              int    `json:"job_id" binding:"required" example:"1"`
 	JobName            string `json:"job_name" binding:"required" example:"my-sync-job"`
+	// SourceID is the saved source being discovered, counted against its discover cap when
+	// job_id does not name a job
+	SourceID int `json:"source_id,omitempty" example:"1"`
+	// WorkerPool runs the discover on the workers of a pool instead of those of the job or source
+	WorkerPool string `json:"worker_pool,omitempty" example:"vpc-orders"`
 }
 
 // TODO: frontend needs to send only version no need for source version
@@ -61,13 +94,17 @@ type DestinationTestConnectionRequest struct {
 	Config        string `json:"config" binding:"required" example:"{\"catalog_type\":\"glue\",\"warehouse\":\"s3://my-bucket/warehouse\"}"`
 	SourceType    string `json:"source_type" example:"postgres"`
 	SourceVersion string `json:"source_version" example:"v0.2.7"`
+	WorkerPool    string `json:"worker_pool,omitempty" example:"default"`
 }
 
 type CreateSourceRequest struct {
-	Name    string `json:"name" binding:"required" example:"my-postgres-source"`
-	Type    string `json:"type" binding:"required" example:"postgres"`
-	Version string `json:"version" binding:"required" example:"v0.2.7"`
-	Config  string `json:"config" orm:"type(jsonb)" binding:"required" example:"{\"host\":\"localhost\",\"port\":5432,\"database\":\"mydb\",\"user\":\"postgres\",\"password\":\"secret\"}"`
+	Name    string            `json:"name" binding:"required" example:"my-postgres-source"`
+	Type    string            `json:"type" binding:"required" example:"postgres"`
+	Version string            `json:"version" binding:"required" example:"v0.2.7"`
+	Config  string            `json:"config" orm:"type(jsonb)" binding:"required" example:"{\"host\":\"localhost\",\"port\":5432,\"database\":\"mydb\",\"user\":\"postgres\",\"password\":\"secret\"}"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	// WorkerPool is the worker pool the runs of the source go to, empty for the default pool
+	WorkerPool string `json:"worker_pool,omitempty" example:"vpc-orders"`
 }
 
 type UpdateSourceRequest struct {
@@ -75,13 +112,16 @@ type UpdateSourceRequest struct {
 	Type    string `json:"type" binding:"required" example:"postgres"`
 	Version string `json:"version" binding:"required" example:"v0.2.7"`
 	Config  string `json:"config" orm:"type(jsonb)" binding:"required" example:"{\"host\":\"localhost\",\"port\":5432,\"database\":\"mydb\",\"user\":\"postgres\",\"password\":\"newsecret\"}"`
+	// WorkerPool changes the worker pool of the source when set, "" being the default pool
+	WorkerPool *string `json:"worker_pool,omitempty" example:"vpc-orders"`
 }
 
 type CreateDestinationRequest struct {
-	Name    string `json:"name" binding:"required" example:"my-iceberg-destination"`
-	Type    string `json:"type" binding:"required" example:"iceberg"`
-	Version string `json:"version" binding:"required" example:"v0.2.7"`
-	Config  string `json:"config" orm:"type(jsonb)" binding:"required" example:"{\"catalog_type\":\"glue\",\"warehouse\":\"s3://my-bucket/warehouse\"}"`
+	Name    string            `json:"name" binding:"required" example:"my-iceberg-destination"`
+	Type    string            `json:"type" binding:"required" example:"iceberg"`
+	Version string            `json:"version" binding:"required" example:"v0.2.7"`
+	Config  string            `json:"config" orm:"type(jsonb)" binding:"required" example:"{\"catalog_type\":\"glue\",\"warehouse\":\"s3://my-bucket/warehouse\"}"`
+	Labels  map[string]string `json:"labels,omitempty"`
 }
 
 type UpdateDestinationRequest struct {
@@ -91,8 +131,56 @@ type UpdateDestinationRequest struct {
 	Config  string `json:"config" orm:"type(jsonb)" binding:"required" example:"{\"catalog_type\":\"glue\",\"warehouse\":\"s3://my-bucket/warehouse-v2\"}"`
 }
 
+// AdvancedSettings tune a job. RunTimeout, Retry, OverlapPolicy and CatchupWindow make up
+// the execution policy of its syncs; durations are given as e.g. "12h".
 type AdvancedSettings struct {
 	MaxDiscoverThreads *int `json:"max_discover_threads,omitempty" example:"50"`
+	// RunTimeout is how long a sync may run, at most the default of 720h
+	RunTimeout string `json:"run_timeout,omitempty" example:"12h"`
+	// Retry retries a failed scheduled sync
+	Retry *RetryPolicy `json:"retry,omitempty"`
+	// OverlapPolicy decides what a scheduled sync does while the previous one still runs
+	OverlapPolicy string `json:"overlap_policy,omitempty" example:"skip"` // "skip" | "buffer_one" | "cancel_other"
+	// CatchupWindow is how late a sync missed while temporal was down may still start
+	CatchupWindow string `json:"catchup_window,omitempty" example:"1h"`
+	// WorkerPool is the worker pool the job's runs go to instead of the pool of its source
+	WorkerPool string `json:"worker_pool,omitempty" example:"cdc-large"`
+	// Resources are the cpu and memory of the job's driver container, the server defaults
+	// for those left out
+	Resources *DriverResources `json:"resources,omitempty"`
+	// Env are extra environment variables of the job's driver container
+	Env []DriverEnvVar `json:"env,omitempty"`
+}
+
+// DriverResources are the cpu and memory a driver container requests and is limited to, as
+// kubernetes quantities such as "500m" or "2" cpu and "512Mi" or "4Gi" memory
+type DriverResources struct {
+	Requests *ResourceQuantities `json:"requests,omitempty"`
+	Limits   *ResourceQuantities `json:"limits,omitempty"`
+}
+
+type ResourceQuantities struct {
+	CPU    string `json:"cpu,omitempty" example:"500m"`
+	Memory string `json:"memory,omitempty" example:"2Gi"`
+}
+
+// DriverEnvVar is an environment variable of a driver container. The value of a secret
+// variable is stored encrypted and left out of responses; sent without a value it keeps the
+// value it has.
+type DriverEnvVar struct {
+	Name   string `json:"name" example:"GOMEMLIMIT"`
+	Value  string `json:"value,omitempty" example:"3GiB"`
+	Secret bool   `json:"secret,omitempty" example:"false"`
+}
+
+// RetryPolicy runs a failed sync again up to MaxAttempts runs in total, waiting
+// InitialInterval before the first retry and BackoffCoefficient times longer before each
+// next one, up to MaxInterval.
+type RetryPolicy struct {
+	MaxAttempts        int     `json:"max_attempts" example:"3"`
+	InitialInterval    string  `json:"initial_interval,omitempty" example:"1m"`
+	BackoffCoefficient float64 `json:"backoff_coefficient,omitempty" example:"2"`
+	MaxInterval        string  `json:"max_interval,omitempty" example:"1h"`
 }
 
 type CreateJobRequest struct {
@@ -100,9 +188,11 @@ type CreateJobRequest struct {
 	Source           *DriverConfig     `json:"source" binding:"required"`
 	Destination      *DriverConfig     `json:"destination" binding:"required"`
 	Frequency        string            `json:"frequency" binding:"required" example:"0 */6 * * *"`
+	TimeZone         string            `json:"time_zone,omitempty" example:"Europe/Berlin"` // empty follows the project default
 	StreamsConfig    string            `json:"streams_config" orm:"type(jsonb)" binding:"required"`
 	Activate         bool              `json:"activate,omitempty" example:"true"`
 	AdvancedSettings *AdvancedSettings `json:"advanced_settings,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
 }
 
 type UpdateJobRequest struct {
@@ -110,6 +200,7 @@ type UpdateJobRequest struct {
 	Source            *DriverConfig     `json:"source" binding:"required"`
 	Destination       *DriverConfig     `json:"destination" binding:"required"`
 	Frequency         string            `json:"frequency" binding:"required" example:"0 */12 * * *"`
+	TimeZone          *string           `json:"time_zone,omitempty" example:"Europe/Berlin"` // omitted keeps the zone, empty follows the project default
 	StreamsConfig     string            `json:"streams_config" orm:"type(jsonb)" binding:"required"`
 	DifferenceStreams string            `json:"difference_streams,omitempty" example:"[]"`
 	Activate          bool              `json:"activate,omitempty" example:"true"`
@@ -124,14 +215,197 @@ type JobTaskRequest struct {
 	FilePath string `json:"file_path" binding:"required" example:"sync-123-2-2026-01-19T13:45:09Z"`
 }
 
+// SyncJobRequest runs a one-off sync of some streams of a job, given as "namespace.name",
+// and with run overrides. The saved job and its schedule are left as they are.
+type SyncJobRequest struct {
+	Streams   []string          `json:"streams,omitempty" example:"public.orders"`
+	Overrides *SyncRunOverrides `json:"overrides,omitempty"`
+	// OverrideMaintenanceWindow runs the sync even while the job is in a maintenance window
+	OverrideMaintenanceWindow bool `json:"override_maintenance_window,omitempty" example:"false"`
+}
+
+// SyncRunOverrides change a single sync run. Timeout is a duration such as "2h".
+type SyncRunOverrides struct {
+	Timeout string `json:"timeout,omitempty" example:"2h"`
+}
+
+// JobRunQuery waits for a run to close with wait=true, for at most timeout, a duration such
+// as "5m".
+type JobRunQuery struct {
+	Wait    bool   `form:"wait" example:"true"`
+	Timeout string `form:"timeout" example:"5m"`
+}
+
+// SchedulePreviewRequest previews the next fire times of a frequency before it is saved on a
+// job. A job without a time zone follows the project default, so an empty one does too. The
+// maintenance windows of the project apply, and those of SourceID when it is set.
+type SchedulePreviewRequest struct {
+	Frequency string `json:"frequency" binding:"required" example:"weekdays at 02:30 jitter 5m"`
+	TimeZone  string `json:"time_zone,omitempty" example:"Europe/Berlin"`
+	SourceID  *int   `json:"source_id,omitempty" example:"1"`
+	Count     int    `json:"count,omitempty" example:"10"`
+}
+
+// BackfillRequest backfills the fire times from From to To that the schedules of the jobs
+// listed in JobIDs or matched by Selector missed. With DryRun the missed fire times are only
+// listed.
+type BackfillRequest struct {
+	From     time.Time `json:"from" binding:"required" example:"2024-01-09T00:00:00Z"`
+	To       time.Time `json:"to" binding:"required" example:"2024-01-09T06:00:00Z"`
+	Mode     string    `json:"mode,omitempty" example:"catch_up"` // "catch_up" | "per_window"
+	JobIDs   []int     `json:"job_ids,omitempty" example:"1,2"`
+	Selector string    `json:"selector,omitempty" example:"tier=critical"`
+	DryRun   bool      `json:"dry_run,omitempty" example:"false"`
+	// OverrideMaintenanceWindow backfills jobs that are in a maintenance window
+	OverrideMaintenanceWindow bool `json:"override_maintenance_window,omitempty" example:"false"`
+}
+
+// MaintenanceWindowRequest creates or replaces a maintenance window of a project, or of a
+// source or a job of the project. A recurring window sets start_time and end_time, and
+// optionally days and time_zone; a one-off window sets starts_at and ends_at.
+type MaintenanceWindowRequest struct {
+	Name      string     `json:"name" binding:"required,max=100" example:"nightly backup"`
+	SourceID  *int       `json:"source_id,omitempty" example:"1"`
+	JobID     *int       `json:"job_id,omitempty" example:"2"`
+	Days      string     `json:"days,omitempty" example:"mon-fri"` // "daily" | "weekdays" | "weekends" | days such as "mon,wed" or "mon-fri"
+	StartTime string     `json:"start_time,omitempty" example:"01:00"`
+	EndTime   string     `json:"end_time,omitempty" example:"03:00"`
+	TimeZone  string     `json:"time_zone,omitempty" example:"Europe/Berlin"`
+	StartsAt  *time.Time `json:"starts_at,omitempty" example:"2024-01-20T22:00:00Z"`
+	EndsAt    *time.Time `json:"ends_at,omitempty" example:"2024-01-21T04:00:00Z"`
+}
+
+// ConcurrencyLimitRequest sets the sync and discover caps of a project, or of a source or a
+// destination of the project given by ID. 0 removes a cap.
+type ConcurrencyLimitRequest struct {
+	Scope                  string `json:"scope" binding:"required" example:"source"` // "project" | "source" | "destination"
+	ID                     int    `json:"id,omitempty" example:"1"`
+	MaxConcurrentSyncs     int    `json:"max_concurrent_syncs" example:"3"`
+	MaxConcurrentDiscovers int    `json:"max_concurrent_discovers" example:"1"`
+}
+
+// SchedulePreviewQuery sets the number of fire times of the schedule preview of a job
+type SchedulePreviewQuery struct {
+	Count int `form:"count" example:"10"`
+}
+
+// RunCalendarQuery sets the window of the run calendar of a project, a duration such as "24h"
+type RunCalendarQuery struct {
+	Window string `form:"window" example:"24h"`
+}
+
+// CreateTriggerTokenRequest names a new trigger token of a job, e.g. after the external
+// scheduler that uses it.
+type CreateTriggerTokenRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"airflow"`
+}
+
+// JobDependenciesRequest sets the upstream jobs of a job and the policy by which they trigger
+// it, all_succeeded by default. An empty list removes the dependencies.
+type JobDependenciesRequest struct {
+	UpstreamJobIDs []int  `json:"upstream_job_ids" example:"2"`
+	Policy         string `json:"policy,omitempty" example:"all_succeeded"` // "all_succeeded" | "any_completed"
+}
+
 type JobStatusRequest struct {
 	Activate bool `json:"activate" example:"true"`
 }
 
+// CloneJobRequest creates a copy of a job, optionally retargeted to another source or destination.
+type CloneJobRequest struct {
+	Name          string `json:"name" binding:"required" example:"my-sync-job-copy"`
+	SourceID      *int   `json:"source_id,omitempty" example:"2"`
+	DestinationID *int   `json:"destination_id,omitempty" example:"3"`
+}
+
+// JobTemplateRequest creates one job per source from a base job.
+// NamePattern supports the {job}, {source}, {source_id} and {index} placeholders.
+type JobTemplateRequest struct {
+	SourceIDs     []int  `json:"source_ids" binding:"required" example:"2,3,4"`
+	NamePattern   string `json:"name_pattern,omitempty" example:"{job}-{source}"`
+	DestinationID *int   `json:"destination_id,omitempty" example:"3"`
+}
+
+// ExportJobsQuery selects the jobs of a bundle export. Without job ids or a selector
+// every job of the project is exported.
+type ExportJobsQuery struct {
+	JobIDs   []int  `form:"job_ids" example:"1"`
+	Selector string `form:"selector" example:"tier=critical"`
+	Format   string `form:"format" example:"yaml"`
+}
+
+// ImportJobsRequest imports a bundle into the project. With on_conflict "fail" (the default)
+// entities whose name already exists are reported as conflicts; with "update" they are updated.
+// Nothing is applied while conflicts remain or when dry_run is set.
+type ImportJobsRequest struct {
+	Bundle     *JobBundle     `json:"bundle" binding:"required"`
+	Overlay    *BundleOverlay `json:"overlay,omitempty"`
+	OnConflict string         `json:"on_conflict,omitempty" example:"fail"`
+	DryRun     bool           `json:"dry_run,omitempty" example:"true"`
+}
+
+// JobRevisionDiffQuery compares two revisions of a job; without to, from is compared with
+// the latest revision.
+type JobRevisionDiffQuery struct {
+	From int `form:"from" binding:"required,min=1" example:"2"`
+	To   int `form:"to" binding:"omitempty,min=1" example:"5"`
+}
+
+// JobStateQuery picks the state snapshot to show; without it the current state is shown.
+type JobStateQuery struct {
+	SnapshotID int `form:"snapshot_id" binding:"omitempty,min=1" example:"12"`
+}
+
+// ResyncStreamsRequest resyncs streams of a job from scratch. Streams are given as
+// "namespace.name"; with clear_destination their destination tables are truncated first.
+type ResyncStreamsRequest struct {
+	Streams          []string `json:"streams" binding:"required,min=1" example:"public.orders"`
+	ClearDestination bool     `json:"clear_destination,omitempty" example:"true"`
+}
+
+// ReconcileQuery runs the schedule reconciler; with dry_run drift is only reported.
+type ReconcileQuery struct {
+	DryRun bool `form:"dry_run" example:"true"`
+}
+
+// BulkJobFilter selects jobs by the same criteria as the job list endpoint.
+type BulkJobFilter struct {
+	Name      string `json:"name,omitempty" example:"orders"`
+	Type      string `json:"type,omitempty" example:"postgres"`
+	Active    *bool  `json:"active,omitempty" example:"true"`
+	CreatedBy string `json:"created_by,omitempty" example:"admin"`
+	Selector  string `json:"selector,omitempty" example:"tier=critical,team!=growth"`
+}
+
+// BulkJobRequest applies one action to the jobs listed in JobIDs or matched by Filter.
+type BulkJobRequest struct {
+	// enum: pause,resume,trigger,cancel,change_frequency,change_version,delete
+	Action    string         `json:"action" binding:"required" example:"pause"`
+	JobIDs    []int          `json:"job_ids,omitempty" example:"1,2,3"`
+	Filter    *BulkJobFilter `json:"filter,omitempty"`
+	Frequency string         `json:"frequency,omitempty" example:"0 */6 * * *"` // change_frequency only
+	Version   string         `json:"version,omitempty" example:"v0.3.15"`       // change_version only
+	// OverrideMaintenanceWindow triggers jobs that are in a maintenance window, trigger only
+	OverrideMaintenanceWindow bool `json:"override_maintenance_window,omitempty" example:"false"`
+}
+
+// ReplaceLabelsRequest replaces every label of an entity.
+type ReplaceLabelsRequest struct {
+	Labels map[string]string `json:"labels" binding:"required"`
+}
+
+// PatchLabelsRequest adds or overwrites the labels in Set and removes the keys in Remove.
+type PatchLabelsRequest struct {
+	Set    map[string]string `json:"set,omitempty"`
+	Remove []string          `json:"remove,omitempty" example:"team"`
+}
+
 type UpsertProjectSettingsRequest struct {
 	ID              int    `json:"id" example:"1"`
 	ProjectID       string `json:"project_id" binding:"required" example:"project-123"`
 	WebhookAlertURL string `json:"webhook_alert_url" example:"https://hooks.slack.com/services/xxx/yyy/zzz"`
+	// DefaultTimeZone is left as it is when omitted; an empty string resets it to UTC
+	DefaultTimeZone *string `json:"default_time_zone,omitempty" example:"Asia/Kolkata"`
 }
 
 type UpdateSyncTelemetryRequest struct {
@@ -141,8 +415,12 @@ type UpdateSyncTelemetryRequest struct {
 	Environment string `json:"environment"`
 }
 
+// UpdateStateFileRequest writes the state of a job. WorkflowID and RunID are the run that
+// produced it; without them the state is tied to the sync running at the time.
 type UpdateStateFileRequest struct {
-	StateFile string `json:"state_file" binding:"required"`
+	StateFile  string `json:"state_file" binding:"required"`
+	WorkflowID string `json:"workflow_id,omitempty" example:"sync-123-1-1704800000"`
+	RunID      string `json:"run_id,omitempty" example:"0b6a2f9c-5a4e-4f0e-9a57-5d7c1c2b4e11"`
 }
 
 type CatalogRequest struct {
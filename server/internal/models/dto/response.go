@@ -8,6 +8,15 @@ type JSONResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// PaginatedResponse is the envelope returned by list endpoints.
+// NextCursor is empty when there are no more pages.
+type PaginatedResponse struct {
+	Items      interface{} `json:"items"`
+	Total      int64       `json:"total" example:"120"`
+	Limit      int         `json:"limit" example:"50"`
+	NextCursor string      `json:"next_cursor,omitempty" example:"eyJ2IjoiMjAyNC0wMS0wOVQxMjowMDowMFoiLCJpZCI6NDJ9"`
+}
+
 // ErrorResponse represents an error response from the API
 type ErrorResponse struct {
 	Success bool   `json:"success" example:"false"`
@@ -44,6 +53,18 @@ type Error413Response struct {
 	Message string `json:"message" example:"Payload too large"`
 }
 
+// Error429Response represents a 429 Too Many Requests error
+type Error429Response struct {
+	Success bool   `json:"success" example:"false"`
+	Message string `json:"message" example:"Concurrency limit reached"`
+}
+
+// Error503Response represents a 503 Service Unavailable error
+type Error503Response struct {
+	Success bool   `json:"success" example:"false"`
+	Message string `json:"message" example:"no workers are polling the task queue"`
+}
+
 // Error500Response represents a 500 Internal Server Error
 type Error500Response struct {
 	Success bool   `json:"success" example:"false"`
@@ -96,21 +117,262 @@ type VersionsResponse struct {
 
 // Job response
 type JobResponse struct {
-	ID               int               `json:"id" example:"1"`
-	Name             string            `json:"name" example:"my-sync-job"`
-	Source           DriverConfig      `json:"source"`
-	Destination      DriverConfig      `json:"destination"`
-	StreamsConfig    string            `json:"streams_config,omitempty"`
-	Frequency        string            `json:"frequency" example:"0 */6 * * *"`
-	LastRunTime      string            `json:"last_run_time,omitempty" example:"2024-01-09T12:00:00Z"`
-	LastRunState     string            `json:"last_run_state,omitempty" example:"completed"`
-	LastRunType      string            `json:"last_run_type,omitempty" example:"sync"` // "sync" | "clear-destination"
-	CreatedAt        string            `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt        string            `json:"updated_at" example:"2024-01-09T12:00:00Z"`
-	Activate         bool              `json:"activate" example:"true"`
-	CreatedBy        string            `json:"created_by,omitempty" example:"admin"`
-	UpdatedBy        string            `json:"updated_by,omitempty" example:"admin"`
-	AdvancedSettings *AdvancedSettings `json:"advanced_settings,omitempty"`
+	ID                int               `json:"id" example:"1"`
+	Name              string            `json:"name" example:"my-sync-job"`
+	Source            DriverConfig      `json:"source"`
+	Destination       DriverConfig      `json:"destination"`
+	StreamsConfig     string            `json:"streams_config,omitempty"`
+	Frequency         string            `json:"frequency" example:"0 */6 * * *"`
+	ScheduleMode      string            `json:"schedule_mode" example:"cron"` // "cron" | "interval" | "calendar" | "manual" | "once"
+	TimeZone          string            `json:"time_zone,omitempty" example:"Europe/Berlin"`
+	EffectiveTimeZone string            `json:"effective_time_zone" example:"Europe/Berlin"` // the job's, else the project default, else UTC
+	LastRunTime       string            `json:"last_run_time,omitempty" example:"2024-01-09T12:00:00Z"`
+	LastRunState      string            `json:"last_run_state,omitempty" example:"completed"`
+	LastRunType       string            `json:"last_run_type,omitempty" example:"sync"` // "sync" | "clear-destination"
+	CreatedAt         string            `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt         string            `json:"updated_at" example:"2024-01-09T12:00:00Z"`
+	Activate          bool              `json:"activate" example:"true"`
+	CreatedBy         string            `json:"created_by,omitempty" example:"admin"`
+	UpdatedBy         string            `json:"updated_by,omitempty" example:"admin"`
+	AdvancedSettings  *AdvancedSettings `json:"advanced_settings,omitempty"`
+	// ExecutionPolicy is the policy the job's syncs run with, defaults filled in
+	ExecutionPolicy ExecutionPolicy `json:"execution_policy"`
+	// WorkerPool and TaskQueue are where the job's runs go: the pool of its advanced settings,
+	// else the pool of its source, else the default pool
+	WorkerPool string `json:"worker_pool" example:"cdc-large"`
+	TaskQueue  string `json:"task_queue,omitempty" example:"OLAKE_CDC_QUEUE"`
+	// DriverResources are the resources the job's driver container runs with, server
+	// defaults filled in
+	DriverResources DriverResources   `json:"driver_resources"`
+	Labels          map[string]string `json:"labels"`
+	// ScheduleStatus is set while a change to the job's temporal schedule is not applied yet
+	ScheduleStatus string `json:"schedule_status,omitempty" example:"pending"` // "pending" | "failed"
+	ScheduleError  string `json:"schedule_error,omitempty" example:"failed to update schedule: context deadline exceeded"`
+	// MaintenanceWindows are the windows of the job's project, source and the job itself
+	MaintenanceWindows   []MaintenanceWindowItem `json:"maintenance_windows"`
+	TriggerDeferredUntil string                  `json:"trigger_deferred_until,omitempty" example:"2024-01-10T03:00:00Z"`
+}
+
+// ExecutionPolicy is the effective execution policy of a job's syncs. Retries apply to
+// scheduled syncs only; max_attempts 1 means a failed sync is not retried.
+type ExecutionPolicy struct {
+	RunTimeout         string  `json:"run_timeout" example:"720h0m0s"`
+	MaxAttempts        int     `json:"max_attempts" example:"1"`
+	InitialInterval    string  `json:"initial_interval" example:"1m0s"`
+	BackoffCoefficient float64 `json:"backoff_coefficient" example:"2"`
+	MaxInterval        string  `json:"max_interval" example:"1h0m0s"`
+	OverlapPolicy      string  `json:"overlap_policy" example:"skip"`
+	CatchupWindow      string  `json:"catchup_window" example:"8760h0m0s"`
+}
+
+type CloneJobResponse struct {
+	ID   int    `json:"id" example:"12"`
+	Name string `json:"name" example:"my-sync-job-copy"`
+}
+
+// JobTemplateResult is the outcome of creating a job for one source of a template request.
+type JobTemplateResult struct {
+	SourceID int    `json:"source_id" example:"2"`
+	JobID    int    `json:"job_id,omitempty" example:"12"`
+	JobName  string `json:"job_name,omitempty" example:"my-sync-job-tenant-a"`
+	Success  bool   `json:"success" example:"true"`
+	Error    string `json:"error,omitempty" example:"job name 'my-sync-job-tenant-a' is not unique"`
+}
+
+type JobTemplateResponse struct {
+	Total     int                 `json:"total" example:"40"`
+	Succeeded int                 `json:"succeeded" example:"39"`
+	Failed    int                 `json:"failed" example:"1"`
+	Results   []JobTemplateResult `json:"results"`
+}
+
+// ImportItem is the planned or applied outcome of importing one bundle entity.
+type ImportItem struct {
+	Kind     string `json:"kind" example:"source"`
+	Name     string `json:"name" example:"prod-pg-staging"`
+	Action   string `json:"action,omitempty" example:"create"`
+	ID       int    `json:"id,omitempty" example:"7"`
+	Conflict string `json:"conflict,omitempty" example:"source 'prod-pg-staging' already exists"`
+	Error    string `json:"error,omitempty" example:"failed to create source"`
+}
+
+type ImportJobsResponse struct {
+	Applied   bool         `json:"applied" example:"false"`
+	Conflicts int          `json:"conflicts" example:"1"`
+	Failed    int          `json:"failed" example:"0"`
+	Items     []ImportItem `json:"items"`
+}
+
+// ReconcileItem is a job or sync schedule whose schedule has drifted from the job. Drift
+// lists schedule (missing), schedule_frequency, schedule_paused, schedule_action (stuck on
+// clear-destination) or orphan_schedule (a sync schedule without a job).
+type ReconcileItem struct {
+	ScheduleID string   `json:"schedule_id" example:"schedule-sync-123-7"`
+	ProjectID  string   `json:"project_id" example:"123"`
+	JobID      int      `json:"job_id" example:"7"`
+	JobName    string   `json:"job_name,omitempty" example:"orders-sync"`
+	Drift      []string `json:"drift" example:"schedule_paused"`
+	Repaired   bool     `json:"repaired" example:"true"`
+	Error      string   `json:"error,omitempty" example:"failed to describe schedule"`
+}
+
+type ReconcileResponse struct {
+	DryRun    bool            `json:"dry_run" example:"false"`
+	Jobs      int             `json:"jobs" example:"40"`
+	Schedules int             `json:"schedules" example:"41"`
+	Skipped   int             `json:"skipped" example:"1"`
+	Drifted   int             `json:"drifted" example:"2"`
+	Repaired  int             `json:"repaired" example:"2"`
+	Failed    int             `json:"failed" example:"0"`
+	Items     []ReconcileItem `json:"items"`
+}
+
+// ScheduleOperationItem is a change to a job's temporal schedule queued in the schedule outbox.
+type ScheduleOperationItem struct {
+	ID            int    `json:"id" example:"31"`
+	Operation     string `json:"operation" example:"update_schedule"`
+	Status        string `json:"status" example:"pending"` // "pending" | "applied" | "failed" | "superseded"
+	Attempts      int    `json:"attempts" example:"2"`
+	LastError     string `json:"last_error,omitempty" example:"failed to update schedule: context deadline exceeded"`
+	NextAttemptAt string `json:"next_attempt_at,omitempty" example:"2024-01-09T12:00:20Z"`
+	CreatedAt     string `json:"created_at" example:"2024-01-09T12:00:00Z"`
+	AppliedAt     string `json:"applied_at,omitempty" example:"2024-01-09T12:00:21Z"`
+}
+
+type ScheduleOperationsResponse struct {
+	JobID          int                     `json:"job_id" example:"1"`
+	ScheduleStatus string                  `json:"schedule_status" example:"pending"` // "applied" | "pending" | "failed"
+	Operations     []ScheduleOperationItem `json:"operations"`
+}
+
+// TrashItem is a job, source or destination that was deleted and can still be restored.
+type TrashItem struct {
+	Kind      string `json:"kind" example:"job"` // "job" | "source" | "destination"
+	ID        int    `json:"id" example:"1"`
+	Name      string `json:"name" example:"my-sync-job"`
+	Type      string `json:"type,omitempty" example:"postgres"`
+	DeletedAt string `json:"deleted_at" example:"2024-01-09T12:00:00Z"`
+	PurgeAt   string `json:"purge_at,omitempty" example:"2024-02-08T12:00:00Z"`
+}
+
+type TrashResponse struct {
+	Items []TrashItem `json:"items"`
+}
+
+// JobRevisionItem is a saved revision of the configuration of a job.
+type JobRevisionItem struct {
+	Revision      int    `json:"revision" example:"3"`
+	Change        string `json:"change" example:"update"` // "baseline" | "create" | "update" | "rollback"
+	RestoredFrom  int    `json:"restored_from,omitempty" example:"1"`
+	SourceID      int    `json:"source_id" example:"1"`
+	DestinationID int    `json:"destination_id" example:"1"`
+	Frequency     string `json:"frequency" example:"0 */6 * * *"`
+	CreatedBy     string `json:"created_by,omitempty" example:"admin"`
+	CreatedAt     string `json:"created_at" example:"2024-01-09T12:00:00Z"`
+}
+
+type JobRevisionsResponse struct {
+	JobID     int               `json:"job_id" example:"1"`
+	Revisions []JobRevisionItem `json:"revisions"`
+}
+
+// JobRevisionStreamChange is a stream-level difference between two revisions of a job, with
+// the sync mode and column selection changes of a modified stream spelled out.
+type JobRevisionStreamChange struct {
+	StreamChange
+	SyncMode       *SyncModeChange `json:"sync_mode,omitempty"`
+	AddedColumns   []string        `json:"added_columns,omitempty" example:"discount"`
+	RemovedColumns []string        `json:"removed_columns,omitempty" example:"notes"`
+}
+
+type SyncModeChange struct {
+	From string `json:"from" example:"full_refresh"`
+	To   string `json:"to" example:"cdc"`
+}
+
+type JobRevisionDiffResponse struct {
+	JobID         int                       `json:"job_id" example:"1"`
+	From          int                       `json:"from" example:"2"`
+	To            int                       `json:"to" example:"5"`
+	Fields        []string                  `json:"fields" example:"streams_config"` // "source" | "destination" | "frequency" | "streams_config" | "advanced_settings"
+	StreamChanges []JobRevisionStreamChange `json:"stream_changes"`
+}
+
+// JobStateSnapshotItem is a state a job had, with the run that wrote it.
+type JobStateSnapshotItem struct {
+	ID           int    `json:"id" example:"12"`
+	Reason       string `json:"reason" example:"sync"` // "baseline" | "sync" | "clear_destination" | "restore"
+	WorkflowID   string `json:"workflow_id,omitempty" example:"sync-123-1-1704800000"`
+	RunID        string `json:"run_id,omitempty" example:"0b6a2f9c-5a4e-4f0e-9a57-5d7c1c2b4e11"`
+	RestoredFrom int    `json:"restored_from,omitempty" example:"9"`
+	CreatedAt    string `json:"created_at" example:"2024-01-09T12:00:00Z"`
+}
+
+type JobStateHistoryResponse struct {
+	JobID     int                    `json:"job_id" example:"1"`
+	Snapshots []JobStateSnapshotItem `json:"snapshots"`
+}
+
+// StreamState is the state of a stream: its cursor for incremental syncs, the chunks left of
+// its initial load and whether it follows the CDC position of the job.
+type StreamState struct {
+	Stream        string         `json:"stream" example:"public.orders"`
+	SyncMode      string         `json:"sync_mode,omitempty" example:"incremental"`
+	CursorField   string         `json:"cursor_field,omitempty" example:"updated_at"`
+	CursorValue   any            `json:"cursor_value,omitempty"`
+	PendingChunks int            `json:"pending_chunks,omitempty" example:"0"`
+	CDC           bool           `json:"cdc,omitempty" example:"false"`
+	State         map[string]any `json:"state,omitempty"`
+}
+
+// JobStateResponse is the state of a job, current or of a snapshot, broken down by stream.
+// CDCPosition is the position, such as an LSN or binlog offset, shared by its CDC streams.
+type JobStateResponse struct {
+	JobID       int           `json:"job_id" example:"1"`
+	SnapshotID  int           `json:"snapshot_id,omitempty" example:"12"`
+	Type        string        `json:"type,omitempty" example:"GLOBAL"`
+	CDCPosition any           `json:"cdc_position,omitempty"`
+	Streams     []StreamState `json:"streams"`
+}
+
+// ResyncStreamsResponse reports the streams whose state a resync removed and the snapshot it
+// saved. With cleared_destination the sync starts once clear-destination has run.
+type ResyncStreamsResponse struct {
+	JobID              int      `json:"job_id" example:"1"`
+	Streams            []string `json:"streams" example:"public.orders"`
+	SnapshotID         int      `json:"snapshot_id" example:"14"`
+	ClearedDestination bool     `json:"cleared_destination" example:"true"`
+}
+
+// JobRollbackResponse reports the revision a rollback saved and the streams whose destination
+// data it cleared. Revision is 0 when the job already had the configuration rolled back to.
+type JobRollbackResponse struct {
+	JobID          int      `json:"job_id" example:"1"`
+	RestoredFrom   int      `json:"restored_from" example:"2"`
+	Revision       int      `json:"revision" example:"6"`
+	ClearedStreams []string `json:"cleared_streams,omitempty" example:"public.orders"`
+}
+
+// BulkJobResult is the outcome of a bulk action for a single job.
+type BulkJobResult struct {
+	JobID   int    `json:"job_id" example:"1"`
+	JobName string `json:"job_name,omitempty" example:"my-sync-job"`
+	Success bool   `json:"success" example:"true"`
+	Message string `json:"message,omitempty" example:"schedule paused"`
+	Error   string `json:"error,omitempty" example:"failed to pause schedule"`
+}
+
+type BulkJobResponse struct {
+	Action    string          `json:"action" example:"pause"`
+	Total     int             `json:"total" example:"80"`
+	Succeeded int             `json:"succeeded" example:"78"`
+	Failed    int             `json:"failed" example:"2"`
+	Results   []BulkJobResult `json:"results"`
+}
+
+// LabelsResponse holds the labels of a job, source or destination.
+type LabelsResponse struct {
+	Labels map[string]string `json:"labels"`
 }
 
 type JobTask struct {
@@ -119,32 +381,227 @@ type JobTask struct {
 	Status    string `json:"status" example:"completed"`
 	FilePath  string `json:"file_path" example:"sync-123-2-2026-01-19T13:45:09Z"`
 	JobType   string `json:"job_type" example:"sync"` // "sync" | "clear-destination"
+	AdHoc     bool   `json:"ad_hoc,omitempty" example:"false"`
+}
+
+// SyncJobResponse is the run a sync trigger started: a run of the job's schedule, or a
+// one-off sync of some streams of a job or with run overrides. AlreadyRunning is set when a
+// scheduled sync was running already, which is then the run returned. The run ids are empty
+// if the scheduled run could not be found yet; it then shows up in the job tasks.
+type SyncJobResponse struct {
+	Message        string   `json:"message" example:"sync triggered successfully"`
+	WorkflowID     string   `json:"workflow_id,omitempty" example:"sync-123-1-adhoc-1704800000"`
+	RunID          string   `json:"run_id,omitempty" example:"0b6a2f9c-5a4e-4f0e-9a57-5d7c1c2b4e11"`
+	AlreadyRunning bool     `json:"already_running,omitempty" example:"false"`
+	AdHoc          bool     `json:"ad_hoc" example:"true"`
+	Streams        []string `json:"streams,omitempty" example:"public.orders"`
+	Timeout        string   `json:"timeout,omitempty" example:"2h0m0s"`
+	// Queued is set when a concurrency cap held the sync back, it starts once a slot is free
+	Queued bool `json:"queued,omitempty" example:"false"`
+}
+
+// JobRunResponse is the status of a run of a job. Done is set once the run has closed, and
+// Error then summarises why it did not complete.
+type JobRunResponse struct {
+	JobID      int    `json:"job_id" example:"1"`
+	WorkflowID string `json:"workflow_id" example:"sync-123-1-2024-01-09T12:00:00Z"`
+	RunID      string `json:"run_id" example:"0b6a2f9c-5a4e-4f0e-9a57-5d7c1c2b4e11"`
+	JobType    string `json:"job_type" example:"sync"` // "sync" | "clear"
+	AdHoc      bool   `json:"ad_hoc,omitempty" example:"false"`
+	Status     string `json:"status" example:"Completed"`
+	Done       bool   `json:"done" example:"true"`
+	Succeeded  bool   `json:"succeeded" example:"true"`
+	StartTime  string `json:"start_time" example:"2024-01-09T12:00:00Z"`
+	CloseTime  string `json:"close_time,omitempty" example:"2024-01-09T12:01:30Z"`
+	Duration   string `json:"duration" example:"1m30s"`
+	Error      string `json:"error,omitempty" example:"sync failed: connection refused"`
+}
+
+// MaintenanceWindowItem is a maintenance window. ActiveUntil is set while the window is in
+// effect, to the end of the current occurrence.
+type MaintenanceWindowItem struct {
+	ID          int    `json:"id" example:"1"`
+	Name        string `json:"name" example:"nightly backup"`
+	Scope       string `json:"scope" example:"source"` // "project" | "source" | "job"
+	SourceID    *int   `json:"source_id,omitempty" example:"1"`
+	JobID       *int   `json:"job_id,omitempty" example:"2"`
+	Recurring   bool   `json:"recurring" example:"true"`
+	Days        string `json:"days,omitempty" example:"mon-fri"`
+	StartTime   string `json:"start_time,omitempty" example:"01:00"`
+	EndTime     string `json:"end_time,omitempty" example:"03:00"`
+	TimeZone    string `json:"time_zone,omitempty" example:"Europe/Berlin"`
+	StartsAt    string `json:"starts_at,omitempty" example:"2024-01-20T22:00:00Z"`
+	EndsAt      string `json:"ends_at,omitempty" example:"2024-01-21T04:00:00Z"`
+	ActiveUntil string `json:"active_until,omitempty" example:"2024-01-10T03:00:00+01:00"`
+	CreatedBy   string `json:"created_by,omitempty" example:"admin"`
+	CreatedAt   string `json:"created_at" example:"2024-01-09T12:00:00Z"`
+}
+
+// SchedulePreviewResponse lists the next fire times of a schedule in its time zone. Source is
+// temporal when they are taken from the job's temporal schedule, which includes its jitter,
+// and local when they are computed from the frequency, without jitter: every run may then
+// start up to Jitter later. Manual and once schedules have no fire times.
+type SchedulePreviewResponse struct {
+	JobID        int      `json:"job_id,omitempty" example:"1"`
+	Frequency    string   `json:"frequency" example:"weekdays at 02:30 jitter 5m"`
+	ScheduleMode string   `json:"schedule_mode" example:"calendar"` // "cron" | "interval" | "calendar" | "manual" | "once"
+	TimeZone     string   `json:"time_zone" example:"Europe/Berlin"`
+	Jitter       string   `json:"jitter,omitempty" example:"5m0s"`
+	Paused       bool     `json:"paused,omitempty" example:"false"`
+	Source       string   `json:"source" example:"local"` // "temporal" | "local"
+	FireTimes    []string `json:"fire_times" example:"2024-01-09T02:30:00+01:00"`
+}
+
+// BackfillResponse reports the fire times each job missed from From to To and the runs
+// that were enqueued for them
+type BackfillResponse struct {
+	From     string              `json:"from" example:"2024-01-09T00:00:00Z"`
+	To       string              `json:"to" example:"2024-01-09T06:00:00Z"`
+	Mode     string              `json:"mode" example:"catch_up"`
+	DryRun   bool                `json:"dry_run" example:"false"`
+	Missed   int                 `json:"missed" example:"6"`
+	Enqueued int                 `json:"enqueued" example:"1"`
+	Jobs     []BackfillJobResult `json:"jobs"`
+}
+
+// BackfillJobResult is the outcome of a backfill for a single job. Fire times are on the
+// wall clock of the job's schedule time zone.
+type BackfillJobResult struct {
+	JobID         int      `json:"job_id" example:"1"`
+	JobName       string   `json:"job_name,omitempty" example:"my-sync-job"`
+	MissedWindows []string `json:"missed_windows" example:"2024-01-09T01:00:00+01:00"`
+	Enqueued      []string `json:"enqueued" example:"2024-01-09T06:00:00+01:00"`
+	Skipped       string   `json:"skipped,omitempty" example:"job is paused"`
+	Error         string   `json:"error,omitempty" example:"failed to backfill schedule"`
+}
+
+// RunCalendarResponse lists the scheduled runs of the active jobs of a project from From to
+// To, grouped by source
+type RunCalendarResponse struct {
+	From    string              `json:"from" example:"2024-01-09T12:00:00Z"`
+	To      string              `json:"to" example:"2024-01-10T12:00:00Z"`
+	Sources []RunCalendarSource `json:"sources"`
+}
+
+// RunCalendarSource is the runs of the jobs of one source, and where they overlap
+type RunCalendarSource struct {
+	SourceID   int                  `json:"source_id" example:"1"`
+	SourceName string               `json:"source_name" example:"orders-db"`
+	SourceType string               `json:"source_type" example:"postgres"`
+	Runs       []RunCalendarRun     `json:"runs"`
+	Overlaps   []RunCalendarOverlap `json:"overlaps"`
+}
+
+// RunCalendarRun is a scheduled run of a job. EndTime is an estimate: the fire time plus the
+// jitter of the schedule and the duration of the last completed sync of the job.
+type RunCalendarRun struct {
+	JobID             int    `json:"job_id" example:"1"`
+	JobName           string `json:"job_name" example:"orders-sync"`
+	StartTime         string `json:"start_time" example:"2024-01-09T13:00:00Z"`
+	EndTime           string `json:"end_time" example:"2024-01-09T13:12:00Z"`
+	Jitter            string `json:"jitter,omitempty" example:"10m0s"`
+	EstimatedDuration string `json:"estimated_duration,omitempty" example:"2m0s"`
+}
+
+// RunCalendarOverlap is a span of time in which runs of two or more jobs of a source may run
+// at the same time
+type RunCalendarOverlap struct {
+	StartTime string `json:"start_time" example:"2024-01-09T13:00:00Z"`
+	EndTime   string `json:"end_time" example:"2024-01-09T13:12:00Z"`
+	JobIDs    []int  `json:"job_ids" example:"1,2"`
+}
+
+// JobDependencyRef is a job within a dependency graph. Deleted is set for a job in the trash.
+type JobDependencyRef struct {
+	JobID   int    `json:"job_id" example:"2"`
+	Name    string `json:"name,omitempty" example:"raw-orders"`
+	Active  bool   `json:"active" example:"true"`
+	Deleted bool   `json:"deleted,omitempty" example:"false"`
+}
+
+// JobUpstream is an upstream job of a job, with how its latest sync since the job was last
+// triggered by its dependencies ended: pending while there was none, completed or failed.
+type JobUpstream struct {
+	JobDependencyRef
+	Status         string `json:"status" example:"completed"` // "pending" | "completed" | "failed"
+	LastWorkflowID string `json:"last_workflow_id,omitempty" example:"sync-123-2-2024-01-09T12:00:00Z"`
+	LastClosedAt   string `json:"last_closed_at,omitempty" example:"2024-01-09T12:01:30Z"`
+}
+
+// JobDependenciesResponse is the upstream and downstream jobs of a job. WaitingOn lists the
+// upstream jobs whose syncs the job is still waiting for before it is triggered.
+type JobDependenciesResponse struct {
+	JobID       int                `json:"job_id" example:"1"`
+	Policy      string             `json:"policy,omitempty" example:"all_succeeded"`
+	Upstreams   []JobUpstream      `json:"upstreams"`
+	Downstreams []JobDependencyRef `json:"downstreams"`
+	WaitingOn   []int              `json:"waiting_on"`
+}
+
+// DependencyGraphResponse is the dependency graph of the jobs of a project: every job with
+// upstream or downstream jobs, and an edge from each job to each of its upstream jobs.
+type DependencyGraphResponse struct {
+	Nodes []DependencyGraphNode `json:"nodes"`
+	Edges []DependencyGraphEdge `json:"edges"`
+}
+
+type DependencyGraphNode struct {
+	JobDependencyRef
+	Policy    string `json:"policy,omitempty" example:"all_succeeded"`
+	WaitingOn []int  `json:"waiting_on,omitempty" example:"2"`
+}
+
+type DependencyGraphEdge struct {
+	JobID         int    `json:"job_id" example:"1"`
+	UpstreamJobID int    `json:"upstream_job_id" example:"2"`
+	Status        string `json:"status" example:"pending"` // "pending" | "completed" | "failed"
+}
+
+// TriggerTokenItem is a trigger token of a job, without its secret
+type TriggerTokenItem struct {
+	ID         int    `json:"id" example:"1"`
+	Name       string `json:"name" example:"airflow"`
+	Prefix     string `json:"prefix" example:"olt_3fa9c1"`
+	CreatedBy  string `json:"created_by,omitempty" example:"admin"`
+	CreatedAt  string `json:"created_at" example:"2024-01-09T12:00:00Z"`
+	LastUsedAt string `json:"last_used_at,omitempty" example:"2024-01-10T06:00:00Z"`
+}
+
+// CreateTriggerTokenResponse is a new trigger token of a job. Token is its secret, which is
+// returned only here.
+type CreateTriggerTokenResponse struct {
+	TriggerTokenItem
+	Token string `json:"token" example:"olt_3fa9c1..."`
 }
 
 type SourceDataItem struct {
-	ID        int           `json:"id" example:"1"`
-	Name      string        `json:"name" example:"my-postgres-source"`
-	Type      string        `json:"type" example:"postgres"`
-	Version   string        `json:"version" example:"v0.2.7"`
-	Config    string        `json:"config" example:"{\"host\":\"localhost\",\"port\":5432}"`
-	CreatedAt string        `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt string        `json:"updated_at" example:"2024-01-09T12:00:00Z"`
-	CreatedBy string        `json:"created_by" example:"admin"`
-	UpdatedBy string        `json:"updated_by" example:"admin"`
-	Jobs      []JobDataItem `json:"jobs"`
+	ID        int               `json:"id" example:"1"`
+	Name      string            `json:"name" example:"my-postgres-source"`
+	Type      string            `json:"type" example:"postgres"`
+	Version   string            `json:"version" example:"v0.2.7"`
+	Config    string            `json:"config" example:"{\"host\":\"localhost\",\"port\":5432}"`
+	CreatedAt string            `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt string            `json:"updated_at" example:"2024-01-09T12:00:00Z"`
+	CreatedBy string            `json:"created_by" example:"admin"`
+	UpdatedBy string            `json:"updated_by" example:"admin"`
+	Labels    map[string]string `json:"labels"`
+	Jobs      []JobDataItem     `json:"jobs"`
+	// WorkerPool is the worker pool of the source, empty for the default pool
+	WorkerPool string `json:"worker_pool,omitempty" example:"vpc-orders"`
 }
 
 type DestinationDataItem struct {
-	ID        int           `json:"id" example:"1"`
-	Name      string        `json:"name" example:"my-iceberg-destination"`
-	Type      string        `json:"type" example:"iceberg"`
-	Version   string        `json:"version" example:"v0.2.7"`
-	Config    string        `json:"config" example:"{\"catalog_type\":\"glue\",\"warehouse\":\"s3://bucket/warehouse\"}"`
-	CreatedAt string        `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt string        `json:"updated_at" example:"2024-01-09T12:00:00Z"`
-	CreatedBy string        `json:"created_by" example:"admin"`
-	UpdatedBy string        `json:"updated_by" example:"admin"`
-	Jobs      []JobDataItem `json:"jobs"`
+	ID        int               `json:"id" example:"1"`
+	Name      string            `json:"name" example:"my-iceberg-destination"`
+	Type      string            `json:"type" example:"iceberg"`
+	Version   string            `json:"version" example:"v0.2.7"`
+	Config    string            `json:"config" example:"{\"catalog_type\":\"glue\",\"warehouse\":\"s3://bucket/warehouse\"}"`
+	CreatedAt string            `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt string            `json:"updated_at" example:"2024-01-09T12:00:00Z"`
+	CreatedBy string            `json:"created_by" example:"admin"`
+	UpdatedBy string            `json:"updated_by" example:"admin"`
+	Labels    map[string]string `json:"labels"`
+	Jobs      []JobDataItem     `json:"jobs"`
 }
 
 type JobDataItem struct {
@@ -171,6 +628,7 @@ type ProjectSettingsResponse struct {
 	ID              int    `json:"id" example:"1"`
 	ProjectID       string `json:"project_id" example:"project-123"`
 	WebhookAlertURL string `json:"webhook_alert_url" example:"https://hooks.slack.com/services/xxx/yyy/zzz"`
+	DefaultTimeZone string `json:"default_time_zone" example:"Asia/Kolkata"`
 }
 
 type LoginResponse struct {
@@ -256,3 +714,50 @@ type TableProperties struct {
 type TerminalSessionResponse struct {
 	SessionID string `json:"sessionId"`
 }
+
+// ConcurrencyResponse shows the sync and discover caps of a project and of its capped sources
+// and destinations, how many runs use them and the runs waiting for a slot
+type ConcurrencyResponse struct {
+	Project      ConcurrencyUsage   `json:"project"`
+	Sources      []ConcurrencyUsage `json:"sources"`
+	Destinations []ConcurrencyUsage `json:"destinations"`
+	Queue        []RunQueueItem     `json:"queue"`
+}
+
+// ConcurrencyUsage is the caps of a project, source or destination and the runs counted
+// against them. A cap of 0 is no cap.
+type ConcurrencyUsage struct {
+	ID                     int    `json:"id,omitempty" example:"1"`
+	Name                   string `json:"name,omitempty" example:"orders-db"`
+	MaxConcurrentSyncs     int    `json:"max_concurrent_syncs" example:"3"`
+	RunningSyncs           int    `json:"running_syncs" example:"3"`
+	QueuedSyncs            int    `json:"queued_syncs" example:"2"`
+	MaxConcurrentDiscovers int    `json:"max_concurrent_discovers" example:"1"`
+	RunningDiscovers       int    `json:"running_discovers" example:"0"`
+	QueuedDiscovers        int    `json:"queued_discovers" example:"0"`
+}
+
+// RunQueueItem is a run waiting for a concurrency slot, or a discover holding one. Position
+// is the place of a queued run among the queued runs of its kind.
+type RunQueueItem struct {
+	ID            int    `json:"id" example:"7"`
+	Kind          string `json:"kind" example:"sync"`     // "sync" | "discover"
+	Status        string `json:"status" example:"queued"` // "queued" | "running"
+	JobID         *int   `json:"job_id,omitempty" example:"2"`
+	JobName       string `json:"job_name,omitempty" example:"orders-sync"`
+	SourceID      *int   `json:"source_id,omitempty" example:"1"`
+	DestinationID *int   `json:"destination_id,omitempty" example:"1"`
+	Reason        string `json:"reason,omitempty" example:"source 'orders-db' runs 3 of 3 syncs"`
+	QueuedAt      string `json:"queued_at" example:"2024-01-09T12:00:00Z"`
+	Position      int    `json:"position,omitempty" example:"1"`
+}
+
+// WorkerPoolItem is a configured worker pool and the number of workers polling its task
+// queue. Error is set when the task queue could not be described.
+type WorkerPoolItem struct {
+	Name      string `json:"name" example:"cdc-large"`
+	TaskQueue string `json:"task_queue" example:"OLAKE_CDC_QUEUE"`
+	Default   bool   `json:"default" example:"false"`
+	Pollers   int    `json:"pollers" example:"2"`
+	Error     string `json:"error,omitempty" example:"failed to describe task queue"`
+}
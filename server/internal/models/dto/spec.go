@@ -0,0 +1,67 @@
+package dto
+
+// Spec is the declarative desired state of one or more projects. Entities are matched to
+// existing ones by name within their project. Config values may reference server environment
+// variables as "${env:NAME}" so that secrets do not have to be committed with the spec.
+type Spec struct {
+	Version  string        `json:"version" example:"v1"`
+	Projects []ProjectSpec `json:"projects"`
+}
+
+// ProjectSpec is the desired state of a project. Jobs refer to their source and destination
+// by name and both must be part of the same project spec. With prune, jobs, sources and
+// destinations of the project that are not in the spec are deleted.
+type ProjectSpec struct {
+	ID           string               `json:"id" example:"123"`
+	Prune        bool                 `json:"prune,omitempty" example:"false"`
+	Settings     *ProjectSettingsSpec `json:"settings,omitempty"`
+	Sources      []BundleSource       `json:"sources,omitempty"`
+	Destinations []BundleDestination  `json:"destinations,omitempty"`
+	Jobs         []BundleJob          `json:"jobs,omitempty"`
+}
+
+type ProjectSettingsSpec struct {
+	WebhookAlertURL string `json:"webhook_alert_url" example:"https://hooks.slack.com/services/T000/B000/XXXX"`
+}
+
+// SpecPlanRequest carries a spec to plan or apply. Apply refuses destructive stream changes
+// unless allow_destructive is set.
+type SpecPlanRequest struct {
+	Spec             *Spec `json:"spec" binding:"required"`
+	AllowDestructive bool  `json:"allow_destructive,omitempty" example:"false"`
+}
+
+// SpecChange is one planned change. Fields lists what differs for an update, including
+// drift of the job's temporal schedule (schedule, schedule_frequency, schedule_paused,
+// schedule_action). A destructive change alters streams whose destination data must be
+// cleared and re-synced.
+type SpecChange struct {
+	ProjectID     string         `json:"project_id" example:"123"`
+	Kind          string         `json:"kind" example:"job"`
+	Name          string         `json:"name" example:"orders-sync"`
+	ID            int            `json:"id,omitempty" example:"7"`
+	Action        string         `json:"action" example:"update"`
+	Fields        []string       `json:"fields,omitempty" example:"streams_config"`
+	Destructive   bool           `json:"destructive,omitempty" example:"true"`
+	StreamChanges []StreamChange `json:"stream_changes,omitempty"`
+	Error         string         `json:"error,omitempty" example:"failed to update job"`
+}
+
+// StreamChange is a stream-level difference between the current and desired streams config.
+type StreamChange struct {
+	Stream      string   `json:"stream" example:"public.orders"`
+	Change      string   `json:"change" example:"modified"`
+	Fields      []string `json:"fields,omitempty" example:"partition_regex"`
+	Destructive bool     `json:"destructive,omitempty" example:"true"`
+}
+
+type SpecPlanResponse struct {
+	Applied     bool         `json:"applied" example:"false"`
+	Creates     int          `json:"creates" example:"2"`
+	Updates     int          `json:"updates" example:"1"`
+	Deletes     int          `json:"deletes" example:"0"`
+	Destructive int          `json:"destructive" example:"1"`
+	Failed      int          `json:"failed" example:"0"`
+	Errors      []string     `json:"errors,omitempty" example:"job 'orders-sync' references source 'pg' that is not in the spec"`
+	Changes     []SpecChange `json:"changes"`
+}
@@ -2,8 +2,14 @@ package dto
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/datazip-inc/olake-ui/server/internal/appconfig"
 	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"golang.org/x/mod/semver"
 )
 
 // ValidateSourceType checks if the provided type is in the list of supported source types
@@ -25,3 +31,387 @@ func ValidateDestinationType(t string) error {
 	}
 	return fmt.Errorf("invalid destination type '%s', supported destinations are: %v", t, constants.SupportedDestinationTypes)
 }
+
+// ValidateTimeZone checks that a schedule time zone is an IANA time zone name. An empty
+// name is accepted and leaves the choice of time zone to the project default.
+func ValidateTimeZone(name string) error {
+	if name == "" {
+		return nil
+	}
+	// "Local" names the zone of the server, which temporal knows nothing about
+	if name == "Local" {
+		return fmt.Errorf("%w: '%s' is not an IANA time zone name", constants.ErrInvalidTimeZone, name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("%w: '%s' is not an IANA time zone name, e.g. 'Europe/Berlin'", constants.ErrInvalidTimeZone, name)
+	}
+	return nil
+}
+
+// Validate checks the page size and sort options of a list query against the sort keys
+// supported by the endpoint.
+func (q *ListQuery) Validate(sortKeys ...string) error {
+	if q.Limit < 0 || q.Limit > constants.MaxListPageSize {
+		return fmt.Errorf("limit must be between 0 and %d (0 = no limit)", constants.MaxListPageSize)
+	}
+	if q.SortBy != "" && !slices.Contains(sortKeys, q.SortBy) {
+		return fmt.Errorf("invalid sort_by '%s', supported keys are: %v", q.SortBy, sortKeys)
+	}
+	if q.SortOrder != "" && q.SortOrder != constants.SortOrderAsc && q.SortOrder != constants.SortOrderDesc {
+		return fmt.Errorf("invalid sort_order '%s', supported values are: asc, desc", q.SortOrder)
+	}
+	return nil
+}
+
+// Validate checks the page size and filters of a job task list query.
+func (q *JobTaskListQuery) Validate() error {
+	if q.Limit < 0 || q.Limit > constants.MaxListPageSize {
+		return fmt.Errorf("limit must be between 0 and %d (0 = no limit)", constants.MaxListPageSize)
+	}
+	if q.Status != "" && !slices.Contains(constants.WorkflowExecutionStatuses, q.Status) {
+		return fmt.Errorf("invalid status '%s', supported values are: %v", q.Status, constants.WorkflowExecutionStatuses)
+	}
+	if q.Type != "" && q.Type != "sync" && q.Type != "clear" {
+		return fmt.Errorf("invalid type '%s', supported values are: sync, clear", q.Type)
+	}
+	return nil
+}
+
+// WaitTimeout is how long a run status request waits for the run to close: 0 without wait,
+// constants.RunWaitDefaultTimeout unless a timeout is given, and at most runWaitLimit.
+func (q *JobRunQuery) WaitTimeout() (time.Duration, error) {
+	if !q.Wait {
+		return 0, nil
+	}
+	limit := runWaitLimit(appconfig.Load().HTTPWriteTimeout)
+	if q.Timeout == "" {
+		return min(constants.RunWaitDefaultTimeout, limit), nil
+	}
+	timeout, err := time.ParseDuration(q.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout '%s': %s", q.Timeout, err)
+	}
+	if timeout <= 0 || timeout > limit {
+		return 0, fmt.Errorf("timeout must be between 0 and %s", limit)
+	}
+	return timeout, nil
+}
+
+// runWaitLimit is the longest a run status request may wait, constants.RunWaitMaxTimeout
+// or less so that the response is written before the HTTP write timeout
+func runWaitLimit(writeTimeout time.Duration) time.Duration {
+	if writeTimeout <= 0 {
+		return constants.RunWaitMaxTimeout
+	}
+	return max(min(constants.RunWaitMaxTimeout, writeTimeout-constants.RunWaitWriteMargin), writeTimeout/2)
+}
+
+// Validate checks the time zone and the count of a schedule preview, defaulting the count.
+// The frequency is checked by the caller.
+func (r *SchedulePreviewRequest) Validate() error {
+	if err := ValidateTimeZone(r.TimeZone); err != nil {
+		return err
+	}
+	count, err := previewCount(r.Count)
+	if err != nil {
+		return err
+	}
+	r.Count = count
+	return nil
+}
+
+// Validate checks the mode, range and jobs of a backfill. The range ends in the past, it is
+// cut off at the time of the request.
+func (r *BackfillRequest) Validate() error {
+	switch r.Mode {
+	case "":
+		r.Mode = constants.BackfillModeCatchUp
+	case constants.BackfillModeCatchUp, constants.BackfillModePerWindow:
+	default:
+		return fmt.Errorf("invalid mode '%s', expected %s or %s", r.Mode, constants.BackfillModeCatchUp, constants.BackfillModePerWindow)
+	}
+	if !r.To.After(r.From) {
+		return fmt.Errorf("to must be after from")
+	}
+	if r.To.Sub(r.From) > constants.MaxBackfillRange {
+		return fmt.Errorf("a backfill covers at most %s", constants.MaxBackfillRange)
+	}
+	if !r.From.Before(time.Now()) {
+		return fmt.Errorf("from must be in the past")
+	}
+	if (len(r.JobIDs) > 0) == (r.Selector != "") {
+		return fmt.Errorf("exactly one of job_ids or selector must be provided")
+	}
+	if len(r.JobIDs) > constants.MaxBulkJobs {
+		return fmt.Errorf("at most %d jobs can be targeted at once", constants.MaxBulkJobs)
+	}
+	return nil
+}
+
+// Validate checks the scope and caps of a concurrency limit change
+func (r *ConcurrencyLimitRequest) Validate() error {
+	switch r.Scope {
+	case constants.ConcurrencyScopeProject:
+		if r.ID != 0 {
+			return fmt.Errorf("id is not allowed for the project scope")
+		}
+	case constants.ConcurrencyScopeSource, constants.ConcurrencyScopeDestination:
+		if r.ID <= 0 {
+			return fmt.Errorf("id of the %s is required", r.Scope)
+		}
+	default:
+		return fmt.Errorf("invalid scope '%s', expected %s, %s or %s", r.Scope, constants.ConcurrencyScopeProject, constants.ConcurrencyScopeSource, constants.ConcurrencyScopeDestination)
+	}
+	if r.MaxConcurrentSyncs < 0 || r.MaxConcurrentSyncs > constants.MaxConcurrencyCap {
+		return fmt.Errorf("max_concurrent_syncs must be between 0 and %d", constants.MaxConcurrencyCap)
+	}
+	if r.MaxConcurrentDiscovers < 0 || r.MaxConcurrentDiscovers > constants.MaxConcurrencyCap {
+		return fmt.Errorf("max_concurrent_discovers must be between 0 and %d", constants.MaxConcurrencyCap)
+	}
+	return nil
+}
+
+// Validate checks the execution policy of a job. Settings left out keep their defaults.
+func (a *AdvancedSettings) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if err := validateDuration("run_timeout", a.RunTimeout, constants.MinRunTimeout, constants.DefaultSyncTimeout); err != nil {
+		return err
+	}
+	if r := a.Retry; r != nil {
+		if r.MaxAttempts < 1 || r.MaxAttempts > constants.MaxRetryAttempts {
+			return fmt.Errorf("retry.max_attempts must be between 1 and %d", constants.MaxRetryAttempts)
+		}
+		if err := validateDuration("retry.initial_interval", r.InitialInterval, time.Second, constants.DefaultSyncTimeout); err != nil {
+			return err
+		}
+		if err := validateDuration("retry.max_interval", r.MaxInterval, time.Second, constants.DefaultSyncTimeout); err != nil {
+			return err
+		}
+		if r.BackoffCoefficient != 0 && r.BackoffCoefficient < 1 {
+			return fmt.Errorf("retry.backoff_coefficient must be at least 1")
+		}
+		if r.InitialInterval != "" && r.MaxInterval != "" {
+			initial, _ := time.ParseDuration(r.InitialInterval)
+			maxInterval, _ := time.ParseDuration(r.MaxInterval)
+			if maxInterval < initial {
+				return fmt.Errorf("retry.max_interval must not be shorter than retry.initial_interval")
+			}
+		}
+	}
+	switch a.OverlapPolicy {
+	case "", constants.OverlapPolicySkip, constants.OverlapPolicyBufferOne, constants.OverlapPolicyCancelOther:
+	default:
+		return fmt.Errorf("invalid overlap_policy '%s', expected %s, %s or %s", a.OverlapPolicy,
+			constants.OverlapPolicySkip, constants.OverlapPolicyBufferOne, constants.OverlapPolicyCancelOther)
+	}
+	if err := validateDuration("catchup_window", a.CatchupWindow, constants.MinCatchupWindow, constants.DefaultCatchupWindow); err != nil {
+		return err
+	}
+	return validateDriverEnv(a.Env)
+}
+
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateDriverEnv checks the names of the extra environment variables of a driver
+// container. The resources are checked against the server maximums when the job is saved.
+func validateDriverEnv(env []DriverEnvVar) error {
+	if len(env) > constants.MaxDriverEnvVars {
+		return fmt.Errorf("at most %d environment variables can be set", constants.MaxDriverEnvVars)
+	}
+	names := make(map[string]bool, len(env))
+	for _, v := range env {
+		if !envVarNamePattern.MatchString(v.Name) {
+			return fmt.Errorf("invalid environment variable name '%s'", v.Name)
+		}
+		if names[v.Name] {
+			return fmt.Errorf("environment variable '%s' is set twice", v.Name)
+		}
+		names[v.Name] = true
+	}
+	return nil
+}
+
+// validateDuration checks that a duration setting, when set, lies between lowest and highest
+func validateDuration(name, value string, lowest, highest time.Duration) error {
+	if value == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s '%s': %s", name, value, err)
+	}
+	if d < lowest || d > highest {
+		return fmt.Errorf("%s must be between %s and %s", name, lowest, highest)
+	}
+	return nil
+}
+
+// Validate checks the scope and time zone of a maintenance window. The window itself is read
+// by utils.ParseWindow.
+func (r *MaintenanceWindowRequest) Validate() error {
+	if r.SourceID != nil && r.JobID != nil {
+		return fmt.Errorf("a maintenance window applies to a source or to a job, not both")
+	}
+	return ValidateTimeZone(r.TimeZone)
+}
+
+// Validate checks the count of a job schedule preview, defaulting it
+func (q *SchedulePreviewQuery) Validate() error {
+	count, err := previewCount(q.Count)
+	if err != nil {
+		return err
+	}
+	q.Count = count
+	return nil
+}
+
+func previewCount(count int) (int, error) {
+	if count == 0 {
+		return constants.SchedulePreviewDefaultCount, nil
+	}
+	if count < 0 || count > constants.SchedulePreviewMaxCount {
+		return 0, fmt.Errorf("count must be between 1 and %d", constants.SchedulePreviewMaxCount)
+	}
+	return count, nil
+}
+
+// WindowDuration returns the window of a run calendar, constants.RunCalendarDefaultWindow
+// when none is given
+func (q *RunCalendarQuery) WindowDuration() (time.Duration, error) {
+	if q.Window == "" {
+		return constants.RunCalendarDefaultWindow, nil
+	}
+	window, err := time.ParseDuration(q.Window)
+	if err != nil {
+		return 0, fmt.Errorf("invalid window '%s': %s", q.Window, err)
+	}
+	if window <= 0 || window > constants.RunCalendarMaxWindow {
+		return 0, fmt.Errorf("window must be between 0 and %s", constants.RunCalendarMaxWindow)
+	}
+	return window, nil
+}
+
+// Validate checks the policy and the number of upstream jobs of a dependencies request,
+// defaulting the policy to all_succeeded.
+func (r *JobDependenciesRequest) Validate() error {
+	if r.Policy == "" {
+		r.Policy = constants.DependencyPolicyAllSucceeded
+	}
+	if r.Policy != constants.DependencyPolicyAllSucceeded && r.Policy != constants.DependencyPolicyAnyCompleted {
+		return fmt.Errorf("invalid policy '%s', supported values are: %s, %s", r.Policy, constants.DependencyPolicyAllSucceeded, constants.DependencyPolicyAnyCompleted)
+	}
+	if len(r.UpstreamJobIDs) > constants.MaxUpstreamJobs {
+		return fmt.Errorf("a job can depend on at most %d jobs", constants.MaxUpstreamJobs)
+	}
+	return nil
+}
+
+// Validate checks that a bulk job request targets jobs either by id or by a non-empty
+// filter and carries the parameters its action needs.
+func (r *BulkJobRequest) Validate() error {
+	switch r.Action {
+	case constants.BulkActionPause, constants.BulkActionResume, constants.BulkActionTrigger,
+		constants.BulkActionCancel, constants.BulkActionDelete:
+	case constants.BulkActionChangeFrequency:
+		if r.Frequency == "" {
+			return fmt.Errorf("frequency is required for action '%s'", r.Action)
+		}
+	case constants.BulkActionChangeVersion:
+		if !semver.IsValid(r.Version) {
+			return fmt.Errorf("a valid version (e.g. v0.3.15) is required for action '%s'", r.Action)
+		}
+	default:
+		return fmt.Errorf("invalid action '%s'", r.Action)
+	}
+
+	if (len(r.JobIDs) > 0) == (r.Filter != nil) {
+		return fmt.Errorf("exactly one of job_ids or filter must be provided")
+	}
+	if len(r.JobIDs) > constants.MaxBulkJobs {
+		return fmt.Errorf("at most %d jobs can be targeted at once", constants.MaxBulkJobs)
+	}
+	if f := r.Filter; f != nil && f.Name == "" && f.Type == "" && f.Active == nil && f.CreatedBy == "" && f.Selector == "" {
+		return fmt.Errorf("filter must set at least one criterion")
+	}
+	return nil
+}
+
+// Validate checks the source list and naming pattern of a job template request.
+func (r *JobTemplateRequest) Validate() error {
+	if len(r.SourceIDs) == 0 {
+		return fmt.Errorf("source_ids must not be empty")
+	}
+	if len(r.SourceIDs) > constants.MaxBulkJobs {
+		return fmt.Errorf("at most %d jobs can be created at once", constants.MaxBulkJobs)
+	}
+	seen := make(map[int]bool, len(r.SourceIDs))
+	for _, id := range r.SourceIDs {
+		if seen[id] {
+			return fmt.Errorf("source id %d is listed more than once", id)
+		}
+		seen[id] = true
+	}
+	if r.NamePattern != "" && len(r.SourceIDs) > 1 &&
+		!strings.Contains(r.NamePattern, "{source}") && !strings.Contains(r.NamePattern, "{source_id}") && !strings.Contains(r.NamePattern, "{index}") {
+		return fmt.Errorf("name_pattern must contain {source}, {source_id} or {index} to give every job a unique name")
+	}
+	return nil
+}
+
+// Validate checks the export format.
+func (q *ExportJobsQuery) Validate() error {
+	if q.Format != "" && q.Format != constants.BundleFormatJSON && q.Format != constants.BundleFormatYAML {
+		return fmt.Errorf("invalid format '%s', supported values are: json, yaml", q.Format)
+	}
+	if len(q.JobIDs) > 0 && q.Selector != "" {
+		return fmt.Errorf("only one of job_ids or selector can be provided")
+	}
+	if len(q.JobIDs) > constants.MaxBulkJobs {
+		return fmt.Errorf("at most %d jobs can be exported at once", constants.MaxBulkJobs)
+	}
+	return nil
+}
+
+// Validate checks the bundle version and the conflict policy of an import request.
+func (r *ImportJobsRequest) Validate() error {
+	if r.Bundle.Version != constants.BundleVersion {
+		return fmt.Errorf("unsupported bundle version '%s', supported version is %s", r.Bundle.Version, constants.BundleVersion)
+	}
+	if r.OnConflict != "" && r.OnConflict != constants.ImportOnConflictFail && r.OnConflict != constants.ImportOnConflictUpdate {
+		return fmt.Errorf("invalid on_conflict '%s', supported values are: fail, update", r.OnConflict)
+	}
+	if len(r.Bundle.Jobs) > constants.MaxBulkJobs {
+		return fmt.Errorf("at most %d jobs can be imported at once", constants.MaxBulkJobs)
+	}
+	return nil
+}
+
+// Validate checks the version of a spec and that every project is listed once.
+func (r *SpecPlanRequest) Validate() error {
+	if r.Spec.Version != constants.SpecVersion {
+		return fmt.Errorf("unsupported spec version '%s', supported version is %s", r.Spec.Version, constants.SpecVersion)
+	}
+	projects := make(map[string]bool, len(r.Spec.Projects))
+	for _, project := range r.Spec.Projects {
+		if project.ID == "" {
+			return fmt.Errorf("project id is required")
+		}
+		if projects[project.ID] {
+			return fmt.Errorf("project '%s' is listed more than once", project.ID)
+		}
+		projects[project.ID] = true
+	}
+	return nil
+}
+
+// ValidateProject checks that a spec sent to the routes of a project only describes that project.
+func (r *SpecPlanRequest) ValidateProject(projectID string) error {
+	for _, project := range r.Spec.Projects {
+		if project.ID != projectID {
+			return fmt.Errorf("spec project '%s' does not match project '%s' of the request", project.ID, projectID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+)
+
+// Labels are the key/value pairs attached to a job, source or destination.
+// They are stored as a jsonb object.
+type Labels map[string]string
+
+// Value implements driver.Valuer, storing nil labels as an empty object.
+func (l Labels) Value() (driver.Value, error) {
+	if l == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(map[string]string(l))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal labels: %s", err)
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner.
+func (l *Labels) Scan(value interface{}) error {
+	var raw []byte
+	switch v := value.(type) {
+	case nil:
+		*l = Labels{}
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("unsupported labels type %T", value)
+	}
+	labels := Labels{}
+	if err := json.Unmarshal(raw, &labels); err != nil {
+		return fmt.Errorf("failed to unmarshal labels: %s", err)
+	}
+	*l = labels
+	return nil
+}
+
+// SelectorOperator is the comparison applied by a single selector requirement.
+type SelectorOperator string
+
+const (
+	SelectorEquals       SelectorOperator = "="
+	SelectorNotEquals    SelectorOperator = "!="
+	SelectorIn           SelectorOperator = "in"
+	SelectorNotIn        SelectorOperator = "notin"
+	SelectorExists       SelectorOperator = "exists"
+	SelectorDoesNotExist SelectorOperator = "!"
+)
+
+var (
+	labelKeyPattern   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+	labelValuePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?)?$`)
+	setRequirement    = regexp.MustCompile(`^([^\s!=()]+)\s+(in|notin)\s*\(([^()]*)\)$`)
+)
+
+// LabelRequirement is one comma separated term of a label selector.
+type LabelRequirement struct {
+	Key      string
+	Operator SelectorOperator
+	Values   []string
+}
+
+// LabelSelector matches entities whose labels satisfy every requirement.
+// A zero value selector matches everything.
+//
+// Supported syntax (terms are comma separated and AND-ed):
+//
+//	tier=critical, tier==critical, team!=growth, env in (prod,staging), env notin (dev), team, !team
+type LabelSelector struct {
+	Requirements []LabelRequirement
+}
+
+// ParseLabelSelector parses a selector such as "tier=critical,team!=growth".
+// An empty string yields an empty selector.
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	var parsed LabelSelector
+	for _, term := range splitSelectorTerms(selector) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		requirement, err := parseRequirement(term)
+		if err != nil {
+			return LabelSelector{}, fmt.Errorf("%w: %q: %s", constants.ErrInvalidLabelSelector, term, err)
+		}
+		parsed.Requirements = append(parsed.Requirements, requirement)
+	}
+	return parsed, nil
+}
+
+// Empty reports whether the selector has no requirements.
+func (s LabelSelector) Empty() bool {
+	return len(s.Requirements) == 0
+}
+
+// Matches reports whether the given labels satisfy the selector.
+func (s LabelSelector) Matches(labels Labels) bool {
+	for _, r := range s.Requirements {
+		value, ok := labels[r.Key]
+		switch r.Operator {
+		case SelectorEquals:
+			if !ok || value != r.Values[0] {
+				return false
+			}
+		case SelectorNotEquals:
+			if ok && value == r.Values[0] {
+				return false
+			}
+		case SelectorIn:
+			if !ok || !slices.Contains(r.Values, value) {
+				return false
+			}
+		case SelectorNotIn:
+			if ok && slices.Contains(r.Values, value) {
+				return false
+			}
+		case SelectorExists:
+			if !ok {
+				return false
+			}
+		case SelectorDoesNotExist:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String renders the selector in its canonical form.
+func (s LabelSelector) String() string {
+	terms := make([]string, 0, len(s.Requirements))
+	for _, r := range s.Requirements {
+		switch r.Operator {
+		case SelectorEquals, SelectorNotEquals:
+			terms = append(terms, r.Key+string(r.Operator)+r.Values[0])
+		case SelectorIn, SelectorNotIn:
+			terms = append(terms, fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ",")))
+		case SelectorExists:
+			terms = append(terms, r.Key)
+		case SelectorDoesNotExist:
+			terms = append(terms, "!"+r.Key)
+		}
+	}
+	return strings.Join(terms, ",")
+}
+
+// ValidateLabels checks label keys and values against the allowed format and size.
+func ValidateLabels(labels Labels) error {
+	if len(labels) > constants.MaxLabelsPerEntity {
+		return fmt.Errorf("%w: at most %d labels are allowed", constants.ErrInvalidLabel, constants.MaxLabelsPerEntity)
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := validateLabelKey(key); err != nil {
+			return err
+		}
+		if err := validateLabelValue(labels[key]); err != nil {
+			return fmt.Errorf("%w (key %q)", err, key)
+		}
+	}
+	return nil
+}
+
+func validateLabelKey(key string) error {
+	if len(key) > constants.MaxLabelKeyLength || !labelKeyPattern.MatchString(key) {
+		return fmt.Errorf("%w: key %q must be at most %d alphanumeric characters, '-', '_', '.' or '/', starting and ending with an alphanumeric character",
+			constants.ErrInvalidLabel, key, constants.MaxLabelKeyLength)
+	}
+	return nil
+}
+
+func validateLabelValue(value string) error {
+	if len(value) > constants.MaxLabelValueLength || !labelValuePattern.MatchString(value) {
+		return fmt.Errorf("%w: value %q must be at most %d alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric character",
+			constants.ErrInvalidLabel, value, constants.MaxLabelValueLength)
+	}
+	return nil
+}
+
+// splitSelectorTerms splits on commas that are not inside a set requirement's parentheses.
+func splitSelectorTerms(selector string) []string {
+	var terms []string
+	depth, start := 0, 0
+	for i, ch := range selector {
+		switch ch {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(terms, selector[start:])
+}
+
+func parseRequirement(term string) (LabelRequirement, error) {
+	if m := setRequirement.FindStringSubmatch(term); m != nil {
+		var values []string
+		for _, v := range strings.Split(m[3], ",") {
+			v = strings.TrimSpace(v)
+			if err := validateLabelValue(v); err != nil {
+				return LabelRequirement{}, err
+			}
+			values = append(values, v)
+		}
+		if err := validateLabelKey(m[1]); err != nil {
+			return LabelRequirement{}, err
+		}
+		return LabelRequirement{Key: m[1], Operator: SelectorOperator(m[2]), Values: values}, nil
+	}
+
+	var requirement LabelRequirement
+	switch {
+	case strings.Contains(term, "!="):
+		key, value, _ := strings.Cut(term, "!=")
+		requirement = LabelRequirement{Key: key, Operator: SelectorNotEquals, Values: []string{value}}
+	case strings.Contains(term, "=="):
+		key, value, _ := strings.Cut(term, "==")
+		requirement = LabelRequirement{Key: key, Operator: SelectorEquals, Values: []string{value}}
+	case strings.Contains(term, "="):
+		key, value, _ := strings.Cut(term, "=")
+		requirement = LabelRequirement{Key: key, Operator: SelectorEquals, Values: []string{value}}
+	case strings.HasPrefix(term, "!"):
+		requirement = LabelRequirement{Key: strings.TrimPrefix(term, "!"), Operator: SelectorDoesNotExist}
+	default:
+		requirement = LabelRequirement{Key: term, Operator: SelectorExists}
+	}
+
+	requirement.Key = strings.TrimSpace(requirement.Key)
+	if err := validateLabelKey(requirement.Key); err != nil {
+		return LabelRequirement{}, err
+	}
+	for i, v := range requirement.Values {
+		requirement.Values[i] = strings.TrimSpace(v)
+		if err := validateLabelValue(requirement.Values[i]); err != nil {
+			return LabelRequirement{}, err
+		}
+	}
+	return requirement, nil
+}
@@ -0,0 +1,214 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		name         string
+		selector     string
+		requirements []LabelRequirement
+		canonical    string
+	}{
+		{
+			name:     "empty",
+			selector: "",
+		},
+		{
+			name:     "only separators",
+			selector: " , ,",
+		},
+		{
+			name:         "equals",
+			selector:     "tier=critical",
+			requirements: []LabelRequirement{{Key: "tier", Operator: SelectorEquals, Values: []string{"critical"}}},
+			canonical:    "tier=critical",
+		},
+		{
+			name:         "double equals",
+			selector:     "tier==critical",
+			requirements: []LabelRequirement{{Key: "tier", Operator: SelectorEquals, Values: []string{"critical"}}},
+			canonical:    "tier=critical",
+		},
+		{
+			name:         "equals an empty value",
+			selector:     "tier=",
+			requirements: []LabelRequirement{{Key: "tier", Operator: SelectorEquals, Values: []string{""}}},
+			canonical:    "tier=",
+		},
+		{
+			name:         "not equals",
+			selector:     "team!=growth",
+			requirements: []LabelRequirement{{Key: "team", Operator: SelectorNotEquals, Values: []string{"growth"}}},
+			canonical:    "team!=growth",
+		},
+		{
+			name:         "in",
+			selector:     "env in (prod, staging)",
+			requirements: []LabelRequirement{{Key: "env", Operator: SelectorIn, Values: []string{"prod", "staging"}}},
+			canonical:    "env in (prod,staging)",
+		},
+		{
+			name:         "notin",
+			selector:     "env notin (dev)",
+			requirements: []LabelRequirement{{Key: "env", Operator: SelectorNotIn, Values: []string{"dev"}}},
+			canonical:    "env notin (dev)",
+		},
+		{
+			name:         "exists",
+			selector:     "team",
+			requirements: []LabelRequirement{{Key: "team", Operator: SelectorExists}},
+			canonical:    "team",
+		},
+		{
+			name:         "does not exist",
+			selector:     "!team",
+			requirements: []LabelRequirement{{Key: "team", Operator: SelectorDoesNotExist}},
+			canonical:    "!team",
+		},
+		{
+			name:     "terms with spaces and a set",
+			selector: " tier = critical , env in (prod,staging), !deprecated,owner ",
+			requirements: []LabelRequirement{
+				{Key: "tier", Operator: SelectorEquals, Values: []string{"critical"}},
+				{Key: "env", Operator: SelectorIn, Values: []string{"prod", "staging"}},
+				{Key: "deprecated", Operator: SelectorDoesNotExist},
+				{Key: "owner", Operator: SelectorExists},
+			},
+			canonical: "tier=critical,env in (prod,staging),!deprecated,owner",
+		},
+		{
+			name:         "key with a prefix",
+			selector:     "olake.io/tier=gold",
+			requirements: []LabelRequirement{{Key: "olake.io/tier", Operator: SelectorEquals, Values: []string{"gold"}}},
+			canonical:    "olake.io/tier=gold",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := ParseLabelSelector(tt.selector)
+			require.NoError(t, err)
+			require.Equal(t, tt.requirements, selector.Requirements)
+			require.Equal(t, len(tt.requirements) == 0, selector.Empty())
+			require.Equal(t, tt.canonical, selector.String())
+
+			// the canonical form parses back to the same selector
+			reparsed, err := ParseLabelSelector(selector.String())
+			require.NoError(t, err)
+			require.Equal(t, selector.Requirements, reparsed.Requirements)
+		})
+	}
+}
+
+func TestParseLabelSelectorErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		err      string
+	}{
+		{"empty key", "=prod", `key ""`},
+		{"empty negated key", "!", `key ""`},
+		{"double negation", "!!team", `key "!team"`},
+		{"key with a space", "my team=growth", `key "my team"`},
+		{"key ending in a dot", "team.=growth", `key "team."`},
+		{"value with a space", "tier=very critical", `value "very critical"`},
+		{"value with an equals sign", "a=b=c", `value "b=c"`},
+		{"value with a slash", "tier=a/b", `value "a/b"`},
+		{"unclosed set", "env in (prod", `key "env in (prod"`},
+		{"set without parentheses", "env in prod", `key "env in prod"`},
+		{"unknown set operator", "env within (prod)", `key "env within (prod)"`},
+		{"invalid value in a set", "env in (prod,st ag)", `value "st ag"`},
+		{"key too long", strings.Repeat("k", 64), "at most 63"},
+		{"value too long", "tier=" + strings.Repeat("v", 64), "at most 63"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseLabelSelector(tt.selector)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, constants.ErrInvalidLabelSelector))
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestLabelSelectorMatches(t *testing.T) {
+	labels := Labels{"tier": "critical", "env": "prod", "team": ""}
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"tier=critical", true},
+		{"tier=low", false},
+		{"missing=critical", false},
+		{"tier!=low", true},
+		{"tier!=critical", false},
+		{"missing!=critical", true},
+		{"env in (prod,staging)", true},
+		{"env in (dev,staging)", false},
+		{"missing in (prod)", false},
+		{"env notin (dev)", true},
+		{"env notin (prod)", false},
+		{"missing notin (prod)", true},
+		{"team", true},
+		{"missing", false},
+		{"!missing", true},
+		{"!team", false},
+		{"team=", true},
+		{"tier=critical,env in (prod),!missing", true},
+		{"tier=critical,env notin (prod)", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := ParseLabelSelector(tt.selector)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, selector.Matches(labels))
+		})
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	tooMany := Labels{}
+	for i := range constants.MaxLabelsPerEntity + 1 {
+		tooMany[fmt.Sprintf("key%d", i)] = "v"
+	}
+	tests := []struct {
+		name   string
+		labels Labels
+		err    string
+	}{
+		{name: "nil", labels: nil},
+		{name: "valid", labels: Labels{"tier": "critical", "olake.io/team": "data-eng", "env_name": "prod.eu-1", "flag": ""}},
+		{name: "longest key and value", labels: Labels{strings.Repeat("k", 63): strings.Repeat("v", 63)}},
+		{name: "too many labels", labels: tooMany, err: "at most 64 labels are allowed"},
+		{name: "empty key", labels: Labels{"": "v"}, err: `key ""`},
+		{name: "key starting with a dash", labels: Labels{"-tier": "v"}, err: `key "-tier"`},
+		{name: "key with a colon", labels: Labels{"tier:a": "v"}, err: `key "tier:a"`},
+		{name: "key too long", labels: Labels{strings.Repeat("k", 64): "v"}, err: "at most 63"},
+		{name: "value with a slash", labels: Labels{"tier": "a/b"}, err: `value "a/b" must be`},
+		{name: "value ending in a dash", labels: Labels{"tier": "gold-"}, err: `(key "tier")`},
+		{name: "value too long", labels: Labels{"tier": strings.Repeat("v", 64)}, err: "at most 63"},
+		// keys are checked in sorted order, so the first invalid key is reported
+		{name: "first invalid key reported", labels: Labels{"b b": "v", "a a": "v"}, err: `key "a a"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLabels(tt.labels)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.True(t, errors.Is(err, constants.ErrInvalidLabel))
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
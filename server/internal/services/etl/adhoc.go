@@ -0,0 +1,100 @@
+package etl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+)
+
+// Ad-hoc sync methods on AppService
+
+// adHocSync starts a one-off sync of a job, limited to some of its streams or with run
+// overrides, without changing the job or the action of its schedule. The stream subset is
+// handed to the run as a temporary streams file. The job has to be idle, and its schedule is
+// paused until the run has closed so that no scheduled sync overlaps it.
+func (s Service) adHocSync(ctx context.Context, job *models.Job, req *dto.SyncJobRequest) (*dto.SyncJobResponse, error) {
+	if job.Source == nil {
+		return nil, fmt.Errorf("job source details not found")
+	}
+
+	resp := &dto.SyncJobResponse{Message: "ad-hoc sync started", AdHoc: true}
+	var streamsConfig string
+	if len(req.Streams) > 0 {
+		streams, err := selectedStreamKeys(job.StreamsConfig, req.Streams)
+		if err != nil {
+			return nil, err
+		}
+		if streamsConfig, err = filterStreamsConfig(job.StreamsConfig, streams); err != nil {
+			return nil, err
+		}
+		resp.Streams = streams
+	}
+	timeout, err := syncRunTimeout(req.Overrides)
+	if err != nil {
+		return nil, err
+	}
+	if timeout > 0 {
+		resp.Timeout = timeout.String()
+	}
+
+	release, err := s.holdIdleJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	resp.WorkflowID, resp.RunID, err = s.temporal.RunAdHocSync(ctx, job, streamsConfig, timeout)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	go s.resumeAfterAdHocSync(job, resp.WorkflowID, resp.RunID, timeout)
+
+	logger.Infof("ad-hoc sync %s started for job_id[%d] streams%v", resp.WorkflowID, job.ID, resp.Streams)
+	return resp, nil
+}
+
+// resumeAfterAdHocSync unpauses the schedule of a job once its ad-hoc sync has closed, if the
+// job is still active by then. If the server stops first, the reconciler unpauses it.
+func (s Service) resumeAfterAdHocSync(job *models.Job, workflowID, runID string, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = temporal.JobExecutionPolicy(job).RunTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout+time.Minute)
+	defer cancel()
+
+	if err := waitForWorkflowClose(ctx, s.temporal, workflowID, runID); err != nil {
+		logger.Warnf("failed to wait for ad-hoc sync %s of job_id[%d]: %s", workflowID, job.ID, err)
+	}
+
+	current, err := s.db.GetJobByID(job.ID, false)
+	if err != nil {
+		logger.Warnf("schedule of job_id[%d] is left paused after ad-hoc sync: %s", job.ID, err)
+		return
+	}
+	if !current.Active {
+		return
+	}
+	if err := s.temporal.ResumeSchedule(context.Background(), job.ProjectID, job.ID); err != nil {
+		logger.Errorf("failed to resume schedule of job_id[%d] after ad-hoc sync, the reconciler resumes it: %s", job.ID, err)
+	}
+}
+
+// syncRunTimeout reads the timeout override of a sync run; 0 keeps the job's run timeout
+func syncRunTimeout(overrides *dto.SyncRunOverrides) (time.Duration, error) {
+	if overrides == nil || overrides.Timeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(overrides.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("%w: timeout '%s': %s", constants.ErrInvalidSyncRun, overrides.Timeout, err)
+	}
+	if maxTimeout := temporal.GetWorkflowTimeout(temporal.Sync); timeout <= 0 || timeout > maxTimeout {
+		return 0, fmt.Errorf("%w: timeout must be between 0 and %s", constants.ErrInvalidSyncRun, maxTimeout)
+	}
+	return timeout, nil
+}
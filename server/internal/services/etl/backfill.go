@@ -0,0 +1,251 @@
+package etl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+// Schedule backfill methods on AppService
+
+// BackfillJobs finds the fire times from req.From to req.To that the schedules of the
+// selected jobs missed, e.g. while temporal or the workers were down, and backfills them on
+// the temporal schedules: the latest one in catch_up mode, every one in per_window mode. A
+// fire time is missed when no run of the job was running at it or started shortly after it.
+// Fire times are computed from the job's current frequency and maintenance windows. A failure
+// on one job does not stop the others.
+func (s Service) BackfillJobs(ctx context.Context, projectID string, req *dto.BackfillRequest) (*dto.BackfillResponse, error) {
+	jobs, results, err := s.resolveBackfillJobs(projectID, req)
+	if err != nil {
+		return nil, err
+	}
+	from := req.From.UTC().Truncate(time.Second)
+	// runs of the last few minutes may not have started yet
+	to := req.To.UTC()
+	if latest := time.Now().UTC().Add(-constants.BackfillMatchTolerance); to.After(latest) {
+		to = latest
+	}
+
+	logger.Infof("backfill %s initiated project_id[%s] jobs[%d] from[%s] to[%s] dry_run[%t]", req.Mode, projectID, len(jobs), from.Format(time.RFC3339), to.Format(time.RFC3339), req.DryRun)
+
+	jobResults := make([]dto.BackfillJobResult, len(jobs))
+	sem := make(chan struct{}, constants.BulkJobOperationLimit)
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			jobResults[i] = s.backfillJob(ctx, job, from, to, req)
+		}()
+	}
+	wg.Wait()
+	results = append(results, jobResults...)
+	slices.SortStableFunc(results, func(a, b dto.BackfillJobResult) int { return a.JobID - b.JobID })
+
+	resp := &dto.BackfillResponse{
+		From:   from.Format(time.RFC3339),
+		To:     to.Format(time.RFC3339),
+		Mode:   req.Mode,
+		DryRun: req.DryRun,
+		Jobs:   results,
+	}
+	for _, result := range results {
+		resp.Missed += len(result.MissedWindows)
+		resp.Enqueued += len(result.Enqueued)
+	}
+	return resp, nil
+}
+
+// resolveBackfillJobs loads the jobs targeted by a backfill. Requested ids that do not exist
+// in the project are returned as failed results.
+func (s Service) resolveBackfillJobs(projectID string, req *dto.BackfillRequest) ([]*models.Job, []dto.BackfillJobResult, error) {
+	if req.Selector != "" {
+		selector, err := models.ParseLabelSelector(req.Selector)
+		if err != nil {
+			return nil, nil, err
+		}
+		jobs, total, err := s.db.ListJobsByProjectID(projectID, database.ListOptions{Selector: selector})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list jobs: %s", err)
+		}
+		if total > int64(constants.MaxBulkJobs) {
+			return nil, nil, fmt.Errorf("%w: selector matches %d jobs, at most %d can be targeted at once", constants.ErrBulkLimitExceeded, total, constants.MaxBulkJobs)
+		}
+		return jobs, nil, nil
+	}
+
+	ids := slices.Clone(req.JobIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	jobs, err := s.db.GetJobsByIDs(projectID, ids, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	found := make(map[int]bool, len(jobs))
+	for _, job := range jobs {
+		found[job.ID] = true
+	}
+	var missing []dto.BackfillJobResult
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, dto.BackfillJobResult{JobID: id, MissedWindows: []string{}, Enqueued: []string{}, Error: constants.ErrJobNotFound.Error()})
+		}
+	}
+	return jobs, missing, nil
+}
+
+// backfillJob lists the fire times a job missed from from to to and, unless the request is a
+// dry run, backfills them
+func (s Service) backfillJob(ctx context.Context, job *models.Job, from, to time.Time, req *dto.BackfillRequest) dto.BackfillJobResult {
+	result := dto.BackfillJobResult{JobID: job.ID, JobName: job.Name, MissedWindows: []string{}, Enqueued: []string{}}
+	fail := func(err error) dto.BackfillJobResult {
+		logger.Errorf("backfill failed for job_id[%d]: %s", job.ID, err)
+		result.Error = err.Error()
+		return result
+	}
+	if utils.IsUnscheduledFrequency(job.Frequency) {
+		result.Skipped = fmt.Sprintf("job runs %s, it has no fire times", job.Frequency)
+		return result
+	}
+
+	missed, location, err := s.missedFireTimes(ctx, job, from, to)
+	if err != nil {
+		return fail(err)
+	}
+	for _, at := range missed {
+		result.MissedWindows = append(result.MissedWindows, at.In(location).Format(time.RFC3339))
+	}
+	if len(missed) == 0 || req.DryRun {
+		return result
+	}
+
+	switch {
+	case !job.Active:
+		result.Skipped = "job is paused"
+		return result
+	case len(missed) > constants.MaxBackfillWindows:
+		return fail(fmt.Errorf("job missed more than %d fire times, narrow the range", constants.MaxBackfillWindows))
+	}
+	if err := s.checkMaintenanceWindow(job, req.OverrideMaintenanceWindow); err != nil {
+		return fail(err)
+	}
+	desc, err := s.temporal.DescribeSchedule(ctx, job.ProjectID, job.ID)
+	if err != nil {
+		return fail(fmt.Errorf("failed to describe schedule: %s", err))
+	}
+	if command := temporal.ActionCommand(desc.Schedule.Action); command != "" && command != temporal.Sync {
+		result.Skipped = fmt.Sprintf("schedule runs %s, try again once it is done", command)
+		return result
+	}
+
+	enqueue := missed
+	if req.Mode == constants.BackfillModeCatchUp {
+		enqueue = missed[len(missed)-1:]
+	}
+	if err := s.temporal.BackfillSchedule(ctx, job.ProjectID, job.ID, enqueue, req.Mode == constants.BackfillModePerWindow); err != nil {
+		return fail(fmt.Errorf("failed to backfill schedule: %s", err))
+	}
+	for _, at := range enqueue {
+		result.Enqueued = append(result.Enqueued, at.In(location).Format(time.RFC3339))
+	}
+	logger.Infof("backfilled %d of %d missed fire times of job_id[%d]", len(enqueue), len(missed), job.ID)
+	return result
+}
+
+// missedFireTimes returns the fire times of a job's schedule from from to to that no run of
+// the job covered, at most one more than constants.MaxBackfillWindows, and the time zone of
+// the schedule. A run covers a fire time when it was running at it or started within the
+// jitter of the schedule and constants.BackfillMatchTolerance after it.
+func (s Service) missedFireTimes(ctx context.Context, job *models.Job, from, to time.Time) ([]time.Time, *time.Location, error) {
+	timeZone, err := s.scheduleTimeZone(job)
+	if err != nil {
+		return nil, nil, err
+	}
+	windows, err := s.db.ListJobMaintenanceWindows(job.ProjectID, job.SourceID, job.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	// one-off windows that were over by now still kept the schedule from firing back then
+	schedule, err := buildJobSchedule(job.Frequency, timeZone, windows, from)
+	if err != nil {
+		return nil, nil, err
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unknown time zone '%s'", timeZone)
+	}
+
+	var fireTimes []time.Time
+	for at := schedule.Next(from.Add(-time.Second).In(location)); !at.IsZero() && !at.After(to); at = schedule.Next(at) {
+		fireTimes = append(fireTimes, at)
+		if len(fireTimes) > constants.MaxBackfillWindows {
+			break
+		}
+	}
+	if len(fireTimes) == 0 {
+		return nil, location, nil
+	}
+
+	slack := schedule.Jitter + constants.BackfillMatchTolerance
+	runs, err := s.jobRunSpans(ctx, job, fireTimes[0], fireTimes[len(fireTimes)-1].Add(slack))
+	if err != nil {
+		return nil, nil, err
+	}
+	var missed []time.Time
+	for _, at := range fireTimes {
+		covered := slices.ContainsFunc(runs, func(run runSpan) bool {
+			return !run.start.After(at.Add(slack)) && (run.end.IsZero() || !run.end.Before(at))
+		})
+		if !covered {
+			missed = append(missed, at)
+		}
+	}
+	return missed, location, nil
+}
+
+// jobRunSpans returns the runs of a job, of any kind, that started by to and were still
+// running at from, reading at most constants.BackfillHistoryPages pages of runs. The end of a
+// run that is still running is zero.
+func (s Service) jobRunSpans(ctx context.Context, job *models.Job, from, to time.Time) ([]runSpan, error) {
+	query := fmt.Sprintf(
+		"WorkflowId BETWEEN 'sync-%s-%d-' AND 'sync-%s-%d-z' AND StartTime <= '%s' AND (ExecutionStatus = 'Running' OR CloseTime >= '%s')",
+		job.ProjectID, job.ID, job.ProjectID, job.ID, to.UTC().Format(time.RFC3339Nano), from.UTC().Format(time.RFC3339Nano),
+	)
+	var spans []runSpan
+	var nextPageToken []byte
+	for range constants.BackfillHistoryPages {
+		resp, err := s.temporal.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         query,
+			PageSize:      int32(constants.DefaultListWorkflowPageSize),
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list runs: %s", err)
+		}
+		for _, execution := range resp.Executions {
+			span := runSpan{jobID: job.ID, start: execution.StartTime.AsTime()}
+			if execution.CloseTime != nil {
+				span.end = execution.CloseTime.AsTime()
+			}
+			spans = append(spans, span)
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		nextPageToken = resp.NextPageToken
+	}
+	return spans, nil
+}
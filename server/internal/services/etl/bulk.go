@@ -0,0 +1,253 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+)
+
+// Bulk job operations on AppService
+
+// BulkJobOperation applies one action to the jobs selected by id or filter and reports
+// the outcome of every job. A failure on one job does not stop the others.
+func (s Service) BulkJobOperation(ctx context.Context, projectID string, req *dto.BulkJobRequest, userID *int) (*dto.BulkJobResponse, error) {
+	jobs, results, err := s.resolveBulkJobs(projectID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("bulk %s initiated project_id[%s] jobs[%d]", req.Action, projectID, len(jobs))
+
+	switch req.Action {
+	case constants.BulkActionPause:
+		results = append(results, s.bulkSetActive(ctx, jobs, false, *userID)...)
+	case constants.BulkActionResume:
+		results = append(results, s.bulkSetActive(ctx, jobs, true, *userID)...)
+	case constants.BulkActionTrigger:
+		results = append(results, forEachJob(ctx, jobs, func(ctx context.Context, job *models.Job) (string, error) {
+			if !job.Active {
+				return "", fmt.Errorf("job is paused, please unpause to run sync")
+			}
+			if err := s.checkMaintenanceWindow(job, req.OverrideMaintenanceWindow); err != nil {
+				return "", err
+			}
+			resp, err := s.triggerScheduledSync(ctx, job)
+			if err != nil {
+				return "", err
+			}
+			return resp.Message, nil
+		})...)
+	case constants.BulkActionCancel:
+		results = append(results, forEachJob(ctx, jobs, func(ctx context.Context, job *models.Job) (string, error) {
+			if err := cancelAllJobWorkflows(ctx, s.temporal, []*models.Job{job}, projectID); err != nil {
+				return "", fmt.Errorf("failed to cancel job workflow: %s", err)
+			}
+			return "running sync cancelled", nil
+		})...)
+	case constants.BulkActionChangeFrequency:
+		results = append(results, forEachJob(ctx, jobs, func(ctx context.Context, job *models.Job) (string, error) {
+			if job.Frequency == req.Frequency {
+				return "frequency unchanged", nil
+			}
+			err := s.db.Transaction(func(tx *database.Database) error {
+				params := map[string]any{"frequency": req.Frequency, "updated_by_id": *userID}
+				rev := &models.JobRevision{JobID: job.ID, Change: constants.JobRevisionChangeUpdate, CreatedByID: *userID}
+				if err := updateJobWithRevision(tx, job.ID, params, rev); err != nil {
+					return err
+				}
+				operations := append([]string{constants.ScheduleOpUpdate}, runOnceOperations(req.Frequency, job.Active)...)
+				return tx.EnqueueScheduleOperations(projectID, job.ID, operations...)
+			})
+			if err != nil {
+				return "", err
+			}
+			return scheduleChangeResult(fmt.Sprintf("frequency changed from '%s' to '%s'", job.Frequency, req.Frequency), s.applyScheduleOperations(ctx, job.ID))
+		})...)
+	case constants.BulkActionChangeVersion:
+		results = append(results, s.bulkChangeVersion(ctx, projectID, jobs, req.Version, *userID)...)
+	case constants.BulkActionDelete:
+		results = append(results, forEachJob(ctx, jobs, func(ctx context.Context, job *models.Job) (string, error) {
+			_, err := s.DeleteJob(ctx, job.ID)
+			return scheduleChangeResult("job deleted", err)
+		})...)
+	default:
+		return nil, fmt.Errorf("invalid action '%s'", req.Action)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].JobID < results[j].JobID })
+
+	resp := &dto.BulkJobResponse{
+		Action:  req.Action,
+		Total:   len(results),
+		Results: results,
+	}
+	for _, result := range results {
+		if result.Success {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+	return resp, nil
+}
+
+// resolveBulkJobs loads the jobs targeted by a bulk request. Requested ids that do not
+// exist in the project are returned as failed results.
+func (s Service) resolveBulkJobs(projectID string, req *dto.BulkJobRequest) ([]*models.Job, []dto.BulkJobResult, error) {
+	if req.Filter != nil {
+		selector, err := models.ParseLabelSelector(req.Filter.Selector)
+		if err != nil {
+			return nil, nil, err
+		}
+		jobs, total, err := s.db.ListJobsByProjectID(projectID, database.ListOptions{
+			Name:      req.Filter.Name,
+			Type:      req.Filter.Type,
+			Active:    req.Filter.Active,
+			CreatedBy: req.Filter.CreatedBy,
+			Selector:  selector,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list jobs: %s", err)
+		}
+		if total > int64(constants.MaxBulkJobs) {
+			return nil, nil, fmt.Errorf("%w: filter matches %d jobs, at most %d can be targeted at once", constants.ErrBulkLimitExceeded, total, constants.MaxBulkJobs)
+		}
+		return jobs, nil, nil
+	}
+
+	ids := slices.Clone(req.JobIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	jobs, err := s.db.GetJobsByIDs(projectID, ids, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	found := make(map[int]bool, len(jobs))
+	for _, job := range jobs {
+		found[job.ID] = true
+	}
+	var missing []dto.BulkJobResult
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, dto.BulkJobResult{JobID: id, Error: constants.ErrJobNotFound.Error()})
+		}
+	}
+	return jobs, missing, nil
+}
+
+// bulkSetActive pauses or resumes the given jobs. The schedule changes are queued with the
+// new status, so a schedule that cannot be changed right away is reported as pending.
+func (s Service) bulkSetActive(ctx context.Context, jobs []*models.Job, active bool, userID int) []dto.BulkJobResult {
+	return forEachJob(ctx, jobs, func(ctx context.Context, job *models.Job) (string, error) {
+		if job.Active == active {
+			return fmt.Sprintf("job already %s", activeState(active)), nil
+		}
+		return scheduleChangeResult(fmt.Sprintf("job %s", activeState(active)), s.setJobActive(ctx, job, active, userID))
+	})
+}
+
+// scheduleChangeResult turns the outcome of a job change into a bulk result message. The job
+// change is saved even when its schedule change is still pending, so that is not a failure.
+func scheduleChangeResult(message string, err error) (string, error) {
+	if errors.Is(err, constants.ErrSchedulePending) {
+		return fmt.Sprintf("%s, %s", message, err), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return message, nil
+}
+
+// bulkChangeVersion sets the driver version on the sources of the given jobs. The version
+// lives on the source, so it applies to every job sharing that source.
+func (s Service) bulkChangeVersion(ctx context.Context, projectID string, jobs []*models.Job, version string, userID int) []dto.BulkJobResult {
+	jobsBySourceID := make(map[int][]*models.Job)
+	for _, job := range jobs {
+		jobsBySourceID[job.SourceID] = append(jobsBySourceID[job.SourceID], job)
+	}
+
+	results := make([]dto.BulkJobResult, 0, len(jobs))
+	for sourceID, selected := range jobsBySourceID {
+		message, err := s.changeSourceVersion(ctx, projectID, sourceID, selected[0].Source, version, userID)
+		for _, job := range selected {
+			results = append(results, bulkResult(job, message, err))
+		}
+	}
+	return results
+}
+
+func (s Service) changeSourceVersion(ctx context.Context, projectID string, sourceID int, source *models.Source, version string, userID int) (string, error) {
+	if source == nil {
+		return "", fmt.Errorf("job source details not found")
+	}
+	if source.Version == version {
+		return fmt.Sprintf("source '%s' already on version %s", source.Name, version), nil
+	}
+
+	// same as UpdateSource: stop running syncs of every job using the source before switching versions
+	sourceJobs, err := s.db.GetJobsBySourceID([]int{sourceID})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch jobs for source update: %s", err)
+	}
+	if err := cancelAllJobWorkflows(ctx, s.temporal, sourceJobs, projectID); err != nil {
+		return "", fmt.Errorf("failed to cancel workflows for source update: %s", err)
+	}
+	if err := s.db.UpdateSourceVersion(sourceID, version, userID); err != nil {
+		return "", fmt.Errorf("failed to update source version: %s", err)
+	}
+
+	return fmt.Sprintf("source '%s' (used by %d jobs) changed from %s to %s", source.Name, len(sourceJobs), source.Version, version), nil
+}
+
+// forEachJob runs fn for every job with at most constants.BulkJobOperationLimit calls in
+// flight and returns the results in the order of jobs.
+func forEachJob(ctx context.Context, jobs []*models.Job, fn func(ctx context.Context, job *models.Job) (string, error)) []dto.BulkJobResult {
+	results := make([]dto.BulkJobResult, len(jobs))
+	sem := make(chan struct{}, constants.BulkJobOperationLimit)
+
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			message, err := fn(ctx, job)
+			if err != nil {
+				logger.Errorf("bulk operation failed for job_id[%d]: %s", job.ID, err)
+			}
+			results[i] = bulkResult(job, message, err)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func bulkResult(job *models.Job, message string, err error) dto.BulkJobResult {
+	result := dto.BulkJobResult{JobID: job.ID, JobName: job.Name, Success: err == nil, Message: message}
+	if err != nil {
+		result.Message = ""
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func activeState(active bool) string {
+	if active {
+		return "resumed"
+	}
+	return "paused"
+}
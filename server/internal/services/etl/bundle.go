@@ -0,0 +1,734 @@
+package etl
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/telemetry"
+)
+
+// Job bundle export and import methods on AppService
+
+// ExportJobs builds a bundle of the selected jobs with the sources and destinations they use.
+// Secret config values and secret environment variables of jobs are replaced by
+// placeholders that have to be supplied on import.
+func (s Service) ExportJobs(_ context.Context, projectID string, query *dto.ExportJobsQuery) (*dto.JobBundle, error) {
+	ids, err := s.resolveExportJobIDs(projectID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.db.GetJobsByIDs(projectID, ids, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(query.JobIDs) > 0 && len(jobs) != len(ids) {
+		return nil, fmt.Errorf("%w: %d of the requested jobs do not exist in project_id[%s]", constants.ErrJobNotFound, len(ids)-len(jobs), projectID)
+	}
+
+	bundle := &dto.JobBundle{
+		Version:      constants.BundleVersion,
+		ExportedAt:   time.Now().UTC().Format(time.RFC3339),
+		ProjectID:    projectID,
+		Sources:      []dto.BundleSource{},
+		Destinations: []dto.BundleDestination{},
+		Jobs:         make([]dto.BundleJob, 0, len(jobs)),
+	}
+	exportedSources := make(map[int]bool)
+	exportedDestinations := make(map[int]bool)
+
+	for _, job := range jobs {
+		if job.Source == nil || job.Destination == nil {
+			return nil, fmt.Errorf("job source or destination details not found job_id[%d]", job.ID)
+		}
+
+		if !exportedSources[job.SourceID] {
+			config, secrets, err := exportConfig(constants.BundleKindSource, job.Source.Name, job.Source.Config)
+			if err != nil {
+				return nil, err
+			}
+			bundle.Sources = append(bundle.Sources, dto.BundleSource{
+				Name:    job.Source.Name,
+				Type:    job.Source.Type,
+				Version: job.Source.Version,
+				Config:  config,
+				Labels:  job.Source.Labels,
+			})
+			bundle.Secrets = append(bundle.Secrets, secrets...)
+			exportedSources[job.SourceID] = true
+		}
+
+		if !exportedDestinations[job.DestID] {
+			config, secrets, err := exportConfig(constants.BundleKindDestination, job.Destination.Name, job.Destination.Config)
+			if err != nil {
+				return nil, err
+			}
+			bundle.Destinations = append(bundle.Destinations, dto.BundleDestination{
+				Name:    job.Destination.Name,
+				Type:    job.Destination.DestType,
+				Version: job.Destination.Version,
+				Config:  config,
+				Labels:  job.Destination.Labels,
+			})
+			bundle.Secrets = append(bundle.Secrets, secrets...)
+			exportedDestinations[job.DestID] = true
+		}
+
+		bundleJob := dto.BundleJob{
+			Name:        job.Name,
+			Source:      job.Source.Name,
+			Destination: job.Destination.Name,
+			Frequency:   job.Frequency,
+			TimeZone:    job.TimeZone,
+			Active:      job.Active,
+			Labels:      job.Labels,
+		}
+		if job.StreamsConfig != "" {
+			if bundleJob.StreamsConfig, err = decodeBundleValue(job.StreamsConfig); err != nil {
+				return nil, fmt.Errorf("failed to parse streams config job_id[%d]: %s", job.ID, err)
+			}
+		}
+		if job.AdvancedSettings != nil {
+			if err := json.Unmarshal([]byte(*job.AdvancedSettings), &bundleJob.AdvancedSettings); err != nil {
+				return nil, fmt.Errorf("failed to parse advanced settings job_id[%d]: %s", job.ID, err)
+			}
+			bundle.Secrets = append(bundle.Secrets, exportDriverEnv(job.Name, bundleJob.AdvancedSettings)...)
+		}
+		bundle.Jobs = append(bundle.Jobs, bundleJob)
+	}
+
+	slices.Sort(bundle.Secrets)
+	logger.Infof("exported bundle project_id[%s] jobs[%d] sources[%d] destinations[%d]", projectID, len(bundle.Jobs), len(bundle.Sources), len(bundle.Destinations))
+	return bundle, nil
+}
+
+// resolveExportJobIDs returns the ids of the jobs to export: the requested ids, the jobs
+// matching the selector, or every job of the project.
+func (s Service) resolveExportJobIDs(projectID string, query *dto.ExportJobsQuery) ([]int, error) {
+	if len(query.JobIDs) > 0 {
+		ids := slices.Clone(query.JobIDs)
+		slices.Sort(ids)
+		return slices.Compact(ids), nil
+	}
+
+	selector, err := models.ParseLabelSelector(query.Selector)
+	if err != nil {
+		return nil, err
+	}
+	jobs, total, err := s.db.ListJobsByProjectID(projectID, database.ListOptions{Selector: selector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %s", err)
+	}
+	if total > int64(constants.MaxBulkJobs) {
+		return nil, fmt.Errorf("%w: %d jobs selected, at most %d can be exported at once", constants.ErrBulkLimitExceeded, total, constants.MaxBulkJobs)
+	}
+
+	ids := make([]int, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids, nil
+}
+
+// importedSource, importedDestination and importedJob pair a bundle entity with the item
+// reported for it and the model it is created or updated from.
+type importedSource struct {
+	item   dto.ImportItem
+	source *models.Source
+}
+
+type importedDestination struct {
+	item        dto.ImportItem
+	destination *models.Destination
+}
+
+type importedJob struct {
+	item        dto.ImportItem
+	job         dto.BundleJob
+	existing    *models.Job
+	source      *importedSource
+	destination *importedDestination
+}
+
+type importPlan struct {
+	sources      []*importedSource
+	destinations []*importedDestination
+	jobs         []*importedJob
+}
+
+// ImportJobs plans the import of a bundle into the project and applies it when the plan
+// has no conflicts and the request is not a dry run.
+func (s Service) ImportJobs(ctx context.Context, projectID string, req *dto.ImportJobsRequest, userID *int) (*dto.ImportJobsResponse, error) {
+	overlay := req.Overlay
+	if overlay == nil {
+		overlay = &dto.BundleOverlay{}
+	}
+
+	plan, err := s.planImport(projectID, req.Bundle, overlay, req.OnConflict == constants.ImportOnConflictUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ImportJobsResponse{}
+	for _, item := range plan.items() {
+		if item.Conflict != "" {
+			resp.Conflicts++
+		}
+	}
+	if resp.Conflicts > 0 || req.DryRun {
+		resp.Items = plan.items()
+		return resp, nil
+	}
+
+	logger.Infof("import bundle initiated project_id[%s] jobs[%d] sources[%d] destinations[%d]", projectID, len(plan.jobs), len(plan.sources), len(plan.destinations))
+	s.applyImport(ctx, projectID, plan, *userID)
+
+	resp.Applied = true
+	resp.Items = plan.items()
+	for _, item := range resp.Items {
+		if item.Error != "" {
+			resp.Failed++
+		}
+	}
+	return resp, nil
+}
+
+// planImport resolves every bundle entity against the target project without changing anything.
+func (s Service) planImport(projectID string, bundle *dto.JobBundle, overlay *dto.BundleOverlay, update bool) (*importPlan, error) {
+	existingSources, _, err := s.db.ListSourcesByProjectID(projectID, database.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources: %s", err)
+	}
+	existingDestinations, _, err := s.db.ListDestinationsByProjectID(projectID, database.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list destinations: %s", err)
+	}
+	existingJobs, _, err := s.db.ListJobsByProjectID(projectID, database.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %s", err)
+	}
+
+	hosts := hostReplacer(overlay.Hosts)
+	plan := &importPlan{}
+
+	sourcesByName := make(map[string]*importedSource)
+	for _, bs := range bundle.Sources {
+		name := overlay.NamePrefix + bs.Name
+		planned := &importedSource{item: dto.ImportItem{Kind: constants.BundleKindSource, Name: name, Action: constants.ImportActionCreate}}
+		plan.sources = append(plan.sources, planned)
+		if sourcesByName[bs.Name] != nil {
+			planned.item.Conflict = fmt.Sprintf("source '%s' is listed more than once in the bundle", bs.Name)
+			continue
+		}
+		sourcesByName[bs.Name] = planned
+
+		existing := findByName(existingSources, name, func(src *models.Source) string { return src.Name })
+		existingConfig := ""
+		if existing != nil {
+			existingConfig = existing.Config
+		}
+		config, conflict := importConfig(bs.Config, overlay.Sources[bs.Name], existingConfig, overlay.Secrets, hosts)
+		switch {
+		case existing != nil && !update:
+			conflict = fmt.Sprintf("source '%s' already exists", name)
+		case existing != nil && existing.Type != bs.Type:
+			conflict = fmt.Sprintf("source '%s' already exists with type '%s'", name, existing.Type)
+		case conflict == "":
+			if err := dto.ValidateSourceType(bs.Type); err != nil {
+				conflict = err.Error()
+			} else if err := models.ValidateLabels(bs.Labels); err != nil {
+				conflict = err.Error()
+			}
+		}
+		planned.item.Conflict = conflict
+		planned.source = &models.Source{Name: name, Type: bs.Type, Version: bs.Version, Config: config, Labels: bs.Labels, ProjectID: projectID}
+		if existing != nil {
+			planned.item.Action = constants.ImportActionUpdate
+			planned.item.ID = existing.ID
+			planned.source.ID = existing.ID
+		}
+	}
+
+	destinationsByName := make(map[string]*importedDestination)
+	for _, bd := range bundle.Destinations {
+		name := overlay.NamePrefix + bd.Name
+		planned := &importedDestination{item: dto.ImportItem{Kind: constants.BundleKindDestination, Name: name, Action: constants.ImportActionCreate}}
+		plan.destinations = append(plan.destinations, planned)
+		if destinationsByName[bd.Name] != nil {
+			planned.item.Conflict = fmt.Sprintf("destination '%s' is listed more than once in the bundle", bd.Name)
+			continue
+		}
+		destinationsByName[bd.Name] = planned
+
+		existing := findByName(existingDestinations, name, func(dest *models.Destination) string { return dest.Name })
+		existingConfig := ""
+		if existing != nil {
+			existingConfig = existing.Config
+		}
+		config, conflict := importConfig(bd.Config, overlay.Destinations[bd.Name], existingConfig, overlay.Secrets, hosts)
+		switch {
+		case existing != nil && !update:
+			conflict = fmt.Sprintf("destination '%s' already exists", name)
+		case existing != nil && existing.DestType != bd.Type:
+			conflict = fmt.Sprintf("destination '%s' already exists with type '%s'", name, existing.DestType)
+		case conflict == "":
+			if err := dto.ValidateDestinationType(bd.Type); err != nil {
+				conflict = err.Error()
+			} else if err := models.ValidateLabels(bd.Labels); err != nil {
+				conflict = err.Error()
+			}
+		}
+		planned.item.Conflict = conflict
+		planned.destination = &models.Destination{Name: name, DestType: bd.Type, Version: bd.Version, Config: config, Labels: bd.Labels, ProjectID: projectID}
+		if existing != nil {
+			planned.item.Action = constants.ImportActionUpdate
+			planned.item.ID = existing.ID
+			planned.destination.ID = existing.ID
+		}
+	}
+
+	jobNames := make(map[string]bool)
+	for _, bj := range bundle.Jobs {
+		name := overlay.NamePrefix + bj.Name
+		planned := &importedJob{
+			item:        dto.ImportItem{Kind: constants.BundleKindJob, Name: name, Action: constants.ImportActionCreate},
+			job:         bj,
+			source:      sourcesByName[bj.Source],
+			destination: destinationsByName[bj.Destination],
+		}
+		plan.jobs = append(plan.jobs, planned)
+
+		existing := findByName(existingJobs, name, func(job *models.Job) string { return job.Name })
+		switch {
+		case jobNames[bj.Name]:
+			planned.item.Conflict = fmt.Sprintf("job '%s' is listed more than once in the bundle", bj.Name)
+		case planned.source == nil:
+			planned.item.Conflict = fmt.Sprintf("source '%s' is not part of the bundle", bj.Source)
+		case planned.destination == nil:
+			planned.item.Conflict = fmt.Sprintf("destination '%s' is not part of the bundle", bj.Destination)
+		case bj.Frequency == "":
+			planned.item.Conflict = "frequency is required"
+		case existing != nil && !update:
+			planned.item.Conflict = fmt.Sprintf("job '%s' already exists", name)
+		default:
+			if err := models.ValidateLabels(bj.Labels); err != nil {
+				planned.item.Conflict = err.Error()
+			} else if err := dto.ValidateTimeZone(bj.TimeZone); err != nil {
+				planned.item.Conflict = err.Error()
+			} else if err := validateJobFrequency(bj.Frequency, existing); err != nil {
+				planned.item.Conflict = err.Error()
+			} else if settings, conflict := importDriverEnv(bj.Name, bj.AdvancedSettings, overlay.Secrets, existing); conflict != "" {
+				planned.item.Conflict = conflict
+			} else if err := settings.Validate(); err != nil {
+				planned.item.Conflict = err.Error()
+			} else {
+				planned.job.AdvancedSettings = settings
+			}
+		}
+		jobNames[bj.Name] = true
+
+		if existing != nil {
+			planned.existing = existing
+			planned.item.Action = constants.ImportActionUpdate
+			planned.item.ID = existing.ID
+		}
+	}
+
+	return plan, nil
+}
+
+// applyImport creates or updates the planned entities in dependency order. A failed entity
+// is reported on its item; jobs using a failed source or destination are skipped.
+func (s Service) applyImport(ctx context.Context, projectID string, plan *importPlan, userID int) {
+	user := &models.User{ID: userID}
+
+	for _, planned := range plan.sources {
+		src := planned.source
+		var err error
+		if src.ID == 0 {
+			src.CreatedByID, src.UpdatedByID, src.CreatedBy, src.UpdatedBy = user.ID, user.ID, user, user
+			if err = s.db.CreateSource(src); err == nil {
+				telemetry.TrackSourceCreation(ctx, src)
+			}
+		} else {
+			err = s.UpdateSource(ctx, projectID, src.ID, &dto.UpdateSourceRequest{Name: src.Name, Type: src.Type, Version: src.Version, Config: src.Config}, &userID)
+			if err == nil {
+				err = s.db.SetLabels(constants.SourceTable, projectID, src.ID, src.Labels)
+			}
+		}
+		planned.item.ID = src.ID
+		setImportError(&planned.item, err)
+	}
+
+	for _, planned := range plan.destinations {
+		dest := planned.destination
+		var err error
+		if dest.ID == 0 {
+			dest.CreatedByID, dest.UpdatedByID, dest.CreatedBy, dest.UpdatedBy = user.ID, user.ID, user, user
+			if err = s.db.CreateDestination(dest); err == nil {
+				telemetry.TrackDestinationCreation(ctx, dest)
+			}
+		} else {
+			err = s.UpdateDestination(ctx, dest.ID, projectID, &dto.UpdateDestinationRequest{Name: dest.Name, Type: dest.DestType, Version: dest.Version, Config: dest.Config}, &userID)
+			if err == nil {
+				err = s.db.SetLabels(constants.DestinationTable, projectID, dest.ID, dest.Labels)
+			}
+		}
+		planned.item.ID = dest.ID
+		setImportError(&planned.item, err)
+	}
+
+	for _, planned := range plan.jobs {
+		switch {
+		case planned.source.item.Error != "":
+			planned.item.Error = fmt.Sprintf("source '%s' was not imported", planned.source.item.Name)
+		case planned.destination.item.Error != "":
+			planned.item.Error = fmt.Sprintf("destination '%s' was not imported", planned.destination.item.Name)
+		default:
+			id, err := s.importJob(ctx, projectID, planned, userID)
+			planned.item.ID = id
+			setImportError(&planned.item, err)
+		}
+	}
+}
+
+// importJob creates the job of a bundle or updates the existing job of the same name.
+// The activation status of an existing job is left as is.
+func (s Service) importJob(ctx context.Context, projectID string, planned *importedJob, userID int) (int, error) {
+	bj := planned.job
+	streamsConfig, err := json.Marshal(bj.StreamsConfig)
+	if err != nil {
+		return 0, fmt.Errorf("failed to serialise streams_config: %s", err)
+	}
+	sourceID, destID := planned.source.source.ID, planned.destination.destination.ID
+
+	if planned.existing != nil {
+		err := s.UpdateJob(ctx, &dto.UpdateJobRequest{
+			Name:             planned.item.Name,
+			Source:           &dto.DriverConfig{ID: &sourceID},
+			Destination:      &dto.DriverConfig{ID: &destID},
+			Frequency:        bj.Frequency,
+			TimeZone:         &bj.TimeZone,
+			StreamsConfig:    string(streamsConfig),
+			Activate:         planned.existing.Active,
+			AdvancedSettings: bj.AdvancedSettings,
+		}, projectID, planned.existing.ID, &userID)
+		if err != nil && !errors.Is(err, constants.ErrSchedulePending) {
+			return planned.existing.ID, err
+		}
+		return planned.existing.ID, s.db.SetLabels(constants.JobTable, projectID, planned.existing.ID, bj.Labels)
+	}
+
+	job := &models.Job{
+		Name:          planned.item.Name,
+		SourceID:      sourceID,
+		DestID:        destID,
+		Source:        planned.source.source,
+		Destination:   planned.destination.destination,
+		Frequency:     bj.Frequency,
+		TimeZone:      bj.TimeZone,
+		StreamsConfig: string(streamsConfig),
+		Labels:        bj.Labels,
+		ProjectID:     projectID,
+	}
+	advancedSettings, err := marshalAdvancedSettings(bj.AdvancedSettings, nil)
+	if err != nil {
+		return 0, err
+	}
+	job.AdvancedSettings = advancedSettings
+	if err := s.createScheduledJob(ctx, job, bj.Active, userID); err != nil {
+		return 0, err
+	}
+
+	return job.ID, nil
+}
+
+func (p *importPlan) items() []dto.ImportItem {
+	items := make([]dto.ImportItem, 0, len(p.sources)+len(p.destinations)+len(p.jobs))
+	for _, planned := range p.sources {
+		items = append(items, planned.item)
+	}
+	for _, planned := range p.destinations {
+		items = append(items, planned.item)
+	}
+	for _, planned := range p.jobs {
+		items = append(items, planned.item)
+	}
+	return items
+}
+
+func setImportError(item *dto.ImportItem, err error) {
+	if err != nil {
+		logger.Errorf("failed to import %s '%s': %s", item.Kind, item.Name, err)
+		item.Error = err.Error()
+	}
+}
+
+func findByName[T any](items []T, name string, nameOf func(T) string) T {
+	var zero T
+	for _, item := range items {
+		if nameOf(item) == name {
+			return item
+		}
+	}
+	return zero
+}
+
+// exportConfig parses a driver config and replaces its secret values with placeholders.
+// It returns the keys of the placeholders it created.
+func exportConfig(kind, name, raw string) (map[string]any, []string, error) {
+	config := map[string]any{}
+	if raw != "" {
+		value, err := decodeBundleValue(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s config '%s': %s", kind, name, err)
+		}
+		if config, _ = value.(map[string]any); config == nil {
+			return nil, nil, fmt.Errorf("failed to parse %s config '%s': config is not an object", kind, name)
+		}
+	}
+
+	var secrets []string
+	var mask func(value any, path string) any
+	mask = func(value any, path string) any {
+		switch v := value.(type) {
+		case map[string]any:
+			for key, child := range v {
+				childPath := strings.TrimPrefix(path+"."+key, ".")
+				if s, ok := child.(string); ok && s != "" && isSecretConfigKey(key) {
+					secretKey := fmt.Sprintf("%s/%s/%s", kind, name, childPath)
+					secrets = append(secrets, secretKey)
+					v[key] = constants.SecretPlaceholderPrefix + secretKey + constants.SecretPlaceholderSuffix
+					continue
+				}
+				v[key] = mask(child, childPath)
+			}
+		case []any:
+			for i, child := range v {
+				v[i] = mask(child, path+"."+strconv.Itoa(i))
+			}
+		}
+		return value
+	}
+	mask(config, "")
+	return config, secrets, nil
+}
+
+// exportDriverEnv replaces the values of the secret environment variables of a job with
+// placeholders. It returns the keys of the placeholders it created.
+func exportDriverEnv(name string, settings *dto.AdvancedSettings) []string {
+	if settings == nil {
+		return nil
+	}
+	var secrets []string
+	for i, v := range settings.Env {
+		if !v.Secret {
+			continue
+		}
+		secretKey := driverEnvSecretKey(name, v.Name)
+		secrets = append(secrets, secretKey)
+		settings.Env[i].Value = constants.SecretPlaceholderPrefix + secretKey + constants.SecretPlaceholderSuffix
+	}
+	return secrets
+}
+
+// importDriverEnv resolves the secret placeholders of the environment variables of a bundle
+// job. A secret without an overlay value is left empty when the existing job has a value for
+// it, which the update keeps; otherwise it is reported as a conflict.
+func importDriverEnv(name string, settings *dto.AdvancedSettings, secrets map[string]string, existing *models.Job) (*dto.AdvancedSettings, string) {
+	if settings == nil || len(settings.Env) == 0 {
+		return settings, ""
+	}
+	var saved dto.AdvancedSettings
+	if existing != nil && existing.AdvancedSettings != nil {
+		_ = json.Unmarshal([]byte(*existing.AdvancedSettings), &saved)
+	}
+
+	resolved := *settings
+	resolved.Env = slices.Clone(settings.Env)
+	var missing []string
+	for i, v := range resolved.Env {
+		if !v.Secret {
+			continue
+		}
+		secretKey, ok := secretPlaceholderKey(v.Value)
+		if !ok {
+			if v.Value != "" {
+				continue
+			}
+			// bundles of earlier releases left secret values out
+			secretKey = driverEnvSecretKey(name, v.Name)
+		}
+		if secret, ok := secrets[secretKey]; ok {
+			resolved.Env[i].Value = secret
+			continue
+		}
+		resolved.Env[i].Value = ""
+		if !slices.ContainsFunc(saved.Env, func(e dto.DriverEnvVar) bool { return e.Secret && e.Name == v.Name }) {
+			missing = append(missing, secretKey)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return nil, fmt.Sprintf("missing values for secrets: %s", strings.Join(missing, ", "))
+	}
+	return &resolved, ""
+}
+
+func driverEnvSecretKey(jobName, envName string) string {
+	return fmt.Sprintf("%s/%s/env/%s", constants.BundleKindJob, jobName, envName)
+}
+
+// decodeBundleValue decodes a JSON document keeping integers as integers, so that they are
+// not rendered as floats when the bundle is written as YAML.
+func decodeBundleValue(raw string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var normalize func(value any) any
+	normalize = func(value any) any {
+		switch v := value.(type) {
+		case map[string]any:
+			for key, child := range v {
+				v[key] = normalize(child)
+			}
+		case []any:
+			for i, child := range v {
+				v[i] = normalize(child)
+			}
+		case json.Number:
+			if n, err := v.Int64(); err == nil {
+				return n
+			}
+			f, _ := v.Float64()
+			return f
+		}
+		return value
+	}
+	return normalize(value), nil
+}
+
+// importConfig merges the overlay patch into a bundle config, resolves its secret
+// placeholders and rewrites hostnames. A placeholder without an overlay value keeps the
+// value of the existing entity; otherwise it is reported as a conflict.
+func importConfig(config, patch map[string]any, existingRaw string, secrets map[string]string, hosts *strings.Replacer) (string, string) {
+	var existing any
+	if existingRaw != "" {
+		_ = json.Unmarshal([]byte(existingRaw), &existing)
+	}
+
+	var missing []string
+	var resolve func(value, existing any) any
+	resolve = func(value, existing any) any {
+		switch v := value.(type) {
+		case map[string]any:
+			existingMap, _ := existing.(map[string]any)
+			out := make(map[string]any, len(v))
+			for key, child := range v {
+				out[key] = resolve(child, existingMap[key])
+			}
+			return out
+		case []any:
+			existingSlice, _ := existing.([]any)
+			out := make([]any, len(v))
+			for i, child := range v {
+				var existingChild any
+				if i < len(existingSlice) {
+					existingChild = existingSlice[i]
+				}
+				out[i] = resolve(child, existingChild)
+			}
+			return out
+		case string:
+			key, ok := secretPlaceholderKey(v)
+			if !ok {
+				return hosts.Replace(v)
+			}
+			if secret, ok := secrets[key]; ok {
+				return secret
+			}
+			if current, ok := existing.(string); ok {
+				return current
+			}
+			missing = append(missing, key)
+			return v
+		}
+		return value
+	}
+
+	resolved := resolve(mergeConfig(config, patch), existing)
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return "", fmt.Sprintf("missing values for secrets: %s", strings.Join(missing, ", "))
+	}
+
+	b, err := json.Marshal(resolved)
+	if err != nil {
+		return "", fmt.Sprintf("failed to serialise config: %s", err)
+	}
+	return string(b), ""
+}
+
+// mergeConfig returns base with the values of patch applied; nested objects are merged key by key.
+func mergeConfig(base, patch map[string]any) map[string]any {
+	out := maps.Clone(base)
+	if out == nil {
+		out = map[string]any{}
+	}
+	for key, value := range patch {
+		baseChild, baseOK := out[key].(map[string]any)
+		patchChild, patchOK := value.(map[string]any)
+		if baseOK && patchOK {
+			out[key] = mergeConfig(baseChild, patchChild)
+			continue
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// hostReplacer rewrites hostnames, trying longer hostnames first so that a hostname
+// that contains another one is replaced as a whole.
+func hostReplacer(hosts map[string]string) *strings.Replacer {
+	from := slices.SortedFunc(maps.Keys(hosts), func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), strings.Compare(a, b))
+	})
+	pairs := make([]string, 0, 2*len(from))
+	for _, host := range from {
+		if host != "" {
+			pairs = append(pairs, host, hosts[host])
+		}
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+func secretPlaceholderKey(value string) (string, bool) {
+	if !strings.HasPrefix(value, constants.SecretPlaceholderPrefix) || !strings.HasSuffix(value, constants.SecretPlaceholderSuffix) {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(value, constants.SecretPlaceholderPrefix), constants.SecretPlaceholderSuffix), true
+}
+
+func isSecretConfigKey(key string) bool {
+	key = strings.ToLower(key)
+	return slices.ContainsFunc(constants.SecretConfigKeys, func(fragment string) bool {
+		return strings.Contains(key, fragment)
+	})
+}
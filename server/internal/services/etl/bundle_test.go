@@ -0,0 +1,238 @@
+package etl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+)
+
+func TestDriverEnvSecrets(t *testing.T) {
+	settings := &dto.AdvancedSettings{Env: []dto.DriverEnvVar{
+		{Name: "GOMEMLIMIT", Value: "3GiB"},
+		{Name: "API_TOKEN", Value: "encrypted", Secret: true},
+	}}
+	secrets := exportDriverEnv("orders", settings)
+	require.Equal(t, []string{"job/orders/env/API_TOKEN"}, secrets)
+	require.Equal(t, []dto.DriverEnvVar{
+		{Name: "GOMEMLIMIT", Value: "3GiB"},
+		{Name: "API_TOKEN", Value: "${secret:job/orders/env/API_TOKEN}", Secret: true},
+	}, settings.Env)
+
+	savedSecret := `{"env":[{"name":"API_TOKEN","value":"saved","secret":true}]}`
+	savedPlain := `{"env":[{"name":"API_TOKEN","value":"plain"}]}`
+	tests := []struct {
+		name     string
+		env      []dto.DriverEnvVar
+		secrets  map[string]string
+		existing *models.Job
+		value    string
+		conflict string
+	}{
+		{
+			name:    "overlay value",
+			env:     settings.Env,
+			secrets: map[string]string{"job/orders/env/API_TOKEN": "token"},
+			value:   "token",
+		},
+		{
+			name:     "new job without a value",
+			env:      settings.Env,
+			conflict: "missing values for secrets: job/orders/env/API_TOKEN",
+		},
+		{
+			name:     "existing job keeps its value",
+			env:      settings.Env,
+			existing: &models.Job{AdvancedSettings: &savedSecret},
+			value:    "",
+		},
+		{
+			name:     "existing job without the secret",
+			env:      settings.Env,
+			existing: &models.Job{AdvancedSettings: &savedPlain},
+			conflict: "missing values for secrets: job/orders/env/API_TOKEN",
+		},
+		{
+			name:     "empty value of an earlier export",
+			env:      []dto.DriverEnvVar{{Name: "API_TOKEN", Secret: true}},
+			conflict: "missing values for secrets: job/orders/env/API_TOKEN",
+		},
+		{
+			name:    "empty value resolved by its key",
+			env:     []dto.DriverEnvVar{{Name: "API_TOKEN", Secret: true}},
+			secrets: map[string]string{"job/orders/env/API_TOKEN": "token"},
+			value:   "token",
+		},
+		{
+			name:  "literal secret value",
+			env:   []dto.DriverEnvVar{{Name: "API_TOKEN", Value: "literal", Secret: true}},
+			value: "literal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundled := &dto.AdvancedSettings{Env: tt.env}
+			resolved, conflict := importDriverEnv("orders", bundled, tt.secrets, tt.existing)
+			require.Equal(t, tt.conflict, conflict)
+			if tt.conflict != "" {
+				return
+			}
+			secret := resolved.Env[len(resolved.Env)-1]
+			require.Equal(t, "API_TOKEN", secret.Name)
+			require.Equal(t, tt.value, secret.Value)
+			// the bundle itself is left as is
+			require.Equal(t, tt.env, bundled.Env)
+		})
+	}
+}
+
+func TestExportConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    map[string]any
+		secrets []string
+		err     string
+	}{
+		{
+			name: "empty config",
+			want: map[string]any{},
+		},
+		{
+			name: "nested secrets",
+			raw:  `{"host":"db","port":5432,"password":"pw","ssl":{"client_key_password":"key","mode":"require"},"replicas":[{"host":"r1","password":"r1pw"}],"api_token":""}`,
+			want: map[string]any{
+				"host":      "db",
+				"port":      int64(5432),
+				"password":  "${secret:source/pg/password}",
+				"ssl":       map[string]any{"client_key_password": "${secret:source/pg/ssl.client_key_password}", "mode": "require"},
+				"replicas":  []any{map[string]any{"host": "r1", "password": "${secret:source/pg/replicas.0.password}"}},
+				"api_token": "",
+			},
+			secrets: []string{"source/pg/password", "source/pg/ssl.client_key_password", "source/pg/replicas.0.password"},
+		},
+		{
+			name: "large numbers keep their precision",
+			raw:  `{"server_id":9007199254740993,"ratio":0.5}`,
+			want: map[string]any{"server_id": int64(9007199254740993), "ratio": 0.5},
+		},
+		{
+			name: "not an object",
+			raw:  `["host"]`,
+			err:  "failed to parse source config 'pg': config is not an object",
+		},
+		{
+			name: "invalid json",
+			raw:  `{"host"`,
+			err:  "failed to parse source config 'pg'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, secrets, err := exportConfig("source", "pg", tt.raw)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, config)
+			require.ElementsMatch(t, tt.secrets, secrets)
+		})
+	}
+}
+
+func TestImportConfig(t *testing.T) {
+	config := func() map[string]any {
+		return map[string]any{
+			"host":     "db.staging.internal",
+			"password": "${secret:source/pg/password}",
+			"ssl":      map[string]any{"mode": "require", "key_password": "${secret:source/pg/ssl.key_password}"},
+			"replicas": []any{"db-replica.staging.internal"},
+		}
+	}
+	hosts := hostReplacer(map[string]string{"staging.internal": "prod.internal"})
+	tests := []struct {
+		name     string
+		patch    map[string]any
+		existing string
+		secrets  map[string]string
+		want     string
+		conflict string
+	}{
+		{
+			name:    "overlay secrets and hosts",
+			secrets: map[string]string{"source/pg/password": "pw", "source/pg/ssl.key_password": "key"},
+			want:    `{"host":"db.prod.internal","password":"pw","replicas":["db-replica.prod.internal"],"ssl":{"key_password":"key","mode":"require"}}`,
+		},
+		{
+			name:     "existing values of missing secrets",
+			existing: `{"password":"saved","ssl":{"key_password":"saved-key"}}`,
+			secrets:  map[string]string{"source/pg/password": "pw"},
+			want:     `{"host":"db.prod.internal","password":"pw","replicas":["db-replica.prod.internal"],"ssl":{"key_password":"saved-key","mode":"require"}}`,
+		},
+		{
+			name:     "patch merged key by key",
+			patch:    map[string]any{"ssl": map[string]any{"mode": "verify-full"}, "password": "literal"},
+			existing: `{"ssl":{"key_password":"saved-key"}}`,
+			want:     `{"host":"db.prod.internal","password":"literal","replicas":["db-replica.prod.internal"],"ssl":{"key_password":"saved-key","mode":"verify-full"}}`,
+		},
+		{
+			name:     "missing secrets",
+			existing: `{"password":"saved"}`,
+			conflict: "missing values for secrets: source/pg/ssl.key_password",
+		},
+		{
+			name:     "missing secrets of a new entity",
+			conflict: "missing values for secrets: source/pg/password, source/pg/ssl.key_password",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflict := importConfig(config(), tt.patch, tt.existing, tt.secrets, hosts)
+			require.Equal(t, tt.conflict, conflict)
+			if tt.conflict == "" {
+				require.JSONEq(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHostReplacer(t *testing.T) {
+	tests := []struct {
+		name  string
+		hosts map[string]string
+		value string
+		want  string
+	}{
+		{
+			name:  "no hosts",
+			value: "db.staging.internal",
+			want:  "db.staging.internal",
+		},
+		{
+			name:  "longer hostname first",
+			hosts: map[string]string{"db": "pg", "db.staging.internal": "db.prod.internal"},
+			value: "db.staging.internal:5432,db:5432",
+			want:  "db.prod.internal:5432,pg:5432",
+		},
+		{
+			name:  "replaced values are not replaced again",
+			hosts: map[string]string{"a.internal": "b.internal", "b.internal": "c.internal"},
+			value: "a.internal b.internal",
+			want:  "b.internal c.internal",
+		},
+		{
+			name:  "empty hostname ignored",
+			hosts: map[string]string{"": "x"},
+			value: "db",
+			want:  "db",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, hostReplacer(tt.hosts).Replace(tt.value))
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package etl
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+// Schedule preview and run calendar methods on AppService
+
+// PreviewSchedule computes the next fire times of a frequency that is not saved yet. Without
+// a time zone the frequency is evaluated in the project default, as for a job. The fire times
+// skip the maintenance windows of the project and of the source of the request.
+func (s Service) PreviewSchedule(_ context.Context, projectID string, req *dto.SchedulePreviewRequest) (*dto.SchedulePreviewResponse, error) {
+	settings, err := s.db.GetProjectSettingsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := s.db.ListMaintenanceWindows(projectID)
+	if err != nil {
+		return nil, err
+	}
+	// a job that is not saved yet has no windows of its own
+	job := &models.Job{}
+	if req.SourceID != nil {
+		job.SourceID = *req.SourceID
+	}
+	timeZone := effectiveTimeZone(req.TimeZone, settings.DefaultTimeZone)
+	schedule, err := buildJobSchedule(req.Frequency, timeZone, jobMaintenanceWindows(windows, job), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	resp := schedulePreview(req.Frequency, timeZone, schedule)
+	resp.FireTimes = localFireTimes(schedule, timeZone, time.Now(), req.Count)
+	return resp, nil
+}
+
+// GetJobSchedulePreview returns the next fire times of the schedule of a job. They are taken
+// from its temporal schedule when that reports enough of them, and computed from the job's
+// frequency otherwise, e.g. while the schedule is missing.
+func (s Service) GetJobSchedulePreview(ctx context.Context, projectID string, jobID, count int) (*dto.SchedulePreviewResponse, error) {
+	job, err := s.getProjectJob(projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := s.jobSchedule(job)
+	if err != nil {
+		return nil, err
+	}
+	timeZone := schedule.TimeZone
+	resp := schedulePreview(job.Frequency, timeZone, schedule)
+	resp.JobID = job.ID
+	resp.Paused = !job.Active
+	if utils.IsUnscheduledFrequency(job.Frequency) {
+		return resp, nil
+	}
+
+	desc, err := s.temporal.DescribeSchedule(ctx, projectID, jobID)
+	if err == nil && len(desc.Info.NextActionTimes) >= count {
+		location, _ := time.LoadLocation(timeZone)
+		for _, at := range desc.Info.NextActionTimes[:count] {
+			resp.FireTimes = append(resp.FireTimes, at.In(location).Format(time.RFC3339))
+		}
+		resp.Source = constants.SchedulePreviewSourceTemporal
+		if desc.Schedule.State != nil {
+			resp.Paused = desc.Schedule.State.Paused
+		}
+		return resp, nil
+	}
+	if err != nil {
+		logger.Debugf("failed to describe schedule of job_id[%d], computing fire times from its frequency: %s", jobID, err)
+	}
+	resp.FireTimes = localFireTimes(schedule, timeZone, time.Now(), count)
+	return resp, nil
+}
+
+// GetRunCalendar lists the scheduled runs of the active jobs of a project in the coming
+// window, grouped by source, with the spans in which runs of different jobs of a source may
+// overlap. Runs are computed from the frequencies and maintenance windows of the jobs and end
+// after the jitter of their schedule and the duration of the last completed sync of their job.
+func (s Service) GetRunCalendar(ctx context.Context, projectID string, window time.Duration) (*dto.RunCalendarResponse, error) {
+	jobs, err := s.db.ListActiveJobsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.db.GetProjectSettingsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := s.db.ListMaintenanceWindows(projectID)
+	if err != nil {
+		return nil, err
+	}
+	durations, err := s.lastSyncDurations(ctx, projectID)
+	if err != nil {
+		// runs without an estimated duration still show where fire times collide
+		logger.Warnf("failed to estimate run durations project_id[%s]: %s", projectID, err)
+	}
+
+	from := time.Now().UTC().Truncate(time.Second)
+	to := from.Add(window)
+	bySource := map[int]*dto.RunCalendarSource{}
+	spans := map[int][]runSpan{}
+	for _, job := range jobs {
+		if job.Source == nil || utils.IsUnscheduledFrequency(job.Frequency) {
+			continue
+		}
+		timeZone := effectiveTimeZone(job.TimeZone, settings.DefaultTimeZone)
+		schedule, err := buildJobSchedule(job.Frequency, timeZone, jobMaintenanceWindows(windows, job), from)
+		if err != nil {
+			logger.Warnf("skipping job_id[%d] in run calendar: %s", job.ID, err)
+			continue
+		}
+		location, err := time.LoadLocation(timeZone)
+		if err != nil {
+			logger.Warnf("skipping job_id[%d] in run calendar: %s", job.ID, err)
+			continue
+		}
+
+		source, ok := bySource[job.Source.ID]
+		if !ok {
+			source = &dto.RunCalendarSource{
+				SourceID:   job.Source.ID,
+				SourceName: job.Source.Name,
+				SourceType: job.Source.Type,
+				Runs:       []dto.RunCalendarRun{},
+				Overlaps:   []dto.RunCalendarOverlap{},
+			}
+			bySource[job.Source.ID] = source
+		}
+		duration := durations[job.ID]
+		at := from.In(location)
+		for range constants.RunCalendarMaxRunsPerJob {
+			at = schedule.Next(at)
+			if at.IsZero() || at.After(to) {
+				break
+			}
+			end := at.Add(schedule.Jitter + duration)
+			source.Runs = append(source.Runs, dto.RunCalendarRun{
+				JobID:             job.ID,
+				JobName:           job.Name,
+				StartTime:         at.UTC().Format(time.RFC3339),
+				EndTime:           end.UTC().Format(time.RFC3339),
+				Jitter:            utils.Ternary(schedule.Jitter > 0, schedule.Jitter.String(), "").(string),
+				EstimatedDuration: utils.Ternary(duration > 0, duration.String(), "").(string),
+			})
+			spans[job.Source.ID] = append(spans[job.Source.ID], runSpan{jobID: job.ID, start: at, end: end})
+		}
+	}
+
+	resp := &dto.RunCalendarResponse{
+		From:    from.Format(time.RFC3339),
+		To:      to.Format(time.RFC3339),
+		Sources: []dto.RunCalendarSource{},
+	}
+	for sourceID, source := range bySource {
+		slices.SortStableFunc(source.Runs, func(a, b dto.RunCalendarRun) int {
+			return strings.Compare(a.StartTime, b.StartTime)
+		})
+		source.Overlaps = runOverlaps(spans[sourceID])
+		resp.Sources = append(resp.Sources, *source)
+	}
+	slices.SortFunc(resp.Sources, func(a, b dto.RunCalendarSource) int {
+		return cmp.Or(strings.Compare(a.SourceName, b.SourceName), cmp.Compare(a.SourceID, b.SourceID))
+	})
+	return resp, nil
+}
+
+// runSpan is the time a run of a job may take up
+type runSpan struct {
+	jobID      int
+	start, end time.Time
+}
+
+// runOverlaps merges overlapping runs into spans and returns the spans taken up by more than
+// one job. Runs that fire at the same time overlap even without an estimated duration, a run
+// that starts as another one ends does not.
+func runOverlaps(spans []runSpan) []dto.RunCalendarOverlap {
+	overlaps := []dto.RunCalendarOverlap{}
+	slices.SortFunc(spans, func(a, b runSpan) int {
+		return a.start.Compare(b.start)
+	})
+	flush := func(start, end time.Time, jobIDs []int) {
+		if len(jobIDs) > 1 {
+			slices.Sort(jobIDs)
+			overlaps = append(overlaps, dto.RunCalendarOverlap{
+				StartTime: start.UTC().Format(time.RFC3339),
+				EndTime:   end.UTC().Format(time.RFC3339),
+				JobIDs:    jobIDs,
+			})
+		}
+	}
+	var start, end time.Time
+	var jobIDs []int
+	for i, span := range spans {
+		if i > 0 && (span.start.After(end) || span.start.Equal(end) && end.After(start)) {
+			flush(start, end, jobIDs)
+			jobIDs = nil
+		}
+		if len(jobIDs) == 0 {
+			start, end = span.start, span.end
+		}
+		if span.end.After(end) {
+			end = span.end
+		}
+		if !slices.Contains(jobIDs, span.jobID) {
+			jobIDs = append(jobIDs, span.jobID)
+		}
+	}
+	flush(start, end, jobIDs)
+	return overlaps
+}
+
+// lastSyncDurations returns how long the last completed scheduled sync of each job of a
+// project took, reading at most constants.RunCalendarHistoryPages pages of syncs
+func (s Service) lastSyncDurations(ctx context.Context, projectID string) (map[int]time.Duration, error) {
+	query := fmt.Sprintf(
+		"WorkflowId BETWEEN 'sync-%s-' AND 'sync-%s-z' AND OperationType != '%s' AND ExecutionStatus = 'Completed'",
+		projectID, projectID, temporal.ClearDestination,
+	)
+	durations := map[int]time.Duration{}
+	var nextPageToken []byte
+	for range constants.RunCalendarHistoryPages {
+		resp, err := s.temporal.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         query,
+			PageSize:      int32(constants.DefaultListWorkflowPageSize),
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return durations, fmt.Errorf("failed to list workflows: %s", err)
+		}
+		for _, execution := range resp.Executions {
+			jobID, ok := utils.ExtractJobIDFromWorkflowID(execution.Execution.WorkflowId, projectID)
+			if !ok || execution.CloseTime == nil || strings.HasPrefix(execution.Execution.WorkflowId, temporal.AdHocWorkflowPrefix(projectID, jobID)) {
+				continue
+			}
+			if _, exists := durations[jobID]; !exists {
+				durations[jobID] = execution.CloseTime.AsTime().Sub(execution.StartTime.AsTime()).Round(time.Second)
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		nextPageToken = resp.NextPageToken
+	}
+	return durations, nil
+}
+
+// schedulePreview describes a parsed schedule, without its fire times
+func schedulePreview(frequency, timeZone string, schedule *utils.Schedule) *dto.SchedulePreviewResponse {
+	return &dto.SchedulePreviewResponse{
+		Frequency:    frequency,
+		ScheduleMode: schedule.Mode,
+		TimeZone:     timeZone,
+		Jitter:       utils.Ternary(schedule.Jitter > 0, schedule.Jitter.String(), "").(string),
+		Source:       constants.SchedulePreviewSourceLocal,
+		FireTimes:    []string{},
+	}
+}
+
+// localFireTimes computes up to count fire times of a schedule after from, on the wall clock
+// of its time zone
+func localFireTimes(schedule *utils.Schedule, timeZone string, from time.Time, count int) []string {
+	times := []string{}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return times
+	}
+	at := from.In(location)
+	for range count {
+		at = schedule.Next(at)
+		if at.IsZero() {
+			break
+		}
+		times = append(times, at.Format(time.RFC3339))
+	}
+	return times
+}
@@ -0,0 +1,230 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/telemetry"
+)
+
+// Job clone and template methods on AppService
+
+// CloneJob copies the streams config, frequency, advanced settings and labels of a job
+// into a new job, optionally pointing it at a different source or destination.
+func (s Service) CloneJob(ctx context.Context, projectID string, jobID int, req *dto.CloneJobRequest, userID *int) (*dto.CloneJobResponse, error) {
+	base, err := s.getProjectJob(projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	source := base.Source
+	if req.SourceID != nil {
+		if source, err = s.getCloneSource(projectID, *req.SourceID, base); err != nil {
+			return nil, err
+		}
+	}
+
+	dest := base.Destination
+	if req.DestinationID != nil {
+		if dest, err = s.getCloneDestination(projectID, *req.DestinationID); err != nil {
+			return nil, err
+		}
+	}
+
+	job, err := s.cloneJob(ctx, base, req.Name, source, dest, *userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CloneJobResponse{ID: job.ID, Name: job.Name}, nil
+}
+
+// CreateJobsFromTemplate creates one job per source from a base job. Job names are built
+// from the naming pattern; each source reports its own result.
+func (s Service) CreateJobsFromTemplate(ctx context.Context, projectID string, jobID int, req *dto.JobTemplateRequest, userID *int) (*dto.JobTemplateResponse, error) {
+	base, err := s.getProjectJob(projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	dest := base.Destination
+	if req.DestinationID != nil {
+		if dest, err = s.getCloneDestination(projectID, *req.DestinationID); err != nil {
+			return nil, err
+		}
+	}
+
+	pattern := req.NamePattern
+	if pattern == "" {
+		pattern = constants.DefaultJobTemplateNamePattern
+	}
+
+	resp := &dto.JobTemplateResponse{Total: len(req.SourceIDs), Results: make([]dto.JobTemplateResult, 0, len(req.SourceIDs))}
+	for i, sourceID := range req.SourceIDs {
+		result := dto.JobTemplateResult{SourceID: sourceID}
+
+		source, err := s.getCloneSource(projectID, sourceID, base)
+		if err == nil {
+			result.JobName = templateJobName(pattern, base, source, i+1)
+			var job *models.Job
+			if job, err = s.cloneJob(ctx, base, result.JobName, source, dest, *userID); err == nil {
+				result.JobID = job.ID
+			}
+		}
+
+		if err != nil {
+			logger.Errorf("failed to create job from template job_id[%d] source_id[%d]: %s", base.ID, sourceID, err)
+			result.Error = err.Error()
+			resp.Failed++
+		} else {
+			result.Success = true
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+// cloneJob creates a job from base with the given name, source and destination.
+func (s Service) cloneJob(ctx context.Context, base *models.Job, name string, source *models.Source, dest *models.Destination, userID int) (*models.Job, error) {
+	var advancedSettings *string
+	if base.AdvancedSettings != nil {
+		settings := *base.AdvancedSettings
+		advancedSettings = &settings
+	}
+
+	job := &models.Job{
+		Name:             name,
+		SourceID:         source.ID,
+		DestID:           dest.ID,
+		Source:           source,
+		Destination:      dest,
+		Frequency:        base.Frequency,
+		TimeZone:         base.TimeZone,
+		StreamsConfig:    base.StreamsConfig,
+		AdvancedSettings: advancedSettings,
+		Labels:           maps.Clone(base.Labels),
+		ProjectID:        base.ProjectID,
+	}
+	if err := s.createScheduledJob(ctx, job, true, userID); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("job_id[%d] cloned from job_id[%d] source_id[%d] dest_id[%d]", job.ID, base.ID, source.ID, dest.ID)
+	return job, nil
+}
+
+// createScheduledJob saves a new job with an empty state and queues the creation of its
+// schedule, paused unless the job is active, in the same transaction. A schedule that cannot
+// be created right away is left to the outbox dispatcher; the job reports it as pending.
+func (s Service) createScheduledJob(ctx context.Context, job *models.Job, active bool, userID int) error {
+	unique, err := s.db.IsJobNameUniqueInProject(ctx, job.ProjectID, job.Name)
+	if err != nil {
+		return fmt.Errorf("failed to check job name uniqueness: %s", err)
+	}
+	if !unique {
+		return fmt.Errorf("%w: job name '%s' is not unique", constants.ErrJobNameNotUnique, job.Name)
+	}
+
+	user := &models.User{ID: userID}
+	job.Active = active
+	job.State = "{}"
+	job.CreatedByID = user.ID
+	job.UpdatedByID = user.ID
+	job.CreatedBy = user
+	job.UpdatedBy = user
+	operations := []string{constants.ScheduleOpCreate}
+	if !active {
+		operations = append(operations, constants.ScheduleOpPause)
+	}
+	operations = append(operations, runOnceOperations(job.Frequency, active)...)
+	err = s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.CreateJob(job); err != nil {
+			return fmt.Errorf("failed to create job: %s", err)
+		}
+		if err := tx.SaveJobRevision(&models.JobRevision{JobID: job.ID, Change: constants.JobRevisionChangeCreate, CreatedByID: user.ID}); err != nil {
+			return err
+		}
+		return tx.EnqueueScheduleOperations(job.ProjectID, job.ID, operations...)
+	})
+	if err != nil {
+		return err
+	}
+
+	telemetry.TrackJobCreation(ctx, job)
+	if err := s.applyScheduleOperations(ctx, job.ID); err != nil {
+		logger.Warnf("job_id[%d] created, %s", job.ID, err)
+	}
+	return nil
+}
+
+// getProjectJob fetches a job with decrypted source and destination configs and
+// verifies that it belongs to the project.
+func (s Service) getProjectJob(projectID string, jobID int) (*models.Job, error) {
+	job, err := s.db.GetJobByID(jobID, true)
+	if err != nil {
+		if errors.Is(err, constants.ErrJobNotFound) {
+			return nil, fmt.Errorf("%w: %v", constants.ErrJobNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get job: %s", err)
+	}
+	if job.ProjectID != projectID {
+		return nil, fmt.Errorf("%w: job not found id[%d] project_id[%s]", constants.ErrJobNotFound, jobID, projectID)
+	}
+	if job.Source == nil || job.Destination == nil {
+		return nil, fmt.Errorf("job source or destination details not found")
+	}
+	return job, nil
+}
+
+// getCloneSource fetches a source to retarget a cloned job to. The streams config of the
+// base job was discovered with its source driver, so the new source must be of the same type.
+func (s Service) getCloneSource(projectID string, sourceID int, base *models.Job) (*models.Source, error) {
+	source, err := s.db.GetSourceByID(sourceID)
+	if err != nil {
+		if errors.Is(err, constants.ErrSourceNotFound) {
+			return nil, fmt.Errorf("%w: %v", constants.ErrSourceNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get source: %s", err)
+	}
+	if source.ProjectID != projectID {
+		return nil, fmt.Errorf("%w: source not found id[%d] project_id[%s]", constants.ErrSourceNotFound, sourceID, projectID)
+	}
+	if source.Type != base.Source.Type {
+		return nil, fmt.Errorf("%w: source '%s' is of type '%s' but the job streams were discovered from a '%s' source",
+			constants.ErrSourceTypeMismatch, source.Name, source.Type, base.Source.Type)
+	}
+	return source, nil
+}
+
+func (s Service) getCloneDestination(projectID string, destID int) (*models.Destination, error) {
+	dest, err := s.db.GetDestinationByID(destID)
+	if err != nil {
+		if errors.Is(err, constants.ErrDestinationNotFound) {
+			return nil, fmt.Errorf("%w: %v", constants.ErrDestinationNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get destination: %s", err)
+	}
+	if dest.ProjectID != projectID {
+		return nil, fmt.Errorf("%w: destination not found id[%d] project_id[%s]", constants.ErrDestinationNotFound, destID, projectID)
+	}
+	return dest, nil
+}
+
+// templateJobName expands the {job}, {source}, {source_id} and {index} placeholders of a naming pattern.
+func templateJobName(pattern string, base *models.Job, source *models.Source, index int) string {
+	return strings.NewReplacer(
+		"{job}", base.Name,
+		"{source}", source.Name,
+		"{source_id}", strconv.Itoa(source.ID),
+		"{index}", strconv.Itoa(index),
+	).Replace(pattern)
+}
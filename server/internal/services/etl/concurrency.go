@@ -0,0 +1,574 @@
+package etl
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+// Concurrency cap and run queue methods on AppService
+
+// concurrencyCaps are the caps of a project and of its capped sources and destinations
+type concurrencyCaps struct {
+	project      *models.ProjectSettings
+	sources      map[int]*models.Source
+	destinations map[int]*models.Destination
+}
+
+// syncUsage counts the syncs running against the project and each source and destination
+type syncUsage struct {
+	project      int
+	sources      map[int]int
+	destinations map[int]int
+}
+
+// runningSync is a running sync of a job of the project
+type runningSync struct {
+	job        *models.Job
+	workflowID string
+	runID      string
+	start      time.Time
+	adHoc      bool
+}
+
+func newSyncUsage() *syncUsage {
+	return &syncUsage{sources: map[int]int{}, destinations: map[int]int{}}
+}
+
+func (u *syncUsage) add(job *models.Job) {
+	u.project++
+	u.sources[job.SourceID]++
+	u.destinations[job.DestID]++
+}
+
+// loadConcurrencyCaps reads the caps of a project
+func (s Service) loadConcurrencyCaps(projectID string) (*concurrencyCaps, error) {
+	settings, err := s.db.GetProjectSettingsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := s.db.ListCappedSources(projectID)
+	if err != nil {
+		return nil, err
+	}
+	destinations, err := s.db.ListCappedDestinations(projectID)
+	if err != nil {
+		return nil, err
+	}
+	caps := &concurrencyCaps{project: settings, sources: map[int]*models.Source{}, destinations: map[int]*models.Destination{}}
+	for _, source := range sources {
+		caps.sources[source.ID] = source
+	}
+	for _, destination := range destinations {
+		caps.destinations[destination.ID] = destination
+	}
+	return caps, nil
+}
+
+// syncCapped reports whether a sync cap applies to the syncs of a job
+func (c *concurrencyCaps) syncCapped(job *models.Job) bool {
+	return c.project.MaxConcurrentSyncs > 0 ||
+		(c.sources[job.SourceID] != nil && c.sources[job.SourceID].MaxConcurrentSyncs > 0) ||
+		(c.destinations[job.DestID] != nil && c.destinations[job.DestID].MaxConcurrentSyncs > 0)
+}
+
+// sharesCappedScope reports whether a queued sync counts against a sync cap of a job
+func (c *concurrencyCaps) sharesCappedScope(entry *models.RunQueueEntry, job *models.Job) bool {
+	if c.project.MaxConcurrentSyncs > 0 {
+		return true
+	}
+	if source := c.sources[job.SourceID]; source != nil && source.MaxConcurrentSyncs > 0 && entry.SourceID != nil && *entry.SourceID == job.SourceID {
+		return true
+	}
+	destination := c.destinations[job.DestID]
+	return destination != nil && destination.MaxConcurrentSyncs > 0 && entry.DestinationID != nil && *entry.DestinationID == job.DestID
+}
+
+// blockedSync returns which cap keeps a sync of a job from starting with the given syncs
+// running, empty when every cap has room
+func (c *concurrencyCaps) blockedSync(job *models.Job, usage *syncUsage) string {
+	if limit := c.project.MaxConcurrentSyncs; limit > 0 && usage.project >= limit {
+		return fmt.Sprintf("project runs %d of %d syncs", usage.project, limit)
+	}
+	if source := c.sources[job.SourceID]; source != nil && source.MaxConcurrentSyncs > 0 && usage.sources[job.SourceID] >= source.MaxConcurrentSyncs {
+		return fmt.Sprintf("source '%s' runs %d of %d syncs", source.Name, usage.sources[job.SourceID], source.MaxConcurrentSyncs)
+	}
+	if destination := c.destinations[job.DestID]; destination != nil && destination.MaxConcurrentSyncs > 0 && usage.destinations[job.DestID] >= destination.MaxConcurrentSyncs {
+		return fmt.Sprintf("destination '%s' runs %d of %d syncs", destination.Name, usage.destinations[job.DestID], destination.MaxConcurrentSyncs)
+	}
+	return ""
+}
+
+// runningSyncs lists the running syncs of a project in the order they started, leaving out
+// clear-destination runs and runs of jobs that no longer exist
+func (s Service) runningSyncs(ctx context.Context, projectID string) ([]runningSync, error) {
+	query := fmt.Sprintf(
+		"WorkflowId BETWEEN 'sync-%s-' AND 'sync-%s-z' AND OperationType != '%s' AND ExecutionStatus = 'Running'",
+		projectID, projectID, temporal.ClearDestination,
+	)
+	var runs []runningSync
+	var jobIDs []int
+	var nextPageToken []byte
+	for range constants.ConcurrencyHistoryPages {
+		resp, err := s.temporal.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         query,
+			PageSize:      int32(constants.DefaultListWorkflowPageSize),
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list running syncs: %s", err)
+		}
+		for _, execution := range resp.Executions {
+			jobID, ok := utils.ExtractJobIDFromWorkflowID(execution.Execution.WorkflowId, projectID)
+			if !ok {
+				continue
+			}
+			runs = append(runs, runningSync{
+				job:        &models.Job{ID: jobID},
+				workflowID: execution.Execution.WorkflowId,
+				runID:      execution.Execution.RunId,
+				start:      execution.StartTime.AsTime(),
+				adHoc:      strings.HasPrefix(execution.Execution.WorkflowId, temporal.AdHocWorkflowPrefix(projectID, jobID)),
+			})
+			jobIDs = append(jobIDs, jobID)
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		nextPageToken = resp.NextPageToken
+	}
+
+	jobs, err := s.db.GetJobsByIDs(projectID, jobIDs, false)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]*models.Job, len(jobs))
+	for _, job := range jobs {
+		byID[job.ID] = job
+	}
+	runs = slices.DeleteFunc(runs, func(run runningSync) bool { return byID[run.job.ID] == nil })
+	for i := range runs {
+		runs[i].job = byID[runs[i].job.ID]
+	}
+	slices.SortStableFunc(runs, func(a, b runningSync) int { return a.start.Compare(b.start) })
+	return runs, nil
+}
+
+// syncBlockedReason returns why a sync of a job cannot start now: a cap it counts against is
+// full, or syncs queued before it wait for one of its capped scopes. It is empty when the
+// sync can start or the job is running already.
+func (s Service) syncBlockedReason(ctx context.Context, job *models.Job) (string, error) {
+	caps, err := s.loadConcurrencyCaps(job.ProjectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read concurrency caps: %s", err)
+	}
+	if !caps.syncCapped(job) {
+		return "", nil
+	}
+	running, err := s.runningSyncs(ctx, job.ProjectID)
+	if err != nil {
+		return "", err
+	}
+	usage := newSyncUsage()
+	for _, run := range running {
+		if run.job.ID == job.ID {
+			return "", nil
+		}
+		usage.add(run.job)
+	}
+	if reason := caps.blockedSync(job, usage); reason != "" {
+		return reason, nil
+	}
+
+	queue, err := s.db.ListRunQueue(job.ProjectID)
+	if err != nil {
+		return "", err
+	}
+	ahead := 0
+	for _, entry := range queue {
+		if entry.Kind == constants.RunKindSync && *entry.JobID != job.ID && caps.sharesCappedScope(entry, job) {
+			ahead++
+		}
+	}
+	if ahead > 0 {
+		return fmt.Sprintf("%d earlier syncs are queued", ahead), nil
+	}
+	return "", nil
+}
+
+// admitSync queues a sync of a job that cannot start now under the concurrency caps and
+// returns why, empty when the sync can be triggered
+func (s Service) admitSync(ctx context.Context, job *models.Job) (string, error) {
+	reason, err := s.syncBlockedReason(ctx, job)
+	if err != nil || reason == "" {
+		return "", err
+	}
+	if err := s.enqueueSync(job, reason); err != nil {
+		return "", err
+	}
+	return reason, nil
+}
+
+func (s Service) enqueueSync(job *models.Job, reason string) error {
+	entry := &models.RunQueueEntry{
+		ProjectID:     job.ProjectID,
+		JobID:         &job.ID,
+		SourceID:      &job.SourceID,
+		DestinationID: &job.DestID,
+		Reason:        reason,
+	}
+	if err := s.db.EnqueueSyncRun(entry); err != nil {
+		return err
+	}
+	logger.Infof("sync of job_id[%d] queued: %s", job.ID, reason)
+	return nil
+}
+
+// RunConcurrencyController enforces the sync caps of projects every interval until the
+// context is done: scheduled syncs that started over a cap are cancelled and queued, and
+// queued syncs are started once their caps have room.
+func (s Service) RunConcurrencyController(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		projectIDs, err := s.db.ListConcurrencyProjectIDs()
+		if err != nil {
+			logger.Errorf("failed to enforce concurrency caps: %s", err)
+			continue
+		}
+		for _, projectID := range projectIDs {
+			if err := s.enforceConcurrency(ctx, projectID); err != nil {
+				logger.Errorf("failed to enforce concurrency caps project_id[%s]: %s", projectID, err)
+			}
+		}
+		if _, err := s.db.DeleteExpiredRunQueueEntries(time.Now()); err != nil {
+			logger.Errorf("failed to clean up run queue: %s", err)
+		}
+	}
+}
+
+// enforceConcurrency checks the running syncs of a project against its caps in start order.
+// A scheduled sync over a cap that started recently is cancelled and queued; ad-hoc syncs and
+// syncs that have been running a while are left alone. Queued syncs are then started in
+// queue order while their caps have room, through the schedule of their job.
+func (s Service) enforceConcurrency(ctx context.Context, projectID string) error {
+	caps, err := s.loadConcurrencyCaps(projectID)
+	if err != nil {
+		return err
+	}
+	running, err := s.runningSyncs(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	usage := newSyncUsage()
+	runningJobs := map[int]bool{}
+	for _, run := range running {
+		if reason := caps.blockedSync(run.job, usage); reason != "" && !run.adHoc && time.Since(run.start) < constants.ConcurrencyAdmissionGrace {
+			if err := s.temporal.CancelWorkflow(ctx, run.workflowID, run.runID); err != nil {
+				logger.Warnf("failed to cancel sync of job_id[%d] over a concurrency cap: %s", run.job.ID, err)
+			} else if err := s.enqueueSync(run.job, reason); err != nil {
+				logger.Errorf("sync of job_id[%d] over a concurrency cap cancelled but not queued: %s", run.job.ID, err)
+			} else {
+				continue
+			}
+		}
+		usage.add(run.job)
+		runningJobs[run.job.ID] = true
+	}
+
+	queue, err := s.db.ListRunQueue(projectID)
+	if err != nil {
+		return err
+	}
+	var jobIDs []int
+	for _, entry := range queue {
+		if entry.Kind == constants.RunKindSync {
+			jobIDs = append(jobIDs, *entry.JobID)
+		}
+	}
+	jobs, err := s.db.GetJobsByIDs(projectID, jobIDs, false)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]*models.Job, len(jobs))
+	for _, job := range jobs {
+		byID[job.ID] = job
+	}
+
+	for _, entry := range queue {
+		if entry.Kind != constants.RunKindSync {
+			continue
+		}
+		job := byID[*entry.JobID]
+		drop := func(why string) {
+			if err := s.db.DeleteRunQueueEntry(projectID, entry.ID); err != nil {
+				logger.Errorf("failed to remove queued sync of job_id[%d]: %s", *entry.JobID, err)
+				return
+			}
+			logger.Infof("queued sync of job_id[%d] removed: %s", *entry.JobID, why)
+		}
+		switch {
+		case job == nil:
+			drop("job no longer exists")
+			continue
+		case !job.Active:
+			drop("job is paused")
+			continue
+		case runningJobs[job.ID]:
+			drop("job is running already")
+			continue
+		}
+		reason := caps.blockedSync(job, usage)
+		if reason == "" {
+			if reason, err = s.scheduleTriggerBlocked(ctx, job); err != nil {
+				logger.Warnf("failed to check schedule of queued sync of job_id[%d]: %s", job.ID, err)
+				continue
+			}
+		}
+		if reason != "" {
+			if reason != entry.Reason {
+				if err := s.db.UpdateRunQueueReason(entry.ID, reason); err != nil {
+					logger.Warnf("failed to update queued sync of job_id[%d]: %s", job.ID, err)
+				}
+			}
+			continue
+		}
+
+		// a job in a maintenance window is triggered once the window is over, and queued
+		// again then if its caps are full
+		deferred, err := s.deferTriggerInMaintenanceWindow(job)
+		if err != nil {
+			logger.Warnf("failed to start queued sync of job_id[%d]: %s", job.ID, err)
+			continue
+		}
+		if deferred {
+			drop("trigger deferred by a maintenance window")
+			continue
+		}
+		if err := s.temporal.TriggerSchedule(ctx, projectID, job.ID); err != nil {
+			logger.Warnf("failed to start queued sync of job_id[%d]: %s", job.ID, err)
+			continue
+		}
+		usage.add(job)
+		runningJobs[job.ID] = true
+		drop("sync started")
+	}
+	return nil
+}
+
+// waitForDiscoverSlot queues a discover of a project, and of a source and destination when
+// known, until the discover caps have room, at most constants.DiscoverQueueTimeout. The
+// returned func frees the slot once the discover is done. A discover takes a queue entry even
+// without caps so that running discovers show in the concurrency usage.
+func (s Service) waitForDiscoverSlot(ctx context.Context, projectID string, sourceID, destinationID *int) (func(), error) {
+	caps, err := s.loadConcurrencyCaps(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read concurrency caps: %s", err)
+	}
+	limits := database.DiscoverLimits{Project: caps.project.MaxConcurrentDiscovers}
+	if sourceID != nil && caps.sources[*sourceID] != nil {
+		limits.Source = caps.sources[*sourceID].MaxConcurrentDiscovers
+	}
+	if destinationID != nil && caps.destinations[*destinationID] != nil {
+		limits.Destination = caps.destinations[*destinationID].MaxConcurrentDiscovers
+	}
+
+	entry := &models.RunQueueEntry{ProjectID: projectID, SourceID: sourceID, DestinationID: destinationID}
+	if err := s.db.EnqueueDiscoverRun(entry); err != nil {
+		return nil, err
+	}
+	release := func() {
+		if err := s.db.DeleteRunQueueEntry(projectID, entry.ID); err != nil {
+			logger.Warnf("failed to free discover slot[%d]: %s", entry.ID, err)
+		}
+	}
+
+	timeout := time.NewTimer(constants.DiscoverQueueTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(constants.DiscoverQueuePollInterval)
+	defer ticker.Stop()
+	queued := false
+	for {
+		reason, err := s.db.TryStartDiscover(entry, limits)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		if reason == "" {
+			return release, nil
+		}
+		if !queued {
+			queued = true
+			logger.Infof("discover project_id[%s] queued: %s", projectID, reason)
+		}
+		select {
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		case <-timeout.C:
+			release()
+			return nil, fmt.Errorf("%w: waited %s for a discover slot, %s", constants.ErrConcurrencyLimit, constants.DiscoverQueueTimeout, reason)
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetConcurrency returns the caps of a project and of its capped sources and destinations,
+// how many syncs and discovers use them and the run queue
+func (s Service) GetConcurrency(ctx context.Context, projectID string) (*dto.ConcurrencyResponse, error) {
+	caps, err := s.loadConcurrencyCaps(projectID)
+	if err != nil {
+		return nil, err
+	}
+	running, err := s.runningSyncs(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	queue, err := s.db.ListRunQueue(projectID)
+	if err != nil {
+		return nil, err
+	}
+	var jobIDs []int
+	for _, entry := range queue {
+		if entry.JobID != nil {
+			jobIDs = append(jobIDs, *entry.JobID)
+		}
+	}
+	jobs, err := s.db.GetJobsByIDs(projectID, jobIDs, false)
+	if err != nil {
+		return nil, err
+	}
+	jobNames := make(map[int]string, len(jobs))
+	for _, job := range jobs {
+		jobNames[job.ID] = job.Name
+	}
+
+	resp := &dto.ConcurrencyResponse{
+		Project: dto.ConcurrencyUsage{
+			MaxConcurrentSyncs:     caps.project.MaxConcurrentSyncs,
+			MaxConcurrentDiscovers: caps.project.MaxConcurrentDiscovers,
+		},
+		Sources:      []dto.ConcurrencyUsage{},
+		Destinations: []dto.ConcurrencyUsage{},
+		Queue:        []dto.RunQueueItem{},
+	}
+	sourceUsage := map[int]*dto.ConcurrencyUsage{}
+	for _, source := range caps.sources {
+		sourceUsage[source.ID] = &dto.ConcurrencyUsage{ID: source.ID, Name: source.Name, MaxConcurrentSyncs: source.MaxConcurrentSyncs, MaxConcurrentDiscovers: source.MaxConcurrentDiscovers}
+	}
+	destinationUsage := map[int]*dto.ConcurrencyUsage{}
+	for _, destination := range caps.destinations {
+		destinationUsage[destination.ID] = &dto.ConcurrencyUsage{ID: destination.ID, Name: destination.Name, MaxConcurrentSyncs: destination.MaxConcurrentSyncs, MaxConcurrentDiscovers: destination.MaxConcurrentDiscovers}
+	}
+	// count adds a run to the usage of the project and of its source and destination
+	count := func(sourceID, destinationID *int, add func(usage *dto.ConcurrencyUsage)) {
+		add(&resp.Project)
+		if sourceID != nil && sourceUsage[*sourceID] != nil {
+			add(sourceUsage[*sourceID])
+		}
+		if destinationID != nil && destinationUsage[*destinationID] != nil {
+			add(destinationUsage[*destinationID])
+		}
+	}
+	for _, run := range running {
+		count(&run.job.SourceID, &run.job.DestID, func(usage *dto.ConcurrencyUsage) { usage.RunningSyncs++ })
+	}
+
+	positions := map[string]int{}
+	for _, entry := range queue {
+		item := dto.RunQueueItem{
+			ID:            entry.ID,
+			Kind:          entry.Kind,
+			Status:        entry.Status,
+			JobID:         entry.JobID,
+			SourceID:      entry.SourceID,
+			DestinationID: entry.DestinationID,
+			Reason:        entry.Reason,
+			QueuedAt:      entry.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		if entry.JobID != nil {
+			item.JobName = jobNames[*entry.JobID]
+		}
+		switch {
+		case entry.Status == constants.RunQueueStatusQueued:
+			positions[entry.Kind]++
+			item.Position = positions[entry.Kind]
+			if entry.Kind == constants.RunKindSync {
+				count(entry.SourceID, entry.DestinationID, func(usage *dto.ConcurrencyUsage) { usage.QueuedSyncs++ })
+			} else {
+				count(entry.SourceID, entry.DestinationID, func(usage *dto.ConcurrencyUsage) { usage.QueuedDiscovers++ })
+			}
+		case entry.Kind == constants.RunKindDiscover:
+			count(entry.SourceID, entry.DestinationID, func(usage *dto.ConcurrencyUsage) { usage.RunningDiscovers++ })
+		}
+		resp.Queue = append(resp.Queue, item)
+	}
+
+	for _, usage := range sourceUsage {
+		resp.Sources = append(resp.Sources, *usage)
+	}
+	for _, usage := range destinationUsage {
+		resp.Destinations = append(resp.Destinations, *usage)
+	}
+	byID := func(a, b dto.ConcurrencyUsage) int { return a.ID - b.ID }
+	slices.SortFunc(resp.Sources, byID)
+	slices.SortFunc(resp.Destinations, byID)
+	return resp, nil
+}
+
+// SetConcurrencyLimits sets the sync and discover caps of a project, or of one of its sources
+// or destinations. Queued syncs that fit under raised caps are started by the controller.
+func (s Service) SetConcurrencyLimits(_ context.Context, projectID string, req *dto.ConcurrencyLimitRequest) error {
+	var err error
+	switch req.Scope {
+	case constants.ConcurrencyScopeProject:
+		err = s.db.SetProjectConcurrencyLimits(projectID, req.MaxConcurrentSyncs, req.MaxConcurrentDiscovers)
+	case constants.ConcurrencyScopeSource:
+		err = s.db.SetSourceConcurrencyLimits(projectID, req.ID, req.MaxConcurrentSyncs, req.MaxConcurrentDiscovers)
+	case constants.ConcurrencyScopeDestination:
+		err = s.db.SetDestinationConcurrencyLimits(projectID, req.ID, req.MaxConcurrentSyncs, req.MaxConcurrentDiscovers)
+	}
+	if err != nil {
+		return err
+	}
+	logger.Infof("concurrency limits of %s[%d] project_id[%s] set syncs[%d] discovers[%d]", req.Scope, req.ID, projectID, req.MaxConcurrentSyncs, req.MaxConcurrentDiscovers)
+	return nil
+}
+
+// DeleteRunQueueEntry drops a queued sync. Discovers leave the queue with their request.
+func (s Service) DeleteRunQueueEntry(_ context.Context, projectID string, id int) error {
+	queue, err := s.db.ListRunQueue(projectID)
+	if err != nil {
+		return err
+	}
+	idx := slices.IndexFunc(queue, func(entry *models.RunQueueEntry) bool {
+		return entry.ID == id && entry.Kind == constants.RunKindSync
+	})
+	if idx < 0 {
+		return fmt.Errorf("%w: id[%d] project_id[%s]", constants.ErrRunQueueEntryNotFound, id, projectID)
+	}
+	if err := s.db.DeleteRunQueueEntry(projectID, id); err != nil {
+		return err
+	}
+	logger.Infof("queued sync of job_id[%d] removed from the queue", *queue[idx].JobID)
+	return nil
+}
@@ -0,0 +1,142 @@
+package etl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+// testCaps caps the syncs of the project at project, of source 1 at two and of destination 2
+// at one; source 3 and destination 4 only cap discovers
+func testCaps(project int) *concurrencyCaps {
+	return &concurrencyCaps{
+		project: &models.ProjectSettings{MaxConcurrentSyncs: project},
+		sources: map[int]*models.Source{
+			1: {ID: 1, Name: "pg", MaxConcurrentSyncs: 2},
+			3: {ID: 3, Name: "mysql", MaxConcurrentDiscovers: 1},
+		},
+		destinations: map[int]*models.Destination{
+			2: {ID: 2, Name: "iceberg", MaxConcurrentSyncs: 1},
+			4: {ID: 4, Name: "parquet", MaxConcurrentDiscovers: 1},
+		},
+	}
+}
+
+func TestBlockedSync(t *testing.T) {
+	tests := []struct {
+		name    string
+		project int
+		job     *models.Job
+		running []*models.Job
+		want    string
+	}{
+		{
+			name: "nothing running",
+			job:  &models.Job{SourceID: 1, DestID: 2},
+		},
+		{
+			name:    "project cap full",
+			project: 2,
+			job:     &models.Job{SourceID: 3, DestID: 4},
+			running: []*models.Job{{SourceID: 3, DestID: 4}, {SourceID: 5, DestID: 6}},
+			want:    "project runs 2 of 2 syncs",
+		},
+		{
+			name:    "project cap with room",
+			project: 3,
+			job:     &models.Job{SourceID: 3, DestID: 4},
+			running: []*models.Job{{SourceID: 3, DestID: 4}, {SourceID: 5, DestID: 6}},
+		},
+		{
+			name:    "source cap full",
+			job:     &models.Job{SourceID: 1, DestID: 4},
+			running: []*models.Job{{SourceID: 1, DestID: 4}, {SourceID: 1, DestID: 6}},
+			want:    "source 'pg' runs 2 of 2 syncs",
+		},
+		{
+			name:    "destination cap full",
+			job:     &models.Job{SourceID: 3, DestID: 2},
+			running: []*models.Job{{SourceID: 5, DestID: 2}},
+			want:    "destination 'iceberg' runs 1 of 1 syncs",
+		},
+		{
+			name:    "caps of other scopes",
+			job:     &models.Job{SourceID: 3, DestID: 4},
+			running: []*models.Job{{SourceID: 1, DestID: 2}, {SourceID: 1, DestID: 2}},
+		},
+		{
+			name:    "discover caps only",
+			job:     &models.Job{SourceID: 3, DestID: 4},
+			running: []*models.Job{{SourceID: 3, DestID: 4}, {SourceID: 3, DestID: 4}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage := newSyncUsage()
+			for _, job := range tt.running {
+				usage.add(job)
+			}
+			require.Equal(t, tt.want, testCaps(tt.project).blockedSync(tt.job, usage))
+		})
+	}
+}
+
+func TestSharesCappedScope(t *testing.T) {
+	id := func(v int) *int { return &v }
+	tests := []struct {
+		name    string
+		project int
+		entry   *models.RunQueueEntry
+		job     *models.Job
+		want    bool
+	}{
+		{
+			name:    "project cap",
+			project: 1,
+			entry:   &models.RunQueueEntry{SourceID: id(5), DestinationID: id(6)},
+			job:     &models.Job{SourceID: 3, DestID: 4},
+			want:    true,
+		},
+		{
+			name:  "same capped source",
+			entry: &models.RunQueueEntry{SourceID: id(1), DestinationID: id(6)},
+			job:   &models.Job{SourceID: 1, DestID: 4},
+			want:  true,
+		},
+		{
+			name:  "same capped destination",
+			entry: &models.RunQueueEntry{SourceID: id(5), DestinationID: id(2)},
+			job:   &models.Job{SourceID: 3, DestID: 2},
+			want:  true,
+		},
+		{
+			name:  "same source with a discover cap only",
+			entry: &models.RunQueueEntry{SourceID: id(3), DestinationID: id(4)},
+			job:   &models.Job{SourceID: 3, DestID: 4},
+		},
+		{
+			name:  "other capped scopes",
+			entry: &models.RunQueueEntry{SourceID: id(1), DestinationID: id(2)},
+			job:   &models.Job{SourceID: 3, DestID: 4},
+		},
+		{
+			name:  "entry without a source and destination",
+			entry: &models.RunQueueEntry{},
+			job:   &models.Job{SourceID: 1, DestID: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, testCaps(tt.project).sharesCappedScope(tt.entry, tt.job))
+		})
+	}
+}
+
+func TestSyncCapped(t *testing.T) {
+	require.True(t, testCaps(1).syncCapped(&models.Job{SourceID: 3, DestID: 4}))
+	require.True(t, testCaps(0).syncCapped(&models.Job{SourceID: 1, DestID: 4}))
+	require.True(t, testCaps(0).syncCapped(&models.Job{SourceID: 3, DestID: 2}))
+	require.False(t, testCaps(0).syncCapped(&models.Job{SourceID: 3, DestID: 4}))
+}
@@ -0,0 +1,351 @@
+package etl
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+// Job dependency methods on AppService
+
+// SetJobDependencies replaces the upstream jobs of a job and the policy by which their syncs
+// trigger it. Upstream jobs have to be live jobs of the same project, and a change that would
+// make a job depend on itself, directly or through other jobs, is rejected.
+func (s Service) SetJobDependencies(ctx context.Context, projectID string, jobID int, req *dto.JobDependenciesRequest) (*dto.JobDependenciesResponse, error) {
+	if _, err := s.getProjectJob(projectID, jobID); err != nil {
+		return nil, err
+	}
+
+	upstreamIDs := slices.Clone(req.UpstreamJobIDs)
+	slices.Sort(upstreamIDs)
+	upstreamIDs = slices.Compact(upstreamIDs)
+	if slices.Contains(upstreamIDs, jobID) {
+		return nil, fmt.Errorf("%w: a job cannot depend on itself", constants.ErrInvalidDependency)
+	}
+	upstreams, err := s.db.GetJobsByIDs(projectID, upstreamIDs, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(upstreams) != len(upstreamIDs) {
+		for _, id := range upstreamIDs {
+			if !slices.ContainsFunc(upstreams, func(job *models.Job) bool { return job.ID == id }) {
+				return nil, fmt.Errorf("%w: upstream job_id[%d] not found in project", constants.ErrInvalidDependency, id)
+			}
+		}
+	}
+
+	policy := utils.Ternary(len(upstreamIDs) > 0, req.Policy, "").(string)
+	err = s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.LockProjectDependencies(projectID); err != nil {
+			return err
+		}
+		deps, err := tx.ListProjectJobDependencies(projectID)
+		if err != nil {
+			return err
+		}
+		if cycle := dependencyCycle(deps, jobID, upstreamIDs); cycle != nil {
+			path := make([]string, 0, len(cycle))
+			for _, id := range cycle {
+				path = append(path, fmt.Sprintf("job_id[%d]", id))
+			}
+			return fmt.Errorf("%w: %s", constants.ErrDependencyCycle, strings.Join(path, " -> "))
+		}
+		return tx.SetJobDependencies(jobID, projectID, policy, upstreamIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("dependencies of job_id[%d] set to upstreams%v policy[%s]", jobID, upstreamIDs, policy)
+	return s.GetJobDependencies(ctx, projectID, jobID)
+}
+
+// GetJobDependencies returns the upstream and downstream jobs of a job, and the upstream jobs
+// it is waiting on.
+func (s Service) GetJobDependencies(_ context.Context, projectID string, jobID int) (*dto.JobDependenciesResponse, error) {
+	job, err := s.getProjectJob(projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	deps, err := s.db.ListProjectJobDependencies(projectID)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := s.dependencyRefs(projectID, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.JobDependenciesResponse{
+		JobID:       jobID,
+		Policy:      job.DependencyPolicy,
+		Upstreams:   []dto.JobUpstream{},
+		Downstreams: []dto.JobDependencyRef{},
+	}
+	var upstreams []*models.JobDependency
+	for _, dep := range deps {
+		switch jobID {
+		case dep.JobID:
+			upstreams = append(upstreams, dep)
+			upstream := dto.JobUpstream{
+				JobDependencyRef: refs[dep.UpstreamJobID],
+				Status:           dependencyStatus(dep),
+				LastWorkflowID:   dep.UpstreamWorkflowID,
+			}
+			if dep.UpstreamClosedAt != nil {
+				upstream.LastClosedAt = dep.UpstreamClosedAt.UTC().Format(time.RFC3339)
+			}
+			resp.Upstreams = append(resp.Upstreams, upstream)
+		case dep.UpstreamJobID:
+			resp.Downstreams = append(resp.Downstreams, refs[dep.JobID])
+		}
+	}
+	resp.WaitingOn = waitingOn(job.DependencyPolicy, upstreams)
+	return resp, nil
+}
+
+// GetDependencyGraph returns the jobs of a project that have upstream or downstream jobs,
+// with the dependencies between them and what each job is waiting on.
+func (s Service) GetDependencyGraph(_ context.Context, projectID string) (*dto.DependencyGraphResponse, error) {
+	deps, err := s.db.ListProjectJobDependencies(projectID)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := s.dependencyRefs(projectID, deps)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := s.dependencyPolicies(projectID, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.DependencyGraphResponse{Nodes: []dto.DependencyGraphNode{}, Edges: []dto.DependencyGraphEdge{}}
+	upstreams := map[int][]*models.JobDependency{}
+	for _, dep := range deps {
+		upstreams[dep.JobID] = append(upstreams[dep.JobID], dep)
+		resp.Edges = append(resp.Edges, dto.DependencyGraphEdge{JobID: dep.JobID, UpstreamJobID: dep.UpstreamJobID, Status: dependencyStatus(dep)})
+	}
+	for _, id := range slices.Sorted(maps.Keys(refs)) {
+		node := dto.DependencyGraphNode{JobDependencyRef: refs[id]}
+		if len(upstreams[id]) > 0 {
+			node.Policy = policies[id]
+			node.WaitingOn = waitingOn(policies[id], upstreams[id])
+		}
+		resp.Nodes = append(resp.Nodes, node)
+	}
+	return resp, nil
+}
+
+// upstreamRunClosed records a sync of a job that has closed on the jobs depending on it, and
+// triggers those whose dependencies are met through the schedule outbox. One-off syncs of
+// some streams of a job do not count. It is called for the worker callback and the watcher,
+// and a sync seen by both counts once.
+func (s Service) upstreamRunClosed(ctx context.Context, projectID string, jobID int, workflowID string, succeeded bool, closedAt time.Time) {
+	if workflowID == "" || strings.HasPrefix(workflowID, temporal.AdHocWorkflowPrefix(projectID, jobID)) {
+		return
+	}
+	status := utils.Ternary(succeeded, constants.DependencyRunCompleted, constants.DependencyRunFailed).(string)
+	downstreams, err := s.db.RecordUpstreamRun(jobID, workflowID, status, closedAt)
+	if err != nil {
+		logger.Errorf("failed to record sync of job_id[%d] for its downstream jobs: %s", jobID, err)
+		return
+	}
+
+	for _, downstreamID := range downstreams {
+		triggered := false
+		err := s.db.Transaction(func(tx *database.Database) error {
+			job, deps, err := tx.LockJobDependencies(downstreamID)
+			if err != nil || job == nil || !job.Active {
+				return err
+			}
+			if len(waitingOn(job.DependencyPolicy, deps)) > 0 {
+				return nil
+			}
+			if err := tx.ResetJobDependencies(downstreamID); err != nil {
+				return err
+			}
+			triggered = true
+			return tx.EnqueueScheduleOperations(job.ProjectID, downstreamID, constants.ScheduleOpTrigger)
+		})
+		if err != nil {
+			logger.Errorf("failed to evaluate dependencies of job_id[%d]: %s", downstreamID, err)
+			continue
+		}
+		if !triggered {
+			continue
+		}
+		logger.Infof("job_id[%d] triggered by sync %s of upstream job_id[%d]", downstreamID, workflowID, jobID)
+		if err := s.applyScheduleOperations(ctx, downstreamID); err != nil {
+			logger.Warnf("trigger of job_id[%d] is retried by the schedule dispatcher: %s", downstreamID, err)
+		}
+	}
+}
+
+// RunDependencyWatcher looks for closed syncs of upstream jobs every interval until the
+// context is done, so that downstream jobs are triggered also when the worker callback of a
+// sync is missed.
+func (s Service) RunDependencyWatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deps, err := s.db.ListAllJobDependencies()
+		if err != nil {
+			logger.Errorf("failed to list job dependencies: %s", err)
+			continue
+		}
+		watched := map[int]bool{}
+		for _, dep := range deps {
+			if watched[dep.UpstreamJobID] {
+				continue
+			}
+			watched[dep.UpstreamJobID] = true
+
+			execution, err := s.latestClosedSync(ctx, dep.ProjectID, dep.UpstreamJobID)
+			if err != nil {
+				logger.Warnf("failed to find latest sync of upstream job_id[%d]: %s", dep.UpstreamJobID, err)
+				continue
+			}
+			if execution == nil {
+				continue
+			}
+			succeeded := execution.Status == enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED
+			s.upstreamRunClosed(ctx, dep.ProjectID, dep.UpstreamJobID, execution.Execution.WorkflowId, succeeded, execution.CloseTime.AsTime())
+		}
+	}
+}
+
+// latestClosedSync returns the latest scheduled sync of a job that has closed, if any
+func (s Service) latestClosedSync(ctx context.Context, projectID string, jobID int) (*workflowpb.WorkflowExecutionInfo, error) {
+	query := fmt.Sprintf(
+		"WorkflowId BETWEEN 'sync-%s-%d-' AND 'sync-%s-%d-z' AND OperationType != '%s' AND ExecutionStatus != 'Running'",
+		projectID, jobID, projectID, jobID, temporal.ClearDestination,
+	)
+	resp, err := s.temporal.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+		Query:    query,
+		PageSize: int32(constants.DependencyWatchPageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, execution := range resp.Executions {
+		if execution.CloseTime != nil && !strings.HasPrefix(execution.Execution.WorkflowId, temporal.AdHocWorkflowPrefix(projectID, jobID)) {
+			return execution, nil
+		}
+	}
+	return nil, nil
+}
+
+// dependencyRefs describes every job of a set of dependencies, including jobs in the trash
+func (s Service) dependencyRefs(projectID string, deps []*models.JobDependency) (map[int]dto.JobDependencyRef, error) {
+	refs := map[int]dto.JobDependencyRef{}
+	for _, dep := range deps {
+		refs[dep.JobID] = dto.JobDependencyRef{JobID: dep.JobID, Deleted: true}
+		refs[dep.UpstreamJobID] = dto.JobDependencyRef{JobID: dep.UpstreamJobID, Deleted: true}
+	}
+	jobs, err := s.db.GetJobsByIDs(projectID, slices.Sorted(maps.Keys(refs)), false)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		refs[job.ID] = dto.JobDependencyRef{JobID: job.ID, Name: job.Name, Active: job.Active}
+	}
+	return refs, nil
+}
+
+// dependencyPolicies returns the dependency policy of every job with upstream jobs
+func (s Service) dependencyPolicies(projectID string, deps []*models.JobDependency) (map[int]string, error) {
+	ids := make([]int, 0, len(deps))
+	for _, dep := range deps {
+		ids = append(ids, dep.JobID)
+	}
+	jobs, err := s.db.GetJobsByIDs(projectID, ids, false)
+	if err != nil {
+		return nil, err
+	}
+	policies := make(map[int]string, len(jobs))
+	for _, job := range jobs {
+		policies[job.ID] = job.DependencyPolicy
+	}
+	return policies, nil
+}
+
+// waitingOn lists the upstream jobs a job still waits for before its dependencies trigger it:
+// those without a completed sync with all_succeeded, or those without a closed sync, completed
+// or failed, until any has one with any_completed.
+func waitingOn(policy string, upstreams []*models.JobDependency) []int {
+	anyCompleted := policy == constants.DependencyPolicyAnyCompleted
+	waiting := []int{}
+	for _, dep := range upstreams {
+		closed := dep.UpstreamStatus == constants.DependencyRunCompleted ||
+			(anyCompleted && dep.UpstreamStatus == constants.DependencyRunFailed)
+		if !closed {
+			waiting = append(waiting, dep.UpstreamJobID)
+		}
+	}
+	if anyCompleted && len(waiting) < len(upstreams) {
+		return []int{}
+	}
+	return waiting
+}
+
+// dependencyCycle returns the cycle that giving a job the upstreams would create, from the job
+// back to itself, or nil. The current upstreams of the job are replaced by the new ones.
+func dependencyCycle(deps []*models.JobDependency, jobID int, upstreamIDs []int) []int {
+	graph := map[int][]int{jobID: upstreamIDs}
+	for _, dep := range deps {
+		if dep.JobID != jobID {
+			graph[dep.JobID] = append(graph[dep.JobID], dep.UpstreamJobID)
+		}
+	}
+
+	visited := map[int]bool{}
+	var path []int
+	var visit func(id int) bool
+	visit = func(id int) bool {
+		path = append(path, id)
+		for _, next := range graph[id] {
+			if next == jobID {
+				path = append(path, next)
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				if visit(next) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if visit(jobID) {
+		return path
+	}
+	return nil
+}
+
+func dependencyStatus(dep *models.JobDependency) string {
+	return utils.Ternary(dep.UpstreamStatus != "", dep.UpstreamStatus, constants.DependencyRunPending).(string)
+}
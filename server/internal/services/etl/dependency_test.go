@@ -0,0 +1,130 @@
+package etl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+func TestDependencyCycle(t *testing.T) {
+	// edge builds a dependency of job on upstream
+	edge := func(job, upstream int) *models.JobDependency {
+		return &models.JobDependency{JobID: job, UpstreamJobID: upstream}
+	}
+	tests := []struct {
+		name      string
+		deps      []*models.JobDependency
+		jobID     int
+		upstreams []int
+		want      []int
+	}{
+		{
+			name:      "no upstreams",
+			deps:      []*models.JobDependency{edge(2, 1)},
+			jobID:     1,
+			upstreams: nil,
+		},
+		{
+			name:      "self edge",
+			jobID:     1,
+			upstreams: []int{1},
+			want:      []int{1, 1},
+		},
+		{
+			name:      "direct cycle",
+			deps:      []*models.JobDependency{edge(2, 1)},
+			jobID:     1,
+			upstreams: []int{2},
+			want:      []int{1, 2, 1},
+		},
+		{
+			name:      "indirect cycle in path order",
+			deps:      []*models.JobDependency{edge(2, 3), edge(3, 4), edge(4, 1)},
+			jobID:     1,
+			upstreams: []int{2},
+			want:      []int{1, 2, 3, 4, 1},
+		},
+		{
+			name:      "cycle through the second upstream",
+			deps:      []*models.JobDependency{edge(3, 1)},
+			jobID:     1,
+			upstreams: []int{2, 3},
+			want:      []int{1, 3, 1},
+		},
+		{
+			name:      "shared upstreams are no cycle",
+			deps:      []*models.JobDependency{edge(2, 4), edge(3, 4)},
+			jobID:     1,
+			upstreams: []int{2, 3},
+		},
+		{
+			name:      "downstream chain is no cycle",
+			deps:      []*models.JobDependency{edge(3, 2), edge(4, 3)},
+			jobID:     2,
+			upstreams: []int{1},
+		},
+		{
+			// the current upstreams of the job are replaced, so its edge that closes a
+			// cycle no longer counts
+			name:      "current upstreams are replaced",
+			deps:      []*models.JobDependency{edge(1, 2), edge(2, 1)},
+			jobID:     1,
+			upstreams: []int{3},
+		},
+		{
+			name:      "replacing upstreams keeps the others",
+			deps:      []*models.JobDependency{edge(1, 5), edge(2, 1)},
+			jobID:     1,
+			upstreams: []int{2},
+			want:      []int{1, 2, 1},
+		},
+		{
+			name:      "cycle elsewhere in the graph is not reported",
+			deps:      []*models.JobDependency{edge(2, 3), edge(3, 2)},
+			jobID:     1,
+			upstreams: []int{2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, dependencyCycle(tt.deps, tt.jobID, tt.upstreams))
+		})
+	}
+}
+
+func TestWaitingOn(t *testing.T) {
+	// upstreams builds the dependencies of a job on jobs 1, 2 and 3 with the given statuses
+	upstreams := func(statuses ...string) []*models.JobDependency {
+		deps := make([]*models.JobDependency, 0, len(statuses))
+		for i, status := range statuses {
+			deps = append(deps, &models.JobDependency{JobID: 10, UpstreamJobID: i + 1, UpstreamStatus: status})
+		}
+		return deps
+	}
+	none, completed, failed := "", constants.DependencyRunCompleted, constants.DependencyRunFailed
+	tests := []struct {
+		name   string
+		policy string
+		deps   []*models.JobDependency
+		want   []int
+	}{
+		{"all succeeded without syncs", constants.DependencyPolicyAllSucceeded, upstreams(none, none, none), []int{1, 2, 3}},
+		{"all succeeded waits for the rest", constants.DependencyPolicyAllSucceeded, upstreams(completed, none, completed), []int{2}},
+		{"all succeeded waits on a failed sync", constants.DependencyPolicyAllSucceeded, upstreams(completed, failed, completed), []int{2}},
+		{"all succeeded met", constants.DependencyPolicyAllSucceeded, upstreams(completed, completed, completed), []int{}},
+		{"empty policy is all succeeded", "", upstreams(completed, failed), []int{2}},
+		{"any completed without syncs", constants.DependencyPolicyAnyCompleted, upstreams(none, none, none), []int{1, 2, 3}},
+		{"any completed met by a completed sync", constants.DependencyPolicyAnyCompleted, upstreams(none, completed, none), []int{}},
+		{"any completed met by a failed sync", constants.DependencyPolicyAnyCompleted, upstreams(none, none, failed), []int{}},
+		{"any completed met by all", constants.DependencyPolicyAnyCompleted, upstreams(completed, failed, completed), []int{}},
+		{"no upstreams", constants.DependencyPolicyAllSucceeded, nil, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, waitingOn(tt.policy, tt.deps))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
 	"github.com/datazip-inc/olake-ui/server/internal/models"
 	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
 	"github.com/datazip-inc/olake-ui/server/internal/utils"
@@ -52,6 +53,7 @@ func (s Service) GetDestination(ctx context.Context, projectID string, destinati
 		Config:    destination.Config,
 		CreatedAt: destination.CreatedAt.Format(time.RFC3339),
 		UpdatedAt: destination.UpdatedAt.Format(time.RFC3339),
+		Labels:    labelsOrEmpty(destination.Labels),
 		Jobs:      jobItems,
 	}
 	setUsernames(&item.CreatedBy, &item.UpdatedBy, destination.CreatedBy, destination.UpdatedBy)
@@ -59,12 +61,20 @@ func (s Service) GetDestination(ctx context.Context, projectID string, destinati
 	return item, nil
 }
 
-// ListDestinations returns all destinations for a project with lightweight job summaries.
-func (s Service) ListDestinations(ctx context.Context, projectID string) ([]dto.DestinationDataItem, error) {
-	destinations, err := s.db.ListDestinationsByProjectID(projectID)
+// ListDestinations returns one page of destinations for a project with lightweight job summaries.
+func (s Service) ListDestinations(ctx context.Context, projectID string, query dto.ListQuery) (*dto.PaginatedResponse, error) {
+	opts, err := toListOptions(query)
+	if err != nil {
+		return nil, err
+	}
+
+	destinations, total, err := s.db.ListDestinationsByProjectID(projectID, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list destinations: %s", err)
 	}
+	destinations, nextCursor := nextPage(destinations, query.Limit, func(dest *models.Destination) database.Cursor {
+		return database.NewCursor(opts.SortBy, dest.ID, dest.Name, dest.BaseModel)
+	})
 
 	destIDs := make([]int, 0, len(destinations))
 	for _, dest := range destinations {
@@ -98,6 +108,7 @@ func (s Service) ListDestinations(ctx context.Context, projectID string) ([]dto.
 			Config:    dest.Config,
 			CreatedAt: dest.CreatedAt.Format(time.RFC3339),
 			UpdatedAt: dest.UpdatedAt.Format(time.RFC3339),
+			Labels:    labelsOrEmpty(dest.Labels),
 		}
 		setUsernames(&entity.CreatedBy, &entity.UpdatedBy, dest.CreatedBy, dest.UpdatedBy)
 
@@ -110,7 +121,12 @@ func (s Service) ListDestinations(ctx context.Context, projectID string) ([]dto.
 		destItems = append(destItems, entity)
 	}
 
-	return destItems, nil
+	return &dto.PaginatedResponse{
+		Items:      destItems,
+		Total:      total,
+		Limit:      query.Limit,
+		NextCursor: nextCursor,
+	}, nil
 }
 
 func (s Service) CreateDestination(ctx context.Context, req *dto.CreateDestinationRequest, projectID string, userID *int) error {
@@ -127,6 +143,7 @@ func (s Service) CreateDestination(ctx context.Context, req *dto.CreateDestinati
 		DestType:  req.Type,
 		Version:   req.Version,
 		Config:    req.Config,
+		Labels:    req.Labels,
 		ProjectID: projectID,
 	}
 	user := &models.User{ID: *userID}
@@ -222,7 +239,7 @@ func (s Service) TestDestinationConnection(ctx context.Context, req *dto.Destina
 		return nil, nil, fmt.Errorf("failed to encrypt config for test connection: %s", err)
 	}
 	workflowID := fmt.Sprintf("test-connection-%s-%d", req.Type, time.Now().Unix())
-	result, err := s.temporal.VerifyDriverCredentials(ctx, workflowID, "destination", driver, version, encryptedConfig)
+	result, err := s.temporal.VerifyDriverCredentials(ctx, req.WorkerPool, workflowID, "destination", driver, version, encryptedConfig)
 	// TODO: handle from frontend
 	if result == nil {
 		result = map[string]interface{}{
@@ -232,7 +249,7 @@ func (s Service) TestDestinationConnection(ctx context.Context, req *dto.Destina
 	}
 
 	if err != nil {
-		return result, nil, fmt.Errorf("connection test failed: %s", err)
+		return result, nil, fmt.Errorf("connection test failed: %w", err)
 	}
 
 	homeDir := constants.DefaultConfigDir
@@ -267,9 +284,9 @@ func (s Service) GetDestinationSpec(ctx context.Context, req *dto.SpecRequest) (
 		return dto.SpecResponse{}, fmt.Errorf("failed to get driver image tags: %s", err)
 	}
 
-	specOut, err := s.temporal.GetDriverSpecs(ctx, req.Type, driver, req.Version)
+	specOut, err := s.temporal.GetDriverSpecs(ctx, req.WorkerPool, req.Type, driver, req.Version)
 	if err != nil {
-		return dto.SpecResponse{}, fmt.Errorf("failed to get spec: %s", err)
+		return dto.SpecResponse{}, fmt.Errorf("failed to get spec: %w", err)
 	}
 
 	return dto.SpecResponse{
@@ -4,16 +4,19 @@ import (
 	"archive/tar"
 	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
 	"github.com/datazip-inc/olake-ui/server/internal/models"
 	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
 	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
@@ -25,17 +28,72 @@ import (
 
 // Job-related methods on AppService
 
-func (s Service) ListJobs(ctx context.Context, projectID string) ([]dto.JobResponse, error) {
-	jobs, err := s.db.ListJobsByProjectID(projectID)
+func (s Service) ListJobs(ctx context.Context, projectID string, query dto.ListQuery) (*dto.PaginatedResponse, error) {
+	opts, err := toListOptions(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Last run status lives in temporal, so when filtering on it every matching job is
+	// loaded and the page is cut in memory after the status filter is applied.
+	filterByLastRun := query.LastRunStatus != ""
+	if filterByLastRun {
+		opts.Limit = 0
+		opts.Cursor = nil
+	}
+
+	jobs, total, err := s.db.ListJobsByProjectID(projectID, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list jobs: %s", err)
 	}
 
+	var nextCursor string
+	cursorOf := func(job *models.Job) database.Cursor {
+		return database.NewCursor(opts.SortBy, job.ID, job.Name, job.BaseModel)
+	}
+	if !filterByLastRun {
+		jobs, nextCursor = nextPage(jobs, query.Limit, cursorOf)
+	}
+
 	lastRunByJobID, err := fetchLatestJobRunsByJobIDs(ctx, s.temporal, projectID, jobs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch latest job runs from temporal: %s", err)
 	}
 
+	if filterByLastRun {
+		filtered := make([]*models.Job, 0, len(jobs))
+		for _, job := range jobs {
+			if lr, ok := lastRunByJobID[job.ID]; ok && strings.EqualFold(lr.LastRunState, query.LastRunStatus) {
+				filtered = append(filtered, job)
+			}
+		}
+		total = int64(len(filtered))
+
+		filtered, err = skipToCursor(filtered, query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		jobs, nextCursor = nextPage(filtered, query.Limit, cursorOf)
+	}
+
+	jobIDs := make([]int, 0, len(jobs))
+	for _, job := range jobs {
+		jobIDs = append(jobIDs, job.ID)
+	}
+	unappliedByJobID, err := s.db.GetUnappliedScheduleOperations(jobIDs)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.db.GetProjectSettingsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := s.db.ListMaintenanceWindows(projectID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
 	jobResponses := make([]dto.JobResponse, 0, len(jobs))
 	for _, job := range jobs {
 		var lastRun *JobLastRunInfo
@@ -43,15 +101,22 @@ func (s Service) ListJobs(ctx context.Context, projectID string) ([]dto.JobRespo
 			lastRun = &lr
 		}
 
-		jobResp, err := s.buildJobResponse(job, lastRun, false)
+		jobResp, err := s.buildJobResponse(job, lastRun, settings.DefaultTimeZone, false)
 		if err != nil {
 			return nil, fmt.Errorf("failed to build job response: %s", err)
 		}
+		setScheduleStatus(&jobResp, unappliedByJobID[job.ID])
+		setMaintenanceWindows(&jobResp, job, windows, settings.DefaultTimeZone, now)
 
 		jobResponses = append(jobResponses, jobResp)
 	}
 
-	return jobResponses, nil
+	return &dto.PaginatedResponse{
+		Items:      jobResponses,
+		Total:      total,
+		Limit:      query.Limit,
+		NextCursor: nextCursor,
+	}, nil
 }
 
 func (s Service) GetJob(ctx context.Context, projectID string, jobID int) (*dto.JobResponse, error) {
@@ -75,11 +140,26 @@ func (s Service) GetJob(ctx context.Context, projectID string, jobID int) (*dto.
 		lastRun = &lr
 	}
 
-	jobResponse, err := s.buildJobResponse(job, lastRun, true)
+	settings, err := s.db.GetProjectSettingsByProjectID(job.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	jobResponse, err := s.buildJobResponse(job, lastRun, settings.DefaultTimeZone, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build job response: %s", err)
 	}
 
+	unapplied, err := s.db.GetUnappliedScheduleOperations([]int{job.ID})
+	if err != nil {
+		return nil, err
+	}
+	setScheduleStatus(&jobResponse, unapplied[job.ID])
+	windows, err := s.db.ListJobMaintenanceWindows(job.ProjectID, job.SourceID, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	setMaintenanceWindows(&jobResponse, job, windows, settings.DefaultTimeZone, time.Now())
+
 	return &jobResponse, nil
 }
 
@@ -104,14 +184,9 @@ func (s Service) CreateJob(ctx context.Context, req *dto.CreateJobRequest, proje
 
 	user := &models.User{ID: *userID}
 
-	var advancedSettings *string
-	if req.AdvancedSettings != nil {
-		b, err := json.Marshal(req.AdvancedSettings)
-		if err != nil {
-			return fmt.Errorf("failed to serialise advanced_settings: %s", err)
-		}
-		s := string(b)
-		advancedSettings = &s
+	advancedSettings, err := marshalAdvancedSettings(req.AdvancedSettings, nil)
+	if err != nil {
+		return err
 	}
 
 	job := &models.Job{
@@ -122,36 +197,45 @@ func (s Service) CreateJob(ctx context.Context, req *dto.CreateJobRequest, proje
 		Destination:      dest,
 		Active:           true,
 		Frequency:        req.Frequency,
+		TimeZone:         req.TimeZone,
 		StreamsConfig:    req.StreamsConfig,
 		State:            "{}",
 		AdvancedSettings: advancedSettings,
+		Labels:           req.Labels,
 		ProjectID:        projectID,
 		CreatedByID:      user.ID,
 		UpdatedByID:      user.ID,
 		CreatedBy:        user,
 		UpdatedBy:        user,
 	}
-	if err := s.db.CreateJob(job); err != nil {
-		return fmt.Errorf("failed to create job: %s", err)
+	if err := s.temporal.CheckWorkerPool(ctx, temporal.JobWorkerPool(job)); err != nil {
+		return err
 	}
-
-	defer func() {
-		if err != nil {
-			if err := s.db.DeleteJob(job.ID); err != nil {
-				logger.Errorf("failed to delete job: %s", err)
-			}
+	err = s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.CreateJob(job); err != nil {
+			return fmt.Errorf("failed to create job: %s", err)
 		}
-	}()
-
-	if err = s.temporal.CreateSchedule(ctx, job); err != nil {
-		return fmt.Errorf("failed to create temporal workflow: %s", err)
+		if err := tx.SaveJobRevision(&models.JobRevision{JobID: job.ID, Change: constants.JobRevisionChangeCreate, CreatedByID: user.ID}); err != nil {
+			return err
+		}
+		operations := append([]string{constants.ScheduleOpCreate}, runOnceOperations(job.Frequency, true)...)
+		return tx.EnqueueScheduleOperations(projectID, job.ID, operations...)
+	})
+	if err != nil {
+		return err
 	}
 
 	telemetry.TrackJobCreation(ctx, job)
-	return nil
+	return s.applyScheduleOperations(ctx, job.ID)
 }
 
 func (s Service) UpdateJob(ctx context.Context, req *dto.UpdateJobRequest, projectID string, jobID int, userID *int) error {
+	return s.updateJob(ctx, req, projectID, jobID, userID, &models.JobRevision{Change: constants.JobRevisionChangeUpdate})
+}
+
+// updateJob saves a job change and records it as the revision rev, which gets the number the
+// revision is saved with, if the configuration changed.
+func (s Service) updateJob(ctx context.Context, req *dto.UpdateJobRequest, projectID string, jobID int, userID *int, rev *models.JobRevision) error {
 	// TODO: remove fetching existing job from database to verify it's existence, fetch only if the details aren't already available in the params/request. If job not exists it will fail during query execution.
 	existingJob, err := s.db.GetJobByID(jobID, true)
 	if err != nil {
@@ -160,6 +244,12 @@ func (s Service) UpdateJob(ctx context.Context, req *dto.UpdateJobRequest, proje
 		}
 		return fmt.Errorf("failed to get job: %s", err)
 	}
+	// a deprecated frequency the job had in the revision rolled back to is kept
+	if rev.Change != constants.JobRevisionChangeRollback {
+		if err := validateJobFrequency(req.Frequency, existingJob); err != nil {
+			return err
+		}
+	}
 
 	// Block when clear-destination is running
 	clearRunning, _, err := isWorkflowRunning(ctx, s.temporal, projectID, jobID, temporal.ClearDestination)
@@ -208,30 +298,57 @@ func (s Service) UpdateJob(ctx context.Context, req *dto.UpdateJobRequest, proje
 		"project_id":     projectID,
 		"updated_by_id":  *userID,
 	}
-	if req.AdvancedSettings != nil {
-		b, err := json.Marshal(req.AdvancedSettings)
-		if err != nil {
-			return fmt.Errorf("failed to serialise advanced_settings: %s", err)
-		}
-		updateParams["advanced_settings"] = string(b)
+	advancedSettings, err := marshalAdvancedSettings(req.AdvancedSettings, existingJob.AdvancedSettings)
+	if err != nil {
+		return err
+	}
+	if advancedSettings != nil {
+		updateParams["advanced_settings"] = *advancedSettings
 	} else {
 		updateParams["advanced_settings"] = nil
 	}
 
-	if err := s.db.UpdateJob(existingJob.ID, updateParams); err != nil {
-		return fmt.Errorf("failed to update job: %s", err)
+	timeZone := existingJob.TimeZone
+	if req.TimeZone != nil {
+		timeZone = *req.TimeZone
+		updateParams["time_zone"] = timeZone
 	}
 
-	// TODO: check compensation/outbox pattern or any relevant measures to handle failure of update schedule workflow
-	// Update temporal schedule only if frequency has changed
-	if req.Frequency != existingJob.Frequency {
-		err = s.temporal.UpdateSchedule(ctx, req.Frequency, projectID, existingJob.ID, nil)
-		if err != nil {
-			return fmt.Errorf("failed to update temporal workflow: %s", err)
+	// the schedule follows the frequency, time zone, maintenance windows of the source,
+	// execution policy, worker pool, driver container and activation status saved with the job
+	var operations []string
+	updatedJob := &models.Job{AdvancedSettings: advancedSettings, Source: source}
+	policyChanged := temporal.JobExecutionPolicy(existingJob) != temporal.JobExecutionPolicy(updatedJob)
+	poolChanged := temporal.JobWorkerPool(existingJob) != temporal.JobWorkerPool(updatedJob)
+	driverChanged := temporal.JobDriverResources(existingJob) != temporal.JobDriverResources(updatedJob) ||
+		!slices.Equal(temporal.JobDriverEnv(existingJob), temporal.JobDriverEnv(updatedJob))
+	if poolChanged {
+		if err := s.temporal.CheckWorkerPool(ctx, temporal.JobWorkerPool(updatedJob)); err != nil {
+			return err
+		}
+	}
+	if req.Frequency != existingJob.Frequency || timeZone != existingJob.TimeZone || source.ID != existingJob.SourceID || policyChanged || poolChanged || driverChanged {
+		operations = append(operations, constants.ScheduleOpUpdate)
+	}
+	if req.Activate != existingJob.Active {
+		operations = append(operations, utils.Ternary(req.Activate, constants.ScheduleOpResume, constants.ScheduleOpPause).(string))
+	}
+	if existingJob.Frequency != constants.FrequencyOnce || !existingJob.Active {
+		operations = append(operations, runOnceOperations(req.Frequency, req.Activate)...)
+	}
+	rev.JobID = existingJob.ID
+	rev.CreatedByID = *userID
+	err = s.db.Transaction(func(tx *database.Database) error {
+		if err := updateJobWithRevision(tx, existingJob.ID, updateParams, rev); err != nil {
+			return err
 		}
+		return tx.EnqueueScheduleOperations(projectID, existingJob.ID, operations...)
+	})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return s.applyScheduleOperations(ctx, existingJob.ID)
 }
 
 func (s Service) DeleteJob(ctx context.Context, jobID int) (string, error) {
@@ -243,18 +360,36 @@ func (s Service) DeleteJob(ctx context.Context, jobID int) (string, error) {
 		return "", fmt.Errorf("failed to find job: %s", err)
 	}
 
-	if err = s.temporal.DeleteSchedule(ctx, job.ProjectID, job.ID); err != nil {
-		return "", fmt.Errorf("failed to delete temporal workflow: %s", err)
+	// a clear-destination is left to finish, running syncs are cancelled with the job
+	clearRunning, _, err := isWorkflowRunning(ctx, s.temporal, job.ProjectID, job.ID, temporal.ClearDestination)
+	if err != nil {
+		return "", fmt.Errorf("failed to check if clear-destination is running: %s", err)
+	}
+	if clearRunning {
+		return "", fmt.Errorf("%w: clear-destination is in progress, please wait for it to finish", constants.ErrJobNotIdle)
+	}
+	if err := cancelAllJobWorkflows(ctx, s.temporal, []*models.Job{job}, job.ProjectID); err != nil {
+		return "", fmt.Errorf("failed to cancel sync: %s", err)
 	}
 
-	if err := s.db.DeleteJob(jobID); err != nil {
-		return "", fmt.Errorf("failed to delete job: %s", err)
+	// the job goes to the trash, so its schedule is paused and kept for a restore
+	err = s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.DeleteJob(jobID); err != nil {
+			return fmt.Errorf("failed to delete job: %s", err)
+		}
+		return tx.EnqueueScheduleOperations(job.ProjectID, job.ID, constants.ScheduleOpPause)
+	})
+	if err != nil {
+		return "", err
 	}
 
-	return job.Name, nil
+	return job.Name, s.applyScheduleOperations(ctx, job.ID)
 }
 
-func (s Service) SyncJob(ctx context.Context, projectID string, jobID int) (interface{}, error) {
+// SyncJob triggers a sync of a job through its schedule and returns the run it started, or the
+// scheduled run already in progress. With a stream subset or run overrides a one-off sync is
+// started instead, see adHocSync.
+func (s Service) SyncJob(ctx context.Context, projectID string, jobID int, req *dto.SyncJobRequest) (*dto.SyncJobResponse, error) {
 	job, err := s.db.GetJobByID(jobID, true)
 	if err != nil {
 		if errors.Is(err, constants.ErrJobNotFound) {
@@ -266,14 +401,80 @@ func (s Service) SyncJob(ctx context.Context, projectID string, jobID int) (inte
 	if !job.Active {
 		return nil, fmt.Errorf("job is paused, please unpause to run sync")
 	}
+	if err := s.checkMaintenanceWindow(job, req != nil && req.OverrideMaintenanceWindow); err != nil {
+		return nil, err
+	}
 
-	if err := s.temporal.TriggerSchedule(ctx, projectID, jobID); err != nil {
+	if req != nil && (len(req.Streams) > 0 || req.Overrides != nil) {
+		// a one-off sync does not go through the schedule, it cannot wait in the queue
+		reason, err := s.syncBlockedReason(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+		if reason != "" {
+			return nil, fmt.Errorf("%w: %s", constants.ErrConcurrencyLimit, reason)
+		}
+		return s.adHocSync(ctx, job, req)
+	}
+	return s.triggerScheduledSync(ctx, job)
+}
+
+// triggerScheduledSync triggers a sync of a job through its schedule and returns the run it
+// started, the scheduled run already in progress, or that the sync was queued by a
+// concurrency cap. It refuses while a clear-destination or a one-off sync is running, or the
+// schedule cannot be triggered for a sync.
+func (s Service) triggerScheduledSync(ctx context.Context, job *models.Job) (*dto.SyncJobResponse, error) {
+	projectID, jobID := job.ProjectID, job.ID
+	// the schedule only knows its own runs, and a trigger fires even while it is paused
+	clearRunning, _, err := isWorkflowRunning(ctx, s.temporal, projectID, jobID, temporal.ClearDestination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if clear-destination is running: %s", err)
+	}
+	if clearRunning {
+		return nil, fmt.Errorf("%w: clear-destination is in progress", constants.ErrJobNotIdle)
+	}
+	syncRunning, executions, err := isWorkflowRunning(ctx, s.temporal, projectID, jobID, temporal.Sync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check sync status: %s", err)
+	}
+	if syncRunning {
+		execution := executions[0].Execution
+		if strings.HasPrefix(execution.WorkflowId, temporal.AdHocWorkflowPrefix(projectID, jobID)) {
+			return nil, fmt.Errorf("%w: ad-hoc sync is in progress, please wait or cancel the sync", constants.ErrJobNotIdle)
+		}
+		return &dto.SyncJobResponse{Message: "sync is already running", AlreadyRunning: true, WorkflowID: execution.WorkflowId, RunID: execution.RunId}, nil
+	}
+	blocked, err := s.scheduleTriggerBlocked(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check schedule: %s", err)
+	}
+	if blocked != "" {
+		return nil, fmt.Errorf("%w: %s", constants.ErrJobNotIdle, blocked)
+	}
+
+	reason, err := s.admitSync(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		return &dto.SyncJobResponse{Message: fmt.Sprintf("sync queued: %s", reason), Queued: true}, nil
+	}
+
+	run, running, err := s.temporal.TriggerScheduleRun(ctx, projectID, jobID)
+	if err != nil {
 		return nil, fmt.Errorf("failed to trigger sync: %s", err)
 	}
 
-	return map[string]any{
-		"message": "sync triggered successfully",
-	}, nil
+	resp := &dto.SyncJobResponse{Message: "sync triggered successfully", AlreadyRunning: running}
+	if running {
+		resp.Message = "sync is already running"
+	}
+	if run != nil {
+		resp.WorkflowID, resp.RunID = run.WorkflowID, run.FirstExecutionRunID
+	} else {
+		logger.Warnf("sync of job_id[%d] was triggered but its run was not found within %s", jobID, constants.SyncTriggerWaitTimeout)
+	}
+	return resp, nil
 }
 
 func (s Service) CancelJobRun(ctx context.Context, projectID string, jobID int) error {
@@ -305,26 +506,28 @@ func (s Service) ActivateJob(ctx context.Context, jobID int, req dto.JobStatusRe
 		return nil
 	}
 
-	if req.Activate {
-		if err := s.temporal.ResumeSchedule(ctx, job.ProjectID, job.ID); err != nil {
-			return fmt.Errorf("failed to unpause schedule: %s", err)
-		}
-	} else {
-		if err := s.temporal.PauseSchedule(ctx, job.ProjectID, job.ID); err != nil {
-			return fmt.Errorf("failed to pause schedule: %s", err)
-		}
-	}
+	return s.setJobActive(ctx, job, req.Activate, *userID)
+}
 
+// setJobActive saves the activation status of a job together with the pause or resume of its
+// schedule, then applies the schedule change.
+func (s Service) setJobActive(ctx context.Context, job *models.Job, active bool, userID int) error {
 	updateParams := map[string]any{
-		"active":        req.Activate,
-		"updated_by_id": *userID,
+		"active":        active,
+		"updated_by_id": userID,
 	}
-
-	if err := s.db.UpdateJob(job.ID, updateParams); err != nil {
-		return fmt.Errorf("failed to update job activation status: %s", err)
+	err := s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.UpdateJob(job.ID, updateParams); err != nil {
+			return fmt.Errorf("failed to update job activation status: %s", err)
+		}
+		operations := append([]string{utils.Ternary(active, constants.ScheduleOpResume, constants.ScheduleOpPause).(string)}, runOnceOperations(job.Frequency, active)...)
+		return tx.EnqueueScheduleOperations(job.ProjectID, job.ID, operations...)
+	})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return s.applyScheduleOperations(ctx, job.ID)
 }
 
 func (s Service) ClearDestination(ctx context.Context, projectID string, jobID int, streamsConfig string, syncWaitTime time.Duration, resetState bool) error {
@@ -361,7 +564,8 @@ func (s Service) ClearDestination(ctx context.Context, projectID string, jobID i
 
 	// for manual clear-destination, update the state file to empty object
 	if resetState {
-		if err := s.UpdateStateFile(jobID, "{}"); err != nil {
+		snapshot := &models.JobStateSnapshot{JobID: jobID, Reason: constants.StateSnapshotReasonClearDestination}
+		if err := s.db.SaveJobState("{}", snapshot); err != nil {
 			return fmt.Errorf("failed to update state file: %s", err)
 		}
 		logger.Infof("state file updated to {} for manual clear-destination for job_id[%d]", jobID)
@@ -441,7 +645,7 @@ func (s Service) CheckUniqueName(ctx context.Context, projectID string, req dto.
 	return unique, nil
 }
 
-func (s Service) GetJobTasks(ctx context.Context, projectID string, jobID int) ([]dto.JobTask, error) {
+func (s Service) GetJobTasks(ctx context.Context, projectID string, jobID int, query dto.JobTaskListQuery) (*dto.PaginatedResponse, error) {
 	job, err := s.db.GetJobByID(jobID, true)
 	if err != nil {
 		if errors.Is(err, constants.ErrJobNotFound) {
@@ -450,37 +654,75 @@ func (s Service) GetJobTasks(ctx context.Context, projectID string, jobID int) (
 		return nil, fmt.Errorf("failed to find job: %s", err)
 	}
 
-	var tasks []dto.JobTask
-	query := fmt.Sprintf("WorkflowId BETWEEN 'sync-%s-%d-' AND 'sync-%s-%d-z'", projectID, job.ID, projectID, job.ID)
+	nextPageToken, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", constants.ErrInvalidCursor, err)
+	}
+
+	visibilityQuery := fmt.Sprintf("WorkflowId BETWEEN 'sync-%s-%d-' AND 'sync-%s-%d-z'", projectID, job.ID, projectID, job.ID)
+	if query.Status != "" {
+		visibilityQuery += fmt.Sprintf(" AND ExecutionStatus = '%s'", query.Status)
+	}
+	switch query.Type {
+	case "sync":
+		visibilityQuery += fmt.Sprintf(" AND OperationType != '%s'", temporal.ClearDestination)
+	case "clear":
+		visibilityQuery += fmt.Sprintf(" AND OperationType = '%s'", temporal.ClearDestination)
+	}
 
-	resp, err := s.temporal.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
-		Query: query,
-	})
+	total, err := s.temporal.CountWorkflow(ctx, visibilityQuery)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list workflows: %s", err)
+		return nil, fmt.Errorf("failed to count workflows: %s", err)
 	}
 
-	for _, execution := range resp.Executions {
-		startTime := execution.StartTime.AsTime().UTC()
-		var runTime string
-		if execution.CloseTime != nil {
-			runTime = execution.CloseTime.AsTime().UTC().Sub(startTime).Round(time.Second).String()
-		} else {
-			runTime = time.Since(startTime).Round(time.Second).String()
-		}
+	pageSize := utils.Ternary(query.Limit > 0, query.Limit, constants.DefaultListWorkflowPageSize).(int)
+	tasks := []dto.JobTask{}
 
-		opType := syncWorkflowOperationType(execution)
-		jobType := utils.Ternary(opType == temporal.Sync, "sync", "clear").(string)
-		tasks = append(tasks, dto.JobTask{
-			Runtime:   runTime,
-			StartTime: startTime.Format(time.RFC3339),
-			Status:    execution.Status.String(),
-			FilePath:  execution.Execution.WorkflowId,
-			JobType:   jobType,
+	// Without a limit every page is followed; with a limit a single page is returned
+	// and the visibility page token is handed back as the cursor.
+	for {
+		resp, err := s.temporal.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         visibilityQuery,
+			PageSize:      int32(pageSize),
+			NextPageToken: nextPageToken,
 		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workflows: %s", err)
+		}
+
+		for _, execution := range resp.Executions {
+			startTime := execution.StartTime.AsTime().UTC()
+			var runTime string
+			if execution.CloseTime != nil {
+				runTime = execution.CloseTime.AsTime().UTC().Sub(startTime).Round(time.Second).String()
+			} else {
+				runTime = time.Since(startTime).Round(time.Second).String()
+			}
+
+			opType := syncWorkflowOperationType(execution)
+			jobType := utils.Ternary(opType == temporal.Sync, "sync", "clear").(string)
+			tasks = append(tasks, dto.JobTask{
+				Runtime:   runTime,
+				StartTime: startTime.Format(time.RFC3339),
+				Status:    execution.Status.String(),
+				FilePath:  execution.Execution.WorkflowId,
+				JobType:   jobType,
+				AdHoc:     strings.HasPrefix(execution.Execution.WorkflowId, temporal.AdHocWorkflowPrefix(projectID, job.ID)),
+			})
+		}
+
+		nextPageToken = resp.NextPageToken
+		if query.Limit > 0 || len(nextPageToken) == 0 {
+			break
+		}
 	}
 
-	return tasks, nil
+	return &dto.PaginatedResponse{
+		Items:      tasks,
+		Total:      total,
+		Limit:      query.Limit,
+		NextCursor: base64.RawURLEncoding.EncodeToString(nextPageToken),
+	}, nil
 }
 
 func (s Service) GetTaskLogs(_ context.Context, jobID int, filePath string, cursor int64, limit int, direction string) (*dto.TaskLogsResponse, error) {
@@ -507,14 +749,18 @@ func (s Service) GetTaskLogs(_ context.Context, jobID int, filePath string, curs
 }
 
 // TODO: frontend needs to send source id and destination id
-func (s Service) buildJobResponse(job *models.Job, lastRun *JobLastRunInfo, includeConfig bool) (dto.JobResponse, error) {
+func (s Service) buildJobResponse(job *models.Job, lastRun *JobLastRunInfo, projectTimeZone string, includeConfig bool) (dto.JobResponse, error) {
 	jobResp := dto.JobResponse{
-		ID:        job.ID,
-		Name:      job.Name,
-		Frequency: job.Frequency,
-		CreatedAt: job.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: job.UpdatedAt.Format(time.RFC3339),
-		Activate:  job.Active,
+		ID:                job.ID,
+		Name:              job.Name,
+		Frequency:         job.Frequency,
+		ScheduleMode:      utils.ScheduleMode(job.Frequency),
+		TimeZone:          job.TimeZone,
+		EffectiveTimeZone: effectiveTimeZone(job.TimeZone, projectTimeZone),
+		CreatedAt:         job.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         job.UpdatedAt.Format(time.RFC3339),
+		Activate:          job.Active,
+		Labels:            labelsOrEmpty(job.Labels),
 	}
 
 	jobResp.StreamsConfig = utils.Ternary(includeConfig, job.StreamsConfig, "").(string)
@@ -557,8 +803,15 @@ func (s Service) buildJobResponse(job *models.Job, lastRun *JobLastRunInfo, incl
 		if err := json.Unmarshal([]byte(*job.AdvancedSettings), &advSettings); err != nil {
 			return dto.JobResponse{}, fmt.Errorf("failed to parse advanced_settings for job %d: %s", job.ID, err)
 		}
+		maskDriverEnv(&advSettings)
 		jobResp.AdvancedSettings = &advSettings
 	}
+	jobResp.ExecutionPolicy = temporal.JobExecutionPolicy(job).Response()
+	jobResp.WorkerPool = temporal.JobWorkerPool(job)
+	if taskQueue, err := s.temporal.PoolTaskQueue(jobResp.WorkerPool); err == nil {
+		jobResp.TaskQueue = taskQueue
+	}
+	jobResp.DriverResources = temporal.JobDriverResources(job).Response()
 
 	return jobResp, nil
 }
@@ -651,13 +904,53 @@ func (s Service) UpdateSyncTelemetry(ctx context.Context, req dto.UpdateSyncTele
 		telemetry.TrackSyncStart(ctx, req.JobID, req.WorkflowID, req.Environment)
 	case "completed":
 		telemetry.TrackSyncCompleted(req.JobID, req.WorkflowID, req.Environment)
+		if err := s.pauseOnceJob(ctx, req.JobID); err != nil {
+			logger.Errorf("failed to pause once job_id[%d] after its run: %s", req.JobID, err)
+		}
+		s.syncRunReported(ctx, req.JobID, req.WorkflowID, true)
 	case "failed":
 		telemetry.TrackSyncFailed(req.JobID, req.WorkflowID, req.Environment)
+		s.syncRunReported(ctx, req.JobID, req.WorkflowID, false)
 	}
 
 	return nil
 }
 
+// syncRunReported hands a sync the worker reported as finished to the jobs depending on its job
+func (s Service) syncRunReported(ctx context.Context, jobID int, workflowID string, succeeded bool) {
+	job, err := s.db.GetJobByID(jobID, false)
+	if err != nil {
+		logger.Warnf("failed to get job_id[%d] of reported sync %s: %s", jobID, workflowID, err)
+		return
+	}
+	s.upstreamRunClosed(ctx, job.ProjectID, jobID, workflowID, succeeded, time.Now())
+}
+
+// pauseOnceJob deactivates a job of the once frequency after a successful run. A failed run
+// leaves it active so that it can be triggered again.
+func (s Service) pauseOnceJob(ctx context.Context, jobID int) error {
+	job, err := s.db.GetJobByID(jobID, false)
+	if err != nil {
+		if errors.Is(err, constants.ErrJobNotFound) {
+			return nil
+		}
+		return err
+	}
+	if job.Frequency != constants.FrequencyOnce || !job.Active {
+		return nil
+	}
+	logger.Infof("once job_id[%d] has run, pausing it", jobID)
+	return s.setJobActive(ctx, job, false, job.UpdatedByID)
+}
+
+// runOnceOperations returns the trigger that starts the run of a once job being activated
+func runOnceOperations(frequency string, active bool) []string {
+	if active && frequency == constants.FrequencyOnce {
+		return []string{constants.ScheduleOpTrigger}
+	}
+	return nil
+}
+
 // RecoverFromClearDestination cancels stuck clear-destination workflows and restores normal sync schedule
 // This is an internal recovery API for when clear-destination gets stuck in infinite retry
 func (s Service) RecoverFromClearDestination(ctx context.Context, projectID string, jobID int) error {
@@ -754,8 +1047,10 @@ func (s Service) StreamLogArchive(jobID int, taskLogFilePath string, writer io.W
 	return nil
 }
 
-func (s Service) UpdateStateFile(jobID int, stateFile string) error {
-	_, err := s.db.GetJobByID(jobID, true)
+// UpdateStateFile saves the state written by a sync of a job. The state of an ad-hoc sync is
+// merged into the current state, see mergeStreamStates.
+func (s Service) UpdateStateFile(ctx context.Context, jobID int, req *dto.UpdateStateFileRequest) error {
+	job, err := s.db.GetJobByID(jobID, false)
 	if err != nil {
 		if errors.Is(err, constants.ErrJobNotFound) {
 			return fmt.Errorf("%w: %v", constants.ErrJobNotFound, err)
@@ -763,10 +1058,34 @@ func (s Service) UpdateStateFile(jobID int, stateFile string) error {
 		return fmt.Errorf("job not found: %s", err)
 	}
 
-	if err := s.db.UpdateJob(jobID, map[string]any{"state": stateFile}); err != nil {
+	snapshot := &models.JobStateSnapshot{
+		JobID:      jobID,
+		Reason:     constants.StateSnapshotReasonSync,
+		WorkflowID: req.WorkflowID,
+		RunID:      req.RunID,
+	}
+	if snapshot.WorkflowID == "" {
+		// the state is written by the sync while it runs
+		running, executions, err := isWorkflowRunning(ctx, s.temporal, job.ProjectID, jobID, temporal.Sync)
+		if err != nil {
+			logger.Warnf("failed to find the sync that wrote the state of job_id[%d]: %s", jobID, err)
+		} else if running {
+			snapshot.WorkflowID = executions[0].Execution.WorkflowId
+			snapshot.RunID = executions[0].Execution.RunId
+		}
+	}
+	state := req.StateFile
+	if strings.HasPrefix(snapshot.WorkflowID, temporal.AdHocWorkflowPrefix(job.ProjectID, jobID)) {
+		// an ad-hoc sync may run some streams only; its schedule is held paused while it
+		// runs, so no other sync writes the state meanwhile
+		if state, err = mergeStreamStates(job.State, req.StateFile); err != nil {
+			return err
+		}
+	}
+	if err := s.db.SaveJobState(state, snapshot); err != nil {
 		return fmt.Errorf("failed to update job: %s", err)
 	}
 
-	logger.Infof("state file updated successfully for job_id[%d] with state: %s", jobID, stateFile)
+	logger.Infof("state file updated successfully for job_id[%d] with state: %s", jobID, state)
 	return nil
 }
@@ -0,0 +1,64 @@
+package etl
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+)
+
+// Label-related methods on AppService, shared by jobs, sources and destinations.
+// The entity is identified by its table type.
+
+// GetLabels returns the labels of the entity.
+func (s Service) GetLabels(_ context.Context, entity constants.TableType, projectID string, id int) (*dto.LabelsResponse, error) {
+	labels, err := s.db.GetLabels(entity, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.LabelsResponse{Labels: labels}, nil
+}
+
+// ReplaceLabels overwrites every label of the entity.
+func (s Service) ReplaceLabels(_ context.Context, entity constants.TableType, projectID string, id int, req *dto.ReplaceLabelsRequest) (*dto.LabelsResponse, error) {
+	labels := models.Labels(req.Labels)
+	if err := models.ValidateLabels(labels); err != nil {
+		return nil, err
+	}
+	if err := s.db.SetLabels(entity, projectID, id, labels); err != nil {
+		return nil, err
+	}
+	return &dto.LabelsResponse{Labels: labelsOrEmpty(labels)}, nil
+}
+
+// PatchLabels merges the given labels into the existing ones and removes the listed keys.
+func (s Service) PatchLabels(ctx context.Context, entity constants.TableType, projectID string, id int, req *dto.PatchLabelsRequest) (*dto.LabelsResponse, error) {
+	labels, err := s.db.GetLabels(entity, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	maps.Copy(labels, req.Set)
+	for _, key := range req.Remove {
+		delete(labels, key)
+	}
+
+	return s.ReplaceLabels(ctx, entity, projectID, id, &dto.ReplaceLabelsRequest{Labels: labels})
+}
+
+// DeleteLabel removes a single label key from the entity.
+func (s Service) DeleteLabel(ctx context.Context, entity constants.TableType, projectID string, id int, key string) (*dto.LabelsResponse, error) {
+	labels, err := s.db.GetLabels(entity, projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := labels[key]; !ok {
+		return nil, fmt.Errorf("%w: key %q is not set", constants.ErrLabelNotFound, key)
+	}
+	delete(labels, key)
+
+	return s.ReplaceLabels(ctx, entity, projectID, id, &dto.ReplaceLabelsRequest{Labels: labels})
+}
@@ -0,0 +1,319 @@
+package etl
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+)
+
+// Maintenance window methods on AppService
+
+// ListMaintenanceWindows returns the maintenance windows of a project. Whether a window
+// without a time zone of its own is in effect is reported on the project default time zone.
+func (s Service) ListMaintenanceWindows(_ context.Context, projectID string) ([]dto.MaintenanceWindowItem, error) {
+	settings, err := s.db.GetProjectSettingsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := s.db.ListMaintenanceWindows(projectID)
+	if err != nil {
+		return nil, err
+	}
+	timeZone := effectiveTimeZone("", settings.DefaultTimeZone)
+	now := time.Now()
+	items := make([]dto.MaintenanceWindowItem, 0, len(windows))
+	for _, window := range windows {
+		items = append(items, maintenanceWindowItem(window, timeZone, now))
+	}
+	return items, nil
+}
+
+// CreateMaintenanceWindow saves a maintenance window and excludes it from the schedules of
+// the jobs it applies to
+func (s Service) CreateMaintenanceWindow(ctx context.Context, projectID string, req *dto.MaintenanceWindowRequest, userID *int) (*dto.MaintenanceWindowItem, error) {
+	if err := s.checkMaintenanceScope(projectID, req.SourceID, req.JobID); err != nil {
+		return nil, err
+	}
+	window := &models.MaintenanceWindow{ProjectID: projectID}
+	setMaintenanceWindowFields(window, req)
+	if userID != nil {
+		window.CreatedByID = *userID
+	}
+
+	var jobIDs []int
+	err := s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.CreateMaintenanceWindow(window); err != nil {
+			return err
+		}
+		var err error
+		jobIDs, err = enqueueMaintenanceScheduleUpdates(tx, projectID, window)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.applyMaintenanceScheduleUpdates(ctx, jobIDs)
+	return s.getMaintenanceWindowItem(projectID, window.ID)
+}
+
+// UpdateMaintenanceWindow replaces a maintenance window. The schedules of the jobs it applied
+// to before and of those it applies to now are updated.
+func (s Service) UpdateMaintenanceWindow(ctx context.Context, projectID string, id int, req *dto.MaintenanceWindowRequest) (*dto.MaintenanceWindowItem, error) {
+	window, err := s.db.GetMaintenanceWindow(projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMaintenanceScope(projectID, req.SourceID, req.JobID); err != nil {
+		return nil, err
+	}
+	previous := *window
+	setMaintenanceWindowFields(window, req)
+
+	var jobIDs []int
+	err = s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.UpdateMaintenanceWindow(window); err != nil {
+			return err
+		}
+		for _, w := range []*models.MaintenanceWindow{&previous, window} {
+			ids, err := enqueueMaintenanceScheduleUpdates(tx, projectID, w)
+			if err != nil {
+				return err
+			}
+			jobIDs = append(jobIDs, ids...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.applyMaintenanceScheduleUpdates(ctx, jobIDs)
+	return s.getMaintenanceWindowItem(projectID, window.ID)
+}
+
+// DeleteMaintenanceWindow removes a maintenance window from a project and from the schedules
+// of the jobs it applied to
+func (s Service) DeleteMaintenanceWindow(ctx context.Context, projectID string, id int) error {
+	window, err := s.db.GetMaintenanceWindow(projectID, id)
+	if err != nil {
+		return err
+	}
+	var jobIDs []int
+	err = s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.DeleteMaintenanceWindow(projectID, id); err != nil {
+			return err
+		}
+		var err error
+		jobIDs, err = enqueueMaintenanceScheduleUpdates(tx, projectID, window)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	s.applyMaintenanceScheduleUpdates(ctx, jobIDs)
+	return nil
+}
+
+// checkMaintenanceScope checks that the source or job a window is scoped to belongs to the
+// project
+func (s Service) checkMaintenanceScope(projectID string, sourceID, jobID *int) error {
+	if jobID != nil {
+		_, err := s.getProjectJob(projectID, *jobID)
+		return err
+	}
+	if sourceID != nil {
+		source, err := s.db.GetSourceByID(*sourceID)
+		if err != nil {
+			if errors.Is(err, constants.ErrSourceNotFound) {
+				return fmt.Errorf("%w: %v", constants.ErrSourceNotFound, err)
+			}
+			return fmt.Errorf("failed to get source: %s", err)
+		}
+		if source.ProjectID != projectID {
+			return fmt.Errorf("%w: source not found id[%d] project_id[%s]", constants.ErrSourceNotFound, *sourceID, projectID)
+		}
+	}
+	return nil
+}
+
+// getMaintenanceWindowItem reads a saved window back with its author
+func (s Service) getMaintenanceWindowItem(projectID string, id int) (*dto.MaintenanceWindowItem, error) {
+	window, err := s.db.GetMaintenanceWindow(projectID, id)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.db.GetProjectSettingsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	item := maintenanceWindowItem(window, effectiveTimeZone("", settings.DefaultTimeZone), time.Now())
+	return &item, nil
+}
+
+// enqueueMaintenanceScheduleUpdates queues a schedule update for every job a window applies
+// to and returns their IDs
+func enqueueMaintenanceScheduleUpdates(tx *database.Database, projectID string, window *models.MaintenanceWindow) ([]int, error) {
+	jobIDs, err := tx.ListMaintenanceJobIDs(projectID, window.SourceID, window.JobID)
+	if err != nil {
+		return nil, err
+	}
+	for _, jobID := range jobIDs {
+		if err := tx.EnqueueScheduleOperations(projectID, jobID, constants.ScheduleOpUpdate); err != nil {
+			return nil, err
+		}
+	}
+	return jobIDs, nil
+}
+
+// applyMaintenanceScheduleUpdates applies the schedule updates queued for a window change.
+// The window is saved either way, schedules not updated here are left to the dispatcher.
+func (s Service) applyMaintenanceScheduleUpdates(ctx context.Context, jobIDs []int) {
+	slices.Sort(jobIDs)
+	for _, jobID := range slices.Compact(jobIDs) {
+		if err := s.applyScheduleOperations(ctx, jobID); err != nil {
+			logger.Warnf("schedule of job_id[%d] not yet updated for maintenance windows: %s", jobID, err)
+		}
+	}
+}
+
+// checkMaintenanceWindow refuses a manual run of a job while it is in one of its maintenance
+// windows, unless the caller overrides the window
+func (s Service) checkMaintenanceWindow(job *models.Job, override bool) error {
+	if override {
+		return nil
+	}
+	timeZone, err := s.scheduleTimeZone(job)
+	if err != nil {
+		return err
+	}
+	windows, err := s.db.ListJobMaintenanceWindows(job.ProjectID, job.SourceID, job.ID)
+	if err != nil {
+		return err
+	}
+	if window, end := activeMaintenanceWindow(windows, timeZone, time.Now()); window != nil {
+		return fmt.Errorf("%w: '%s' ends at %s, set override_maintenance_window to run job_id[%d] anyway",
+			constants.ErrInMaintenanceWindow, window.Name, end.UTC().Format(time.RFC3339), job.ID)
+	}
+	return nil
+}
+
+// parseMaintenanceWindow reads a saved window. A window without a time zone of its own
+// follows the given one, which is the schedule time zone of the job it applies to.
+func parseMaintenanceWindow(window *models.MaintenanceWindow, timeZone string) (utils.Window, error) {
+	return utils.ParseWindow(window.Days, window.StartTime, window.EndTime, cmp.Or(window.TimeZone, timeZone), window.StartsAt, window.EndsAt)
+}
+
+// jobMaintenanceWindows picks the windows that apply to a job out of the windows of its
+// project
+func jobMaintenanceWindows(windows []*models.MaintenanceWindow, job *models.Job) []*models.MaintenanceWindow {
+	var applied []*models.MaintenanceWindow
+	for _, window := range windows {
+		switch {
+		case window.JobID != nil:
+			if *window.JobID == job.ID {
+				applied = append(applied, window)
+			}
+		case window.SourceID != nil:
+			if *window.SourceID == job.SourceID {
+				applied = append(applied, window)
+			}
+		default:
+			applied = append(applied, window)
+		}
+	}
+	return applied
+}
+
+// activeMaintenanceWindow returns the window now lies in and when it ends. Of windows that
+// overlap the one ending last is returned.
+func activeMaintenanceWindow(windows []*models.MaintenanceWindow, timeZone string, now time.Time) (*models.MaintenanceWindow, time.Time) {
+	var active *models.MaintenanceWindow
+	var activeEnd time.Time
+	for _, window := range windows {
+		w, err := parseMaintenanceWindow(window, timeZone)
+		if err != nil {
+			continue
+		}
+		if end, ok := w.Contains(now); ok && end.After(activeEnd) {
+			active, activeEnd = window, end
+		}
+	}
+	return active, activeEnd
+}
+
+// setMaintenanceWindows reports on a job response the windows that apply to the job, out of
+// the windows of its project, and a trigger deferred by one of them
+func setMaintenanceWindows(resp *dto.JobResponse, job *models.Job, windows []*models.MaintenanceWindow, projectTimeZone string, now time.Time) {
+	resp.MaintenanceWindows = jobMaintenanceWindowItems(windows, job, effectiveTimeZone(job.TimeZone, projectTimeZone), now)
+	if job.TriggerDeferredUntil != nil {
+		resp.TriggerDeferredUntil = job.TriggerDeferredUntil.UTC().Format(time.RFC3339)
+	}
+}
+
+// jobMaintenanceWindowItems describes the windows that apply to a job out of the windows of
+// its project
+func jobMaintenanceWindowItems(windows []*models.MaintenanceWindow, job *models.Job, timeZone string, now time.Time) []dto.MaintenanceWindowItem {
+	items := []dto.MaintenanceWindowItem{}
+	for _, window := range jobMaintenanceWindows(windows, job) {
+		items = append(items, maintenanceWindowItem(window, timeZone, now))
+	}
+	return items
+}
+
+// maintenanceWindowItem describes a window, in effect when it contains now on the wall clock
+// of its own time zone, else of the given one
+func maintenanceWindowItem(window *models.MaintenanceWindow, timeZone string, now time.Time) dto.MaintenanceWindowItem {
+	item := dto.MaintenanceWindowItem{
+		ID:        window.ID,
+		Name:      window.Name,
+		Scope:     constants.MaintenanceScopeProject,
+		SourceID:  window.SourceID,
+		JobID:     window.JobID,
+		Recurring: window.StartsAt == nil,
+		Days:      window.Days,
+		StartTime: window.StartTime,
+		EndTime:   window.EndTime,
+		TimeZone:  window.TimeZone,
+		CreatedAt: window.CreatedAt.Format(time.RFC3339),
+	}
+	switch {
+	case window.JobID != nil:
+		item.Scope = constants.MaintenanceScopeJob
+	case window.SourceID != nil:
+		item.Scope = constants.MaintenanceScopeSource
+	}
+	if window.StartsAt != nil && window.EndsAt != nil {
+		item.StartsAt = window.StartsAt.UTC().Format(time.RFC3339)
+		item.EndsAt = window.EndsAt.UTC().Format(time.RFC3339)
+	}
+	if w, err := parseMaintenanceWindow(window, timeZone); err == nil {
+		if end, ok := w.Contains(now); ok {
+			item.ActiveUntil = end.Format(time.RFC3339)
+		}
+	}
+	if window.CreatedBy != nil {
+		item.CreatedBy = window.CreatedBy.Username
+	}
+	return item
+}
+
+// setMaintenanceWindowFields copies a window request onto a window. A recurring window keeps
+// no one-off range and the other way round.
+func setMaintenanceWindowFields(window *models.MaintenanceWindow, req *dto.MaintenanceWindowRequest) {
+	window.Name = req.Name
+	window.SourceID, window.JobID = req.SourceID, req.JobID
+	window.Days, window.StartTime, window.EndTime, window.TimeZone = req.Days, req.StartTime, req.EndTime, req.TimeZone
+	window.StartsAt, window.EndsAt = req.StartsAt, req.EndsAt
+	if req.StartsAt != nil {
+		window.Days, window.StartTime, window.EndTime, window.TimeZone = "", "", "", ""
+	}
+}
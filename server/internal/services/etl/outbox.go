@@ -0,0 +1,377 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	"go.temporal.io/api/serviceerror"
+	sdktemporal "go.temporal.io/sdk/temporal"
+)
+
+// Schedule outbox methods on AppService
+
+// applyScheduleOperations applies the queued schedule operations of a job right after the
+// change that queued them is committed, so the schedule is usually current by the time the
+// request returns. Operations that cannot be applied now are left to the dispatcher and
+// ErrSchedulePending is returned.
+func (s Service) applyScheduleOperations(ctx context.Context, jobID int) error {
+	for {
+		ops, err := s.db.ClaimScheduleOperations(jobID, 1)
+		if err != nil {
+			return fmt.Errorf("%w: %s", constants.ErrSchedulePending, err)
+		}
+		if len(ops) == 0 {
+			break
+		}
+		if err := s.dispatchScheduleOperation(ctx, ops[0]); err != nil {
+			return fmt.Errorf("%w: %s", constants.ErrSchedulePending, err)
+		}
+	}
+
+	// nothing left to claim, but an operation may be leased by another dispatcher or
+	// waiting for its next attempt
+	pending, err := s.db.HasPendingScheduleOperations(jobID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", constants.ErrSchedulePending, err)
+	}
+	if pending {
+		return fmt.Errorf("%w: an earlier schedule change of the job is still being applied", constants.ErrSchedulePending)
+	}
+	return nil
+}
+
+// DispatchScheduleOperations applies the due operations of the schedule outbox until none
+// are left and returns how many were applied and how many failed.
+func (s Service) DispatchScheduleOperations(ctx context.Context) (int, int) {
+	applied, failed := 0, 0
+	for ctx.Err() == nil {
+		ops, err := s.db.ClaimScheduleOperations(0, constants.ScheduleOpBatchSize)
+		if err != nil {
+			logger.Errorf("failed to dispatch schedule operations: %s", err)
+			break
+		}
+		if len(ops) == 0 {
+			break
+		}
+		for _, op := range ops {
+			if err := s.dispatchScheduleOperation(ctx, op); err != nil {
+				failed++
+			} else {
+				applied++
+			}
+		}
+	}
+	return applied, failed
+}
+
+// RunScheduleDispatcher dispatches the schedule outbox every interval until the context is
+// done, and removes finished operations past their retention.
+func (s Service) RunScheduleDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.releaseDeferredTriggers()
+		if applied, failed := s.DispatchScheduleOperations(ctx); applied > 0 || failed > 0 {
+			logger.Infof("dispatched schedule operations applied[%d] failed[%d]", applied, failed)
+		}
+
+		if time.Since(lastCleanup) >= time.Hour {
+			lastCleanup = time.Now()
+			if _, err := s.db.DeleteFinishedScheduleOperations(lastCleanup.Add(-constants.ScheduleOpRetention)); err != nil {
+				logger.Errorf("failed to clean up schedule operations: %s", err)
+			}
+		}
+	}
+}
+
+// dispatchScheduleOperation applies a claimed operation and records the outcome. A failed
+// operation is retried with backoff until it runs out of attempts; a trigger waiting for its
+// schedule stays pending without using up attempts.
+func (s Service) dispatchScheduleOperation(ctx context.Context, op *models.ScheduleOperation) error {
+	err := s.applyScheduleOperation(ctx, op)
+	params := scheduleOperationOutcome(op, err, time.Now())
+	uerr := s.db.UpdateScheduleOperation(op.ID, params)
+	switch {
+	case err == nil:
+		if uerr != nil {
+			// the operation is applied again once its lease runs out, which is harmless
+			return fmt.Errorf("schedule operation applied but failed to record it: %s", uerr)
+		}
+		logger.Debugf("applied schedule operation %s job_id[%d]", op.Operation, op.JobID)
+		return nil
+	case errors.Is(err, constants.ErrScheduleBusy):
+		logger.Debugf("schedule operation %s job_id[%d] waiting: %s", op.Operation, op.JobID, err)
+	case params["status"] == constants.ScheduleOpStatusFailed:
+		logger.Errorf("schedule operation %s job_id[%d] failed after %d attempts: %s", op.Operation, op.JobID, params["attempts"], err)
+	default:
+		logger.Warnf("schedule operation %s job_id[%d] failed on attempt %d: %s", op.Operation, op.JobID, params["attempts"], err)
+	}
+	if uerr != nil {
+		logger.Errorf("failed to record schedule operation outcome job_id[%d]: %s", op.JobID, uerr)
+	}
+	return err
+}
+
+// scheduleOperationOutcome returns the updates that record the outcome err of an attempt at
+// an operation at now: applied on success, pending with backoff after a failure and failed
+// once it runs out of attempts. A trigger waiting for its schedule stays pending without
+// using up an attempt.
+func scheduleOperationOutcome(op *models.ScheduleOperation, err error, now time.Time) map[string]any {
+	attempts := op.Attempts + 1
+	switch {
+	case err == nil:
+		return map[string]any{
+			"status":     constants.ScheduleOpStatusApplied,
+			"attempts":   attempts,
+			"last_error": "",
+			"applied_at": now,
+		}
+	case errors.Is(err, constants.ErrScheduleBusy):
+		return map[string]any{
+			"last_error":      err.Error(),
+			"next_attempt_at": now.Add(constants.ScheduleOpWaitInterval),
+		}
+	}
+	params := map[string]any{
+		"attempts":        attempts,
+		"last_error":      err.Error(),
+		"next_attempt_at": now.Add(scheduleRetryDelay(attempts)),
+	}
+	if attempts >= constants.ScheduleOpMaxAttempts {
+		params["status"] = constants.ScheduleOpStatusFailed
+	}
+	return params
+}
+
+// applyScheduleOperation runs an operation against temporal. Every operation can be applied
+// more than once: create and update read the job as it is now, and a schedule that already
+// exists or is already gone counts as created or deleted.
+func (s Service) applyScheduleOperation(ctx context.Context, op *models.ScheduleOperation) error {
+	switch op.Operation {
+	case constants.ScheduleOpCreate, constants.ScheduleOpUpdate:
+		job, err := s.db.GetJobByID(op.JobID, false)
+		if err != nil {
+			if errors.Is(err, constants.ErrJobNotFound) {
+				// the job was deleted since, its schedule is removed by the delete operation
+				return nil
+			}
+			return err
+		}
+		schedule, err := s.jobSchedule(job)
+		if err != nil {
+			return err
+		}
+		if op.Operation == constants.ScheduleOpUpdate {
+			if err := s.temporal.UpdateSchedule(ctx, job, schedule, nil); err != nil {
+				return fmt.Errorf("failed to update schedule: %s", err)
+			}
+			return nil
+		}
+		if job.Source == nil {
+			return fmt.Errorf("job source details not found")
+		}
+		if err := s.temporal.CreateSchedule(ctx, job, schedule); err != nil && !errors.Is(err, sdktemporal.ErrScheduleAlreadyRunning) {
+			return fmt.Errorf("failed to create schedule: %s", err)
+		}
+	case constants.ScheduleOpPause:
+		if err := s.temporal.PauseSchedule(ctx, op.ProjectID, op.JobID); err != nil {
+			return fmt.Errorf("failed to pause schedule: %s", err)
+		}
+	case constants.ScheduleOpResume:
+		if err := s.temporal.ResumeSchedule(ctx, op.ProjectID, op.JobID); err != nil {
+			return fmt.Errorf("failed to unpause schedule: %s", err)
+		}
+	case constants.ScheduleOpTrigger:
+		// a job paused or deleted since is not run
+		job, err := s.db.GetJobByID(op.JobID, false)
+		if err != nil {
+			if errors.Is(err, constants.ErrJobNotFound) {
+				return nil
+			}
+			return err
+		}
+		if !job.Active {
+			return nil
+		}
+		// a trigger in a maintenance window runs once the window is over, without holding
+		// up later operations of the job
+		deferred, err := s.deferTriggerInMaintenanceWindow(job)
+		if err != nil || deferred {
+			return err
+		}
+		// a trigger over a concurrency cap is queued and started by the controller
+		if reason, err := s.admitSync(ctx, job); err != nil || reason != "" {
+			return err
+		}
+		// a trigger fires even on a paused schedule and runs whatever action it has, so it
+		// waits while the schedule is held paused, running or set to clear-destination
+		reason, err := s.scheduleTriggerBlocked(ctx, job)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			return fmt.Errorf("%w: %s", constants.ErrScheduleBusy, reason)
+		}
+		if err := s.temporal.TriggerSchedule(ctx, op.ProjectID, op.JobID); err != nil {
+			return fmt.Errorf("failed to trigger schedule: %s", err)
+		}
+	case constants.ScheduleOpDelete:
+		err := s.temporal.DeleteSchedule(ctx, op.ProjectID, op.JobID)
+		var notFound *serviceerror.NotFound
+		if err != nil && !errors.As(err, &notFound) {
+			return fmt.Errorf("failed to delete schedule: %s", err)
+		}
+	default:
+		return fmt.Errorf("unknown schedule operation '%s'", op.Operation)
+	}
+	return nil
+}
+
+// deferTriggerInMaintenanceWindow defers the trigger of a job that is in a maintenance window
+// to the end of the window and reports whether it did
+func (s Service) deferTriggerInMaintenanceWindow(job *models.Job) (bool, error) {
+	timeZone, err := s.scheduleTimeZone(job)
+	if err != nil {
+		return false, err
+	}
+	windows, err := s.db.ListJobMaintenanceWindows(job.ProjectID, job.SourceID, job.ID)
+	if err != nil {
+		return false, err
+	}
+	window, end := activeMaintenanceWindow(windows, timeZone, time.Now())
+	if window == nil {
+		return false, nil
+	}
+	if err := s.db.UpdateJob(job.ID, map[string]any{"trigger_deferred_until": end}); err != nil {
+		return false, err
+	}
+	logger.Infof("trigger of job_id[%d] deferred to %s by maintenance window[%d]", job.ID, end.UTC().Format(time.RFC3339), window.ID)
+	return true, nil
+}
+
+// releaseDeferredTriggers queues the triggers deferred by maintenance windows that are over.
+// A job that is in another window by then is deferred again when its trigger is applied.
+func (s Service) releaseDeferredTriggers() {
+	jobs, err := s.db.ListDeferredTriggerJobs(time.Now())
+	if err != nil {
+		logger.Errorf("failed to release deferred triggers: %s", err)
+		return
+	}
+	for _, job := range jobs {
+		err := s.db.Transaction(func(tx *database.Database) error {
+			if err := tx.UpdateJob(job.ID, map[string]any{"trigger_deferred_until": nil}); err != nil {
+				return err
+			}
+			return tx.EnqueueScheduleOperations(job.ProjectID, job.ID, constants.ScheduleOpTrigger)
+		})
+		if err != nil {
+			logger.Errorf("failed to release deferred trigger of job_id[%d]: %s", job.ID, err)
+			continue
+		}
+		logger.Infof("released trigger of job_id[%d] deferred by a maintenance window", job.ID)
+	}
+}
+
+// ListScheduleOperations returns the latest schedule operations of a job and whether its
+// schedule is up to date with the job.
+func (s Service) ListScheduleOperations(_ context.Context, projectID string, jobID int) (*dto.ScheduleOperationsResponse, error) {
+	job, err := s.db.GetJobByID(jobID, false)
+	if err != nil {
+		if errors.Is(err, constants.ErrJobNotFound) {
+			return nil, fmt.Errorf("%w: %v", constants.ErrJobNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to get job: %s", err)
+	}
+	if job.ProjectID != projectID {
+		return nil, fmt.Errorf("%w: job not found id[%d] project_id[%s]", constants.ErrJobNotFound, jobID, projectID)
+	}
+
+	ops, err := s.db.ListScheduleOperations(jobID, constants.MaxScheduleOpsListed)
+	if err != nil {
+		return nil, err
+	}
+	unapplied, err := s.db.GetUnappliedScheduleOperations([]int{jobID})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ScheduleOperationsResponse{
+		JobID:          jobID,
+		ScheduleStatus: constants.ScheduleOpStatusApplied,
+		Operations:     make([]dto.ScheduleOperationItem, 0, len(ops)),
+	}
+	if op, ok := unapplied[jobID]; ok {
+		resp.ScheduleStatus = op.Status
+	}
+	for _, op := range ops {
+		item := dto.ScheduleOperationItem{
+			ID:        op.ID,
+			Operation: op.Operation,
+			Status:    op.Status,
+			Attempts:  op.Attempts,
+			LastError: op.LastError,
+			CreatedAt: op.CreatedAt.Format(time.RFC3339),
+		}
+		if op.Status == constants.ScheduleOpStatusPending {
+			item.NextAttemptAt = op.NextAttemptAt.Format(time.RFC3339)
+		}
+		if op.AppliedAt != nil {
+			item.AppliedAt = op.AppliedAt.Format(time.RFC3339)
+		}
+		resp.Operations = append(resp.Operations, item)
+	}
+	return resp, nil
+}
+
+// RetryScheduleOperations queues the failed schedule operations of a job again and applies
+// them right away.
+func (s Service) RetryScheduleOperations(ctx context.Context, projectID string, jobID int) (*dto.ScheduleOperationsResponse, error) {
+	if _, err := s.ListScheduleOperations(ctx, projectID, jobID); err != nil {
+		return nil, err
+	}
+	retried, err := s.db.RetryFailedScheduleOperations(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if retried > 0 {
+		logger.Infof("retrying %d failed schedule operations job_id[%d]", retried, jobID)
+		if err := s.applyScheduleOperations(ctx, jobID); err != nil {
+			// the outcome is part of the returned operations
+			logger.Warnf("schedule operations of job_id[%d] are still pending: %s", jobID, err)
+		}
+	}
+	return s.ListScheduleOperations(ctx, projectID, jobID)
+}
+
+// setScheduleStatus reports on a job response that its schedule is not up to date yet
+func setScheduleStatus(resp *dto.JobResponse, op *models.ScheduleOperation) {
+	if op == nil {
+		return
+	}
+	resp.ScheduleStatus = op.Status
+	resp.ScheduleError = op.LastError
+}
+
+// scheduleRetryDelay is the exponential backoff before the next attempt of an operation
+func scheduleRetryDelay(attempts int) time.Duration {
+	delay := constants.ScheduleOpRetryBaseDelay
+	for i := 1; i < attempts && delay < constants.ScheduleOpRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, constants.ScheduleOpRetryMaxDelay)
+}
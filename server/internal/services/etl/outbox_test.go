@@ -0,0 +1,125 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+)
+
+func TestScheduleRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 5 * time.Second},
+		{1, 5 * time.Second},
+		{2, 10 * time.Second},
+		{3, 20 * time.Second},
+		{4, 40 * time.Second},
+		{7, 320 * time.Second},
+		// doubling again would pass the maximum
+		{8, 10 * time.Minute},
+		{constants.ScheduleOpMaxAttempts, 10 * time.Minute},
+		{100, 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt %d", tt.attempts), func(t *testing.T) {
+			require.Equal(t, tt.want, scheduleRetryDelay(tt.attempts))
+		})
+	}
+}
+
+func TestScheduleOperationOutcome(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+	failure := errors.New("temporal unavailable")
+	busy := fmt.Errorf("%w: schedule is paused", constants.ErrScheduleBusy)
+
+	tests := []struct {
+		name     string
+		attempts int
+		err      error
+		want     map[string]any
+	}{
+		{
+			name: "applied on the first attempt",
+			err:  nil,
+			want: map[string]any{
+				"status":     constants.ScheduleOpStatusApplied,
+				"attempts":   1,
+				"last_error": "",
+				"applied_at": now,
+			},
+		},
+		{
+			name:     "applied after failures clears the error",
+			attempts: 3,
+			err:      nil,
+			want: map[string]any{
+				"status":     constants.ScheduleOpStatusApplied,
+				"attempts":   4,
+				"last_error": "",
+				"applied_at": now,
+			},
+		},
+		{
+			name: "first failure stays pending with backoff",
+			err:  failure,
+			want: map[string]any{
+				"attempts":        1,
+				"last_error":      "temporal unavailable",
+				"next_attempt_at": now.Add(5 * time.Second),
+			},
+		},
+		{
+			name:     "later failure backs off further",
+			attempts: 3,
+			err:      failure,
+			want: map[string]any{
+				"attempts":        4,
+				"last_error":      "temporal unavailable",
+				"next_attempt_at": now.Add(40 * time.Second),
+			},
+		},
+		{
+			name:     "last attempt fails the operation",
+			attempts: constants.ScheduleOpMaxAttempts - 1,
+			err:      failure,
+			want: map[string]any{
+				"status":          constants.ScheduleOpStatusFailed,
+				"attempts":        constants.ScheduleOpMaxAttempts,
+				"last_error":      "temporal unavailable",
+				"next_attempt_at": now.Add(10 * time.Minute),
+			},
+		},
+		{
+			name:     "busy schedule waits without using an attempt",
+			attempts: constants.ScheduleOpMaxAttempts - 1,
+			err:      busy,
+			want: map[string]any{
+				"last_error":      busy.Error(),
+				"next_attempt_at": now.Add(constants.ScheduleOpWaitInterval),
+			},
+		},
+		{
+			name:     "wrapped busy error waits too",
+			attempts: 2,
+			err:      fmt.Errorf("%w: %w", constants.ErrSchedulePending, busy),
+			want: map[string]any{
+				"last_error":      fmt.Errorf("%w: %w", constants.ErrSchedulePending, busy).Error(),
+				"next_attempt_at": now.Add(constants.ScheduleOpWaitInterval),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := &models.ScheduleOperation{JobID: 1, Operation: constants.ScheduleOpTrigger, Status: constants.ScheduleOpStatusPending, Attempts: tt.attempts}
+			require.Equal(t, tt.want, scheduleOperationOutcome(op, tt.err, now))
+		})
+	}
+}
@@ -46,3 +46,9 @@ func (s Service) GetAllReleasesResponse(
 
 	return utils.BuildReleasesResponse(currentVersion, olakeSourceVersion, fetchedReleases)
 }
+
+// ListWorkerPools lists the worker pools configured on the server with the number of workers
+// polling the task queue of each
+func (s Service) ListWorkerPools(ctx context.Context) []dto.WorkerPoolItem {
+	return s.temporal.WorkerPools(ctx)
+}
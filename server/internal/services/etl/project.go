@@ -1,10 +1,14 @@
 package etl
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
 	"github.com/datazip-inc/olake-ui/server/internal/models"
 	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
 )
 
 func (s Service) GetProjectSettings(projectID string) (dto.ProjectSettingsResponse, error) {
@@ -21,19 +25,53 @@ func (s Service) GetProjectSettings(projectID string) (dto.ProjectSettingsRespon
 		ID:              settings.ID,
 		ProjectID:       settings.ProjectID,
 		WebhookAlertURL: settings.WebhookAlertURL,
+		DefaultTimeZone: settings.DefaultTimeZone,
 	}, nil
 }
 
-func (s Service) UpsertProjectSettings(req dto.UpsertProjectSettingsRequest) error {
+// UpsertProjectSettings saves the settings of a project. A change of the default time zone
+// is carried over to the schedules of the jobs without a time zone of their own.
+func (s Service) UpsertProjectSettings(ctx context.Context, req dto.UpsertProjectSettingsRequest) error {
 	projectSettings := &models.ProjectSettings{
 		ID:              req.ID,
 		ProjectID:       req.ProjectID,
 		WebhookAlertURL: req.WebhookAlertURL,
 	}
 
-	if err := s.db.UpsertProjectSettingsModel(projectSettings); err != nil {
+	current, err := s.db.GetProjectSettingsByProjectID(req.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project settings: %s", err)
+	}
+	var jobIDs []int
+	err = s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.UpsertProjectSettingsModel(projectSettings); err != nil {
+			return err
+		}
+		if req.DefaultTimeZone == nil || *req.DefaultTimeZone == current.DefaultTimeZone {
+			return nil
+		}
+		if err := tx.SetProjectDefaultTimeZone(req.ProjectID, *req.DefaultTimeZone); err != nil {
+			return err
+		}
+		if jobIDs, err = tx.ListJobIDsWithoutTimeZone(req.ProjectID); err != nil {
+			return err
+		}
+		for _, jobID := range jobIDs {
+			if err := tx.EnqueueScheduleOperations(req.ProjectID, jobID, constants.ScheduleOpUpdate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to update project settings: %s", err)
 	}
 
+	// the settings are saved, schedules not updated here are left to the outbox dispatcher
+	for _, jobID := range jobIDs {
+		if err := s.applyScheduleOperations(ctx, jobID); err != nil {
+			logger.Warnf("schedule of job_id[%d] not yet moved to the default time zone of project_id[%s]: %s", jobID, req.ProjectID, err)
+		}
+	}
 	return nil
 }
@@ -0,0 +1,185 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	"go.temporal.io/api/serviceerror"
+)
+
+// Job and schedule reconciliation methods on AppService
+
+// reconcileMu keeps the periodic and on-demand reconciler from running at the same time
+var reconcileMu sync.Mutex
+
+// ReconcileSchedules compares every job with its temporal schedule and every sync schedule
+// with its job, and repairs the drift unless dryRun is set. Jobs and schedules changed
+// within the grace period may be in the middle of an update and are skipped.
+func (s Service) ReconcileSchedules(ctx context.Context, dryRun bool) (*dto.ReconcileResponse, error) {
+	if !reconcileMu.TryLock() {
+		return nil, constants.ErrReconcileInProgress
+	}
+	defer reconcileMu.Unlock()
+
+	// Schedules are listed before jobs. A job row is written before its schedule is created,
+	// so a listed schedule whose job is missing from the job listing really has no job.
+	scheduleIDs, err := s.temporal.ListSyncScheduleIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %s", err)
+	}
+	jobs, err := s.db.ListAllJobs()
+	if err != nil {
+		return nil, err
+	}
+	// schedule changes still queued in the outbox are left to the dispatcher
+	pendingJobIDs, err := s.db.PendingScheduleOperationJobIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ReconcileResponse{DryRun: dryRun, Jobs: len(jobs), Schedules: len(scheduleIDs), Items: []dto.ReconcileItem{}}
+	now := time.Now()
+	scheduledJobs := make(map[string]bool, len(jobs))
+	// the default time zone and maintenance windows of every project
+	projectTimeZones := make(map[string]string)
+	projectWindows := make(map[string][]*models.MaintenanceWindow)
+	for _, job := range jobs {
+		_, scheduleID := s.temporal.WorkflowAndScheduleID(job.ProjectID, job.ID)
+		scheduledJobs[scheduleID] = true
+		if now.Sub(job.UpdatedAt) < constants.ReconcileGracePeriod || pendingJobIDs[job.ID] {
+			resp.Skipped++
+			continue
+		}
+
+		// a job in the trash keeps its schedule paused until it is restored or purged
+		trashed := job.DeletedAt != nil
+		if trashed {
+			job.Active = false
+		}
+
+		item := dto.ReconcileItem{ScheduleID: scheduleID, ProjectID: job.ProjectID, JobID: job.ID, JobName: job.Name}
+		projectTimeZone, ok := projectTimeZones[job.ProjectID]
+		if !ok {
+			settings, err := s.db.GetProjectSettingsByProjectID(job.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+			projectTimeZone = settings.DefaultTimeZone
+			projectTimeZones[job.ProjectID] = projectTimeZone
+			if projectWindows[job.ProjectID], err = s.db.ListMaintenanceWindows(job.ProjectID); err != nil {
+				return nil, err
+			}
+		}
+		// a frequency that cannot be parsed cannot be compared either
+		expected, _ := buildJobSchedule(job.Frequency, effectiveTimeZone(job.TimeZone, projectTimeZone), jobMaintenanceWindows(projectWindows[job.ProjectID], job), now)
+		state, err := s.inspectSchedule(ctx, job.ProjectID, job.ID, expected)
+		if err != nil {
+			item.Error = err.Error()
+			resp.Failed++
+			resp.Items = append(resp.Items, item)
+			continue
+		}
+		if state.Missing && trashed {
+			// restoring the job recreates its schedule
+			continue
+		}
+		if !state.Missing && now.Sub(state.UpdatedAt) < constants.ReconcileGracePeriod {
+			resp.Skipped++
+			continue
+		}
+		if item.Drift = state.drift(job.Active); len(item.Drift) == 0 {
+			if !dryRun {
+				s.supersedeScheduleOperations(job.ID)
+			}
+			continue
+		}
+		reconcileItem(resp, item, dryRun, func() error {
+			if err := s.repairScheduleDrift(ctx, job, item.Drift); err != nil {
+				return err
+			}
+			s.supersedeScheduleOperations(job.ID)
+			return nil
+		})
+	}
+
+	for _, scheduleID := range scheduleIDs {
+		if scheduledJobs[scheduleID] {
+			continue
+		}
+		projectID, jobID, ok := temporal.ParseScheduleID(scheduleID)
+		if !ok {
+			continue
+		}
+		item := dto.ReconcileItem{ScheduleID: scheduleID, ProjectID: projectID, JobID: jobID, Drift: []string{constants.DriftOrphanSchedule}}
+		reconcileItem(resp, item, dryRun, func() error {
+			err := s.temporal.DeleteSchedule(ctx, projectID, jobID)
+			// the schedule listing is eventually consistent and may still show deleted schedules
+			var notFound *serviceerror.NotFound
+			if err != nil && !errors.As(err, &notFound) {
+				return fmt.Errorf("failed to delete orphan schedule: %s", err)
+			}
+			return nil
+		})
+	}
+
+	logger.Infof("reconciled schedules dry_run[%t] jobs[%d] schedules[%d] drifted[%d] repaired[%d] failed[%d] skipped[%d]", dryRun, resp.Jobs, resp.Schedules, resp.Drifted, resp.Repaired, resp.Failed, resp.Skipped)
+	return resp, nil
+}
+
+// RunReconciler reconciles jobs and schedules every interval until the context is done
+func (s Service) RunReconciler(ctx context.Context, interval time.Duration, dryRun bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		resp, err := s.ReconcileSchedules(ctx, dryRun)
+		if err != nil {
+			logger.Errorf("failed to reconcile schedules: %s", err)
+			continue
+		}
+		if dryRun {
+			for _, item := range resp.Items {
+				logger.Warnf("schedule drift found job_id[%d] schedule_id[%s]: %v", item.JobID, item.ScheduleID, item.Drift)
+			}
+		}
+	}
+}
+
+// reconcileItem records a drifted item and repairs it unless dryRun is set
+func reconcileItem(resp *dto.ReconcileResponse, item dto.ReconcileItem, dryRun bool, repair func() error) {
+	resp.Drifted++
+	if !dryRun {
+		if err := repair(); err != nil {
+			logger.Errorf("failed to repair schedule %s: %s", item.ScheduleID, err)
+			item.Error = err.Error()
+			resp.Failed++
+		} else {
+			logger.Infof("repaired schedule %s: %v", item.ScheduleID, item.Drift)
+			item.Repaired = true
+			resp.Repaired++
+		}
+	}
+	resp.Items = append(resp.Items, item)
+}
+
+// supersedeScheduleOperations marks the failed schedule operations of a job as superseded
+// once its schedule matches the job
+func (s Service) supersedeScheduleOperations(jobID int) {
+	if err := s.db.SupersedeFailedScheduleOperations(jobID); err != nil {
+		logger.Errorf("failed to supersede schedule operations job_id[%d]: %s", jobID, err)
+	}
+}
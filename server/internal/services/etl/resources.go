@@ -0,0 +1,89 @@
+package etl
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+)
+
+// marshalAdvancedSettings checks the driver resources of advanced settings against the
+// server maximums, encrypts the values of secret environment variables and serialises the
+// settings. A secret variable sent without a value keeps the value it has in current, the
+// saved settings of the job.
+func marshalAdvancedSettings(settings *dto.AdvancedSettings, current *string) (*string, error) {
+	if settings == nil {
+		return nil, nil
+	}
+	if err := temporal.ValidateDriverResources(settings.Resources); err != nil {
+		return nil, err
+	}
+
+	var saved dto.AdvancedSettings
+	if current != nil && *current != "" {
+		if err := json.Unmarshal([]byte(*current), &saved); err != nil {
+			return nil, fmt.Errorf("failed to parse saved advanced_settings: %s", err)
+		}
+	}
+	stored := *settings
+	stored.Env = slices.Clone(settings.Env)
+	for i, v := range stored.Env {
+		if !v.Secret {
+			continue
+		}
+		if v.Value == "" {
+			idx := slices.IndexFunc(saved.Env, func(s dto.DriverEnvVar) bool { return s.Secret && s.Name == v.Name })
+			if idx < 0 {
+				return nil, fmt.Errorf("%w: '%s'", constants.ErrMissingSecretValue, v.Name)
+			}
+			stored.Env[i].Value = saved.Env[idx].Value
+			continue
+		}
+		encrypted, err := utils.Encrypt(v.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt environment variable '%s': %s", v.Name, err)
+		}
+		stored.Env[i].Value = encrypted
+	}
+
+	b, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialise advanced_settings: %s", err)
+	}
+	s := string(b)
+	return &s, nil
+}
+
+// maskedAdvancedSettings returns serialised advanced settings without the values of secret
+// environment variables
+func maskedAdvancedSettings(raw string) (string, error) {
+	if raw == "" || raw == "null" {
+		return raw, nil
+	}
+	var settings dto.AdvancedSettings
+	if err := json.Unmarshal([]byte(raw), &settings); err != nil {
+		return "", fmt.Errorf("failed to parse advanced_settings: %s", err)
+	}
+	maskDriverEnv(&settings)
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialise advanced_settings: %s", err)
+	}
+	return string(b), nil
+}
+
+// maskDriverEnv leaves the values of secret environment variables out of advanced settings
+func maskDriverEnv(settings *dto.AdvancedSettings) {
+	if settings == nil {
+		return
+	}
+	for i := range settings.Env {
+		if settings.Env[i].Secret {
+			settings.Env[i].Value = ""
+		}
+	}
+}
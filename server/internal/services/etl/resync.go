@@ -0,0 +1,173 @@
+package etl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+)
+
+// Stream resync methods on AppService
+
+// ResyncStreams syncs some streams of a job again from scratch. Their entries are removed
+// from the job state, and from the streams following the CDC position, so that the next sync
+// loads them in full. With req.ClearDestination their destination tables are truncated first by
+// the clear-destination workflow, run for these streams only.
+//
+// The schedule is held from the state change until the sync is triggered, as for
+// ClearDestination: without clearing, the sync is triggered right away; with clearing, it is
+// triggered once the worker has run clear-destination and resumed the schedule.
+func (s Service) ResyncStreams(ctx context.Context, projectID string, jobID int, req *dto.ResyncStreamsRequest) (*dto.ResyncStreamsResponse, error) {
+	job, err := s.getProjectJob(projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if !job.Active {
+		return nil, fmt.Errorf("%w: please unpause to resync streams", constants.ErrJobPaused)
+	}
+
+	streams, err := selectedStreamKeys(job.StreamsConfig, req.Streams)
+	if err != nil {
+		return nil, err
+	}
+
+	var streamsConfig string
+	if req.ClearDestination {
+		if err := CheckClearDestinationCompatibility(job.Source.Version); err != nil {
+			return nil, err
+		}
+		if streamsConfig, err = filterStreamsConfig(job.StreamsConfig, streams); err != nil {
+			return nil, err
+		}
+	}
+
+	release, err := s.holdIdleJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	// the state is read again now that no sync can write it
+	current, err := s.db.GetJobByID(jobID, false)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to find job: %s", err)
+	}
+	state, err := removeStreamStates(current.State, streams)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	snapshot := &models.JobStateSnapshot{JobID: jobID, Reason: constants.StateSnapshotReasonResync}
+	if err := s.db.SaveJobState(state, snapshot); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to update state file: %s", err)
+	}
+	resp := &dto.ResyncStreamsResponse{JobID: jobID, Streams: streams, SnapshotID: snapshot.ID, ClearedDestination: req.ClearDestination}
+	logger.Infof("state of streams %s removed for resync of job_id[%d]", strings.Join(streams, ", "), jobID)
+
+	if !req.ClearDestination {
+		// a scheduled sync starting before the trigger resyncs the streams just as well
+		release()
+		if err := s.temporal.TriggerSchedule(ctx, projectID, jobID); err != nil {
+			return nil, fmt.Errorf("failed to trigger sync: %s", err)
+		}
+		return resp, nil
+	}
+
+	// clear-destination keeps the schedule paused and the worker resumes it once done
+	logger.Infof("running clear destination workflow for job %d for the following streams:\n%s", job.ID, streamsConfig)
+	if err := s.temporal.ClearDestination(ctx, job, streamsConfig); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to clear destination: %s", err)
+	}
+	go s.syncAfterClearDestination(job)
+	return resp, nil
+}
+
+// syncAfterClearDestination triggers a sync of a job once its schedule is back on sync,
+// unpaused and idle after clear-destination. It gives up after constants.ResyncWaitTimeout,
+// leaving the streams to the next scheduled sync.
+func (s Service) syncAfterClearDestination(job *models.Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.ResyncWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(constants.ResyncPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Warnf("clear-destination of job_id[%d] did not finish in %s, the next scheduled sync resyncs its streams", job.ID, constants.ResyncWaitTimeout)
+			return
+		case <-ticker.C:
+		}
+
+		state, err := s.inspectSchedule(ctx, job.ProjectID, job.ID, nil)
+		if err != nil {
+			logger.Warnf("failed to inspect schedule of job_id[%d] for resync: %s", job.ID, err)
+			continue
+		}
+		if state.Missing {
+			logger.Warnf("schedule of job_id[%d] is gone, resync sync is not triggered", job.ID)
+			return
+		}
+		if state.Command != temporal.Sync || state.Paused || state.Running {
+			continue
+		}
+		if err := s.temporal.TriggerSchedule(ctx, job.ProjectID, job.ID); err != nil {
+			logger.Errorf("failed to trigger resync sync of job_id[%d]: %s", job.ID, err)
+			return
+		}
+		logger.Infof("resync sync triggered for job_id[%d] after clear-destination", job.ID)
+		return
+	}
+}
+
+// removeStreamStates drops the given streams from a state file, both their own entries and
+// their membership of the global CDC state. Any other content of the state is kept as is.
+func removeStreamStates(state string, keys []string) (string, error) {
+	if strings.TrimSpace(state) == "" {
+		return state, nil
+	}
+	parsed := map[string]any{}
+	if err := json.Unmarshal([]byte(state), &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse state: %s", err)
+	}
+
+	if streams, ok := parsed["streams"].([]any); ok {
+		kept := make([]any, 0, len(streams))
+		for _, entry := range streams {
+			stream, _ := entry.(map[string]any)
+			name, _ := stream["stream"].(string)
+			namespace, _ := stream["namespace"].(string)
+			if !slices.Contains(keys, streamKey(namespace, name)) {
+				kept = append(kept, entry)
+			}
+		}
+		parsed["streams"] = kept
+	}
+	if global, ok := parsed["global"].(map[string]any); ok {
+		if streams, ok := global["streams"].([]any); ok {
+			kept := make([]any, 0, len(streams))
+			for _, key := range streams {
+				if name, _ := key.(string); !slices.Contains(keys, name) {
+					kept = append(kept, key)
+				}
+			}
+			global["streams"] = kept
+		}
+	}
+
+	b, err := json.Marshal(parsed)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialise state: %s", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,191 @@
+package etl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+)
+
+// Job revision methods on AppService
+
+// ListJobRevisions returns the revisions of a job, newest first.
+func (s Service) ListJobRevisions(_ context.Context, projectID string, jobID int) (*dto.JobRevisionsResponse, error) {
+	if _, err := s.getProjectJob(projectID, jobID); err != nil {
+		return nil, err
+	}
+	revisions, err := s.db.ListJobRevisions(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.JobRevisionsResponse{JobID: jobID, Revisions: make([]dto.JobRevisionItem, 0, len(revisions))}
+	for _, rev := range revisions {
+		item := dto.JobRevisionItem{
+			Revision:      rev.Revision,
+			Change:        rev.Change,
+			RestoredFrom:  rev.RestoredFrom,
+			SourceID:      rev.SourceID,
+			DestinationID: rev.DestID,
+			Frequency:     rev.Frequency,
+			CreatedAt:     rev.CreatedAt.Format(time.RFC3339),
+		}
+		if rev.CreatedBy != nil {
+			item.CreatedBy = rev.CreatedBy.Username
+		}
+		resp.Revisions = append(resp.Revisions, item)
+	}
+	return resp, nil
+}
+
+// DiffJobRevisions compares two revisions of a job, the latest one when to is 0, and lists
+// the job settings that differ and the stream-level changes from one to the other.
+func (s Service) DiffJobRevisions(_ context.Context, projectID string, jobID int, from, to int) (*dto.JobRevisionDiffResponse, error) {
+	if _, err := s.getProjectJob(projectID, jobID); err != nil {
+		return nil, err
+	}
+	fromRev, err := s.db.GetJobRevision(jobID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRev, err := s.db.GetJobRevision(jobID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.JobRevisionDiffResponse{JobID: jobID, From: fromRev.Revision, To: toRev.Revision, Fields: []string{}}
+	if fromRev.SourceID != toRev.SourceID {
+		resp.Fields = append(resp.Fields, "source")
+	}
+	if fromRev.DestID != toRev.DestID {
+		resp.Fields = append(resp.Fields, "destination")
+	}
+	if fromRev.Frequency != toRev.Frequency {
+		resp.Fields = append(resp.Fields, "frequency")
+	}
+	if fromRev.StreamsConfig != toRev.StreamsConfig {
+		resp.Fields = append(resp.Fields, "streams_config")
+	}
+	if (fromRev.AdvancedSettings == nil) != (toRev.AdvancedSettings == nil) ||
+		fromRev.AdvancedSettings != nil && *fromRev.AdvancedSettings != *toRev.AdvancedSettings {
+		resp.Fields = append(resp.Fields, "advanced_settings")
+	}
+
+	resp.StreamChanges, err = diffRevisionStreams(fromRev.StreamsConfig, toRev.StreamsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RollbackJob brings the configuration of a job back to a revision and records that as a
+// new revision. The job keeps its name and activation status. Like an edit of the job's
+// streams, the streams whose destination data does not fit the restored config are cleared
+// first, which needs the job to be active.
+func (s Service) RollbackJob(ctx context.Context, projectID string, jobID, revision int, userID *int) (*dto.JobRollbackResponse, error) {
+	job, err := s.getProjectJob(projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := s.db.GetJobRevision(jobID, revision)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.GetSourceByID(rev.SourceID); err != nil {
+		return nil, fmt.Errorf("%w: source id[%d] of revision %d is not available: %s", constants.ErrRollbackConflict, rev.SourceID, revision, err)
+	}
+	if _, err := s.db.GetDestinationByID(rev.DestID); err != nil {
+		return nil, fmt.Errorf("%w: destination id[%d] of revision %d is not available: %s", constants.ErrRollbackConflict, rev.DestID, revision, err)
+	}
+
+	req := &dto.UpdateJobRequest{
+		Name:          job.Name,
+		Source:        &dto.DriverConfig{ID: &rev.SourceID},
+		Destination:   &dto.DriverConfig{ID: &rev.DestID},
+		Frequency:     rev.Frequency,
+		StreamsConfig: rev.StreamsConfig,
+		Activate:      job.Active,
+	}
+	if rev.AdvancedSettings != nil {
+		req.AdvancedSettings = &dto.AdvancedSettings{}
+		if err := json.Unmarshal([]byte(*rev.AdvancedSettings), req.AdvancedSettings); err != nil {
+			return nil, fmt.Errorf("failed to parse advanced_settings of revision %d: %s", revision, err)
+		}
+		// secret environment variables keep their current values
+		maskDriverEnv(req.AdvancedSettings)
+	}
+
+	resp := &dto.JobRollbackResponse{JobID: jobID, RestoredFrom: revision}
+	// data written to another source or destination is left alone, as on a job update
+	if rev.SourceID == job.SourceID && rev.DestID == job.DestID && !jsonEqual(job.StreamsConfig, rev.StreamsConfig) {
+		req.DifferenceStreams, resp.ClearedStreams, err = s.rollbackStreamDifference(ctx, job, rev.StreamsConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	saved := &models.JobRevision{Change: constants.JobRevisionChangeRollback, RestoredFrom: revision}
+	err = s.updateJob(ctx, req, projectID, jobID, userID, saved)
+	resp.Revision = saved.Revision
+	if err != nil {
+		if errors.Is(err, constants.ErrSchedulePending) {
+			return resp, err
+		}
+		return nil, err
+	}
+	logger.Infof("job_id[%d] rolled back to revision %d, saved as revision %d", jobID, revision, saved.Revision)
+	return resp, nil
+}
+
+// rollbackStreamDifference runs the stream difference between the current streams config of
+// a job and the one rolled back to, and returns it with the streams it holds. Sources too old
+// for clear-destination are rolled back without clearing.
+func (s Service) rollbackStreamDifference(ctx context.Context, job *models.Job, streamsConfig string) (string, []string, error) {
+	if err := CheckClearDestinationCompatibility(job.Source.Version); err != nil {
+		logger.Warnf("job_id[%d] is rolled back without clearing changed streams: %s", job.ID, err)
+		return "", nil, nil
+	}
+
+	diffCatalog, err := s.temporal.GetStreamDifference(ctx, job, job.StreamsConfig, streamsConfig)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get stream difference: %s", err)
+	}
+	b, err := json.Marshal(diffCatalog)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal stream difference: %s", err)
+	}
+	catalog, err := parseStreamsCatalog(string(b))
+	if err != nil {
+		return "", nil, err
+	}
+	streams := slices.Sorted(maps.Keys(catalog.selected()))
+	if len(streams) == 0 {
+		return "", nil, nil
+	}
+	if !job.Active {
+		return "", nil, fmt.Errorf("%w: job is paused, unpause it to roll back stream changes that clear destination data", constants.ErrRollbackConflict)
+	}
+	return string(b), streams, nil
+}
+
+// updateJobWithRevision updates a job and records its new configuration as a revision, within
+// the transaction of the change. A job without revisions first gets the configuration it had
+// before the change recorded as its baseline.
+func updateJobWithRevision(tx *database.Database, jobID int, params map[string]any, rev *models.JobRevision) error {
+	if err := tx.SaveJobBaselineRevision(jobID); err != nil {
+		return err
+	}
+	if err := tx.UpdateJob(jobID, params); err != nil {
+		return fmt.Errorf("failed to update job: %s", err)
+	}
+	return tx.SaveJobRevision(rev)
+}
@@ -0,0 +1,309 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	"go.temporal.io/api/serviceerror"
+)
+
+// scheduleState is what a job's temporal schedule looks like compared to the job
+type scheduleState struct {
+	Missing bool
+	Paused  bool
+	// CronMismatch is set when the spec does not fire at the job's frequency or in its time zone,
+	// or does not skip its maintenance windows
+	CronMismatch bool
+	Command      temporal.Command
+	// Running is set while a workflow started by the schedule is running
+	Running bool
+	// AdHocRunning is set while a paused schedule is held by a running ad-hoc sync of the job
+	AdHocRunning bool
+	UpdatedAt    time.Time
+}
+
+// inspectSchedule describes the schedule of a job and compares its spec with the expected
+// schedule of the job: its frequency, jitter, time zone and maintenance windows. Temporal
+// stores cron expressions as calendars, so the spec is compared through the upcoming fire
+// times it reports, on the wall clock of the time zone. A nil expected schedule leaves the
+// spec out of the comparison.
+func (s Service) inspectSchedule(ctx context.Context, projectID string, jobID int, expected *utils.Schedule) (*scheduleState, error) {
+	desc, err := s.temporal.DescribeSchedule(ctx, projectID, jobID)
+	if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			return &scheduleState{Missing: true}, nil
+		}
+		return nil, fmt.Errorf("failed to describe schedule: %s", err)
+	}
+
+	state := &scheduleState{
+		Command:   temporal.Sync,
+		Running:   len(desc.Info.RunningWorkflows) > 0,
+		UpdatedAt: desc.Info.LastUpdateAt,
+	}
+	if desc.Schedule.State != nil {
+		state.Paused = desc.Schedule.State.Paused
+	}
+	if command := temporal.ActionCommand(desc.Schedule.Action); command != "" {
+		state.Command = command
+	}
+	if state.Paused {
+		if state.AdHocRunning, err = isAdHocSyncRunning(ctx, s.temporal, projectID, jobID); err != nil {
+			return nil, fmt.Errorf("failed to check ad-hoc sync status: %s", err)
+		}
+	}
+	if expected == nil {
+		return state, nil
+	}
+
+	spec := desc.Schedule.Spec
+	if utils.IsUnscheduledFrequency(expected.Frequency) {
+		// manual and once schedules only run when triggered
+		state.CronMismatch = spec != nil && (len(spec.CronExpressions) > 0 || len(spec.Calendars) > 0 || len(spec.Intervals) > 0)
+		return state, nil
+	}
+	specZone, specKey := "", ""
+	var specJitter time.Duration
+	if spec != nil {
+		specZone, specJitter = spec.TimeZoneName, spec.Jitter
+		if len(spec.Skip) > 0 {
+			specKey = spec.Skip[0].Comment
+		}
+	}
+	// temporal reads an empty zone as UTC
+	if utils.Ternary(specZone == "", "UTC", specZone) != expected.TimeZone || specJitter != expected.Jitter || specKey != expected.BlackoutKey() {
+		state.CronMismatch = true
+		return state, nil
+	}
+	location := time.UTC
+	if specZone != "" {
+		if loc, err := time.LoadLocation(specZone); err == nil {
+			location = loc
+		}
+	}
+	// with jitter every action time lies up to the jitter after a fire time of the schedule
+	var fireTime time.Time
+	for i, at := range desc.Info.NextActionTimes {
+		at = at.In(location)
+		if i == 0 {
+			fireTime = expected.Next(at.Add(-expected.Jitter - time.Minute))
+		} else {
+			fireTime = expected.Next(fireTime)
+		}
+		if fireTime.IsZero() || at.Before(fireTime) || at.After(fireTime.Add(expected.Jitter)) {
+			state.CronMismatch = true
+			break
+		}
+	}
+	return state, nil
+}
+
+// drift lists how the schedule differs from a job with the given activation status
+func (st *scheduleState) drift(active bool) []string {
+	if st.Missing {
+		return []string{constants.DriftScheduleMissing}
+	}
+	var fields []string
+	if st.CronMismatch {
+		fields = append(fields, constants.DriftScheduleFrequency)
+	}
+	switch {
+	case st.Command == temporal.ClearDestination:
+		// clear-destination pauses the schedule until it has run, so only a schedule that
+		// is no longer running it is stuck
+		if !st.Running {
+			fields = append(fields, constants.DriftScheduleAction)
+		}
+	case st.Paused == active && !(st.Paused && st.AdHocRunning):
+		// an ad-hoc sync holds the schedule paused until it has closed
+		fields = append(fields, constants.DriftSchedulePaused)
+	}
+	return fields
+}
+
+// validateJobFrequency checks the frequency a job is given. A new frequency has to be valid
+// as new input; the frequency an existing job already has only has to parse, so that jobs
+// saved with a frequency that is deprecated since keep it.
+func validateJobFrequency(frequency string, existing *models.Job) error {
+	if existing != nil && existing.Frequency == frequency {
+		_, err := utils.ParseFrequency(frequency, "")
+		return err
+	}
+	return utils.ValidateFrequency(frequency)
+}
+
+// ReportDeprecatedFrequencies logs the jobs whose saved frequency would be refused as new
+// input. They keep running on it as temporal reads it.
+func (s Service) ReportDeprecatedFrequencies() {
+	jobs, err := s.db.ListAllJobs()
+	if err != nil {
+		logger.Errorf("failed to check job frequencies: %s", err)
+		return
+	}
+	for _, job := range jobs {
+		if job.DeletedAt != nil {
+			continue
+		}
+		schedule, err := utils.ParseFrequency(job.Frequency, "")
+		if err != nil {
+			logger.Warnf("job_id[%d] project_id[%s] has an invalid frequency: %s", job.ID, job.ProjectID, err)
+			continue
+		}
+		if schedule.Deprecated != "" {
+			logger.Warnf("job_id[%d] project_id[%s] has a deprecated frequency that is no longer accepted as new input: %s", job.ID, job.ProjectID, schedule.Deprecated)
+		}
+	}
+}
+
+// scheduleTriggerBlocked tells why the schedule of a job cannot be triggered for a sync right
+// now, empty when it can. A trigger fires even on a paused schedule and runs whatever action
+// it has, so a schedule that is paused, running or set to clear-destination has to wait.
+func (s Service) scheduleTriggerBlocked(ctx context.Context, job *models.Job) (string, error) {
+	state, err := s.inspectSchedule(ctx, job.ProjectID, job.ID, nil)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case state.Missing:
+		return "schedule is missing", nil
+	case state.Command != temporal.Sync:
+		return fmt.Sprintf("schedule is running %s", state.Command), nil
+	case state.Running:
+		return "schedule is running a sync", nil
+	case state.Paused:
+		return "schedule is paused", nil
+	}
+	return "", nil
+}
+
+// repairScheduleDrift brings the schedule of a job in line with the job for the given drift.
+// A schedule stuck on clear-destination is left alone while a clear-destination is running.
+func (s Service) repairScheduleDrift(ctx context.Context, job *models.Job, drift []string) error {
+	schedule, err := s.jobSchedule(job)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(drift, constants.DriftScheduleMissing) {
+		if err := s.temporal.CreateSchedule(ctx, job, schedule); err != nil {
+			return fmt.Errorf("failed to recreate schedule: %s", err)
+		}
+		if !job.Active {
+			if err := s.temporal.PauseSchedule(ctx, job.ProjectID, job.ID); err != nil {
+				return fmt.Errorf("failed to pause recreated schedule: %s", err)
+			}
+		}
+		logger.Infof("recreated missing schedule for job_id[%d]", job.ID)
+		return nil
+	}
+
+	if slices.Contains(drift, constants.DriftScheduleFrequency) {
+		if err := s.temporal.UpdateSchedule(ctx, job, schedule, nil); err != nil {
+			return fmt.Errorf("failed to update schedule frequency: %s", err)
+		}
+	}
+	if slices.Contains(drift, constants.DriftScheduleAction) {
+		running, _, err := isWorkflowRunning(ctx, s.temporal, job.ProjectID, job.ID, temporal.ClearDestination)
+		if err != nil {
+			return fmt.Errorf("failed to check if clear-destination is running: %s", err)
+		}
+		if running {
+			logger.Infof("clear-destination is running for job_id[%d], schedule action is left as is", job.ID)
+			return nil
+		}
+		if err := s.temporal.RestoreSyncSchedule(ctx, job); err != nil {
+			return err
+		}
+	}
+	if slices.Contains(drift, constants.DriftSchedulePaused) || slices.Contains(drift, constants.DriftScheduleAction) {
+		var err error
+		if job.Active {
+			// an ad-hoc sync holds the schedule paused until it has run
+			running, _, rerr := isWorkflowRunning(ctx, s.temporal, job.ProjectID, job.ID, temporal.Sync)
+			if rerr != nil {
+				return fmt.Errorf("failed to check sync status: %s", rerr)
+			}
+			if running {
+				logger.Infof("sync is running for job_id[%d], schedule is left paused", job.ID)
+				return nil
+			}
+			err = s.temporal.ResumeSchedule(ctx, job.ProjectID, job.ID)
+		} else {
+			err = s.temporal.PauseSchedule(ctx, job.ProjectID, job.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set schedule paused state: %s", err)
+		}
+	}
+	return nil
+}
+
+// jobSchedule returns the schedule of a job: its frequency in its schedule time zone, without
+// the maintenance windows of its project, its source and the job itself
+func (s Service) jobSchedule(job *models.Job) (*utils.Schedule, error) {
+	timeZone, err := s.scheduleTimeZone(job)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := s.db.ListJobMaintenanceWindows(job.ProjectID, job.SourceID, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	return buildJobSchedule(job.Frequency, timeZone, windows, time.Now())
+}
+
+// buildJobSchedule parses a frequency in a time zone and excludes the given maintenance
+// windows as they are at now
+func buildJobSchedule(frequency, timeZone string, windows []*models.MaintenanceWindow, now time.Time) (*utils.Schedule, error) {
+	schedule, err := utils.ParseFrequency(frequency, timeZone)
+	if err != nil {
+		return nil, err
+	}
+	parsed := make([]utils.Window, 0, len(windows))
+	for _, window := range windows {
+		w, err := parseMaintenanceWindow(window, timeZone)
+		if err != nil {
+			// windows are validated when they are saved
+			logger.Warnf("skipping maintenance window[%d]: %s", window.ID, err)
+			continue
+		}
+		parsed = append(parsed, w)
+	}
+	if err := schedule.Exclude(parsed, now); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// scheduleTimeZone returns the time zone the schedule of a job fires in: the job's own, else
+// the default of its project, else constants.DefaultTimeZone
+func (s Service) scheduleTimeZone(job *models.Job) (string, error) {
+	if job.TimeZone != "" {
+		return job.TimeZone, nil
+	}
+	settings, err := s.db.GetProjectSettingsByProjectID(job.ProjectID)
+	if err != nil {
+		return "", err
+	}
+	return effectiveTimeZone(job.TimeZone, settings.DefaultTimeZone), nil
+}
+
+// effectiveTimeZone picks the time zone of a job from its own and its project default
+func effectiveTimeZone(jobTimeZone, projectTimeZone string) string {
+	switch {
+	case jobTimeZone != "":
+		return jobTimeZone
+	case projectTimeZone != "":
+		return projectTimeZone
+	default:
+		return constants.DefaultTimeZone
+	}
+}
@@ -8,9 +8,12 @@ import (
 	"time"
 
 	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
 	"github.com/datazip-inc/olake-ui/server/internal/models"
 	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
 	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
 	"github.com/datazip-inc/olake-ui/server/internal/utils/telemetry"
 )
 
@@ -45,26 +48,36 @@ func (s Service) GetSource(ctx context.Context, projectID string, sourceID int)
 	}
 
 	item := &dto.SourceDataItem{
-		ID:        source.ID,
-		Name:      source.Name,
-		Type:      source.Type,
-		Version:   source.Version,
-		Config:    source.Config,
-		CreatedAt: source.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: source.UpdatedAt.Format(time.RFC3339),
-		Jobs:      jobItems,
+		ID:         source.ID,
+		Name:       source.Name,
+		Type:       source.Type,
+		Version:    source.Version,
+		Config:     source.Config,
+		CreatedAt:  source.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  source.UpdatedAt.Format(time.RFC3339),
+		Labels:     labelsOrEmpty(source.Labels),
+		Jobs:       jobItems,
+		WorkerPool: source.WorkerPool,
 	}
 	setUsernames(&item.CreatedBy, &item.UpdatedBy, source.CreatedBy, source.UpdatedBy)
 
 	return item, nil
 }
 
-// GetAllSources returns all sources for a project with lightweight job summaries.
-func (s Service) ListSources(ctx context.Context, projectID string) ([]dto.SourceDataItem, error) {
-	sources, err := s.db.ListSourcesByProjectID(projectID)
+// ListSources returns one page of sources for a project with lightweight job summaries.
+func (s Service) ListSources(ctx context.Context, projectID string, query dto.ListQuery) (*dto.PaginatedResponse, error) {
+	opts, err := toListOptions(query)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, total, err := s.db.ListSourcesByProjectID(projectID, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list sources: %s", err)
 	}
+	sources, nextCursor := nextPage(sources, query.Limit, func(src *models.Source) database.Cursor {
+		return database.NewCursor(opts.SortBy, src.ID, src.Name, src.BaseModel)
+	})
 
 	sourceIDs := make([]int, 0, len(sources))
 	for _, src := range sources {
@@ -91,13 +104,15 @@ func (s Service) ListSources(ctx context.Context, projectID string) ([]dto.Sourc
 	items := make([]dto.SourceDataItem, 0, len(sources))
 	for _, src := range sources {
 		item := dto.SourceDataItem{
-			ID:        src.ID,
-			Name:      src.Name,
-			Type:      src.Type,
-			Version:   src.Version,
-			Config:    src.Config,
-			CreatedAt: src.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: src.UpdatedAt.Format(time.RFC3339),
+			ID:         src.ID,
+			Name:       src.Name,
+			Type:       src.Type,
+			Version:    src.Version,
+			Config:     src.Config,
+			CreatedAt:  src.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:  src.UpdatedAt.Format(time.RFC3339),
+			Labels:     labelsOrEmpty(src.Labels),
+			WorkerPool: src.WorkerPool,
 		}
 		setUsernames(&item.CreatedBy, &item.UpdatedBy, src.CreatedBy, src.UpdatedBy)
 
@@ -111,7 +126,12 @@ func (s Service) ListSources(ctx context.Context, projectID string) ([]dto.Sourc
 		items = append(items, item)
 	}
 
-	return items, nil
+	return &dto.PaginatedResponse{
+		Items:      items,
+		Total:      total,
+		Limit:      query.Limit,
+		NextCursor: nextCursor,
+	}, nil
 }
 
 func (s Service) CreateSource(ctx context.Context, req *dto.CreateSourceRequest, projectID string, userID *int) error {
@@ -122,13 +142,20 @@ func (s Service) CreateSource(ctx context.Context, req *dto.CreateSourceRequest,
 	if !unique {
 		return fmt.Errorf("source name '%s' is not unique", req.Name)
 	}
+	if req.WorkerPool != "" {
+		if err := s.temporal.CheckWorkerPool(ctx, req.WorkerPool); err != nil {
+			return err
+		}
+	}
 
 	src := &models.Source{
-		Name:      req.Name,
-		Type:      req.Type,
-		Version:   req.Version,
-		Config:    req.Config,
-		ProjectID: projectID,
+		Name:       req.Name,
+		Type:       req.Type,
+		Version:    req.Version,
+		Config:     req.Config,
+		Labels:     req.Labels,
+		ProjectID:  projectID,
+		WorkerPool: req.WorkerPool,
 	}
 
 	user := &models.User{ID: *userID}
@@ -159,6 +186,17 @@ func (s Service) UpdateSource(ctx context.Context, projectID string, id int, req
 	existing.Type = req.Type
 	existing.Version = req.Version
 
+	// the schedules of the source's jobs move to the task queue of its new worker pool
+	poolChanged := req.WorkerPool != nil && *req.WorkerPool != existing.WorkerPool
+	if poolChanged {
+		if *req.WorkerPool != "" {
+			if err := s.temporal.CheckWorkerPool(ctx, *req.WorkerPool); err != nil {
+				return err
+			}
+		}
+		existing.WorkerPool = *req.WorkerPool
+	}
+
 	user := &models.User{ID: *userID}
 	existing.UpdatedByID = user.ID
 	existing.UpdatedBy = user
@@ -172,9 +210,29 @@ func (s Service) UpdateSource(ctx context.Context, projectID string, id int, req
 		return fmt.Errorf("failed to cancel workflows for source update: %s", err)
 	}
 
-	if err := s.db.UpdateSource(existing); err != nil {
+	if err := s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.UpdateSource(existing); err != nil {
+			return err
+		}
+		if !poolChanged {
+			return nil
+		}
+		for _, job := range jobs {
+			if err := tx.EnqueueScheduleOperations(projectID, job.ID, constants.ScheduleOpUpdate); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
 		return fmt.Errorf("failed to update source: %s", err)
 	}
+	if poolChanged {
+		for _, job := range jobs {
+			if err := s.applyScheduleOperations(ctx, job.ID); err != nil {
+				logger.Warnf("schedule of job_id[%d] not yet moved to the worker pool of source[%d]: %s", job.ID, existing.ID, err)
+			}
+		}
+	}
 
 	telemetry.TrackSourcesStatus(ctx)
 	return nil
@@ -218,7 +276,7 @@ func (s Service) TestSourceConnection(ctx context.Context, req *dto.SourceTestCo
 		return nil, nil, fmt.Errorf("failed to encrypt config for test connection: %s", err)
 	}
 	workflowID := fmt.Sprintf("test-connection-%s-%d", req.Type, time.Now().Unix())
-	result, err := s.temporal.VerifyDriverCredentials(ctx, workflowID, "config", req.Type, req.Version, encryptedConfig)
+	result, err := s.temporal.VerifyDriverCredentials(ctx, req.WorkerPool, workflowID, "config", req.Type, req.Version, encryptedConfig)
 	// TODO: handle from frontend
 	if result == nil {
 		result = map[string]interface{}{
@@ -228,7 +286,7 @@ func (s Service) TestSourceConnection(ctx context.Context, req *dto.SourceTestCo
 	}
 
 	if err != nil {
-		return result, nil, fmt.Errorf("connection test failed: %s", err)
+		return result, nil, fmt.Errorf("connection test failed: %w", err)
 	}
 	homeDir := constants.DefaultConfigDir
 	mainLogDir := filepath.Join(homeDir, workflowID)
@@ -242,15 +300,36 @@ func (s Service) TestSourceConnection(ctx context.Context, req *dto.SourceTestCo
 	return result, logs.Logs, nil
 }
 
-func (s Service) GetSourceCatalog(ctx context.Context, req *dto.StreamsRequest) (map[string]interface{}, error) {
+func (s Service) GetSourceCatalog(ctx context.Context, projectID string, req *dto.StreamsRequest) (map[string]interface{}, error) {
 	oldStreams := ""
+	pool := req.WorkerPool
+	var sourceID, destinationID *int
 	if req.JobID >= 0 {
 		job, err := s.db.GetJobByID(req.JobID, true)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find job for catalog: %s", err)
 		}
 		oldStreams = job.StreamsConfig
+		sourceID, destinationID = &job.SourceID, &job.DestID
+		if pool == "" {
+			pool = temporal.JobWorkerPool(job)
+		}
+	} else if req.SourceID > 0 {
+		sourceID = &req.SourceID
+		if pool == "" {
+			source, err := s.db.GetSourceByID(req.SourceID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find source for catalog: %s", err)
+			}
+			pool = source.WorkerPool
+		}
+	}
+
+	release, err := s.waitForDiscoverSlot(ctx, projectID, sourceID, destinationID)
+	if err != nil {
+		return nil, err
 	}
+	defer release()
 
 	encryptedConfig, err := utils.Encrypt(req.Config)
 	if err != nil {
@@ -259,6 +338,7 @@ func (s Service) GetSourceCatalog(ctx context.Context, req *dto.StreamsRequest)
 
 	newStreams, err := s.temporal.DiscoverStreams(
 		ctx,
+		pool,
 		req.Type,
 		req.Version,
 		encryptedConfig,
@@ -267,7 +347,7 @@ func (s Service) GetSourceCatalog(ctx context.Context, req *dto.StreamsRequest)
 		req.MaxDiscoverThreads,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get catalog: %s", err)
+		return nil, fmt.Errorf("failed to get catalog: %w", err)
 	}
 
 	return newStreams, nil
@@ -285,9 +365,9 @@ func (s Service) GetSourceVersions(ctx context.Context, sourceType string) (dto.
 
 // TODO: cache spec in db for each version
 func (s Service) GetSourceSpec(ctx context.Context, req *dto.SpecRequest) (dto.SpecResponse, error) {
-	specOut, err := s.temporal.GetDriverSpecs(ctx, "", req.Type, req.Version)
+	specOut, err := s.temporal.GetDriverSpecs(ctx, req.WorkerPool, "", req.Type, req.Version)
 	if err != nil {
-		return dto.SpecResponse{}, fmt.Errorf("failed to get spec: %s", err)
+		return dto.SpecResponse{}, fmt.Errorf("failed to get spec: %w", err)
 	}
 
 	return dto.SpecResponse{
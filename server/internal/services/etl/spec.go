@@ -0,0 +1,847 @@
+package etl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/datazip-inc/olake-ui/server/internal/appconfig"
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/telemetry"
+)
+
+// Declarative spec plan and apply methods on AppService
+
+// specSource, specDestination and specJob pair a spec entity with the change planned for it
+// and the model it is created or updated from. An entity without an action is unchanged.
+type specSource struct {
+	change dto.SpecChange
+	source *models.Source
+}
+
+type specDestination struct {
+	change      dto.SpecChange
+	destination *models.Destination
+}
+
+type specJob struct {
+	change        dto.SpecChange
+	job           dto.BundleJob
+	streamsConfig string
+	existing      *models.Job
+	source        *specSource
+	destination   *specDestination
+	// streams whose destination data is cleared before the update
+	clearStreams []string
+	schedule     *scheduleState
+}
+
+type projectPlan struct {
+	projectID    string
+	settings     *dto.SpecChange
+	webhookURL   string
+	sources      []*specSource
+	destinations []*specDestination
+	jobs         []*specJob
+	deletes      []*dto.SpecChange
+}
+
+type specPlan struct {
+	projects []*projectPlan
+	errors   []string
+}
+
+// envFilter reports whether a spec config may reference the environment variable with the given name
+type envFilter func(name string) bool
+
+// requestEnv allows specs received over the API to reference only the environment variables
+// with the configured prefix, so that the secrets of the server cannot be read through a spec
+func requestEnv(name string) bool {
+	prefix := appconfig.Load().SpecEnvPrefix
+	return prefix != "" && strings.HasPrefix(name, prefix)
+}
+
+// anyEnv allows the trusted spec files of the provisioning directory to reference any variable
+func anyEnv(string) bool { return true }
+
+// PlanSpec compares a spec received over the API with the current state of its projects,
+// including the temporal schedules of their jobs, without changing anything.
+func (s Service) PlanSpec(ctx context.Context, req *dto.SpecPlanRequest) (*dto.SpecPlanResponse, error) {
+	plan, err := s.planSpec(ctx, req.Spec, requestEnv)
+	if err != nil {
+		return nil, err
+	}
+	return plan.response(false), nil
+}
+
+// ApplySpec plans a spec received over the API and applies it when the plan has no errors.
+// Destructive stream changes clear the destination data of the affected streams and are only
+// applied when the request allows them. The plan is returned along with the error when it is
+// refused.
+func (s Service) ApplySpec(ctx context.Context, req *dto.SpecPlanRequest, userID *int) (*dto.SpecPlanResponse, error) {
+	return s.applySpec(ctx, req, userID, requestEnv)
+}
+
+func (s Service) applySpec(ctx context.Context, req *dto.SpecPlanRequest, userID *int, allowEnv envFilter) (*dto.SpecPlanResponse, error) {
+	plan, err := s.planSpec(ctx, req.Spec, allowEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := plan.response(false)
+	if len(plan.errors) > 0 {
+		return resp, fmt.Errorf("%w: %d errors, nothing was applied", constants.ErrInvalidSpec, len(plan.errors))
+	}
+	if resp.Destructive > 0 && !req.AllowDestructive {
+		return resp, fmt.Errorf("%w: %d jobs would clear destination data, set allow_destructive to apply", constants.ErrDestructiveChanges, resp.Destructive)
+	}
+
+	for _, project := range plan.projects {
+		logger.Infof("apply spec initiated project_id[%s] jobs[%d] sources[%d] destinations[%d] deletes[%d]", project.projectID, len(project.jobs), len(project.sources), len(project.destinations), len(project.deletes))
+		s.applyProjectPlan(ctx, project, *userID)
+	}
+	return plan.response(true), nil
+}
+
+func (s Service) planSpec(ctx context.Context, spec *dto.Spec, allowEnv envFilter) (*specPlan, error) {
+	plan := &specPlan{}
+	for i := range spec.Projects {
+		project, err := s.planProject(ctx, &spec.Projects[i], allowEnv, &plan.errors)
+		if err != nil {
+			return nil, err
+		}
+		plan.projects = append(plan.projects, project)
+	}
+	return plan, nil
+}
+
+// planProject resolves the entities of a project spec against the project. Problems that
+// prevent the spec from being applied are recorded on the change and in errs.
+func (s Service) planProject(ctx context.Context, ps *dto.ProjectSpec, allowEnv envFilter, errs *[]string) (*projectPlan, error) {
+	projectID := ps.ID
+	existingSources, _, err := s.db.ListSourcesByProjectID(projectID, database.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources: %s", err)
+	}
+	existingDestinations, _, err := s.db.ListDestinationsByProjectID(projectID, database.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list destinations: %s", err)
+	}
+	listedJobs, _, err := s.db.ListJobsByProjectID(projectID, database.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %s", err)
+	}
+	jobIDs := make([]int, 0, len(listedJobs))
+	for _, job := range listedJobs {
+		jobIDs = append(jobIDs, job.ID)
+	}
+	// the listing leaves out the streams config, which is compared below
+	existingJobs, err := s.db.GetJobsByIDs(projectID, jobIDs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &projectPlan{projectID: projectID}
+	fail := func(change *dto.SpecChange, format string, args ...any) {
+		change.Error = fmt.Sprintf(format, args...)
+		*errs = append(*errs, fmt.Sprintf("project '%s': %s '%s': %s", projectID, change.Kind, change.Name, change.Error))
+	}
+
+	if ps.Settings != nil {
+		current, err := s.db.GetProjectSettingsByProjectID(projectID)
+		if err != nil {
+			return nil, err
+		}
+		if current.ID == 0 || current.WebhookAlertURL != ps.Settings.WebhookAlertURL {
+			plan.settings = &dto.SpecChange{ProjectID: projectID, Kind: constants.SpecKindProjectSettings, Name: projectID, ID: current.ID, Action: constants.SpecActionUpdate, Fields: []string{"webhook_alert_url"}}
+			if current.ID == 0 {
+				plan.settings.Action = constants.SpecActionCreate
+			}
+			plan.webhookURL = ps.Settings.WebhookAlertURL
+		}
+	}
+
+	sourcesByName := make(map[string]*specSource)
+	for _, ss := range ps.Sources {
+		planned := &specSource{change: dto.SpecChange{ProjectID: projectID, Kind: constants.BundleKindSource, Name: ss.Name, Action: constants.SpecActionCreate}}
+		plan.sources = append(plan.sources, planned)
+		if sourcesByName[ss.Name] != nil {
+			fail(&planned.change, "source is listed more than once")
+			continue
+		}
+		sourcesByName[ss.Name] = planned
+
+		config, err := resolveSpecConfig(ss.Config, allowEnv)
+		if err != nil {
+			fail(&planned.change, "%s", err)
+			continue
+		}
+		planned.source = &models.Source{Name: ss.Name, Type: ss.Type, Version: ss.Version, Config: config, Labels: ss.Labels, ProjectID: projectID}
+		if err := dto.ValidateSourceType(ss.Type); err != nil {
+			fail(&planned.change, "%s", err)
+		} else if err := models.ValidateLabels(ss.Labels); err != nil {
+			fail(&planned.change, "%s", err)
+		}
+
+		existing := findByName(existingSources, ss.Name, func(src *models.Source) string { return src.Name })
+		if existing == nil {
+			continue
+		}
+		planned.source.ID = existing.ID
+		planned.change.ID = existing.ID
+		planned.change.Action = constants.SpecActionUpdate
+		if existing.Type != ss.Type {
+			fail(&planned.change, "type cannot be changed from '%s' to '%s'", existing.Type, ss.Type)
+		}
+		planned.change.Fields = driverChanges(existing.Version, ss.Version, existing.Config, config, existing.Labels, ss.Labels)
+		if len(planned.change.Fields) == 0 {
+			planned.change.Action = ""
+		}
+	}
+
+	destinationsByName := make(map[string]*specDestination)
+	for _, sd := range ps.Destinations {
+		planned := &specDestination{change: dto.SpecChange{ProjectID: projectID, Kind: constants.BundleKindDestination, Name: sd.Name, Action: constants.SpecActionCreate}}
+		plan.destinations = append(plan.destinations, planned)
+		if destinationsByName[sd.Name] != nil {
+			fail(&planned.change, "destination is listed more than once")
+			continue
+		}
+		destinationsByName[sd.Name] = planned
+
+		config, err := resolveSpecConfig(sd.Config, allowEnv)
+		if err != nil {
+			fail(&planned.change, "%s", err)
+			continue
+		}
+		planned.destination = &models.Destination{Name: sd.Name, DestType: sd.Type, Version: sd.Version, Config: config, Labels: sd.Labels, ProjectID: projectID}
+		if err := dto.ValidateDestinationType(sd.Type); err != nil {
+			fail(&planned.change, "%s", err)
+		} else if err := models.ValidateLabels(sd.Labels); err != nil {
+			fail(&planned.change, "%s", err)
+		}
+
+		existing := findByName(existingDestinations, sd.Name, func(dest *models.Destination) string { return dest.Name })
+		if existing == nil {
+			continue
+		}
+		planned.destination.ID = existing.ID
+		planned.change.ID = existing.ID
+		planned.change.Action = constants.SpecActionUpdate
+		if existing.DestType != sd.Type {
+			fail(&planned.change, "type cannot be changed from '%s' to '%s'", existing.DestType, sd.Type)
+		}
+		planned.change.Fields = driverChanges(existing.Version, sd.Version, existing.Config, config, existing.Labels, sd.Labels)
+		if len(planned.change.Fields) == 0 {
+			planned.change.Action = ""
+		}
+	}
+
+	jobNames := make(map[string]bool)
+	for _, sj := range ps.Jobs {
+		planned := &specJob{
+			change:      dto.SpecChange{ProjectID: projectID, Kind: constants.BundleKindJob, Name: sj.Name, Action: constants.SpecActionCreate},
+			job:         sj,
+			source:      sourcesByName[sj.Source],
+			destination: destinationsByName[sj.Destination],
+		}
+		plan.jobs = append(plan.jobs, planned)
+		if jobNames[sj.Name] {
+			fail(&planned.change, "job is listed more than once")
+			continue
+		}
+		jobNames[sj.Name] = true
+
+		existing := findByName(existingJobs, sj.Name, func(job *models.Job) string { return job.Name })
+		switch {
+		case planned.source == nil || planned.source.source == nil:
+			fail(&planned.change, "source '%s' is not defined in the project spec", sj.Source)
+			continue
+		case planned.destination == nil || planned.destination.destination == nil:
+			fail(&planned.change, "destination '%s' is not defined in the project spec", sj.Destination)
+			continue
+		case sj.Frequency == "":
+			fail(&planned.change, "frequency is required")
+		case sj.StreamsConfig == nil:
+			fail(&planned.change, "streams_config is required")
+		default:
+			if err := models.ValidateLabels(sj.Labels); err != nil {
+				fail(&planned.change, "%s", err)
+			} else if err := validateJobFrequency(sj.Frequency, existing); err != nil {
+				fail(&planned.change, "%s", err)
+			} else if err := sj.AdvancedSettings.Validate(); err != nil {
+				fail(&planned.change, "%s", err)
+			}
+		}
+		streamsConfig, err := json.Marshal(sj.StreamsConfig)
+		if err != nil {
+			fail(&planned.change, "failed to serialise streams_config: %s", err)
+			continue
+		}
+		planned.streamsConfig = string(streamsConfig)
+
+		if existing == nil {
+			continue
+		}
+		planned.existing = existing
+		planned.change.ID = existing.ID
+		planned.change.Action = constants.SpecActionUpdate
+		if err := s.planJobUpdate(ctx, planned); err != nil {
+			fail(&planned.change, "%s", err)
+		}
+		if len(planned.change.Fields) == 0 && planned.change.Error == "" {
+			planned.change.Action = ""
+		}
+	}
+
+	if ps.Prune {
+		for _, job := range existingJobs {
+			if !jobNames[job.Name] {
+				plan.deletes = append(plan.deletes, &dto.SpecChange{ProjectID: projectID, Kind: constants.BundleKindJob, Name: job.Name, ID: job.ID, Action: constants.SpecActionDelete})
+			}
+		}
+		for _, src := range existingSources {
+			if sourcesByName[src.Name] == nil {
+				plan.deletes = append(plan.deletes, &dto.SpecChange{ProjectID: projectID, Kind: constants.BundleKindSource, Name: src.Name, ID: src.ID, Action: constants.SpecActionDelete})
+			}
+		}
+		for _, dest := range existingDestinations {
+			if destinationsByName[dest.Name] == nil {
+				plan.deletes = append(plan.deletes, &dto.SpecChange{ProjectID: projectID, Kind: constants.BundleKindDestination, Name: dest.Name, ID: dest.ID, Action: constants.SpecActionDelete})
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// planJobUpdate lists what differs between an existing job, its schedule and the spec.
+func (s Service) planJobUpdate(ctx context.Context, planned *specJob) error {
+	fields, err := jobChanges(planned)
+	if err != nil {
+		return err
+	}
+
+	existing := planned.existing
+	expected, err := s.jobSchedule(existing)
+	if err != nil {
+		return err
+	}
+	schedule, err := s.inspectSchedule(ctx, existing.ProjectID, existing.ID, expected)
+	if err != nil {
+		return err
+	}
+	planned.schedule = schedule
+	planned.change.Fields = append(fields, schedule.drift(existing.Active)...)
+
+	if planned.change.Destructive && !planned.job.Active {
+		return fmt.Errorf("destructive stream changes clear the destination, which requires the job to be active")
+	}
+	return nil
+}
+
+// jobChanges lists the fields that differ between an existing job and its spec. A streams
+// config change records the stream changes on the planned job, and the streams whose
+// destination data has to be cleared.
+func jobChanges(planned *specJob) ([]string, error) {
+	existing, sj := planned.existing, planned.job
+	var fields []string
+	if existing.SourceID != planned.source.source.ID {
+		fields = append(fields, "source")
+	}
+	if existing.DestID != planned.destination.destination.ID {
+		fields = append(fields, "destination")
+	}
+	if existing.Frequency != sj.Frequency {
+		fields = append(fields, "frequency")
+	}
+	if !jsonEqual(existing.StreamsConfig, planned.streamsConfig) {
+		fields = append(fields, "streams_config")
+		changes, err := diffStreams(existing.StreamsConfig, planned.streamsConfig)
+		if err != nil {
+			return nil, err
+		}
+		planned.change.StreamChanges = changes
+		for _, change := range changes {
+			if change.Destructive {
+				planned.clearStreams = append(planned.clearStreams, change.Stream)
+			}
+		}
+		planned.change.Destructive = len(planned.clearStreams) > 0
+	}
+
+	// secret values are saved encrypted, so only which secrets are set is compared
+	advancedSettings := "null"
+	if sj.AdvancedSettings != nil {
+		b, err := json.Marshal(sj.AdvancedSettings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialise advanced_settings: %s", err)
+		}
+		if advancedSettings, err = maskedAdvancedSettings(string(b)); err != nil {
+			return nil, err
+		}
+	}
+	savedSettings := ""
+	if existing.AdvancedSettings != nil {
+		masked, err := maskedAdvancedSettings(*existing.AdvancedSettings)
+		if err != nil {
+			return nil, err
+		}
+		savedSettings = masked
+	}
+	if existing.AdvancedSettings == nil && sj.AdvancedSettings != nil ||
+		existing.AdvancedSettings != nil && !jsonEqual(savedSettings, advancedSettings) {
+		fields = append(fields, "advanced_settings")
+	}
+	if !maps.Equal(existing.Labels, models.Labels(sj.Labels)) {
+		fields = append(fields, "labels")
+	}
+	if existing.Active != sj.Active {
+		fields = append(fields, "active")
+	}
+	return fields, nil
+}
+
+// applyProjectPlan applies the changes of a project in dependency order: settings, sources
+// and destinations, jobs, and finally deletions. A failed change is reported on its change;
+// jobs using a failed source or destination are skipped.
+func (s Service) applyProjectPlan(ctx context.Context, plan *projectPlan, userID int) {
+	if plan.settings != nil {
+		err := s.db.UpsertProjectSettingsModel(&models.ProjectSettings{ProjectID: plan.projectID, WebhookAlertURL: plan.webhookURL})
+		setSpecError(plan.settings, err)
+	}
+
+	user := &models.User{ID: userID}
+	for _, planned := range plan.sources {
+		src := planned.source
+		var err error
+		switch {
+		case planned.change.Action == constants.SpecActionCreate:
+			src.CreatedByID, src.UpdatedByID, src.CreatedBy, src.UpdatedBy = user.ID, user.ID, user, user
+			if err = s.db.CreateSource(src); err == nil {
+				telemetry.TrackSourceCreation(ctx, src)
+			}
+		case planned.change.Action == constants.SpecActionUpdate:
+			if slices.Contains(planned.change.Fields, "config") || slices.Contains(planned.change.Fields, "version") {
+				err = s.UpdateSource(ctx, plan.projectID, src.ID, &dto.UpdateSourceRequest{Name: src.Name, Type: src.Type, Version: src.Version, Config: src.Config}, &userID)
+			}
+			if err == nil && slices.Contains(planned.change.Fields, "labels") {
+				err = s.db.SetLabels(constants.SourceTable, plan.projectID, src.ID, src.Labels)
+			}
+		}
+		planned.change.ID = src.ID
+		setSpecError(&planned.change, err)
+	}
+
+	for _, planned := range plan.destinations {
+		dest := planned.destination
+		var err error
+		switch {
+		case planned.change.Action == constants.SpecActionCreate:
+			dest.CreatedByID, dest.UpdatedByID, dest.CreatedBy, dest.UpdatedBy = user.ID, user.ID, user, user
+			if err = s.db.CreateDestination(dest); err == nil {
+				telemetry.TrackDestinationCreation(ctx, dest)
+			}
+		case planned.change.Action == constants.SpecActionUpdate:
+			if slices.Contains(planned.change.Fields, "config") || slices.Contains(planned.change.Fields, "version") {
+				err = s.UpdateDestination(ctx, dest.ID, plan.projectID, &dto.UpdateDestinationRequest{Name: dest.Name, Type: dest.DestType, Version: dest.Version, Config: dest.Config}, &userID)
+			}
+			if err == nil && slices.Contains(planned.change.Fields, "labels") {
+				err = s.db.SetLabels(constants.DestinationTable, plan.projectID, dest.ID, dest.Labels)
+			}
+		}
+		planned.change.ID = dest.ID
+		setSpecError(&planned.change, err)
+	}
+
+	for _, planned := range plan.jobs {
+		switch {
+		case planned.change.Action == "":
+		case planned.source.change.Error != "":
+			planned.change.Error = fmt.Sprintf("source '%s' was not applied", planned.source.change.Name)
+		case planned.destination.change.Error != "":
+			planned.change.Error = fmt.Sprintf("destination '%s' was not applied", planned.destination.change.Name)
+		case planned.existing == nil:
+			setSpecError(&planned.change, s.createSpecJob(ctx, plan.projectID, planned, userID))
+		default:
+			setSpecError(&planned.change, s.updateSpecJob(ctx, plan.projectID, planned, userID))
+		}
+	}
+
+	// jobs are deleted first so that the sources and destinations they use are free
+	for _, change := range plan.deletes {
+		var err error
+		switch change.Kind {
+		case constants.BundleKindJob:
+			if _, err = s.DeleteJob(ctx, change.ID); errors.Is(err, constants.ErrSchedulePending) {
+				// the job is gone and the outbox dispatcher removes its schedule
+				logger.Warnf("job_id[%d] deleted, %s", change.ID, err)
+				err = nil
+			}
+		case constants.BundleKindSource:
+			_, err = s.DeleteSource(ctx, change.ID)
+		case constants.BundleKindDestination:
+			_, err = s.DeleteDestination(ctx, change.ID)
+		}
+		setSpecError(change, err)
+	}
+}
+
+func (s Service) createSpecJob(ctx context.Context, projectID string, planned *specJob, userID int) error {
+	sj := planned.job
+	job := &models.Job{
+		Name:          sj.Name,
+		SourceID:      planned.source.source.ID,
+		DestID:        planned.destination.destination.ID,
+		Source:        planned.source.source,
+		Destination:   planned.destination.destination,
+		Frequency:     sj.Frequency,
+		StreamsConfig: planned.streamsConfig,
+		Labels:        sj.Labels,
+		ProjectID:     projectID,
+	}
+	advancedSettings, err := marshalAdvancedSettings(sj.AdvancedSettings, nil)
+	if err != nil {
+		return err
+	}
+	job.AdvancedSettings = advancedSettings
+	if err := s.createScheduledJob(ctx, job, sj.Active, userID); err != nil {
+		return err
+	}
+	planned.change.ID = job.ID
+
+	return nil
+}
+
+// updateSpecJob brings an existing job and its schedule in line with the spec. A missing
+// schedule is recreated first since the job update and clear-destination act on it.
+func (s Service) updateSpecJob(ctx context.Context, projectID string, planned *specJob, userID int) error {
+	existing, sj, fields := planned.existing, planned.job, planned.change.Fields
+	jobID := existing.ID
+	var pending error
+
+	if planned.schedule.Missing {
+		if err := s.repairScheduleDrift(ctx, existing, []string{constants.DriftScheduleMissing}); err != nil {
+			return err
+		}
+	}
+
+	// clear-destination only runs for active jobs, so activation goes first
+	if slices.Contains(fields, "active") {
+		if err := s.ActivateJob(ctx, jobID, dto.JobStatusRequest{Activate: sj.Active}, &userID); err != nil {
+			return err
+		}
+	}
+
+	if slices.ContainsFunc(fields, func(field string) bool {
+		return slices.Contains([]string{"source", "destination", "frequency", "streams_config", "advanced_settings"}, field)
+	}) {
+		sourceID, destID := planned.source.source.ID, planned.destination.destination.ID
+		req := &dto.UpdateJobRequest{
+			Name:             sj.Name,
+			Source:           &dto.DriverConfig{ID: &sourceID},
+			Destination:      &dto.DriverConfig{ID: &destID},
+			Frequency:        sj.Frequency,
+			StreamsConfig:    planned.streamsConfig,
+			Activate:         sj.Active,
+			AdvancedSettings: sj.AdvancedSettings,
+		}
+		if len(planned.clearStreams) > 0 {
+			// only the streams being cleared are passed, from the config they were written with
+			clearConfig, err := filterStreamsConfig(existing.StreamsConfig, planned.clearStreams)
+			if err != nil {
+				return err
+			}
+			req.DifferenceStreams = clearConfig
+		}
+		if err := s.UpdateJob(ctx, req, projectID, jobID, &userID); err != nil {
+			if !errors.Is(err, constants.ErrSchedulePending) {
+				return err
+			}
+			pending = err
+		}
+	}
+
+	if slices.Contains(fields, "labels") {
+		if err := s.db.SetLabels(constants.JobTable, projectID, jobID, sj.Labels); err != nil {
+			return err
+		}
+	}
+
+	if pending != nil {
+		// the job is saved, its schedule is repaired once the queued changes are applied
+		return pending
+	}
+	return s.repairSchedule(ctx, projectID, planned)
+}
+
+// repairSchedule fixes the drift found in the job's schedule at plan time that the job
+// update did not already take care of.
+func (s Service) repairSchedule(ctx context.Context, projectID string, planned *specJob) error {
+	fields := planned.change.Fields
+	if planned.schedule.Missing || len(planned.clearStreams) > 0 {
+		// a recreated schedule is current and a clear-destination just took over the schedule
+		return nil
+	}
+
+	var drift []string
+	for _, field := range fields {
+		switch {
+		case field == constants.DriftScheduleFrequency && slices.Contains(fields, "frequency"),
+			field == constants.DriftSchedulePaused && slices.Contains(fields, "active"):
+			// already applied by the job update or activation
+		case field == constants.DriftScheduleFrequency, field == constants.DriftSchedulePaused, field == constants.DriftScheduleAction:
+			drift = append(drift, field)
+		}
+	}
+	if len(drift) == 0 {
+		return nil
+	}
+
+	job, err := s.db.GetJobByID(planned.existing.ID, false)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %s", err)
+	}
+	if job.ProjectID != projectID {
+		return fmt.Errorf("job_id[%d] does not belong to project_id[%s]", job.ID, projectID)
+	}
+	return s.repairScheduleDrift(ctx, job, drift)
+}
+
+// ProvisionFromDir applies every spec file (.json, .yaml, .yml) of a directory in name order.
+// Entities are created on behalf of the given user, or of the first user when none is set;
+// users are created after the server starts, so it waits for the user to exist.
+func (s Service) ProvisionFromDir(ctx context.Context, dir, username string, allowDestructive bool) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read provisioning directory: %s", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !entry.IsDir() && (ext == ".json" || ext == ".yaml" || ext == ".yml") {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	if len(files) == 0 {
+		logger.Infof("no spec files found in provisioning directory %s", dir)
+		return nil
+	}
+
+	user, err := s.waitForProvisioningUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read spec file %s: %s", file, err)
+		}
+		// YAML is a superset of JSON, so both are parsed the same way
+		req := &dto.SpecPlanRequest{Spec: &dto.Spec{}, AllowDestructive: allowDestructive}
+		if err := yaml.Unmarshal(data, req.Spec); err != nil {
+			return fmt.Errorf("failed to parse spec file %s: %s", file, err)
+		}
+		if err := req.Validate(); err != nil {
+			return fmt.Errorf("invalid spec file %s: %s", file, err)
+		}
+
+		resp, err := s.applySpec(ctx, req, &user.ID, anyEnv)
+		if err != nil {
+			if resp != nil {
+				for _, msg := range resp.Errors {
+					logger.Errorf("spec file %s: %s", file, msg)
+				}
+			}
+			return fmt.Errorf("failed to apply spec file %s: %s", file, err)
+		}
+		logger.Infof("applied spec file %s: creates[%d] updates[%d] deletes[%d] failed[%d]", file, resp.Creates, resp.Updates, resp.Deletes, resp.Failed)
+	}
+	return nil
+}
+
+func (s Service) waitForProvisioningUser(ctx context.Context, username string) (*models.User, error) {
+	deadline := time.Now().Add(constants.ProvisioningUserWaitTimeout)
+	for {
+		if username != "" {
+			if user, err := s.db.GetUserByUsername(username); err == nil {
+				return user, nil
+			}
+		} else if users, _, err := s.db.ListUsers(database.ListOptions{Limit: 1}); err == nil && len(users) > 0 {
+			return users[0], nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("no user to provision specs with after waiting %s", constants.ProvisioningUserWaitTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(constants.ProvisioningUserPollInterval):
+		}
+	}
+}
+
+func (p *specPlan) response(applied bool) *dto.SpecPlanResponse {
+	resp := &dto.SpecPlanResponse{Applied: applied, Errors: p.errors, Changes: []dto.SpecChange{}}
+	for _, project := range p.projects {
+		for _, change := range project.changes() {
+			if change.Action == "" && change.Error == "" {
+				continue
+			}
+			switch change.Action {
+			case constants.SpecActionCreate:
+				resp.Creates++
+			case constants.SpecActionUpdate:
+				resp.Updates++
+			case constants.SpecActionDelete:
+				resp.Deletes++
+			}
+			if change.Destructive {
+				resp.Destructive++
+			}
+			if applied && change.Error != "" {
+				resp.Failed++
+			}
+			resp.Changes = append(resp.Changes, *change)
+		}
+	}
+	return resp
+}
+
+func (p *projectPlan) changes() []*dto.SpecChange {
+	var changes []*dto.SpecChange
+	if p.settings != nil {
+		changes = append(changes, p.settings)
+	}
+	for _, planned := range p.sources {
+		changes = append(changes, &planned.change)
+	}
+	for _, planned := range p.destinations {
+		changes = append(changes, &planned.change)
+	}
+	for _, planned := range p.jobs {
+		changes = append(changes, &planned.change)
+	}
+	return append(changes, p.deletes...)
+}
+
+func setSpecError(change *dto.SpecChange, err error) {
+	if err != nil {
+		logger.Errorf("failed to apply %s '%s' project_id[%s]: %s", change.Kind, change.Name, change.ProjectID, err)
+		change.Error = err.Error()
+	}
+}
+
+// driverChanges lists the fields that differ between an existing source or destination and its spec
+func driverChanges(version, specVersion, config, specConfig string, labels models.Labels, specLabels map[string]string) []string {
+	var fields []string
+	if version != specVersion {
+		fields = append(fields, "version")
+	}
+	if !jsonEqual(config, specConfig) {
+		fields = append(fields, "config")
+	}
+	if !maps.Equal(labels, models.Labels(specLabels)) {
+		fields = append(fields, "labels")
+	}
+	return fields
+}
+
+// jsonEqual reports whether two JSON documents hold the same value regardless of formatting and key order
+func jsonEqual(a, b string) bool {
+	var va, vb any
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// resolveSpecConfig replaces "${env:NAME}" references in the string values of a driver config
+// with the value of the environment variable and serialises the config. References to
+// variables that allowEnv refuses are errors and are not looked up.
+func resolveSpecConfig(config map[string]any, allowEnv envFilter) (string, error) {
+	var missing, refused []string
+	var resolve func(value any) any
+	resolve = func(value any) any {
+		switch v := value.(type) {
+		case map[string]any:
+			out := make(map[string]any, len(v))
+			for key, child := range v {
+				out[key] = resolve(child)
+			}
+			return out
+		case []any:
+			out := make([]any, len(v))
+			for i, child := range v {
+				out[i] = resolve(child)
+			}
+			return out
+		case string:
+			return expandEnvReferences(v, allowEnv, &missing, &refused)
+		}
+		return value
+	}
+
+	resolved := resolve(config)
+	if len(refused) > 0 {
+		slices.Sort(refused)
+		return "", fmt.Errorf("environment variables may not be referenced: %s", strings.Join(slices.Compact(refused), ", "))
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return "", fmt.Errorf("environment variables are not set: %s", strings.Join(slices.Compact(missing), ", "))
+	}
+	if config == nil {
+		resolved = map[string]any{}
+	}
+	b, err := json.Marshal(resolved)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialise config: %s", err)
+	}
+	return string(b), nil
+}
+
+func expandEnvReferences(value string, allowEnv envFilter, missing, refused *[]string) string {
+	var out strings.Builder
+	for {
+		start := strings.Index(value, constants.EnvReferencePrefix)
+		if start < 0 {
+			break
+		}
+		end := strings.Index(value[start:], constants.EnvReferenceSuffix)
+		if end < 0 {
+			break
+		}
+		name := value[start+len(constants.EnvReferencePrefix) : start+end]
+		var env string
+		if allowEnv(name) {
+			var ok bool
+			if env, ok = os.LookupEnv(name); !ok {
+				*missing = append(*missing, name)
+			}
+		} else {
+			*refused = append(*refused, name)
+		}
+		out.WriteString(value[:start])
+		out.WriteString(env)
+		value = value[start+end+len(constants.EnvReferenceSuffix):]
+	}
+	out.WriteString(value)
+	return out.String()
+}
@@ -0,0 +1,237 @@
+package etl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+)
+
+func TestDriverChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		config     string
+		specLabels map[string]string
+		want       []string
+	}{
+		{
+			name:       "unchanged",
+			version:    "v0.2.0",
+			config:     `{"host":"db","port":5432}`,
+			specLabels: map[string]string{"team": "data"},
+		},
+		{
+			name:       "config formatting and key order",
+			version:    "v0.2.0",
+			config:     `{ "port": 5432, "host": "db" }`,
+			specLabels: map[string]string{"team": "data"},
+		},
+		{
+			name:       "version",
+			version:    "v0.3.0",
+			config:     `{"host":"db","port":5432}`,
+			specLabels: map[string]string{"team": "data"},
+			want:       []string{"version"},
+		},
+		{
+			name:    "labels removed",
+			version: "v0.2.0",
+			config:  `{"host":"db","port":5432}`,
+			want:    []string{"labels"},
+		},
+		{
+			name:       "everything",
+			version:    "v0.3.0",
+			config:     `{"host":"replica","port":5432}`,
+			specLabels: map[string]string{"team": "platform"},
+			want:       []string{"version", "config", "labels"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := driverChanges("v0.2.0", tt.version, `{"host":"db","port":5432}`, tt.config, models.Labels{"team": "data"}, tt.specLabels)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJobChanges(t *testing.T) {
+	const streams = `{"selected_streams":{"public":[{"stream_name":"orders","partition_regex":"","normalization":true},{"stream_name":"users","partition_regex":"","normalization":true}]},` +
+		`"streams":[{"stream":{"name":"orders","namespace":"public","sync_mode":"cdc"}},{"stream":{"name":"users","namespace":"public","sync_mode":"cdc"}}]}`
+	savedSettings := `{"run_timeout":"12h","env":[{"name":"API_TOKEN","value":"encrypted","secret":true}]}`
+	job := func() dto.BundleJob {
+		return dto.BundleJob{
+			Frequency: "0 * * * *",
+			Active:    true,
+			AdvancedSettings: &dto.AdvancedSettings{
+				RunTimeout: "12h",
+				Env:        []dto.DriverEnvVar{{Name: "API_TOKEN", Value: "plain", Secret: true}},
+			},
+			Labels: map[string]string{"team": "data"},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		edit          func(sj *dto.BundleJob)
+		streamsConfig string
+		want          []string
+		streamChanges []dto.StreamChange
+		clearStreams  []string
+	}{
+		{
+			name: "unchanged with a new secret value",
+		},
+		{
+			name: "frequency, labels and active",
+			edit: func(sj *dto.BundleJob) {
+				sj.Frequency = "0 0 * * *"
+				sj.Labels = nil
+				sj.Active = false
+			},
+			want: []string{"frequency", "labels", "active"},
+		},
+		{
+			name: "secret turned into a plain value",
+			edit: func(sj *dto.BundleJob) {
+				sj.AdvancedSettings.Env[0].Secret = false
+			},
+			want: []string{"advanced_settings"},
+		},
+		{
+			name: "advanced settings removed",
+			edit: func(sj *dto.BundleJob) {
+				sj.AdvancedSettings = nil
+			},
+			want: []string{"advanced_settings"},
+		},
+		{
+			name: "streams config formatting",
+			streamsConfig: `{"streams":[{"stream":{"namespace":"public","name":"orders","sync_mode":"cdc"}},{"stream":{"namespace":"public","name":"users","sync_mode":"cdc"}}],` +
+				`"selected_streams":{"public":[{"normalization":true,"partition_regex":"","stream_name":"orders"},{"normalization":true,"partition_regex":"","stream_name":"users"}]}}`,
+		},
+		{
+			name: "destructive partition and sync mode changes",
+			streamsConfig: `{"selected_streams":{"public":[{"stream_name":"orders","partition_regex":"/{created_at,day}","normalization":true},{"stream_name":"users","partition_regex":"","normalization":true}]},` +
+				`"streams":[{"stream":{"name":"orders","namespace":"public","sync_mode":"cdc"}},{"stream":{"name":"users","namespace":"public","sync_mode":"full_refresh"}}]}`,
+			want: []string{"streams_config"},
+			streamChanges: []dto.StreamChange{
+				{Stream: "public.orders", Change: constants.StreamChangeModified, Fields: []string{"partition_regex"}, Destructive: true},
+				{Stream: "public.users", Change: constants.StreamChangeModified, Fields: []string{"sync_mode"}, Destructive: true},
+			},
+			clearStreams: []string{"public.orders", "public.users"},
+		},
+		{
+			name: "added and removed streams",
+			streamsConfig: `{"selected_streams":{"public":[{"stream_name":"orders","partition_regex":"","normalization":true},{"stream_name":"items","partition_regex":"","normalization":true}]},` +
+				`"streams":[{"stream":{"name":"orders","namespace":"public","sync_mode":"cdc"}},{"stream":{"name":"items","namespace":"public","sync_mode":"cdc"}}]}`,
+			want: []string{"streams_config"},
+			streamChanges: []dto.StreamChange{
+				{Stream: "public.items", Change: constants.StreamChangeAdded},
+				{Stream: "public.users", Change: constants.StreamChangeRemoved},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sj := job()
+			if tt.edit != nil {
+				tt.edit(&sj)
+			}
+			streamsConfig := streams
+			if tt.streamsConfig != "" {
+				streamsConfig = tt.streamsConfig
+			}
+			planned := &specJob{
+				job:           sj,
+				streamsConfig: streamsConfig,
+				existing: &models.Job{
+					SourceID:         1,
+					DestID:           2,
+					Frequency:        "0 * * * *",
+					Active:           true,
+					StreamsConfig:    streams,
+					AdvancedSettings: &savedSettings,
+					Labels:           models.Labels{"team": "data"},
+				},
+				source:      &specSource{source: &models.Source{ID: 1}},
+				destination: &specDestination{destination: &models.Destination{ID: 2}},
+			}
+
+			fields, err := jobChanges(planned)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, fields)
+			require.Equal(t, tt.streamChanges, planned.change.StreamChanges)
+			require.Equal(t, tt.clearStreams, planned.clearStreams)
+			require.Equal(t, len(tt.clearStreams) > 0, planned.change.Destructive)
+		})
+	}
+}
+
+func TestJobChangesDrivers(t *testing.T) {
+	planned := &specJob{
+		job:           dto.BundleJob{Frequency: "0 * * * *"},
+		streamsConfig: `{}`,
+		existing:      &models.Job{SourceID: 1, DestID: 2, Frequency: "0 * * * *", StreamsConfig: `{}`},
+		source:        &specSource{source: &models.Source{ID: 3}},
+		destination:   &specDestination{destination: &models.Destination{ID: 4}},
+	}
+	fields, err := jobChanges(planned)
+	require.NoError(t, err)
+	require.Equal(t, []string{"source", "destination"}, fields)
+}
+
+func TestResolveSpecConfig(t *testing.T) {
+	t.Setenv("SPEC_DB_PASSWORD", "secret")
+	t.Setenv("SERVER_TOKEN", "token")
+	specEnv := func(name string) bool { return strings.HasPrefix(name, "SPEC_") }
+
+	tests := []struct {
+		name     string
+		config   map[string]any
+		allowEnv envFilter
+		want     string
+		err      string
+	}{
+		{
+			name:     "nested references",
+			config:   map[string]any{"host": "db", "auth": map[string]any{"password": "${env:SPEC_DB_PASSWORD}"}, "hosts": []any{"${env:SPEC_DB_PASSWORD}-a"}},
+			allowEnv: specEnv,
+			want:     `{"auth":{"password":"secret"},"host":"db","hosts":["secret-a"]}`,
+		},
+		{
+			name:     "refused reference",
+			config:   map[string]any{"token": "${env:SERVER_TOKEN}"},
+			allowEnv: specEnv,
+			err:      "SERVER_TOKEN",
+		},
+		{
+			name:     "any reference from the provisioning directory",
+			config:   map[string]any{"token": "${env:SERVER_TOKEN}"},
+			allowEnv: anyEnv,
+			want:     `{"token":"token"}`,
+		},
+		{
+			name:     "unset reference",
+			config:   map[string]any{"password": "${env:SPEC_UNSET}"},
+			allowEnv: specEnv,
+			err:      "SPEC_UNSET",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSpecConfig(tt.config, tt.allowEnv)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package etl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+)
+
+// Job state history methods on AppService
+
+// jobState is the state file written by the drivers: a state per stream and, for CDC, a
+// global position shared by the streams it lists.
+type jobState struct {
+	Type   string `json:"type,omitempty"`
+	Global *struct {
+		State   any      `json:"state"`
+		Streams []string `json:"streams"`
+	} `json:"global,omitempty"`
+	Streams []struct {
+		Stream    string         `json:"stream"`
+		Namespace string         `json:"namespace"`
+		SyncMode  string         `json:"sync_mode"`
+		State     map[string]any `json:"state"`
+	} `json:"streams,omitempty"`
+}
+
+// ListJobStateHistory returns the state snapshots of a job, newest first.
+func (s Service) ListJobStateHistory(_ context.Context, projectID string, jobID int) (*dto.JobStateHistoryResponse, error) {
+	if _, err := s.getProjectJob(projectID, jobID); err != nil {
+		return nil, err
+	}
+	snapshots, err := s.db.ListJobStateSnapshots(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.JobStateHistoryResponse{JobID: jobID, Snapshots: make([]dto.JobStateSnapshotItem, 0, len(snapshots))}
+	for _, snapshot := range snapshots {
+		resp.Snapshots = append(resp.Snapshots, stateSnapshotItem(snapshot))
+	}
+	return resp, nil
+}
+
+// GetJobState returns the current state of a job, or the state of one of its snapshots,
+// broken down by stream.
+func (s Service) GetJobState(_ context.Context, projectID string, jobID, snapshotID int) (*dto.JobStateResponse, error) {
+	job, err := s.getProjectJob(projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := job.State
+	if snapshotID > 0 {
+		snapshot, err := s.db.GetJobStateSnapshot(jobID, snapshotID)
+		if err != nil {
+			return nil, err
+		}
+		state = snapshot.State
+	}
+
+	resp, err := streamStates(state, job.StreamsConfig)
+	if err != nil {
+		return nil, err
+	}
+	resp.JobID = jobID
+	resp.SnapshotID = snapshotID
+	return resp, nil
+}
+
+// RestoreJobState writes the state of a snapshot back to a job. The job has to be idle, and
+// its schedule is held while the state is written so that no sync starts in between.
+func (s Service) RestoreJobState(ctx context.Context, projectID string, jobID, snapshotID int) (*dto.JobStateSnapshotItem, error) {
+	job, err := s.getProjectJob(projectID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := s.db.GetJobStateSnapshot(jobID, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := s.holdIdleJob(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	restored := &models.JobStateSnapshot{JobID: jobID, Reason: constants.StateSnapshotReasonRestore, RestoredFrom: snapshot.ID}
+	if err := s.db.SaveJobState(snapshot.State, restored); err != nil {
+		return nil, fmt.Errorf("failed to restore state: %s", err)
+	}
+
+	logger.Infof("state of job_id[%d] restored from snapshot_id[%d]", jobID, snapshot.ID)
+	item := stateSnapshotItem(restored)
+	return &item, nil
+}
+
+// holdIdleJob pauses the schedule of a job so that no sync starts while its state is changed,
+// and fails with ErrJobNotIdle when a sync or clear-destination is running. The returned
+// release unpauses the schedule again if the job is active.
+func (s Service) holdIdleJob(ctx context.Context, job *models.Job) (func(), error) {
+	// clear-destination pauses the schedule itself and leaves it to the worker to resume
+	clearRunning, _, err := isWorkflowRunning(ctx, s.temporal, job.ProjectID, job.ID, temporal.ClearDestination)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if clear-destination is running: %s", err)
+	}
+	if clearRunning {
+		return nil, fmt.Errorf("%w: clear-destination is in progress", constants.ErrJobNotIdle)
+	}
+
+	if err := s.temporal.PauseSchedule(ctx, job.ProjectID, job.ID); err != nil {
+		return nil, fmt.Errorf("failed to pause schedule: %s", err)
+	}
+	release := func() {
+		if !job.Active {
+			return
+		}
+		if err := s.temporal.ResumeSchedule(ctx, job.ProjectID, job.ID); err != nil {
+			logger.Errorf("failed to resume schedule of job_id[%d], the reconciler resumes it: %s", job.ID, err)
+		}
+	}
+
+	syncRunning, _, err := isWorkflowRunning(ctx, s.temporal, job.ProjectID, job.ID, temporal.Sync)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to check sync status: %s", err)
+	}
+	if syncRunning {
+		release()
+		return nil, fmt.Errorf("%w: sync is in progress, please wait or cancel the sync", constants.ErrJobNotIdle)
+	}
+	return release, nil
+}
+
+// streamStates breaks a state file down by stream. Cursor fields come from the streams
+// config of the job; a cursor of the form "primary:fallback" is looked up by its primary.
+func streamStates(state, streamsConfig string) (*dto.JobStateResponse, error) {
+	resp := &dto.JobStateResponse{Streams: []dto.StreamState{}}
+	parsed := &jobState{}
+	if state != "" {
+		if err := json.Unmarshal([]byte(state), parsed); err != nil {
+			return nil, fmt.Errorf("failed to parse state: %s", err)
+		}
+	}
+	catalog, err := parseStreamsCatalog(streamsConfig)
+	if err != nil {
+		return nil, err
+	}
+	catalogStreams := catalog.catalogStreams()
+
+	var cdcStreams []string
+	resp.Type = parsed.Type
+	if parsed.Global != nil {
+		resp.CDCPosition = parsed.Global.State
+		cdcStreams = parsed.Global.Streams
+	}
+
+	seen := make(map[string]bool, len(parsed.Streams))
+	for _, stream := range parsed.Streams {
+		key := streamKey(stream.Namespace, stream.Stream)
+		seen[key] = true
+		item := dto.StreamState{
+			Stream:   key,
+			SyncMode: stream.SyncMode,
+			CDC:      slices.Contains(cdcStreams, key),
+			State:    stream.State,
+		}
+		if chunks, ok := stream.State["chunks"].([]any); ok {
+			item.PendingChunks = len(chunks)
+		}
+		if cursor, _ := catalogStreams[key]["cursor_field"].(string); cursor != "" {
+			item.CursorField = cursor
+			if value, ok := stream.State[cursor]; ok {
+				item.CursorValue = value
+			} else if primary, _, found := strings.Cut(cursor, ":"); found {
+				item.CursorValue = stream.State[primary]
+			}
+		}
+		resp.Streams = append(resp.Streams, item)
+	}
+	// CDC streams past their initial load may have no state of their own
+	for _, key := range cdcStreams {
+		if !seen[key] {
+			resp.Streams = append(resp.Streams, dto.StreamState{Stream: key, CDC: true})
+		}
+	}
+	return resp, nil
+}
+
+func stateSnapshotItem(snapshot *models.JobStateSnapshot) dto.JobStateSnapshotItem {
+	return dto.JobStateSnapshotItem{
+		ID:           snapshot.ID,
+		Reason:       snapshot.Reason,
+		WorkflowID:   snapshot.WorkflowID,
+		RunID:        snapshot.RunID,
+		RestoredFrom: snapshot.RestoredFrom,
+		CreatedAt:    snapshot.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// mergeStreamStates applies the state written by a sync of some streams to the current
+// state of a job: the entries of the streams it wrote replace theirs and the entries of the
+// other streams are kept. The current CDC position is kept, as the other CDC streams have
+// not read past it, and the streams that follow it are extended by the ones written; the CDC
+// streams of the sync so read their changes again from that position. Any other content of
+// the current state is kept as is.
+func mergeStreamStates(current, written string) (string, error) {
+	if strings.TrimSpace(current) == "" {
+		return written, nil
+	}
+	merged, update := map[string]any{}, map[string]any{}
+	if err := json.Unmarshal([]byte(current), &merged); err != nil {
+		return "", fmt.Errorf("failed to parse current state: %s", err)
+	}
+	if err := json.Unmarshal([]byte(written), &update); err != nil {
+		return "", fmt.Errorf("failed to parse state: %s", err)
+	}
+
+	entryKey := func(entry any) string {
+		stream, _ := entry.(map[string]any)
+		name, _ := stream["stream"].(string)
+		namespace, _ := stream["namespace"].(string)
+		return streamKey(namespace, name)
+	}
+	if written, ok := update["streams"].([]any); ok {
+		streams, _ := merged["streams"].([]any)
+		for _, entry := range written {
+			key := entryKey(entry)
+			if idx := slices.IndexFunc(streams, func(e any) bool { return entryKey(e) == key }); idx >= 0 {
+				streams[idx] = entry
+			} else {
+				streams = append(streams, entry)
+			}
+		}
+		merged["streams"] = streams
+	}
+	if written, ok := update["global"].(map[string]any); ok {
+		global, ok := merged["global"].(map[string]any)
+		if !ok {
+			merged["global"] = written
+		} else if writtenStreams, ok := written["streams"].([]any); ok {
+			streams, _ := global["streams"].([]any)
+			for _, key := range writtenStreams {
+				if !slices.Contains(streams, key) {
+					streams = append(streams, key)
+				}
+			}
+			global["streams"] = streams
+		}
+	}
+	for key, value := range update {
+		if _, ok := merged[key]; !ok {
+			merged[key] = value
+		}
+	}
+
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialise state: %s", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,67 @@
+package etl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeStreamStates(t *testing.T) {
+	const current = `{"type":"STREAM","global":{"state":{"lsn":"0/100"},"streams":["public.orders"]},"streams":[` +
+		`{"stream":"orders","namespace":"public","sync_mode":"cdc","state":{"chunks":[]}},` +
+		`{"stream":"users","namespace":"public","sync_mode":"incremental","state":{"updated_at":"2025-03-01"}}]}`
+	tests := []struct {
+		name    string
+		current string
+		written string
+		want    string
+	}{
+		{
+			name:    "no current state",
+			written: `{"streams":[{"stream":"users","namespace":"public","state":{"updated_at":"2025-03-10"}}]}`,
+			want:    `{"streams":[{"stream":"users","namespace":"public","state":{"updated_at":"2025-03-10"}}]}`,
+		},
+		{
+			name:    "written streams replace theirs",
+			current: current,
+			written: `{"type":"STREAM","streams":[{"stream":"users","namespace":"public","sync_mode":"incremental","state":{"updated_at":"2025-03-10"}}]}`,
+			want: `{"type":"STREAM","global":{"state":{"lsn":"0/100"},"streams":["public.orders"]},"streams":[` +
+				`{"stream":"orders","namespace":"public","sync_mode":"cdc","state":{"chunks":[]}},` +
+				`{"stream":"users","namespace":"public","sync_mode":"incremental","state":{"updated_at":"2025-03-10"}}]}`,
+		},
+		{
+			name:    "new streams are added",
+			current: current,
+			written: `{"streams":[{"stream":"items","namespace":"public","sync_mode":"full_refresh","state":{}}]}`,
+			want: `{"type":"STREAM","global":{"state":{"lsn":"0/100"},"streams":["public.orders"]},"streams":[` +
+				`{"stream":"orders","namespace":"public","sync_mode":"cdc","state":{"chunks":[]}},` +
+				`{"stream":"users","namespace":"public","sync_mode":"incremental","state":{"updated_at":"2025-03-01"}},` +
+				`{"stream":"items","namespace":"public","sync_mode":"full_refresh","state":{}}]}`,
+		},
+		{
+			name:    "current CDC position is kept",
+			current: current,
+			written: `{"global":{"state":{"lsn":"0/900"},"streams":["public.payments"]},"streams":[{"stream":"payments","namespace":"public","sync_mode":"cdc","state":{}}]}`,
+			want: `{"type":"STREAM","global":{"state":{"lsn":"0/100"},"streams":["public.orders","public.payments"]},"streams":[` +
+				`{"stream":"orders","namespace":"public","sync_mode":"cdc","state":{"chunks":[]}},` +
+				`{"stream":"users","namespace":"public","sync_mode":"incremental","state":{"updated_at":"2025-03-01"}},` +
+				`{"stream":"payments","namespace":"public","sync_mode":"cdc","state":{}}]}`,
+		},
+		{
+			name:    "first CDC position is taken",
+			current: `{"streams":[{"stream":"users","namespace":"public","state":{"updated_at":"2025-03-01"}}]}`,
+			written: `{"global":{"state":{"lsn":"0/900"},"streams":["public.payments"]}}`,
+			want:    `{"global":{"state":{"lsn":"0/900"},"streams":["public.payments"]},"streams":[{"stream":"users","namespace":"public","state":{"updated_at":"2025-03-01"}}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeStreamStates(tt.current, tt.written)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, got)
+		})
+	}
+
+	_, err := mergeStreamStates(current, "not json")
+	require.ErrorContains(t, err, "failed to parse state")
+}
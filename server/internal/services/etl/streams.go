@@ -0,0 +1,248 @@
+package etl
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+)
+
+// streamsCatalog is the streams config of a job: the selected streams by namespace and the
+// discovered catalog of every stream. Entries are kept as raw objects so that a filtered
+// config carries every field the driver wrote.
+type streamsCatalog struct {
+	SelectedStreams map[string][]map[string]any `json:"selected_streams"`
+	Streams         []map[string]any            `json:"streams"`
+}
+
+// catalogStreamFields are the fields of a catalog stream that define how it is written
+var catalogStreamFields = []string{"sync_mode", "cursor_field", "destination_database", "destination_table"}
+
+func parseStreamsCatalog(config string) (*streamsCatalog, error) {
+	catalog := &streamsCatalog{}
+	if config == "" {
+		return catalog, nil
+	}
+	if err := json.Unmarshal([]byte(config), catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse streams config: %s", err)
+	}
+	return catalog, nil
+}
+
+// selected returns the selected stream entries keyed by "namespace.stream_name"
+func (c *streamsCatalog) selected() map[string]map[string]any {
+	out := make(map[string]map[string]any)
+	for namespace, streams := range c.SelectedStreams {
+		for _, stream := range streams {
+			name, _ := stream["stream_name"].(string)
+			out[streamKey(namespace, name)] = stream
+		}
+	}
+	return out
+}
+
+// catalogStreams returns the "stream" object of every catalog entry keyed by "namespace.name"
+func (c *streamsCatalog) catalogStreams() map[string]map[string]any {
+	out := make(map[string]map[string]any)
+	for _, entry := range c.Streams {
+		stream, _ := entry["stream"].(map[string]any)
+		if stream == nil {
+			continue
+		}
+		name, _ := stream["name"].(string)
+		namespace, _ := stream["namespace"].(string)
+		out[streamKey(namespace, name)] = stream
+	}
+	return out
+}
+
+func streamKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// diffStreams compares two streams configs stream by stream. Only selected streams are
+// compared; a modified stream is destructive when a field that changes how its data is
+// written to the destination differs, so that its destination data has to be cleared.
+func diffStreams(oldConfig, newConfig string) ([]dto.StreamChange, error) {
+	oldCatalog, err := parseStreamsCatalog(oldConfig)
+	if err != nil {
+		return nil, err
+	}
+	newCatalog, err := parseStreamsCatalog(newConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	oldSelected, newSelected := oldCatalog.selected(), newCatalog.selected()
+	oldStreams, newStreams := oldCatalog.catalogStreams(), newCatalog.catalogStreams()
+
+	var changes []dto.StreamChange
+	for _, key := range slices.Sorted(maps.Keys(newSelected)) {
+		oldStream, ok := oldSelected[key]
+		if !ok {
+			changes = append(changes, dto.StreamChange{Stream: key, Change: constants.StreamChangeAdded})
+			continue
+		}
+
+		var fields []string
+		newStream := newSelected[key]
+		for _, field := range slices.Sorted(maps.Keys(unionKeys(oldStream, newStream))) {
+			if field != "stream_name" && !reflect.DeepEqual(oldStream[field], newStream[field]) {
+				fields = append(fields, field)
+			}
+		}
+		for _, field := range catalogStreamFields {
+			if !reflect.DeepEqual(oldStreams[key][field], newStreams[key][field]) {
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		changes = append(changes, dto.StreamChange{
+			Stream:      key,
+			Change:      constants.StreamChangeModified,
+			Fields:      fields,
+			Destructive: slices.ContainsFunc(fields, isDestructiveStreamField),
+		})
+	}
+	for _, key := range slices.Sorted(maps.Keys(oldSelected)) {
+		if _, ok := newSelected[key]; !ok {
+			changes = append(changes, dto.StreamChange{Stream: key, Change: constants.StreamChangeRemoved})
+		}
+	}
+	return changes, nil
+}
+
+// diffRevisionStreams compares two streams configs like diffStreams and spells out the sync
+// mode and column selection changes of the modified streams.
+func diffRevisionStreams(oldConfig, newConfig string) ([]dto.JobRevisionStreamChange, error) {
+	changes, err := diffStreams(oldConfig, newConfig)
+	if err != nil {
+		return nil, err
+	}
+	// both configs parsed above
+	oldCatalog, _ := parseStreamsCatalog(oldConfig)
+	newCatalog, _ := parseStreamsCatalog(newConfig)
+	oldSelected, newSelected := oldCatalog.selected(), newCatalog.selected()
+	oldStreams, newStreams := oldCatalog.catalogStreams(), newCatalog.catalogStreams()
+
+	out := make([]dto.JobRevisionStreamChange, 0, len(changes))
+	for _, change := range changes {
+		item := dto.JobRevisionStreamChange{StreamChange: change}
+		if slices.Contains(change.Fields, "sync_mode") {
+			from, _ := oldStreams[change.Stream]["sync_mode"].(string)
+			to, _ := newStreams[change.Stream]["sync_mode"].(string)
+			item.SyncMode = &dto.SyncModeChange{From: from, To: to}
+		}
+		if slices.Contains(change.Fields, "selected_columns") {
+			oldColumns, newColumns := selectedColumns(oldSelected[change.Stream]), selectedColumns(newSelected[change.Stream])
+			// a stream without a column selection syncs every column, so nothing can be listed
+			if oldColumns != nil && newColumns != nil {
+				for _, column := range newColumns {
+					if !slices.Contains(oldColumns, column) {
+						item.AddedColumns = append(item.AddedColumns, column)
+					}
+				}
+				for _, column := range oldColumns {
+					if !slices.Contains(newColumns, column) {
+						item.RemovedColumns = append(item.RemovedColumns, column)
+					}
+				}
+			}
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// selectedColumns returns the columns selected for a stream, or nil when it has no selection
+func selectedColumns(stream map[string]any) []string {
+	selection, _ := stream["selected_columns"].(map[string]any)
+	raw, ok := selection["columns"].([]any)
+	if !ok {
+		return nil
+	}
+	columns := make([]string, 0, len(raw))
+	for _, column := range raw {
+		if name, ok := column.(string); ok {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+// selectedStreamKeys checks that the given streams are selected in a streams config and
+// returns them without duplicates
+func selectedStreamKeys(config string, streams []string) ([]string, error) {
+	catalog, err := parseStreamsCatalog(config)
+	if err != nil {
+		return nil, err
+	}
+	selected := catalog.selected()
+	keys := []string{}
+	for _, stream := range streams {
+		if _, ok := selected[stream]; !ok {
+			return nil, fmt.Errorf("%w: %s", constants.ErrStreamNotSelected, stream)
+		}
+		if !slices.Contains(keys, stream) {
+			keys = append(keys, stream)
+		}
+	}
+	return keys, nil
+}
+
+// filterStreamsConfig returns the streams config reduced to the given streams, in the form
+// expected by the clear-destination workflow.
+func filterStreamsConfig(config string, keys []string) (string, error) {
+	catalog, err := parseStreamsCatalog(config)
+	if err != nil {
+		return "", err
+	}
+
+	filtered := &streamsCatalog{SelectedStreams: map[string][]map[string]any{}, Streams: []map[string]any{}}
+	for namespace, streams := range catalog.SelectedStreams {
+		for _, stream := range streams {
+			name, _ := stream["stream_name"].(string)
+			if slices.Contains(keys, streamKey(namespace, name)) {
+				filtered.SelectedStreams[namespace] = append(filtered.SelectedStreams[namespace], stream)
+			}
+		}
+	}
+	for _, entry := range catalog.Streams {
+		stream, _ := entry["stream"].(map[string]any)
+		name, _ := stream["name"].(string)
+		namespace, _ := stream["namespace"].(string)
+		if slices.Contains(keys, streamKey(namespace, name)) {
+			filtered.Streams = append(filtered.Streams, entry)
+		}
+	}
+
+	b, err := json.Marshal(filtered)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialise streams config: %s", err)
+	}
+	return string(b), nil
+}
+
+func isDestructiveStreamField(field string) bool {
+	return slices.Contains(constants.DestructiveStreamFields, field)
+}
+
+func unionKeys(a, b map[string]any) map[string]struct{} {
+	out := make(map[string]struct{}, len(a)+len(b))
+	for key := range a {
+		out[key] = struct{}{}
+	}
+	for key := range b {
+		out[key] = struct{}{}
+	}
+	return out
+}
@@ -0,0 +1,276 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/appconfig"
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+)
+
+// Trash methods on AppService
+
+// ListTrash returns the deleted jobs, sources and destinations of a project, most recently
+// deleted first within each kind, with the time they are purged at.
+func (s Service) ListTrash(_ context.Context, projectID string) (*dto.TrashResponse, error) {
+	jobs, err := s.db.ListTrashedJobs(projectID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	sources, err := s.db.ListTrashedSources(projectID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	destinations, err := s.db.ListTrashedDestinations(projectID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	retention := appconfig.Load().TrashRetention
+	resp := &dto.TrashResponse{Items: make([]dto.TrashItem, 0, len(jobs)+len(sources)+len(destinations))}
+	add := func(kind string, id int, name, entityType string, deletedAt *time.Time) {
+		item := dto.TrashItem{Kind: kind, ID: id, Name: name, Type: entityType}
+		if deletedAt != nil {
+			item.DeletedAt = deletedAt.Format(time.RFC3339)
+			if retention > 0 {
+				item.PurgeAt = deletedAt.Add(retention).Format(time.RFC3339)
+			}
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	for _, job := range jobs {
+		add(constants.BundleKindJob, job.ID, job.Name, "", job.DeletedAt)
+	}
+	for _, source := range sources {
+		add(constants.BundleKindSource, source.ID, source.Name, source.Type, source.DeletedAt)
+	}
+	for _, dest := range destinations {
+		add(constants.BundleKindDestination, dest.ID, dest.Name, dest.DestType, dest.DeletedAt)
+	}
+	return resp, nil
+}
+
+// RestoreFromTrash takes a job, source or destination out of the trash. A restored job gets
+// its schedule back, recreated if it is gone and paused or running as the job was before.
+func (s Service) RestoreFromTrash(ctx context.Context, projectID, kind string, id int, userID *int) error {
+	switch kind {
+	case constants.BundleKindJob:
+		job, err := s.db.GetTrashedJob(id)
+		if err != nil {
+			return err
+		}
+		if job.ProjectID != projectID {
+			return fmt.Errorf("%w: job not found in trash id[%d] project_id[%s]", constants.ErrJobNotFound, id, projectID)
+		}
+		if job.Source == nil || job.Source.DeletedAt != nil {
+			return fmt.Errorf("%w: source of job '%s' is in the trash, restore it first", constants.ErrRestoreConflict, job.Name)
+		}
+		if job.Destination == nil || job.Destination.DeletedAt != nil {
+			return fmt.Errorf("%w: destination of job '%s' is in the trash, restore it first", constants.ErrRestoreConflict, job.Name)
+		}
+		if err := s.checkRestoreName(ctx, projectID, job.Name, constants.JobTable); err != nil {
+			return err
+		}
+
+		scheduleState := constants.ScheduleOpPause
+		if job.Active {
+			scheduleState = constants.ScheduleOpResume
+		}
+		err = s.db.Transaction(func(tx *database.Database) error {
+			if err := tx.RestoreFromTrash(constants.JobTable, id, *userID); err != nil {
+				return err
+			}
+			// the paused schedule is normally still there, create is a no-op then
+			return tx.EnqueueScheduleOperations(job.ProjectID, job.ID, constants.ScheduleOpCreate, scheduleState)
+		})
+		if err != nil {
+			return err
+		}
+		return s.applyScheduleOperations(ctx, job.ID)
+	case constants.BundleKindSource:
+		source, err := s.db.GetTrashedSource(id)
+		if err != nil {
+			return err
+		}
+		if source.ProjectID != projectID {
+			return fmt.Errorf("%w: source not found in trash id[%d] project_id[%s]", constants.ErrSourceNotFound, id, projectID)
+		}
+		if err := s.checkRestoreName(ctx, projectID, source.Name, constants.SourceTable); err != nil {
+			return err
+		}
+		return s.db.RestoreFromTrash(constants.SourceTable, id, *userID)
+	case constants.BundleKindDestination:
+		dest, err := s.db.GetTrashedDestination(id)
+		if err != nil {
+			return err
+		}
+		if dest.ProjectID != projectID {
+			return fmt.Errorf("%w: destination not found in trash id[%d] project_id[%s]", constants.ErrDestinationNotFound, id, projectID)
+		}
+		if err := s.checkRestoreName(ctx, projectID, dest.Name, constants.DestinationTable); err != nil {
+			return err
+		}
+		return s.db.RestoreFromTrash(constants.DestinationTable, id, *userID)
+	default:
+		return fmt.Errorf("unsupported trash kind '%s'", kind)
+	}
+}
+
+// PurgeFromTrash permanently deletes a job, source or destination in the trash. A purged job
+// has its schedule deleted; sources and destinations still used by a job cannot be purged.
+func (s Service) PurgeFromTrash(ctx context.Context, projectID, kind string, id int) error {
+	switch kind {
+	case constants.BundleKindJob:
+		job, err := s.db.GetTrashedJob(id)
+		if err != nil {
+			return err
+		}
+		if job.ProjectID != projectID {
+			return fmt.Errorf("%w: job not found in trash id[%d] project_id[%s]", constants.ErrJobNotFound, id, projectID)
+		}
+		return s.purgeJob(ctx, job.ProjectID, job.ID)
+	case constants.BundleKindSource:
+		source, err := s.db.GetTrashedSource(id)
+		if err != nil {
+			return err
+		}
+		if source.ProjectID != projectID {
+			return fmt.Errorf("%w: source not found in trash id[%d] project_id[%s]", constants.ErrSourceNotFound, id, projectID)
+		}
+		return s.purgeSource(id)
+	case constants.BundleKindDestination:
+		dest, err := s.db.GetTrashedDestination(id)
+		if err != nil {
+			return err
+		}
+		if dest.ProjectID != projectID {
+			return fmt.Errorf("%w: destination not found in trash id[%d] project_id[%s]", constants.ErrDestinationNotFound, id, projectID)
+		}
+		return s.db.PurgeFromTrash(constants.DestinationTable, id)
+	default:
+		return fmt.Errorf("unsupported trash kind '%s'", kind)
+	}
+}
+
+// PurgeTrash permanently deletes everything deleted before the given time. Jobs go first so
+// that sources and destinations only used by purged jobs are purged in the same pass.
+func (s Service) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	purged := 0
+	jobs, err := s.db.ListTrashedJobs("", before)
+	if err != nil {
+		return 0, err
+	}
+	for _, job := range jobs {
+		if err := s.purgeJob(ctx, job.ProjectID, job.ID); err != nil && !errors.Is(err, constants.ErrSchedulePending) {
+			logger.Errorf("failed to purge job_id[%d]: %s", job.ID, err)
+			continue
+		}
+		purged++
+	}
+
+	sources, err := s.db.ListTrashedSources("", before)
+	if err != nil {
+		return purged, err
+	}
+	for _, source := range sources {
+		if err := s.purgeSource(source.ID); err != nil {
+			// still used by a job in the trash that is not due yet
+			logger.Debugf("source_id[%d] not purged: %s", source.ID, err)
+			continue
+		}
+		purged++
+	}
+
+	destinations, err := s.db.ListTrashedDestinations("", before)
+	if err != nil {
+		return purged, err
+	}
+	for _, dest := range destinations {
+		if err := s.db.PurgeFromTrash(constants.DestinationTable, dest.ID); err != nil {
+			logger.Debugf("destination_id[%d] not purged: %s", dest.ID, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// RunTrashPurger purges everything that has been in the trash longer than the retention,
+// checking every hour until the context is done.
+func (s Service) RunTrashPurger(ctx context.Context, retention time.Duration) {
+	ticker := time.NewTicker(constants.TrashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		purged, err := s.PurgeTrash(ctx, time.Now().Add(-retention))
+		if err != nil {
+			logger.Errorf("failed to purge trash: %s", err)
+		}
+		if purged > 0 {
+			logger.Infof("purged %d items from the trash", purged)
+		}
+	}
+}
+
+// purgeJob deletes a job in the trash along with its revisions, state history, trigger tokens,
+// dependencies, maintenance windows and schedule
+func (s Service) purgeJob(ctx context.Context, projectID string, jobID int) error {
+	err := s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.PurgeFromTrash(constants.JobTable, jobID); err != nil {
+			return err
+		}
+		if err := tx.DeleteJobRevisions(jobID); err != nil {
+			return err
+		}
+		if err := tx.DeleteJobStateSnapshots(jobID); err != nil {
+			return err
+		}
+		if err := tx.DeleteJobTriggerTokens(jobID); err != nil {
+			return err
+		}
+		if err := tx.DeleteJobDependencies(jobID); err != nil {
+			return err
+		}
+		if err := tx.DeleteJobMaintenanceWindows(jobID); err != nil {
+			return err
+		}
+		return tx.EnqueueScheduleOperations(projectID, jobID, constants.ScheduleOpDelete)
+	})
+	if err != nil {
+		return err
+	}
+	return s.applyScheduleOperations(ctx, jobID)
+}
+
+// purgeSource deletes a source in the trash along with its maintenance windows
+func (s Service) purgeSource(sourceID int) error {
+	return s.db.Transaction(func(tx *database.Database) error {
+		if err := tx.PurgeFromTrash(constants.SourceTable, sourceID); err != nil {
+			return err
+		}
+		return tx.DeleteSourceMaintenanceWindows(sourceID)
+	})
+}
+
+// checkRestoreName makes sure no live entity took the name of the one being restored
+func (s Service) checkRestoreName(ctx context.Context, projectID, name string, tableType constants.TableType) error {
+	unique, err := s.db.IsNameUniqueInProject(ctx, projectID, name, tableType)
+	if err != nil {
+		return fmt.Errorf("failed to check name uniqueness: %s", err)
+	}
+	if !unique {
+		return fmt.Errorf("%w: name '%s' is already in use, rename the existing one first", constants.ErrRestoreConflict, name)
+	}
+	return nil
+}
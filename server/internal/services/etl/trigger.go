@@ -0,0 +1,233 @@
+package etl
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+	"github.com/datazip-inc/olake-ui/server/internal/utils/logger"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	workflowservice "go.temporal.io/api/workflowservice/v1"
+)
+
+// Sync run and trigger token methods on AppService
+
+// GetJobRun returns the status of a run of a job. With a wait above 0 it waits up to that
+// long for the run to close first, so that a caller can block on a run with repeated calls.
+func (s Service) GetJobRun(ctx context.Context, projectID string, jobID int, runID string, wait time.Duration) (*dto.JobRunResponse, error) {
+	if _, err := s.getProjectJob(projectID, jobID); err != nil {
+		return nil, err
+	}
+	execution, err := s.findJobRun(ctx, projectID, jobID, runID)
+	if err != nil {
+		return nil, err
+	}
+	workflowID := execution.Execution.WorkflowId
+
+	closeEvent, err := workflowCloseEvent(ctx, s.temporal, workflowID, runID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run status: %s", err)
+	}
+	if closeEvent == nil && wait > 0 {
+		waitCtx, cancel := context.WithTimeout(ctx, wait)
+		closeEvent, err = workflowCloseEvent(waitCtx, s.temporal, workflowID, runID, true)
+		cancel()
+		// a run still open at the end of the wait is returned as running
+		if err != nil && waitCtx.Err() == nil {
+			return nil, fmt.Errorf("failed to wait for run: %s", err)
+		}
+	}
+
+	// visibility may lag behind the close of the run, the execution itself does not
+	describe, err := s.temporal.Client.DescribeWorkflowExecution(ctx, workflowID, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get run status: %s", err)
+	}
+	info := describe.WorkflowExecutionInfo
+
+	startTime := info.StartTime.AsTime().UTC()
+	resp := &dto.JobRunResponse{
+		JobID:      jobID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+		JobType:    utils.Ternary(syncWorkflowOperationType(execution) == temporal.Sync, "sync", "clear").(string),
+		AdHoc:      strings.HasPrefix(workflowID, temporal.AdHocWorkflowPrefix(projectID, jobID)),
+		Status:     info.Status.String(),
+		Done:       info.Status != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		Succeeded:  info.Status == enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+		StartTime:  startTime.Format(time.RFC3339),
+		Duration:   time.Since(startTime).Round(time.Second).String(),
+	}
+	if info.CloseTime != nil {
+		closeTime := info.CloseTime.AsTime().UTC()
+		resp.CloseTime = closeTime.Format(time.RFC3339)
+		resp.Duration = closeTime.Sub(startTime).Round(time.Second).String()
+	}
+	if resp.Done && closeEvent != nil {
+		resp.Error = runErrorSummary(closeEvent)
+	}
+	return resp, nil
+}
+
+// findJobRun looks a run of a job up by its run id. A run started a moment ago may not be
+// visible yet, so the lookup is repeated for up to constants.SyncTriggerWaitTimeout.
+func (s Service) findJobRun(ctx context.Context, projectID string, jobID int, runID string) (*workflowpb.WorkflowExecutionInfo, error) {
+	query := fmt.Sprintf(
+		"WorkflowId BETWEEN 'sync-%s-%d-' AND 'sync-%s-%d-z' AND RunId = '%s'",
+		projectID, jobID, projectID, jobID, strings.ReplaceAll(runID, "'", ""),
+	)
+	deadline := time.Now().Add(constants.SyncTriggerWaitTimeout)
+	for {
+		resp, err := s.temporal.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:    query,
+			PageSize: 1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to find run: %s", err)
+		}
+		if len(resp.Executions) > 0 {
+			return resp.Executions[0], nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: job_id[%d] run_id[%s]", constants.ErrRunNotFound, jobID, runID)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(constants.SyncTriggerPollInterval):
+		}
+	}
+}
+
+// runErrorSummary tells why a closed run did not complete, from the event it closed with.
+// For a failure the innermost cause is reported, as the outer ones only wrap it.
+func runErrorSummary(event *historypb.HistoryEvent) string {
+	switch event.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+		failure := event.GetWorkflowExecutionFailedEventAttributes().GetFailure()
+		for failure.GetCause() != nil {
+			failure = failure.GetCause()
+		}
+		return utils.Ternary(failure.GetMessage() != "", failure.GetMessage(), "run failed").(string)
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+		return "run timed out"
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:
+		return "run was canceled"
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+		if reason := event.GetWorkflowExecutionTerminatedEventAttributes().GetReason(); reason != "" {
+			return fmt.Sprintf("run was terminated: %s", reason)
+		}
+		return "run was terminated"
+	}
+	return ""
+}
+
+// CreateTriggerToken creates a trigger token for a job. The token is returned only here; just
+// its hash is kept.
+func (s Service) CreateTriggerToken(_ context.Context, projectID string, jobID int, req *dto.CreateTriggerTokenRequest, userID *int) (*dto.CreateTriggerTokenResponse, error) {
+	if _, err := s.getProjectJob(projectID, jobID); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, constants.TriggerTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate trigger token: %s", err)
+	}
+	token := constants.TriggerTokenPrefix + hex.EncodeToString(secret)
+	record := &models.JobTriggerToken{
+		JobID:       jobID,
+		ProjectID:   projectID,
+		Name:        req.Name,
+		TokenHash:   hashTriggerToken(token),
+		Prefix:      token[:len(constants.TriggerTokenPrefix)+6],
+		CreatedByID: *userID,
+	}
+	if err := s.db.CreateJobTriggerToken(record); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("trigger token %s created for job_id[%d]", record.Prefix, jobID)
+	return &dto.CreateTriggerTokenResponse{TriggerTokenItem: triggerTokenItem(record), Token: token}, nil
+}
+
+// ListTriggerTokens returns the trigger tokens of a job, newest first
+func (s Service) ListTriggerTokens(_ context.Context, projectID string, jobID int) ([]dto.TriggerTokenItem, error) {
+	if _, err := s.getProjectJob(projectID, jobID); err != nil {
+		return nil, err
+	}
+	tokens, err := s.db.ListJobTriggerTokens(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.TriggerTokenItem, 0, len(tokens))
+	for _, token := range tokens {
+		items = append(items, triggerTokenItem(token))
+	}
+	return items, nil
+}
+
+// DeleteTriggerToken revokes a trigger token of a job
+func (s Service) DeleteTriggerToken(_ context.Context, projectID string, jobID, tokenID int) error {
+	if _, err := s.getProjectJob(projectID, jobID); err != nil {
+		return err
+	}
+	if err := s.db.DeleteJobTriggerToken(jobID, tokenID); err != nil {
+		return err
+	}
+	logger.Infof("trigger token[%d] of job_id[%d] revoked", tokenID, jobID)
+	return nil
+}
+
+// AuthenticateTriggerToken checks that a trigger token was issued for the given job and
+// records its use. Any mismatch is reported as ErrInvalidTriggerToken alone, so that a caller
+// cannot tell which jobs exist.
+func (s Service) AuthenticateTriggerToken(_ context.Context, projectID string, jobID int, token string) error {
+	if !strings.HasPrefix(token, constants.TriggerTokenPrefix) {
+		return fmt.Errorf("%w: malformed token", constants.ErrInvalidTriggerToken)
+	}
+	record, err := s.db.GetJobTriggerTokenByHash(hashTriggerToken(token))
+	if err != nil {
+		return err
+	}
+	if record.JobID != jobID || record.ProjectID != projectID {
+		return fmt.Errorf("%w: token is not valid for job_id[%d]", constants.ErrInvalidTriggerToken, jobID)
+	}
+
+	if err := s.db.TouchJobTriggerToken(record.ID); err != nil {
+		logger.Warnf("failed to record use of trigger token %s: %s", record.Prefix, err)
+	}
+	return nil
+}
+
+func hashTriggerToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func triggerTokenItem(token *models.JobTriggerToken) dto.TriggerTokenItem {
+	item := dto.TriggerTokenItem{
+		ID:        token.ID,
+		Name:      token.Name,
+		Prefix:    token.Prefix,
+		CreatedAt: token.CreatedAt.Format(time.RFC3339),
+	}
+	if token.CreatedBy != nil {
+		item.CreatedBy = token.CreatedBy.Username
+	}
+	if token.LastUsedAt != nil {
+		item.LastUsedAt = token.LastUsedAt.UTC().Format(time.RFC3339)
+	}
+	return item
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 
 	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
 	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -29,12 +31,23 @@ func (s Service) CreateUser(_ context.Context, req *models.User) error {
 	return nil
 }
 
-func (s Service) GetAllUsers(_ context.Context) ([]*models.User, error) {
-	users, err := s.db.ListUsers()
+// GetAllUsers returns one page of users, the total number of matching users and the
+// cursor for the next page.
+func (s Service) GetAllUsers(_ context.Context, query dto.ListQuery) ([]*models.User, int64, string, error) {
+	opts, err := toListOptions(query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list users: %s", err)
+		return nil, 0, "", err
 	}
-	return users, nil
+
+	users, total, err := s.db.ListUsers(opts)
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("failed to list users: %s", err)
+	}
+	users, nextCursor := nextPage(users, query.Limit, func(user *models.User) database.Cursor {
+		return database.NewCursor(opts.SortBy, user.ID, user.Username, user.BaseModel)
+	})
+
+	return users, total, nextCursor, nil
 }
 
 func (s Service) UpdateUser(_ context.Context, id int, req *models.User) (*models.User, error) {
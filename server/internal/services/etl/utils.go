@@ -7,12 +7,14 @@ import (
 	"time"
 
 	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
 	"github.com/datazip-inc/olake-ui/server/internal/models"
 	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
 	"github.com/datazip-inc/olake-ui/server/internal/services/temporal"
 	"github.com/datazip-inc/olake-ui/server/internal/utils"
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
 	workflowpb "go.temporal.io/api/workflow/v1"
 	workflowservice "go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/sdk/converter"
@@ -207,6 +209,20 @@ func isWorkflowRunning(ctx context.Context, tempClient *temporal.Temporal, proje
 	return len(resp.Executions) > 0, resp.Executions, nil
 }
 
+// isAdHocSyncRunning checks if a one-off sync of a job is running. Such a sync holds the
+// schedule of the job paused until it has closed.
+func isAdHocSyncRunning(ctx context.Context, tempClient *temporal.Temporal, projectID string, jobID int) (bool, error) {
+	prefix := temporal.AdHocWorkflowPrefix(projectID, jobID)
+	resp, err := tempClient.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+		Query:    fmt.Sprintf("WorkflowId BETWEEN '%s' AND '%sz' AND ExecutionStatus = 'Running'", prefix, prefix),
+		PageSize: 1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(resp.Executions) > 0, nil
+}
+
 // waitForSyncToStop checks if a sync workflow is running and optionally waits for it to stop.
 // - If sync is not running: returns nil immediately
 // - If sync is running and maxWaitTime <= 0: returns error immediately (no wait)
@@ -227,28 +243,52 @@ func waitForSyncToStop(ctx context.Context, tempClient *temporal.Temporal, proje
 	timedCtx, cancel := context.WithTimeout(ctx, maxWaitTime)
 	defer cancel()
 
-	workflowID := executions[0].Execution.WorkflowId
-	runID := executions[0].Execution.RunId
-
-	_, err = tempClient.Client.WorkflowService().GetWorkflowExecutionHistory(
-		timedCtx,
-		&workflowservice.GetWorkflowExecutionHistoryRequest{
-			Namespace: "default",
-			Execution: &commonpb.WorkflowExecution{
-				WorkflowId: workflowID,
-				RunId:      runID,
-			},
-			WaitNewEvent:           true,
-			HistoryEventFilterType: enumspb.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT,
-		},
-	)
-	if err != nil || timedCtx.Err() != nil {
+	execution := executions[0].Execution
+	if err := waitForWorkflowClose(timedCtx, tempClient, execution.WorkflowId, execution.RunId); err != nil {
 		return fmt.Errorf("timeout waiting for sync to stop after %v", maxWaitTime)
 	}
 
 	return nil
 }
 
+// waitForWorkflowClose blocks until a workflow run has closed or the context is done. The
+// history long-poll returns empty when it times out on the server, and is then repeated.
+func waitForWorkflowClose(ctx context.Context, tempClient *temporal.Temporal, workflowID, runID string) error {
+	_, err := workflowCloseEvent(ctx, tempClient, workflowID, runID, true)
+	return err
+}
+
+// workflowCloseEvent returns the event a workflow execution closed with. With wait it
+// long-polls until the execution closes, otherwise nil is returned while it is running.
+func workflowCloseEvent(ctx context.Context, tempClient *temporal.Temporal, workflowID, runID string, wait bool) (*historypb.HistoryEvent, error) {
+	var nextPageToken []byte
+	for {
+		resp, err := tempClient.Client.WorkflowService().GetWorkflowExecutionHistory(
+			ctx,
+			&workflowservice.GetWorkflowExecutionHistoryRequest{
+				Namespace: "default",
+				Execution: &commonpb.WorkflowExecution{
+					WorkflowId: workflowID,
+					RunId:      runID,
+				},
+				NextPageToken:          nextPageToken,
+				WaitNewEvent:           wait,
+				HistoryEventFilterType: enumspb.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if events := resp.GetHistory().GetEvents(); len(events) > 0 {
+			return events[len(events)-1], nil
+		}
+		if !wait {
+			return nil, nil
+		}
+		nextPageToken = resp.NextPageToken
+	}
+}
+
 // checks the version compatibility for clear-destination and stream difference operation
 // supported in versions >= v0.3.0
 func CheckClearDestinationCompatibility(sourceVersion string) error {
@@ -257,3 +297,68 @@ func CheckClearDestinationCompatibility(sourceVersion string) error {
 	}
 	return nil
 }
+
+// toListOptions converts list query parameters into database list options.
+// Listings default to the most recently updated entities first; name sorting defaults to ascending.
+func toListOptions(query dto.ListQuery) (database.ListOptions, error) {
+	cursor, err := database.DecodeCursor(query.Cursor)
+	if err != nil {
+		return database.ListOptions{}, err
+	}
+
+	selector, err := models.ParseLabelSelector(query.Selector)
+	if err != nil {
+		return database.ListOptions{}, err
+	}
+
+	sortBy := query.SortBy
+	if sortBy == "" {
+		sortBy = constants.SortKeyUpdatedAt
+	}
+	desc := query.SortOrder == constants.SortOrderDesc || (query.SortOrder == "" && sortBy != constants.SortKeyName)
+
+	return database.ListOptions{
+		Name:      query.Name,
+		Type:      query.Type,
+		Active:    query.Active,
+		CreatedBy: query.CreatedBy,
+		Selector:  selector,
+		SortBy:    sortBy,
+		Desc:      desc,
+		Limit:     query.Limit,
+		Cursor:    cursor,
+	}, nil
+}
+
+// nextPage trims the look-ahead row fetched beyond the page limit and returns the
+// cursor for the following page, or an empty cursor on the last page.
+func nextPage[T any](items []T, limit int, cursorOf func(T) database.Cursor) ([]T, string) {
+	if limit <= 0 || len(items) <= limit {
+		return items, ""
+	}
+	items = items[:limit]
+	return items, database.EncodeCursor(cursorOf(items[limit-1]))
+}
+
+// skipToCursor drops every item up to and including the one the cursor points at.
+// It is used when a page has to be cut in memory instead of in the database.
+func skipToCursor(jobs []*models.Job, token string) ([]*models.Job, error) {
+	cursor, err := database.DecodeCursor(token)
+	if err != nil || cursor == nil {
+		return jobs, err
+	}
+	for i, job := range jobs {
+		if job.ID == cursor.ID {
+			return jobs[i+1:], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: job id[%d] is no longer part of the listing", constants.ErrInvalidCursor, cursor.ID)
+}
+
+// labelsOrEmpty returns the labels as a plain map so responses always carry an object.
+func labelsOrEmpty(labels models.Labels) map[string]string {
+	if labels == nil {
+		return map[string]string{}
+	}
+	return labels
+}
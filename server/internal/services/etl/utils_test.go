@@ -0,0 +1,101 @@
+package etl
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/database"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+)
+
+func TestNextPage(t *testing.T) {
+	cursorOf := func(id int) database.Cursor { return database.Cursor{Value: fmt.Sprintf("job-%d", id), ID: id} }
+	tests := []struct {
+		name  string
+		items []int
+		limit int
+		want  []int
+		next  *database.Cursor
+	}{
+		{name: "no limit", items: []int{1, 2, 3}, limit: 0, want: []int{1, 2, 3}},
+		{name: "fewer rows than the limit", items: []int{1, 2}, limit: 3, want: []int{1, 2}},
+		{name: "exactly the limit is the last page", items: []int{1, 2, 3}, limit: 3, want: []int{1, 2, 3}},
+		{name: "look-ahead row means another page", items: []int{1, 2, 3, 4}, limit: 3, want: []int{1, 2, 3}, next: &database.Cursor{Value: "job-3", ID: 3}},
+		{name: "empty", items: []int{}, limit: 3, want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, next := nextPage(tt.items, tt.limit, cursorOf)
+			require.Equal(t, tt.want, items)
+			if tt.next == nil {
+				require.Empty(t, next)
+				return
+			}
+			cursor, err := database.DecodeCursor(next)
+			require.NoError(t, err)
+			require.Equal(t, tt.next, cursor)
+		})
+	}
+}
+
+func TestSkipToCursor(t *testing.T) {
+	jobs := []*models.Job{{ID: 1}, {ID: 2}, {ID: 3}}
+	ids := func(jobs []*models.Job) []int {
+		out := []int{}
+		for _, job := range jobs {
+			out = append(out, job.ID)
+		}
+		return out
+	}
+
+	rest, err := skipToCursor(jobs, "")
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, ids(rest))
+
+	rest, err = skipToCursor(jobs, database.EncodeCursor(database.Cursor{ID: 2}))
+	require.NoError(t, err)
+	require.Equal(t, []int{3}, ids(rest))
+
+	rest, err = skipToCursor(jobs, database.EncodeCursor(database.Cursor{ID: 3}))
+	require.NoError(t, err)
+	require.Empty(t, rest)
+
+	_, err = skipToCursor(jobs, database.EncodeCursor(database.Cursor{ID: 9}))
+	require.True(t, errors.Is(err, constants.ErrInvalidCursor))
+}
+
+func TestToListOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  dto.ListQuery
+		sortBy string
+		desc   bool
+	}{
+		{"defaults to newest updated first", dto.ListQuery{}, constants.SortKeyUpdatedAt, true},
+		{"name sorts ascending by default", dto.ListQuery{SortBy: constants.SortKeyName}, constants.SortKeyName, false},
+		{"name descending", dto.ListQuery{SortBy: constants.SortKeyName, SortOrder: constants.SortOrderDesc}, constants.SortKeyName, true},
+		{"created_at ascending", dto.ListQuery{SortBy: constants.SortKeyCreatedAt, SortOrder: constants.SortOrderAsc}, constants.SortKeyCreatedAt, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := toListOptions(tt.query)
+			require.NoError(t, err)
+			require.Equal(t, tt.sortBy, opts.SortBy)
+			require.Equal(t, tt.desc, opts.Desc)
+			require.Nil(t, opts.Cursor)
+		})
+	}
+
+	opts, err := toListOptions(dto.ListQuery{Limit: 10, Cursor: database.EncodeCursor(database.Cursor{Value: "orders", ID: 7})})
+	require.NoError(t, err)
+	require.Equal(t, 10, opts.Limit)
+	require.Equal(t, &database.Cursor{Value: "orders", ID: 7}, opts.Cursor)
+
+	_, err = toListOptions(dto.ListQuery{Cursor: "not base64!"})
+	require.True(t, errors.Is(err, constants.ErrInvalidCursor))
+}
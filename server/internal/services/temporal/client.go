@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/datazip-inc/olake-ui/server/internal/appconfig"
@@ -18,6 +20,8 @@ import (
 type Temporal struct {
 	Client    client.Client
 	taskQueue string
+	// pools maps the worker pools to their task queues, the default pool to taskQueue
+	pools map[string]string
 }
 
 // NewClient creates a new Temporal client
@@ -32,9 +36,16 @@ func NewClient() (*Temporal, error) {
 	if cfg.TemporalExternal && cfg.TemporalTaskQueue != "" {
 		taskQueue = cfg.TemporalTaskQueue
 	}
+	pools, err := parseWorkerPools(cfg.TemporalWorkerPools, taskQueue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TEMPORAL_WORKER_POOLS: %s", err)
+	}
+	if err := ValidateDriverResources(nil); err != nil {
+		return nil, fmt.Errorf("failed to read DRIVER_* resource settings: %s", err)
+	}
 
 	var temporalClient *Temporal
-	err := utils.RetryWithBackoff(func() error {
+	err = utils.RetryWithBackoff(func() error {
 		clientOptions := client.Options{
 			HostPort:  cfg.TemporalAddress,
 			Namespace: cfg.TemporalNamespace,
@@ -60,6 +71,7 @@ func NewClient() (*Temporal, error) {
 		temporalClient = &Temporal{
 			Client:    c,
 			taskQueue: taskQueue,
+			pools:     pools,
 		}
 		return nil
 	}, 3, time.Second)
@@ -82,52 +94,83 @@ func (t *Temporal) WorkflowAndScheduleID(projectID string, jobID int) (string, s
 	return workflowID, fmt.Sprintf("schedule-%s", workflowID)
 }
 
-// createSchedule creates a new schedule
-func (t *Temporal) CreateSchedule(ctx context.Context, job *models.Job) error {
+// AdHocWorkflowPrefix is the start of the IDs of the one-off syncs of a job. They share the
+// range of the job's scheduled workflow IDs, so they are listed and checked along with them.
+func AdHocWorkflowPrefix(projectID string, jobID int) string {
+	return fmt.Sprintf("sync-%s-%d-adhoc-", projectID, jobID)
+}
+
+// ParseScheduleID returns the project and job of a sync schedule ID built by WorkflowAndScheduleID
+func ParseScheduleID(scheduleID string) (string, int, bool) {
+	rest, ok := strings.CutPrefix(scheduleID, "schedule-sync-")
+	if !ok {
+		return "", 0, false
+	}
+	idx := strings.LastIndex(rest, "-")
+	if idx <= 0 {
+		return "", 0, false
+	}
+	jobID, err := strconv.Atoi(rest[idx+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return rest[:idx], jobID, true
+}
+
+// createSchedule creates a new schedule for a job that fires at the job's parsed schedule,
+// following the job's execution policy, on the task queue of the job's worker pool
+func (t *Temporal) CreateSchedule(ctx context.Context, job *models.Job, schedule *utils.Schedule) error {
 	workflowID, scheduleID := t.WorkflowAndScheduleID(job.ProjectID, job.ID)
-	cronExpression := utils.ToCron(job.Frequency)
 
 	req := buildExecutionReqForSync(job, workflowID)
+	policy := JobExecutionPolicy(job)
+	taskQueue, err := t.jobTaskQueue(job)
+	if err != nil {
+		return err
+	}
 
-	_, err := t.Client.ScheduleClient().Create(ctx, client.ScheduleOptions{
-		ID: scheduleID,
-		Spec: client.ScheduleSpec{
-			CronExpressions: []string{cronExpression},
-		},
-		Action: &client.ScheduleWorkflowAction{
-			ID:        workflowID,
-			Workflow:  RunSyncWorkflow,
-			Args:      []any{*req},
-			TaskQueue: t.taskQueue,
-		},
-		Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+	_, err = t.Client.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		ID:            scheduleID,
+		Spec:          scheduleSpec(schedule),
+		Action:        t.scheduleAction(req, policy, taskQueue),
+		Overlap:       policy.overlapPolicy(),
+		CatchupWindow: policy.CatchupWindow,
 	})
 
 	return err
 }
 
-// UpdateScheduleSpec updates an existing schedule's spec
-func (t *Temporal) UpdateSchedule(ctx context.Context, frequency, projectID string, jobID int, args *ExecutionRequest) error {
-	_, scheduleID := t.WorkflowAndScheduleID(projectID, jobID)
+// UpdateScheduleSpec updates an existing schedule's spec and policies unless schedule is nil,
+// and its action unless args is nil. With a schedule and no args a sync action is rebuilt
+// from the job, so that it follows the job's execution policy; a clear-destination action is
+// left as it is. A rebuilt action goes to the task queue of the job's worker pool.
+func (t *Temporal) UpdateSchedule(ctx context.Context, job *models.Job, schedule *utils.Schedule, args *ExecutionRequest) error {
+	workflowID, scheduleID := t.WorkflowAndScheduleID(job.ProjectID, job.ID)
+	policy := JobExecutionPolicy(job)
+	taskQueue, err := t.jobTaskQueue(job)
+	if err != nil {
+		return err
+	}
 
 	handle := t.Client.ScheduleClient().GetHandle(ctx, scheduleID)
 	return handle.Update(ctx, client.ScheduleUpdateOptions{
 		DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
-			if frequency != "" {
-				cronExpression := utils.ToCron(frequency)
-				input.Description.Schedule.Spec = &client.ScheduleSpec{
-					CronExpressions: []string{cronExpression},
+			if schedule != nil {
+				spec := scheduleSpec(schedule)
+				input.Description.Schedule.Spec = &spec
+				if input.Description.Schedule.Policy == nil {
+					input.Description.Schedule.Policy = &client.SchedulePolicies{}
+				}
+				input.Description.Schedule.Policy.Overlap = policy.overlapPolicy()
+				input.Description.Schedule.Policy.CatchupWindow = policy.CatchupWindow
+				if args == nil && job.Source != nil && ActionCommand(input.Description.Schedule.Action) == Sync {
+					args = buildExecutionReqForSync(job, workflowID)
 				}
 			}
 
 			// update schedule action
 			if args != nil {
-				input.Description.Schedule.Action = &client.ScheduleWorkflowAction{
-					ID:        args.WorkflowID,
-					Workflow:  RunSyncWorkflow,
-					Args:      []any{*args},
-					TaskQueue: t.taskQueue,
-				}
+				input.Description.Schedule.Action = t.scheduleAction(args, policy, taskQueue)
 			}
 
 			return &client.ScheduleUpdate{
@@ -137,6 +180,104 @@ func (t *Temporal) UpdateSchedule(ctx context.Context, frequency, projectID stri
 	})
 }
 
+// scheduleAction returns the action of a job's schedule. Only syncs are retried, a
+// clear-destination run is not.
+func (t *Temporal) scheduleAction(req *ExecutionRequest, policy ExecutionPolicy, taskQueue string) *client.ScheduleWorkflowAction {
+	action := &client.ScheduleWorkflowAction{
+		ID:        req.WorkflowID,
+		Workflow:  RunSyncWorkflow,
+		Args:      []any{*req},
+		TaskQueue: taskQueue,
+	}
+	if req.Command == Sync {
+		action.RetryPolicy = policy.retryPolicy()
+	}
+	return action
+}
+
+// scheduleSpec returns the spec of a schedule for a parsed job frequency, see utils.Schedule.
+// Manual and once jobs get an empty spec, their schedule only runs when triggered.
+//
+// Temporal matches cron and calendar specs against the wall clock of the time zone, so a daily
+// sync stays at midnight local time across DST changes. A time skipped by a change does not
+// fire that day and a time repeated by one fires twice. Intervals count absolute time. The
+// blackouts of maintenance windows become skip calendars, which are matched against the wall
+// clock as well.
+func scheduleSpec(schedule *utils.Schedule) client.ScheduleSpec {
+	spec := client.ScheduleSpec{TimeZoneName: schedule.TimeZone, Jitter: schedule.Jitter}
+	switch schedule.Mode {
+	case constants.FrequencyManual, constants.FrequencyOnce:
+		return client.ScheduleSpec{}
+	case constants.ScheduleModeInterval:
+		spec.Intervals = []client.ScheduleIntervalSpec{{Every: schedule.Every, Offset: schedule.Offset}}
+	case constants.ScheduleModeCalendar:
+		var days []client.ScheduleRange
+		for _, day := range schedule.Days {
+			days = append(days, client.ScheduleRange{Start: day})
+		}
+		// one calendar per time of day, as a calendar fires at every combination of its
+		// hours and minutes
+		for _, at := range schedule.Times {
+			spec.Calendars = append(spec.Calendars, client.ScheduleCalendarSpec{
+				Hour:      []client.ScheduleRange{{Start: at.Hour}},
+				Minute:    []client.ScheduleRange{{Start: at.Minute}},
+				DayOfWeek: days,
+				Comment:   schedule.Frequency,
+			})
+		}
+	default:
+		spec.CronExpressions = []string{schedule.Cron}
+	}
+	spec.Skip = skipCalendars(schedule)
+	return spec
+}
+
+// skipCalendars returns the calendars matching the blackouts of a schedule. A calendar
+// matches every combination of its fields, so a blackout becomes up to three calendars: the
+// minutes of its first hour, its whole hours and the minutes of its last hour. Every calendar
+// carries the blackout key of the schedule as its comment.
+func skipCalendars(schedule *utils.Schedule) []client.ScheduleCalendarSpec {
+	key := schedule.BlackoutKey()
+	var calendars []client.ScheduleCalendarSpec
+	for _, b := range schedule.Blackouts {
+		base := client.ScheduleCalendarSpec{
+			Second:  []client.ScheduleRange{{Start: 0, End: 59}},
+			Comment: key,
+		}
+		if b.Date.IsZero() {
+			base.DayOfWeek = []client.ScheduleRange{{Start: b.Weekday}}
+		} else {
+			base.Year = []client.ScheduleRange{{Start: b.Date.Year()}}
+			base.Month = []client.ScheduleRange{{Start: int(b.Date.Month())}}
+			base.DayOfMonth = []client.ScheduleRange{{Start: b.Date.Day()}}
+		}
+		add := func(hours, minutes client.ScheduleRange) {
+			calendar := base
+			calendar.Hour = []client.ScheduleRange{hours}
+			calendar.Minute = []client.ScheduleRange{minutes}
+			calendars = append(calendars, calendar)
+		}
+
+		fromHour, fromMinute := b.From/60, b.From%60
+		toHour, toMinute := b.To/60, b.To%60
+		if fromHour == toHour {
+			add(client.ScheduleRange{Start: fromHour}, client.ScheduleRange{Start: fromMinute, End: toMinute - 1})
+			continue
+		}
+		if fromMinute > 0 {
+			add(client.ScheduleRange{Start: fromHour}, client.ScheduleRange{Start: fromMinute, End: 59})
+			fromHour++
+		}
+		if toHour > fromHour {
+			add(client.ScheduleRange{Start: fromHour, End: toHour - 1}, client.ScheduleRange{Start: 0, End: 59})
+		}
+		if toMinute > 0 {
+			add(client.ScheduleRange{Start: toHour}, client.ScheduleRange{Start: 0, End: toMinute - 1})
+		}
+	}
+	return calendars
+}
+
 func (t *Temporal) PauseSchedule(ctx context.Context, projectID string, jobID int) error {
 	_, scheduleID := t.WorkflowAndScheduleID(projectID, jobID)
 	return t.Client.ScheduleClient().GetHandle(ctx, scheduleID).Pause(ctx, client.SchedulePauseOptions{
@@ -156,6 +297,32 @@ func (t *Temporal) DeleteSchedule(ctx context.Context, projectID string, jobID i
 	return t.Client.ScheduleClient().GetHandle(ctx, scheduleID).Delete(ctx)
 }
 
+// DescribeSchedule returns the current spec, state and action of a job's schedule
+func (t *Temporal) DescribeSchedule(ctx context.Context, projectID string, jobID int) (*client.ScheduleDescription, error) {
+	_, scheduleID := t.WorkflowAndScheduleID(projectID, jobID)
+	return t.Client.ScheduleClient().GetHandle(ctx, scheduleID).Describe(ctx)
+}
+
+// ListSyncScheduleIDs returns the IDs of all job sync schedules in the namespace
+func (t *Temporal) ListSyncScheduleIDs(ctx context.Context) ([]string, error) {
+	iter, err := t.Client.ScheduleClient().List(ctx, client.ScheduleListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing schedules: %s", err)
+	}
+
+	var ids []string
+	for iter.HasNext() {
+		entry, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("error listing schedules: %s", err)
+		}
+		if strings.HasPrefix(entry.ID, "schedule-sync-") {
+			ids = append(ids, entry.ID)
+		}
+	}
+	return ids, nil
+}
+
 func (t *Temporal) TriggerSchedule(ctx context.Context, projectID string, jobID int) error {
 	_, scheduleID := t.WorkflowAndScheduleID(projectID, jobID)
 	return t.Client.ScheduleClient().GetHandle(ctx, scheduleID).Trigger(ctx, client.ScheduleTriggerOptions{
@@ -163,6 +330,68 @@ func (t *Temporal) TriggerSchedule(ctx context.Context, projectID string, jobID
 	})
 }
 
+// BackfillSchedule runs the schedule of a job for the given fire times, which the schedule
+// missed. With buffer the runs wait for each other and for a running sync, so every fire time
+// gets a run; otherwise only one run waits and any further one is dropped.
+func (t *Temporal) BackfillSchedule(ctx context.Context, projectID string, jobID int, fireTimes []time.Time, buffer bool) error {
+	overlap := enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE
+	if buffer {
+		overlap = enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ALL
+	}
+	backfills := make([]client.ScheduleBackfill, 0, len(fireTimes))
+	for _, at := range fireTimes {
+		// a backfill runs the fire times after its start up to its end
+		backfills = append(backfills, client.ScheduleBackfill{Start: at.Add(-time.Second), End: at, Overlap: overlap})
+	}
+	_, scheduleID := t.WorkflowAndScheduleID(projectID, jobID)
+	return t.Client.ScheduleClient().GetHandle(ctx, scheduleID).Backfill(ctx, client.ScheduleBackfillOptions{Backfill: backfills})
+}
+
+// TriggerScheduleRun triggers the schedule of a job and returns the workflow it started. If a
+// workflow of the schedule is running already the trigger is skipped, so that one is returned
+// with running set. The started workflow is looked up in the recent actions of the schedule;
+// nil is returned if it does not show up within constants.SyncTriggerWaitTimeout.
+func (t *Temporal) TriggerScheduleRun(ctx context.Context, projectID string, jobID int) (*client.ScheduleWorkflowExecution, bool, error) {
+	_, scheduleID := t.WorkflowAndScheduleID(projectID, jobID)
+	handle := t.Client.ScheduleClient().GetHandle(ctx, scheduleID)
+	desc, err := handle.Describe(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(desc.Info.RunningWorkflows) > 0 {
+		return &desc.Info.RunningWorkflows[0], true, nil
+	}
+
+	started := make(map[string]bool, len(desc.Info.RecentActions))
+	for _, action := range desc.Info.RecentActions {
+		if action.StartWorkflowResult != nil {
+			started[action.StartWorkflowResult.WorkflowID] = true
+		}
+	}
+	if err := handle.Trigger(ctx, client.ScheduleTriggerOptions{Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_SKIP}); err != nil {
+		return nil, false, err
+	}
+
+	deadline := time.Now().Add(constants.SyncTriggerWaitTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(constants.SyncTriggerPollInterval):
+		}
+		desc, err := handle.Describe(ctx)
+		if err != nil {
+			continue
+		}
+		for _, action := range desc.Info.RecentActions {
+			if result := action.StartWorkflowResult; result != nil && !started[result.WorkflowID] {
+				return result, false, nil
+			}
+		}
+	}
+	return nil, false, nil
+}
+
 // cancelWorkflow cancels a workflow execution
 func (t *Temporal) CancelWorkflow(ctx context.Context, workflowID, runID string) error {
 	return t.Client.CancelWorkflow(ctx, workflowID, runID)
@@ -179,11 +408,24 @@ func (t *Temporal) ListWorkflow(ctx context.Context, request *workflowservice.Li
 	return resp, nil
 }
 
+// CountWorkflow returns the number of workflow executions matching the provided query
+func (t *Temporal) CountWorkflow(ctx context.Context, query string) (int64, error) {
+	resp, err := t.Client.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Query: query,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error counting workflow executions: %s", err)
+	}
+
+	return resp.Count, nil
+}
+
 // RestoreSyncSchedule restores schedule back to sync workflow from clear-destination
 func (t *Temporal) RestoreSyncSchedule(ctx context.Context, job *models.Job) error {
 	workflowID, _ := t.WorkflowAndScheduleID(job.ProjectID, job.ID)
 	syncReq := buildExecutionReqForSync(job, workflowID)
-	if err := t.UpdateSchedule(ctx, job.Frequency, job.ProjectID, job.ID, syncReq); err != nil {
+	// only the action is restored, the spec was left as it is
+	if err := t.UpdateSchedule(ctx, job, nil, syncReq); err != nil {
 		return fmt.Errorf("failed to update schedule: %s", err)
 	}
 	return nil
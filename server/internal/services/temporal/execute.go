@@ -27,6 +27,10 @@ type ExecutionRequest struct {
 	JobID         int           `json:"job_id"`
 	Timeout       time.Duration `json:"timeout"`
 	OutputFile    string        `json:"output_file"` // to get the output file from the workflow
+	// Resources and Env are the container resources and extra environment variables of the
+	// driver, set for the runs of a job
+	Resources *ContainerResources `json:"resources,omitempty"`
+	Env       []EnvVar            `json:"env,omitempty"`
 
 	TempPath string `json:"temp_path"`
 }
@@ -67,8 +71,12 @@ const (
 // ref: https://docs.temporal.io/troubleshooting/blob-size-limit-error
 
 // DiscoverStreams runs a workflow to discover catalog data
-func (t *Temporal) DiscoverStreams(ctx context.Context, sourceType, version, config, streamsConfig, jobName string, maxDiscoverThreads *int) (map[string]interface{}, error) {
+func (t *Temporal) DiscoverStreams(ctx context.Context, pool, sourceType, version, config, streamsConfig, jobName string, maxDiscoverThreads *int) (map[string]interface{}, error) {
 	workflowID := fmt.Sprintf("discover-catalog-%s-%d", sourceType, time.Now().Unix())
+	taskQueue, err := t.poolTaskQueue(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
 
 	configs := []JobConfig{
 		{Name: "config.json", Data: config},
@@ -121,7 +129,7 @@ func (t *Temporal) DiscoverStreams(ctx context.Context, sourceType, version, con
 
 	workflowOptions := client.StartWorkflowOptions{
 		ID:        workflowID,
-		TaskQueue: t.taskQueue,
+		TaskQueue: taskQueue,
 	}
 
 	run, err := t.Client.ExecuteWorkflow(ctx, workflowOptions, ExecuteWorkflow, req)
@@ -138,8 +146,12 @@ func (t *Temporal) DiscoverStreams(ctx context.Context, sourceType, version, con
 }
 
 // FetchSpec runs a workflow to fetch driver specifications
-func (t *Temporal) GetDriverSpecs(ctx context.Context, destinationType, sourceType, version string) (dto.SpecOutput, error) {
+func (t *Temporal) GetDriverSpecs(ctx context.Context, pool, destinationType, sourceType, version string) (dto.SpecOutput, error) {
 	workflowID := fmt.Sprintf("fetch-spec-%s-%d", sourceType, time.Now().Unix())
+	taskQueue, err := t.poolTaskQueue(ctx, pool)
+	if err != nil {
+		return dto.SpecOutput{}, err
+	}
 
 	// spec version >= DefaultSpecVersion is required
 	if semver.Compare(version, constants.DefaultSpecVersion) < 0 && utils.GetCustomDriverVersion() == "" {
@@ -167,7 +179,7 @@ func (t *Temporal) GetDriverSpecs(ctx context.Context, destinationType, sourceTy
 
 	workflowOptions := client.StartWorkflowOptions{
 		ID:        workflowID,
-		TaskQueue: t.taskQueue,
+		TaskQueue: taskQueue,
 	}
 
 	run, err := t.Client.ExecuteWorkflow(ctx, workflowOptions, ExecuteWorkflow, req)
@@ -186,7 +198,11 @@ func (t *Temporal) GetDriverSpecs(ctx context.Context, destinationType, sourceTy
 }
 
 // TestConnection runs a workflow to test connection
-func (t *Temporal) VerifyDriverCredentials(ctx context.Context, workflowID, flag, sourceType, version, config string) (map[string]interface{}, error) {
+func (t *Temporal) VerifyDriverCredentials(ctx context.Context, pool, workflowID, flag, sourceType, version, config string) (map[string]interface{}, error) {
+	taskQueue, err := t.poolTaskQueue(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
 	configs := []JobConfig{
 		{Name: "config.json", Data: config},
 	}
@@ -216,7 +232,7 @@ func (t *Temporal) VerifyDriverCredentials(ctx context.Context, workflowID, flag
 
 	workflowOptions := client.StartWorkflowOptions{
 		ID:        workflowID,
-		TaskQueue: t.taskQueue,
+		TaskQueue: taskQueue,
 	}
 
 	run, err := t.Client.ExecuteWorkflow(ctx, workflowOptions, ExecuteWorkflow, req)
@@ -261,7 +277,7 @@ func (t *Temporal) ClearDestination(ctx context.Context, job *models.Job, stream
 		return fmt.Errorf("failed to build execution request for clear-destination: %s", err)
 	}
 
-	err = t.UpdateSchedule(ctx, job.Frequency, job.ProjectID, job.ID, clearReq)
+	err = t.UpdateSchedule(ctx, job, nil, clearReq)
 	if err != nil {
 		return fmt.Errorf("failed to update schedule for clear-destination: %s", err)
 	}
@@ -269,7 +285,7 @@ func (t *Temporal) ClearDestination(ctx context.Context, job *models.Job, stream
 	if err := t.TriggerSchedule(ctx, job.ProjectID, job.ID); err != nil {
 		// revert back to sync
 		syncReq := buildExecutionReqForSync(job, workflowID)
-		if uerr := t.UpdateSchedule(ctx, job.Frequency, job.ProjectID, job.ID, syncReq); uerr != nil {
+		if uerr := t.UpdateSchedule(ctx, job, nil, syncReq); uerr != nil {
 			return fmt.Errorf("trigger clear destination workflow failed: %s, revert to sync failed: %s", err, uerr)
 		}
 		return fmt.Errorf("failed to trigger clear destination workflow: %s", err)
@@ -277,9 +293,39 @@ func (t *Temporal) ClearDestination(ctx context.Context, job *models.Job, stream
 	return nil
 }
 
+// RunAdHocSync starts a one-off sync of a job outside of its schedule, which is left as it
+// is. A non-empty streamsConfig limits the run to its streams. It returns the workflow and run
+// ID of the sync.
+func (t *Temporal) RunAdHocSync(ctx context.Context, job *models.Job, streamsConfig string, timeout time.Duration) (string, string, error) {
+	workflowID := fmt.Sprintf("%s%d", AdHocWorkflowPrefix(job.ProjectID, job.ID), time.Now().Unix())
+	taskQueue, err := t.poolTaskQueue(ctx, JobWorkerPool(job))
+	if err != nil {
+		return "", "", err
+	}
+	req, err := buildExecutionReqForAdHocSync(job, workflowID, streamsConfig, timeout)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build execution request for ad-hoc sync: %s", err)
+	}
+
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        workflowID,
+		TaskQueue: taskQueue,
+	}
+
+	run, err := t.Client.ExecuteWorkflow(ctx, workflowOptions, RunSyncWorkflow, *req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to execute ad-hoc sync workflow: %s", err)
+	}
+	return run.GetID(), run.GetRunID(), nil
+}
+
 // GetStreamDifference compares old and new stream configs and returns the difference
 func (t *Temporal) GetStreamDifference(ctx context.Context, job *models.Job, oldConfig, newConfig string) (map[string]interface{}, error) {
 	workflowID := fmt.Sprintf("difference-%s-%d-%d", job.ProjectID, job.ID, time.Now().Unix())
+	taskQueue, err := t.poolTaskQueue(ctx, JobWorkerPool(job))
+	if err != nil {
+		return nil, err
+	}
 
 	configs := []JobConfig{
 		{Name: "old_streams.json", Data: oldConfig},
@@ -310,10 +356,11 @@ func (t *Temporal) GetStreamDifference(ctx context.Context, job *models.Job, old
 		Timeout:       GetWorkflowTimeout(Discover),
 		OutputFile:    "difference_streams.json",
 	}
+	setDriverContainer(req, job)
 
 	workflowOptions := client.StartWorkflowOptions{
 		ID:        workflowID,
-		TaskQueue: t.taskQueue,
+		TaskQueue: taskQueue,
 	}
 
 	run, err := t.Client.ExecuteWorkflow(ctx, workflowOptions, ExecuteWorkflow, req)
@@ -0,0 +1,127 @@
+package temporal
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	sdktemporal "go.temporal.io/sdk/temporal"
+)
+
+// ExecutionPolicy is how the syncs of a job run: how long a run may take, how often a failed
+// scheduled run is retried, what a scheduled run does while the previous one still runs and
+// how late a run missed while temporal was down may still start.
+type ExecutionPolicy struct {
+	RunTimeout         time.Duration
+	MaxAttempts        int
+	InitialInterval    time.Duration
+	BackoffCoefficient float64
+	MaxInterval        time.Duration
+	Overlap            string
+	CatchupWindow      time.Duration
+}
+
+// JobExecutionPolicy reads the execution policy from the advanced settings of a job. Settings
+// that are left out or cannot be read keep their defaults; they are validated when saved.
+func JobExecutionPolicy(job *models.Job) ExecutionPolicy {
+	policy := ExecutionPolicy{
+		RunTimeout:         constants.DefaultSyncTimeout,
+		MaxAttempts:        1,
+		InitialInterval:    constants.DefaultRetryInitialInterval,
+		BackoffCoefficient: constants.DefaultRetryBackoff,
+		MaxInterval:        constants.DefaultRetryMaxInterval,
+		Overlap:            constants.OverlapPolicySkip,
+		CatchupWindow:      constants.DefaultCatchupWindow,
+	}
+	if job == nil || job.AdvancedSettings == nil || *job.AdvancedSettings == "" {
+		return policy
+	}
+	var settings dto.AdvancedSettings
+	if err := json.Unmarshal([]byte(*job.AdvancedSettings), &settings); err != nil {
+		return policy
+	}
+
+	parse := func(value string, target *time.Duration) {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			*target = d
+		}
+	}
+	parse(settings.RunTimeout, &policy.RunTimeout)
+	parse(settings.CatchupWindow, &policy.CatchupWindow)
+	if settings.OverlapPolicy != "" {
+		policy.Overlap = settings.OverlapPolicy
+	}
+	if retry := settings.Retry; retry != nil {
+		if retry.MaxAttempts > 0 {
+			policy.MaxAttempts = retry.MaxAttempts
+		}
+		parse(retry.InitialInterval, &policy.InitialInterval)
+		parse(retry.MaxInterval, &policy.MaxInterval)
+		if retry.BackoffCoefficient >= 1 {
+			policy.BackoffCoefficient = retry.BackoffCoefficient
+		}
+	}
+	return policy
+}
+
+// retryPolicy returns the retry policy of a scheduled sync, nil when a failed run is not retried
+func (p ExecutionPolicy) retryPolicy() *sdktemporal.RetryPolicy {
+	if p.MaxAttempts <= 1 {
+		return nil
+	}
+	return &sdktemporal.RetryPolicy{
+		InitialInterval:    p.InitialInterval,
+		BackoffCoefficient: p.BackoffCoefficient,
+		MaximumInterval:    p.MaxInterval,
+		MaximumAttempts:    int32(p.MaxAttempts),
+	}
+}
+
+// overlapPolicy returns the temporal overlap policy of a schedule
+func (p ExecutionPolicy) overlapPolicy() enumspb.ScheduleOverlapPolicy {
+	switch p.Overlap {
+	case constants.OverlapPolicyBufferOne:
+		return enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE
+	case constants.OverlapPolicyCancelOther:
+		return enumspb.SCHEDULE_OVERLAP_POLICY_CANCEL_OTHER
+	default:
+		return enumspb.SCHEDULE_OVERLAP_POLICY_SKIP
+	}
+}
+
+// Response returns the policy as shown in job responses
+func (p ExecutionPolicy) Response() dto.ExecutionPolicy {
+	return dto.ExecutionPolicy{
+		RunTimeout:         p.RunTimeout.String(),
+		MaxAttempts:        p.MaxAttempts,
+		InitialInterval:    p.InitialInterval.String(),
+		BackoffCoefficient: p.BackoffCoefficient,
+		MaxInterval:        p.MaxInterval.String(),
+		OverlapPolicy:      p.Overlap,
+		CatchupWindow:      p.CatchupWindow.String(),
+	}
+}
+
+// ActionCommand returns the command a schedule action runs, empty when it cannot be read
+func ActionCommand(action client.ScheduleAction) Command {
+	workflowAction, ok := action.(*client.ScheduleWorkflowAction)
+	if !ok || len(workflowAction.Args) == 0 {
+		return ""
+	}
+	var req ExecutionRequest
+	switch arg := workflowAction.Args[0].(type) {
+	case *commonpb.Payload:
+		if err := converter.GetDefaultDataConverter().FromPayload(arg, &req); err != nil {
+			return ""
+		}
+	case ExecutionRequest:
+		req = arg
+	}
+	return req.Command
+}
@@ -0,0 +1,150 @@
+package temporal
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/datazip-inc/olake-ui/server/internal/appconfig"
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	"github.com/datazip-inc/olake-ui/server/internal/utils"
+)
+
+// ContainerResources are the cpu and memory requests and limits of a driver container as
+// kubernetes quantities, empty when not set
+type ContainerResources struct {
+	CPURequest    string `json:"cpu_request,omitempty"`
+	CPULimit      string `json:"cpu_limit,omitempty"`
+	MemoryRequest string `json:"memory_request,omitempty"`
+	MemoryLimit   string `json:"memory_limit,omitempty"`
+}
+
+// EnvVar is an extra environment variable of a driver container. The value of a secret
+// variable is encrypted with the server's key and decrypted by the worker.
+type EnvVar struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Secret bool   `json:"secret,omitempty"`
+}
+
+// JobDriverResources reads the resources of a job's driver container from its advanced
+// settings, with the server defaults for those left out
+func JobDriverResources(job *models.Job) ContainerResources {
+	settings, _ := jobAdvancedSettings(job)
+	return driverResources(settings.Resources)
+}
+
+// JobDriverEnv returns the extra environment variables of a job's driver container
+func JobDriverEnv(job *models.Job) []EnvVar {
+	settings, _ := jobAdvancedSettings(job)
+	env := make([]EnvVar, 0, len(settings.Env))
+	for _, v := range settings.Env {
+		env = append(env, EnvVar{Name: v.Name, Value: v.Value, Secret: v.Secret})
+	}
+	return env
+}
+
+// ValidateDriverResources checks the resources of advanced settings with the server defaults
+// filled in: every quantity must be valid, no limit below its request and none above the
+// server maximum. Nil resources check the server defaults themselves.
+func ValidateDriverResources(resources *dto.DriverResources) error {
+	cfg := appconfig.Load()
+	r := driverResources(resources)
+	checks := []struct {
+		name           string
+		request, limit string
+		maximum        string
+		parse          func(string) (int64, error)
+	}{
+		{"cpu", r.CPURequest, r.CPULimit, cfg.DriverMaxCPU, utils.ParseCPUQuantity},
+		{"memory", r.MemoryRequest, r.MemoryLimit, cfg.DriverMaxMemory, utils.ParseMemoryQuantity},
+	}
+	for _, check := range checks {
+		parsed := map[string]int64{}
+		for _, q := range []struct{ kind, value string }{{"request", check.request}, {"limit", check.limit}, {"maximum", check.maximum}} {
+			if q.value == "" {
+				continue
+			}
+			n, err := check.parse(q.value)
+			if err != nil {
+				return fmt.Errorf("%w: %s %s: %s", constants.ErrInvalidDriverResources, check.name, q.kind, err)
+			}
+			parsed[q.kind] = n
+		}
+		request, hasRequest := parsed["request"]
+		limit, hasLimit := parsed["limit"]
+		maximum, hasMaximum := parsed["maximum"]
+		switch {
+		case hasRequest && hasLimit && limit < request:
+			return fmt.Errorf("%w: %s limit %s is below the %s request %s", constants.ErrInvalidDriverResources, check.name, check.limit, check.name, check.request)
+		case hasMaximum && hasRequest && request > maximum:
+			return fmt.Errorf("%w: %s request %s is above the server maximum %s", constants.ErrInvalidDriverResources, check.name, check.request, check.maximum)
+		case hasMaximum && hasLimit && limit > maximum:
+			return fmt.Errorf("%w: %s limit %s is above the server maximum %s", constants.ErrInvalidDriverResources, check.name, check.limit, check.maximum)
+		}
+	}
+	return nil
+}
+
+// Response returns the resources as shown in job responses
+func (r ContainerResources) Response() dto.DriverResources {
+	var resp dto.DriverResources
+	if r.CPURequest != "" || r.MemoryRequest != "" {
+		resp.Requests = &dto.ResourceQuantities{CPU: r.CPURequest, Memory: r.MemoryRequest}
+	}
+	if r.CPULimit != "" || r.MemoryLimit != "" {
+		resp.Limits = &dto.ResourceQuantities{CPU: r.CPULimit, Memory: r.MemoryLimit}
+	}
+	return resp
+}
+
+// setDriverContainer sets the resources and extra environment of the runs of a job
+func setDriverContainer(req *ExecutionRequest, job *models.Job) {
+	if resources := JobDriverResources(job); resources != (ContainerResources{}) {
+		req.Resources = &resources
+	}
+	if env := JobDriverEnv(job); len(env) > 0 {
+		req.Env = env
+	}
+}
+
+func driverResources(resources *dto.DriverResources) ContainerResources {
+	cfg := appconfig.Load()
+	r := ContainerResources{
+		CPURequest:    cfg.DriverCPURequest,
+		CPULimit:      cfg.DriverCPULimit,
+		MemoryRequest: cfg.DriverMemoryRequest,
+		MemoryLimit:   cfg.DriverMemoryLimit,
+	}
+	if resources == nil {
+		return r
+	}
+	set := func(value string, target *string) {
+		if value != "" {
+			*target = value
+		}
+	}
+	if q := resources.Requests; q != nil {
+		set(q.CPU, &r.CPURequest)
+		set(q.Memory, &r.MemoryRequest)
+	}
+	if q := resources.Limits; q != nil {
+		set(q.CPU, &r.CPULimit)
+		set(q.Memory, &r.MemoryLimit)
+	}
+	return r
+}
+
+// jobAdvancedSettings reads the advanced settings of a job, false when it has none or they
+// cannot be read
+func jobAdvancedSettings(job *models.Job) (dto.AdvancedSettings, bool) {
+	var settings dto.AdvancedSettings
+	if job == nil || job.AdvancedSettings == nil || *job.AdvancedSettings == "" {
+		return settings, false
+	}
+	if err := json.Unmarshal([]byte(*job.AdvancedSettings), &settings); err != nil {
+		return dto.AdvancedSettings{}, false
+	}
+	return settings, true
+}
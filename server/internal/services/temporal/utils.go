@@ -12,7 +12,8 @@ import (
 	"go.temporal.io/sdk/client"
 )
 
-// buildExecutionReqForSync builds the ExecutionRequest for a sync job
+// buildExecutionReqForSync builds the ExecutionRequest for a sync job, with the run timeout
+// of the job's execution policy and the resources of its driver container
 func buildExecutionReqForSync(job *models.Job, workflowID string) *ExecutionRequest {
 	args := []string{
 		"sync",
@@ -22,7 +23,7 @@ func buildExecutionReqForSync(job *models.Job, workflowID string) *ExecutionRequ
 		"--state", "/mnt/config/state.json",
 	}
 
-	return &ExecutionRequest{
+	req := &ExecutionRequest{
 		Command:       Sync,
 		ConnectorType: job.Source.Type,
 		Version:       job.Source.Version,
@@ -31,9 +32,11 @@ func buildExecutionReqForSync(job *models.Job, workflowID string) *ExecutionRequ
 		WorkflowID:    workflowID,
 		JobID:         job.ID,
 		ProjectID:     job.ProjectID,
-		Timeout:       GetWorkflowTimeout(Sync),
+		Timeout:       JobExecutionPolicy(job).RunTimeout,
 		OutputFile:    "state.json",
 	}
+	setDriverContainer(req, job)
+	return req
 }
 
 // buildExecutionReqForClearDestination builds the ExecutionRequest for a clear-destination job
@@ -43,12 +46,9 @@ func buildExecutionReqForClearDestination(job *models.Job, workflowID, streamsCo
 		catalog = job.StreamsConfig
 	}
 
-	streamsDir := fmt.Sprintf("%s-%d", workflowID, time.Now().Unix())
-	relativePath := filepath.Join(streamsDir, "streams.json")
-	streamsPath := filepath.Join(constants.DefaultConfigDir, relativePath)
-
-	if err := utils.WriteFile(streamsPath, []byte(catalog), 0644); err != nil {
-		return nil, fmt.Errorf("failed to write streams config to file: %v", err)
+	relativePath, err := writeTempStreamsFile(workflowID, catalog)
+	if err != nil {
+		return nil, err
 	}
 
 	args := []string{
@@ -58,7 +58,7 @@ func buildExecutionReqForClearDestination(job *models.Job, workflowID, streamsCo
 		"--destination", "/mnt/config/destination.json",
 	}
 
-	return &ExecutionRequest{
+	req := &ExecutionRequest{
 		Command:       ClearDestination,
 		ConnectorType: job.Source.Type,
 		Version:       job.Source.Version,
@@ -70,7 +70,40 @@ func buildExecutionReqForClearDestination(job *models.Job, workflowID, streamsCo
 		Timeout:       GetWorkflowTimeout(ClearDestination),
 		OutputFile:    "state.json",
 		TempPath:      relativePath,
-	}, nil
+	}
+	setDriverContainer(req, job)
+	return req, nil
+}
+
+// buildExecutionReqForAdHocSync builds the ExecutionRequest for a one-off sync of a job. A
+// non-empty streamsConfig is written as a temporary streams file that the run uses instead of
+// the job's streams config; a timeout of 0 keeps the job's run timeout.
+func buildExecutionReqForAdHocSync(job *models.Job, workflowID, streamsConfig string, timeout time.Duration) (*ExecutionRequest, error) {
+	req := buildExecutionReqForSync(job, workflowID)
+	if timeout > 0 {
+		req.Timeout = timeout
+	}
+	if streamsConfig != "" {
+		relativePath, err := writeTempStreamsFile(workflowID, streamsConfig)
+		if err != nil {
+			return nil, err
+		}
+		req.TempPath = relativePath
+	}
+	return req, nil
+}
+
+// writeTempStreamsFile writes a streams config for a single run under the config directory and
+// returns its path relative to it
+func writeTempStreamsFile(workflowID, streamsConfig string) (string, error) {
+	streamsDir := fmt.Sprintf("%s-%d", workflowID, time.Now().Unix())
+	relativePath := filepath.Join(streamsDir, "streams.json")
+	streamsPath := filepath.Join(constants.DefaultConfigDir, relativePath)
+
+	if err := utils.WriteFile(streamsPath, []byte(streamsConfig), 0644); err != nil {
+		return "", fmt.Errorf("failed to write streams config to file: %v", err)
+	}
+	return relativePath, nil
 }
 
 // extractWorkflowResponse extracts and parses the JSON response from a workflow execution result
@@ -104,9 +137,9 @@ func GetWorkflowTimeout(op Command) time.Duration {
 	case Spec:
 		return time.Minute * 5
 	case Sync:
-		return time.Hour * 24 * 30
+		return constants.DefaultSyncTimeout
 	case ClearDestination:
-		return time.Hour * 24 * 30
+		return constants.DefaultSyncTimeout
 	// check what can the fallback time be
 	default:
 		return time.Minute * 5
@@ -0,0 +1,126 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+	"github.com/datazip-inc/olake-ui/server/internal/models"
+	"github.com/datazip-inc/olake-ui/server/internal/models/dto"
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+var workerPoolNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// parseWorkerPools reads the worker pools configured as comma separated name=task-queue
+// pairs. The default pool is always there and polls the default task queue.
+func parseWorkerPools(value, defaultQueue string) (map[string]string, error) {
+	pools := map[string]string{constants.DefaultWorkerPool: defaultQueue}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, queue, ok := strings.Cut(pair, "=")
+		name, queue = strings.TrimSpace(name), strings.TrimSpace(queue)
+		switch {
+		case !ok || queue == "":
+			return nil, fmt.Errorf("invalid worker pool '%s', expected name=task-queue", pair)
+		case !workerPoolNamePattern.MatchString(name):
+			return nil, fmt.Errorf("invalid worker pool name '%s', use lowercase letters, digits and dashes", name)
+		case name == constants.DefaultWorkerPool:
+			return nil, fmt.Errorf("worker pool '%s' is the default task queue and cannot be configured", name)
+		}
+		if _, ok := pools[name]; ok {
+			return nil, fmt.Errorf("worker pool '%s' is configured twice", name)
+		}
+		pools[name] = queue
+	}
+	return pools, nil
+}
+
+// JobWorkerPool returns the worker pool the runs of a job go to: the pool of its advanced
+// settings, else the pool of its source, else the default pool
+func JobWorkerPool(job *models.Job) string {
+	if settings, ok := jobAdvancedSettings(job); ok && settings.WorkerPool != "" {
+		return settings.WorkerPool
+	}
+	if job != nil && job.Source != nil && job.Source.WorkerPool != "" {
+		return job.Source.WorkerPool
+	}
+	return constants.DefaultWorkerPool
+}
+
+// PoolTaskQueue returns the task queue of a worker pool, the default one for an empty name
+func (t *Temporal) PoolTaskQueue(pool string) (string, error) {
+	if pool == "" {
+		pool = constants.DefaultWorkerPool
+	}
+	queue, ok := t.pools[pool]
+	if !ok {
+		return "", fmt.Errorf("%w: '%s'", constants.ErrUnknownWorkerPool, pool)
+	}
+	return queue, nil
+}
+
+// CheckWorkerPool checks that a worker pool is configured and that workers poll its task
+// queue. The default pool is not checked, its workers may come up after the server.
+func (t *Temporal) CheckWorkerPool(ctx context.Context, pool string) error {
+	queue, err := t.PoolTaskQueue(pool)
+	if err != nil || pool == "" || pool == constants.DefaultWorkerPool {
+		return err
+	}
+	pollers, err := t.countPollers(ctx, queue)
+	if err != nil {
+		return err
+	}
+	if pollers == 0 {
+		return fmt.Errorf("%w: worker pool '%s' task queue[%s]", constants.ErrNoWorkerPollers, pool, queue)
+	}
+	return nil
+}
+
+// poolTaskQueue returns the checked task queue of a worker pool, see CheckWorkerPool
+func (t *Temporal) poolTaskQueue(ctx context.Context, pool string) (string, error) {
+	if err := t.CheckWorkerPool(ctx, pool); err != nil {
+		return "", err
+	}
+	return t.PoolTaskQueue(pool)
+}
+
+// jobTaskQueue returns the task queue of the worker pool of a job
+func (t *Temporal) jobTaskQueue(job *models.Job) (string, error) {
+	return t.PoolTaskQueue(JobWorkerPool(job))
+}
+
+func (t *Temporal) countPollers(ctx context.Context, queue string) (int, error) {
+	resp, err := t.Client.DescribeTaskQueue(ctx, queue, enumspb.TASK_QUEUE_TYPE_WORKFLOW)
+	if err != nil {
+		return 0, fmt.Errorf("failed to describe task queue[%s]: %s", queue, err)
+	}
+	return len(resp.Pollers), nil
+}
+
+// WorkerPools lists the configured worker pools with the number of workers polling each
+func (t *Temporal) WorkerPools(ctx context.Context) []dto.WorkerPoolItem {
+	names := make([]string, 0, len(t.pools))
+	for name := range t.pools {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	items := make([]dto.WorkerPoolItem, 0, len(names))
+	for _, name := range names {
+		item := dto.WorkerPoolItem{Name: name, TaskQueue: t.pools[name], Default: name == constants.DefaultWorkerPool}
+		pollers, err := t.countPollers(ctx, item.TaskQueue)
+		if err != nil {
+			item.Error = err.Error()
+		}
+		item.Pollers = pollers
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,265 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression: minute, hour, day of month, month and day of
+// week, optionally preceded by a second and followed by a year. As in standard cron, when
+// both the day of month and the day of week are restricted a time matches if either of them
+// matches.
+type CronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	// years are the years the schedule fires in, any year when nil
+	years            map[int]bool
+	domStar, dowStar bool
+	// bothDays makes a time match only if the day of month and the day of week both match,
+	// as temporal reads such expressions
+	bothDays bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// day of week accepts 7 as an alias for Sunday
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+	cronYear = cronField{name: "year", min: 1970, max: 2099}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a cron expression or one of the @yearly, @monthly, @weekly, @daily and
+// @hourly macros. As temporal reads them, 5 fields are minute, hour, day of month, month and
+// day of week, 6 fields add a year and 7 fields add a second before and a year after. The
+// second defaults to 0 and the year to any year.
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	second, year := "0", "*"
+	switch len(fields) {
+	case 5:
+	case 6:
+		year, fields = fields[5], fields[:5]
+	case 7:
+		second, year, fields = fields[0], fields[6], fields[1:6]
+	default:
+		return nil, fmt.Errorf("cron expression '%s' must have 5 fields (minute hour day-of-month month day-of-week), 6 with a year or 7 with a second and a year, got %d", expr, len(fields))
+	}
+
+	var (
+		schedule CronSchedule
+		err      error
+	)
+	if schedule.second, err = parseCronField(second, cronSecond); err != nil {
+		return nil, err
+	}
+	if schedule.minute, err = parseCronField(fields[0], cronMinute); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], cronHour); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = parseCronField(fields[2], cronDom); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseCronField(fields[3], cronMonth); err != nil {
+		return nil, err
+	}
+	if schedule.dow, err = parseCronField(fields[4], cronDow); err != nil {
+		return nil, err
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	if year != "*" && year != "?" {
+		schedule.years = make(map[int]bool)
+		if err := eachCronValue(year, cronYear, func(v int) { schedule.years[v] = true }); err != nil {
+			return nil, err
+		}
+	}
+	schedule.domStar = fields[2] == "*" || fields[2] == "?"
+	schedule.dowStar = fields[4] == "*" || fields[4] == "?"
+	return &schedule, nil
+}
+
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var bits uint64
+	err := eachCronValue(field, spec, func(v int) { bits |= 1 << uint(v) })
+	return bits, err
+}
+
+// eachCronValue calls add with every value a cron field selects
+func eachCronValue(field string, spec cronField, add func(int)) error {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid step '%s' in cron %s field '%s'", stepPart, spec.name, field)
+			}
+			step = n
+		}
+
+		start, end := spec.min, spec.max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			lo, hi, _ := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseCronValue(lo, spec); err != nil {
+				return err
+			}
+			if end, err = parseCronValue(hi, spec); err != nil {
+				return err
+			}
+			if start > end {
+				return fmt.Errorf("invalid range '%s' in cron %s field", rangePart, spec.name)
+			}
+		default:
+			value, err := parseCronValue(rangePart, spec)
+			if err != nil {
+				return err
+			}
+			start = value
+			if !hasStep {
+				end = value
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			add(v)
+		}
+	}
+	return nil
+}
+
+func parseCronValue(value string, spec cronField) (int, error) {
+	if n, ok := spec.names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < spec.min || n > spec.max {
+		return 0, fmt.Errorf("invalid value '%s' in cron %s field, expected %d-%d", value, spec.name, spec.min, spec.max)
+	}
+	return n, nil
+}
+
+// Matches reports whether t, truncated to the second, is a fire time of the schedule.
+func (c *CronSchedule) Matches(t time.Time) bool {
+	return c.second&(1<<uint(t.Second())) != 0 && c.minuteMatches(t)
+}
+
+func (c *CronSchedule) minuteMatches(t time.Time) bool {
+	return c.minute&(1<<uint(t.Minute())) != 0 &&
+		c.hour&(1<<uint(t.Hour())) != 0 &&
+		c.month&(1<<uint(t.Month())) != 0 &&
+		c.yearMatches(t) &&
+		c.dayMatches(t)
+}
+
+func (c *CronSchedule) yearMatches(t time.Time) bool {
+	return c.years == nil || c.years[t.Year()]
+}
+
+func (c *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar || c.bothDays {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Next returns the first fire time strictly after t in t's location, or the zero time if
+// the schedule never fires within the next five years (e.g. February 30th).
+//
+// Fire times are matched against the wall clock, as temporal does: a fire time skipped by a
+// DST change does not fire and one repeated by a DST change fires twice.
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	minute := t.Truncate(time.Minute)
+	if c.minuteMatches(minute) {
+		for sec := t.Second() + 1; sec < 60; sec++ {
+			if c.second&(1<<uint(sec)) != 0 {
+				return minute.Add(time.Duration(sec) * time.Second)
+			}
+		}
+	}
+	next := c.nextMinute(minute)
+	if next.IsZero() {
+		return next
+	}
+	for sec := 0; sec < 60; sec++ {
+		if c.second&(1<<uint(sec)) != 0 {
+			return next.Add(time.Duration(sec) * time.Second)
+		}
+	}
+	return time.Time{}
+}
+
+// nextMinute returns the first minute strictly after t, a whole minute, that the schedule
+// fires in
+func (c *CronSchedule) nextMinute(t time.Time) time.Time {
+	t = t.Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !c.yearMatches(t):
+			t = wallClockAfter(t, t.Year()+1, time.January, 1, 0)
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = wallClockAfter(t, t.Year(), t.Month()+1, 1, 0)
+		case !c.dayMatches(t):
+			t = wallClockAfter(t, t.Year(), t.Month(), t.Day()+1, 0)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = wallClockAfter(t, t.Year(), t.Month(), t.Day(), t.Hour()+1)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// wallClockAfter returns the start of the given hour in t's location, a wall clock time later
+// than t's. An hour skipped by a DST change starts at the end of the gap; time.Date would
+// place it before the gap, which could be t itself.
+func wallClockAfter(t time.Time, year int, month time.Month, day, hour int) time.Time {
+	next := time.Date(year, month, day, hour, 0, 0, 0, t.Location())
+	if next.After(t) {
+		return next
+	}
+	_, offset := t.Zone()
+	return time.Date(year, month, day, hour, 0, 0, 0, time.FixedZone("", offset)).In(t.Location())
+}
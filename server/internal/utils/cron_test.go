@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		// from and want are UTC times, want holds up to the first three fire times
+		from string
+		want []string
+	}{
+		{
+			name: "five fields fire on second 0",
+			expr: "*/20 * * * *",
+			from: "2025-03-10 07:10:30",
+			want: []string{"2025-03-10 07:20:00", "2025-03-10 07:40:00", "2025-03-10 08:00:00"},
+		},
+		{
+			name: "six fields end with a year",
+			expr: "0 12 1 * * 2027",
+			from: "2025-03-10 00:00:00",
+			want: []string{"2027-01-01 12:00:00", "2027-02-01 12:00:00", "2027-03-01 12:00:00"},
+		},
+		{
+			name: "year range",
+			expr: "0 0 1 1 * 2026-2027",
+			from: "2025-03-10 00:00:00",
+			want: []string{"2026-01-01 00:00:00", "2027-01-01 00:00:00"},
+		},
+		{
+			name: "year in the past never fires",
+			expr: "0 0 * * * 2020",
+			from: "2025-03-10 00:00:00",
+			want: []string{},
+		},
+		{
+			name: "seven fields start with a second",
+			expr: "30 0 6 * * * *",
+			from: "2025-03-10 06:00:30",
+			want: []string{"2025-03-11 06:00:30", "2025-03-12 06:00:30", "2025-03-13 06:00:30"},
+		},
+		{
+			name: "several seconds in a minute",
+			expr: "0,30 15 * * * * *",
+			from: "2025-03-10 07:15:00",
+			want: []string{"2025-03-10 07:15:30", "2025-03-10 08:15:00", "2025-03-10 08:15:30"},
+		},
+		{
+			name: "seven fields with a year",
+			expr: "15 45 23 31 12 * 2025",
+			from: "2025-03-10 00:00:00",
+			want: []string{"2025-12-31 23:45:15"},
+		},
+		{
+			name: "macro",
+			expr: "@hourly",
+			from: "2025-03-10 07:59:59",
+			want: []string{"2025-03-10 08:00:00", "2025-03-10 09:00:00", "2025-03-10 10:00:00"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+
+			at, err := time.Parse(time.DateTime, tt.from)
+			require.NoError(t, err)
+			times := []string{}
+			for range 3 {
+				if at = cron.Next(at); at.IsZero() {
+					break
+				}
+				require.True(t, cron.Matches(at))
+				times = append(times, at.Format(time.DateTime))
+			}
+			require.Equal(t, tt.want, times)
+		})
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		err  string
+	}{
+		{"too few fields", "* * * *", "got 4"},
+		{"too many fields", "0 0 0 * * * * *", "got 8"},
+		{"second out of range", "60 0 0 * * * *", "second field"},
+		{"year out of range", "0 0 * * * 2100", "year field"},
+		{"reversed year range", "0 0 * * * 2030-2026", "invalid range '2030-2026' in cron year field"},
+		{"quartz last day", "0 0 L * *", "day of month field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
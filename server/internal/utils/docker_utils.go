@@ -58,6 +58,13 @@ var ignoredWorkerEnv = map[string]any{ // A map is chosen because it gives O(1)
 	"TEMPORAL_API_KEY":        nil,
 	"TEMPORAL_EXTERNAL":       nil,
 	"TEMPORAL_TASK_QUEUE":     nil,
+	"TEMPORAL_WORKER_POOLS":   nil,
+	"DRIVER_CPU_REQUEST":      nil,
+	"DRIVER_CPU_LIMIT":        nil,
+	"DRIVER_MEMORY_REQUEST":   nil,
+	"DRIVER_MEMORY_LIMIT":     nil,
+	"DRIVER_MAX_CPU":          nil,
+	"DRIVER_MAX_MEMORY":       nil,
 	"OLAKE_SECRET_KEY":        nil,
 	"_":                       nil,
 }
@@ -46,6 +46,10 @@ func BindAndValidate(c *gin.Context, target interface{}) error {
 	return c.ShouldBindJSON(target)
 }
 
+func BindQuery(c *gin.Context, target interface{}) error {
+	return c.ShouldBindQuery(target)
+}
+
 func StatusFromBindError(err error) int {
 	var mbe *http.MaxBytesError
 	if errors.As(err, &mbe) {
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// memoryUnits are the suffixes of kubernetes memory quantities, binary ones first so that
+// "Mi" is not read as "M"
+var memoryUnits = []struct {
+	suffix string
+	bytes  float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40},
+	{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12},
+}
+
+// ParseCPUQuantity reads a kubernetes cpu quantity such as "500m", "0.5" or "2" as millicores
+func ParseCPUQuantity(value string) (int64, error) {
+	number, scale := value, 1000.0
+	if trimmed, ok := strings.CutSuffix(value, "m"); ok {
+		number, scale = trimmed, 1
+	}
+	return parseQuantity(value, number, scale)
+}
+
+// ParseMemoryQuantity reads a kubernetes memory quantity such as "512Mi", "4Gi" or "1G" as bytes
+func ParseMemoryQuantity(value string) (int64, error) {
+	number, scale := value, 1.0
+	for _, unit := range memoryUnits {
+		if trimmed, ok := strings.CutSuffix(value, unit.suffix); ok {
+			number, scale = trimmed, unit.bytes
+			break
+		}
+	}
+	return parseQuantity(value, number, scale)
+}
+
+func parseQuantity(value, number string, scale float64) (int64, error) {
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil || n <= 0 || math.IsInf(n, 0) || strings.ContainsAny(number, "eE+-") {
+		return 0, fmt.Errorf("invalid quantity '%s'", value)
+	}
+	return int64(math.Ceil(n * scale)), nil
+}
@@ -0,0 +1,505 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+)
+
+// Schedule is a parsed job frequency. A frequency is one of:
+//   - "manual" or "once", which have no spec;
+//   - a cron expression of 5 to 7 fields (see ParseCron) or @yearly, @monthly, @weekly,
+//     @daily or @hourly;
+//   - an interval, "every N minutes|hours|days|weeks", where days may add "at HH:MM" and
+//     weeks "on <day>" and "at HH:MM"; a single day or week with either is a calendar;
+//   - a calendar, "[daily|weekdays|weekends|<days>] at HH:MM[,HH:MM...]", e.g.
+//     "weekdays at 02:30" or "mon,wed,fri at 06:00,18:00";
+//   - the legacy "N-minutes", "N-hours", "N-days", "N-weeks", "N-months" and "1-years".
+//
+// Any frequency but manual and once may end with "jitter <duration>", e.g. "jitter 10m",
+// to spread the runs of many jobs over that much time after each fire time.
+type Schedule struct {
+	// Frequency is the frequency the schedule was parsed from
+	Frequency string
+	// TimeZone is the IANA time zone the schedule fires in, UTC when empty
+	TimeZone string
+	// Mode is manual, once, cron, interval or calendar
+	Mode string
+	// Cron is the normalized expression of a cron schedule
+	Cron string
+	// Every and Offset define an interval schedule: it fires at the times since the Unix
+	// epoch that are Offset past a multiple of Every
+	Every  time.Duration
+	Offset time.Duration
+	// Days and Times define a calendar schedule, firing on the given days of the week
+	// (0 is Sunday), all days if none are given, at each of the given times of day
+	Days   []int
+	Times  []ClockTime
+	Jitter time.Duration
+	// Blackouts are the maintenance windows the schedule does not fire in, see Exclude
+	Blackouts []Blackout
+	// Deprecated tells why a frequency saved by an earlier release would be refused as new
+	// input. Such a frequency keeps running as temporal reads it.
+	Deprecated string
+
+	crons    []*CronSchedule
+	location *time.Location
+}
+
+// ClockTime is a time of day
+type ClockTime struct {
+	Hour, Minute int
+}
+
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", c.Hour, c.Minute)
+}
+
+var (
+	weekdayNames  = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+	intervalUnits = map[string]time.Duration{
+		"minute": time.Minute, "minutes": time.Minute,
+		"hour": time.Hour, "hours": time.Hour,
+		"day": 24 * time.Hour, "days": 24 * time.Hour,
+		"week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+	}
+)
+
+// IsUnscheduledFrequency reports whether a frequency is the manual or once mode, whose
+// schedule has no spec and only runs when triggered
+func IsUnscheduledFrequency(frequency string) bool {
+	return frequency == constants.FrequencyManual || frequency == constants.FrequencyOnce
+}
+
+// ScheduleMode returns the mode of a job frequency: manual, once, cron, interval or calendar.
+// A frequency that does not parse is reported as cron.
+func ScheduleMode(frequency string) string {
+	schedule, err := ParseFrequency(frequency, "")
+	if err != nil {
+		return constants.ScheduleModeCron
+	}
+	return schedule.Mode
+}
+
+// ValidateFrequency checks that a new job frequency parses, see Schedule. Unlike
+// ParseFrequency it refuses deprecated frequencies.
+func ValidateFrequency(frequency string) error {
+	_, err := parseFrequencyIn(frequency, "", true)
+	return err
+}
+
+// ParseFrequency parses a job frequency, see Schedule. The times of day of interval
+// schedules are read in the standard time of timeZone, UTC if it is empty. Deprecated
+// frequencies, which earlier releases saved, are accepted with Schedule.Deprecated set.
+// Errors wrap constants.ErrInvalidFrequency.
+func ParseFrequency(frequency, timeZone string) (*Schedule, error) {
+	return parseFrequencyIn(frequency, timeZone, false)
+}
+
+func parseFrequencyIn(frequency, timeZone string, strict bool) (*Schedule, error) {
+	schedule, err := parseFrequency(frequency, timeZone, strict)
+	if err != nil {
+		return nil, fmt.Errorf("%w '%s': %s", constants.ErrInvalidFrequency, frequency, err)
+	}
+	schedule.Frequency, schedule.TimeZone = frequency, timeZone
+	return schedule, nil
+}
+
+func parseFrequency(frequency, timeZone string, strict bool) (*Schedule, error) {
+	tokens := strings.Fields(strings.ToLower(frequency))
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("frequency is empty")
+	}
+	if len(tokens) == 1 && IsUnscheduledFrequency(tokens[0]) {
+		return &Schedule{Mode: tokens[0]}, nil
+	}
+
+	var jitter time.Duration
+	if n := len(tokens); n >= 2 && tokens[n-2] == "jitter" {
+		var err error
+		if jitter, err = time.ParseDuration(tokens[n-1]); err != nil || jitter <= 0 {
+			return nil, fmt.Errorf("jitter '%s' must be a positive duration such as 30s or 10m", tokens[n-1])
+		}
+		if jitter > constants.MaxScheduleJitter {
+			return nil, fmt.Errorf("jitter must be at most %s", constants.MaxScheduleJitter)
+		}
+		tokens = tokens[:n-2]
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("jitter needs a schedule to apply to")
+		}
+	}
+
+	var (
+		schedule *Schedule
+		err      error
+	)
+	switch {
+	case len(tokens) == 1 && strings.Contains(tokens[0], "-") && !strings.ContainsAny(tokens[0], "*/,"):
+		schedule, err = parseLegacyFrequency(tokens[0], timeZone, strict)
+	case tokens[0] == "every":
+		schedule, err = parseInterval(tokens[1:], timeZone, strict)
+	case slices.Contains(tokens, "at"):
+		schedule, err = parseCalendar(tokens)
+	default:
+		schedule, err = parseCronFrequency(strings.Join(tokens, " "), strict)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if jitter > 0 {
+		if gap := schedule.shortestGap(); jitter >= gap {
+			return nil, fmt.Errorf("jitter %s must be shorter than the %s between runs", jitter, gap)
+		}
+		schedule.Jitter = jitter
+	}
+	return schedule, nil
+}
+
+// parseLegacyFrequency reads the "N-unit" frequencies of earlier releases. They keep their
+// cron form, except that more than one day or week becomes an interval, so that "2-weeks"
+// really fires every other week. Earlier releases saved minutes over 59 and hours over 23,
+// which only fire at the start of each hour or day; they are deprecated unless strict.
+func parseLegacyFrequency(frequency, timeZone string, strict bool) (*Schedule, error) {
+	valueStr, unit, _ := strings.Cut(frequency, "-")
+	value, err := strconv.Atoi(valueStr)
+	if err != nil || value <= 0 {
+		return nil, fmt.Errorf("'%s' must be a positive number", valueStr)
+	}
+
+	switch unit {
+	case "minutes":
+		if value > 59 {
+			return deprecatedLegacyFrequency(fmt.Sprintf("*/%d * * * *", value), fmt.Sprintf("use 'every %d minutes' for more than 59 minutes", value), strict)
+		}
+		return parseCronFrequency(fmt.Sprintf("*/%d * * * *", value), true)
+	case "hours":
+		if value > 23 {
+			return deprecatedLegacyFrequency(fmt.Sprintf("0 */%d * * *", value), fmt.Sprintf("use 'every %d hours' for more than 23 hours", value), strict)
+		}
+		return parseCronFrequency(fmt.Sprintf("0 */%d * * *", value), true)
+	case "days":
+		if value == 1 {
+			return parseCronFrequency("0 0 * * *", true)
+		}
+		return parseInterval([]string{valueStr, unit}, timeZone, strict)
+	case "weeks":
+		if value == 1 {
+			return parseCronFrequency("0 0 * * 0", true)
+		}
+		return parseInterval([]string{valueStr, unit}, timeZone, strict)
+	case "months":
+		if value > 12 {
+			return nil, fmt.Errorf("months must be between 1 and 12")
+		}
+		return parseCronFrequency(fmt.Sprintf("0 0 1 */%d *", value), true)
+	case "years":
+		if value != 1 {
+			return nil, fmt.Errorf("every %d years cannot be scheduled, use '1-years' or a cron expression", value)
+		}
+		return parseCronFrequency("0 0 1 1 *", true)
+	default:
+		return nil, fmt.Errorf("unknown unit '%s', expected minutes, hours, days, weeks, months or years", unit)
+	}
+}
+
+// deprecatedLegacyFrequency refuses a legacy frequency for the given reason when strict, and
+// otherwise keeps the cron expression earlier releases scheduled it with
+func deprecatedLegacyFrequency(expr, reason string, strict bool) (*Schedule, error) {
+	if strict {
+		return nil, fmt.Errorf("%s", reason)
+	}
+	schedule, err := parseCronFrequency(expr, true)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Deprecated = reason
+	return schedule, nil
+}
+
+// parseInterval reads "[N] unit [on day] [at HH:MM]", after "every". Days start at midnight
+// and weeks on Sunday at midnight unless told otherwise. A single day or week on a given day
+// or time becomes a calendar, so that it keeps its wall-clock time over DST changes. More
+// days or weeks at a given time are an interval, which counts absolute time and so fires an
+// hour off for half the year; they are refused when strict and deprecated otherwise.
+func parseInterval(tokens []string, timeZone string, strict bool) (*Schedule, error) {
+	count := 1
+	if len(tokens) > 0 {
+		if n, err := strconv.Atoi(tokens[0]); err == nil {
+			if n <= 0 || n > constants.MaxScheduleEvery {
+				return nil, fmt.Errorf("interval count must be between 1 and %d", constants.MaxScheduleEvery)
+			}
+			count = n
+			tokens = tokens[1:]
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("interval needs a unit: minutes, hours, days or weeks")
+	}
+	unitName := tokens[0]
+	unit, ok := intervalUnits[unitName]
+	if !ok {
+		return nil, fmt.Errorf("unknown interval unit '%s', expected minutes, hours, days or weeks", unitName)
+	}
+	tokens = tokens[1:]
+
+	day, at := 0, ClockTime{}
+	var onDay, atTime bool
+	for len(tokens) > 0 {
+		if len(tokens) < 2 {
+			return nil, fmt.Errorf("'%s' needs a value", tokens[0])
+		}
+		switch {
+		case tokens[0] == "on" && unit == 7*24*time.Hour:
+			days, err := parseDays(tokens[1])
+			if err != nil {
+				return nil, err
+			}
+			if len(days) != 1 {
+				return nil, fmt.Errorf("a weekly interval runs on a single day, got '%s'", tokens[1])
+			}
+			day, onDay = days[0], true
+		case tokens[0] == "at" && unit >= 24*time.Hour:
+			times, err := parseTimes(tokens[1])
+			if err != nil {
+				return nil, err
+			}
+			if len(times) != 1 {
+				return nil, fmt.Errorf("an interval runs at a single time of day, got '%s'", tokens[1])
+			}
+			at, atTime = times[0], true
+		default:
+			return nil, fmt.Errorf("unexpected '%s', only day intervals take 'at' and week intervals 'on' and 'at'", strings.Join(tokens, " "))
+		}
+		tokens = tokens[2:]
+	}
+
+	var deprecated string
+	if count == 1 && (onDay || atTime) {
+		var days []int
+		if unit == 7*24*time.Hour {
+			days = []int{day}
+		}
+		return newCalendar(days, []ClockTime{at})
+	}
+	if atTime {
+		reason := fmt.Sprintf("'every %d %s at %s' keeps standard time and fires an hour off during DST, use a calendar or cron expression", count, unitName, at)
+		if strict {
+			return nil, fmt.Errorf("%s", reason)
+		}
+		deprecated = reason
+	}
+
+	every := time.Duration(count) * unit
+	offset := time.Duration(0)
+	if unit >= 24*time.Hour {
+		// the epoch fell on a Thursday; times of day are taken in standard time, as an
+		// interval counts absolute time and does not follow DST changes
+		loc, err := time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone '%s'", timeZone)
+		}
+		offset = time.Duration(at.Hour)*time.Hour + time.Duration(at.Minute)*time.Minute - standardOffset(loc)
+		if unit == 7*24*time.Hour {
+			offset += time.Duration((day-int(time.Thursday)+7)%7) * 24 * time.Hour
+		}
+		offset = ((offset % every) + every) % every
+	}
+	return &Schedule{Mode: constants.ScheduleModeInterval, Every: every, Offset: offset, Deprecated: deprecated}, nil
+}
+
+// parseCalendar reads "[days] at HH:MM[,HH:MM...]"
+func parseCalendar(tokens []string) (*Schedule, error) {
+	idx := slices.Index(tokens, "at")
+	if idx > 1 || len(tokens) != idx+2 {
+		return nil, fmt.Errorf("expected '[days] at HH:MM', e.g. 'weekdays at 02:30'")
+	}
+
+	var days []int
+	if idx == 1 && tokens[0] != "daily" {
+		var err error
+		if days, err = parseDays(tokens[0]); err != nil {
+			return nil, err
+		}
+	}
+	times, err := parseTimes(tokens[idx+1])
+	if err != nil {
+		return nil, err
+	}
+	return newCalendar(days, times)
+}
+
+// newCalendar returns a calendar schedule firing on the given days of the week, all days if
+// none or all are given, at each of the given times of day
+func newCalendar(days []int, times []ClockTime) (*Schedule, error) {
+	schedule := &Schedule{Mode: constants.ScheduleModeCalendar, Days: days, Times: times}
+	dow := "*"
+	if len(days) > 0 && len(days) < 7 {
+		parts := make([]string, 0, len(days))
+		for _, d := range days {
+			parts = append(parts, strconv.Itoa(d))
+		}
+		dow = strings.Join(parts, ",")
+	} else {
+		schedule.Days = nil
+	}
+	for _, t := range times {
+		cron, err := ParseCron(fmt.Sprintf("%d %d * * %s", t.Minute, t.Hour, dow))
+		if err != nil {
+			return nil, err
+		}
+		schedule.crons = append(schedule.crons, cron)
+	}
+	return schedule, nil
+}
+
+// parseCronFrequency validates a cron expression. Temporal requires both the day of month
+// and the day of week to match where standard cron requires either, so restricting both is
+// refused when strict rather than run differently than expected. Otherwise such a saved
+// expression is accepted as deprecated, with fire times as temporal computes them.
+func parseCronFrequency(expr string, strict bool) (*Schedule, error) {
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+	cron, err := ParseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	schedule := &Schedule{Mode: constants.ScheduleModeCron, Cron: expr, crons: []*CronSchedule{cron}}
+	if !cron.domStar && !cron.dowStar {
+		reason := fmt.Sprintf("cron expression '%s' restricts both the day of month and the day of week, restrict only one of them", expr)
+		if strict {
+			return nil, fmt.Errorf("%s", reason)
+		}
+		cron.bothDays = true
+		schedule.Deprecated = reason
+	}
+	return schedule, nil
+}
+
+// parseDays reads "weekdays", "weekends" or days of the week such as "mon,wed" or "mon-fri"
+func parseDays(value string) ([]int, error) {
+	switch value {
+	case "weekdays":
+		return []int{1, 2, 3, 4, 5}, nil
+	case "weekends":
+		return []int{0, 6}, nil
+	}
+
+	var days []int
+	for _, part := range strings.Split(value, ",") {
+		lo, hi, isRange := strings.Cut(part, "-")
+		start := slices.Index(weekdayNames, lo)
+		end := start
+		if isRange {
+			end = slices.Index(weekdayNames, hi)
+		}
+		if start < 0 || end < 0 || end < start {
+			return nil, fmt.Errorf("invalid days '%s', expected daily, weekdays, weekends or days such as mon,wed or mon-fri", value)
+		}
+		for d := start; d <= end; d++ {
+			if !slices.Contains(days, d) {
+				days = append(days, d)
+			}
+		}
+	}
+	slices.Sort(days)
+	return days, nil
+}
+
+// parseTimes reads times of day such as "02:30" or "06:00,18:00"
+func parseTimes(value string) ([]ClockTime, error) {
+	var times []ClockTime
+	for _, part := range strings.Split(value, ",") {
+		hourStr, minuteStr, ok := strings.Cut(part, ":")
+		hour, herr := strconv.Atoi(hourStr)
+		minute, merr := strconv.Atoi(minuteStr)
+		if !ok || herr != nil || merr != nil || len(minuteStr) != 2 || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+			return nil, fmt.Errorf("invalid time '%s', expected HH:MM between 00:00 and 23:59", part)
+		}
+		t := ClockTime{Hour: hour, Minute: minute}
+		if !slices.Contains(times, t) {
+			times = append(times, t)
+		}
+	}
+	if len(times) > constants.MaxScheduleTimes {
+		return nil, fmt.Errorf("at most %d times of day are allowed", constants.MaxScheduleTimes)
+	}
+	slices.SortFunc(times, func(a, b ClockTime) int { return (a.Hour*60 + a.Minute) - (b.Hour*60 + b.Minute) })
+	return times, nil
+}
+
+// standardOffset returns the offset of a time zone from UTC outside DST
+func standardOffset(loc *time.Location) time.Duration {
+	for _, month := range []time.Month{time.January, time.July} {
+		t := time.Date(2025, month, 1, 12, 0, 0, 0, loc)
+		if !t.IsDST() {
+			_, offset := t.Zone()
+			return time.Duration(offset) * time.Second
+		}
+	}
+	_, offset := time.Date(2025, time.January, 1, 12, 0, 0, 0, loc).Zone()
+	return time.Duration(offset) * time.Second
+}
+
+// Next returns the first fire time of the schedule strictly after t, in t's location, before
+// jitter, that does not fall into a blackout. Cron and calendar schedules fire on the wall
+// clock of that location, see CronSchedule.Next. Manual and once schedules, and schedules
+// that never fire, return the zero time.
+func (s *Schedule) Next(t time.Time) time.Time {
+	next := s.next(t)
+	for range constants.MaxBlackoutSkips {
+		end := s.blackedOut(next)
+		if next.IsZero() || end.IsZero() {
+			return next
+		}
+		// the first fire time at or after the end of the blackout
+		next = s.next(end.Add(-time.Nanosecond).In(t.Location()))
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) next(t time.Time) time.Time {
+	if s.Mode == constants.ScheduleModeInterval {
+		every, offset := s.Every.Nanoseconds(), s.Offset.Nanoseconds()
+		n := t.UnixNano() - offset
+		k := n / every
+		if n < 0 && n%every != 0 {
+			k--
+		}
+		return time.Unix(0, (k+1)*every+offset).In(t.Location())
+	}
+
+	var next time.Time
+	for _, cron := range s.crons {
+		if at := cron.Next(t); !at.IsZero() && (next.IsZero() || at.Before(next)) {
+			next = at
+		}
+	}
+	return next
+}
+
+// shortestGap returns the shortest time between two fire times of the schedule, as seen
+// over the first runs of a year
+func (s *Schedule) shortestGap() time.Duration {
+	if s.Mode == constants.ScheduleModeInterval {
+		return s.Every
+	}
+	var gap time.Duration
+	prev := s.next(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+	for i := 0; i < 400 && !prev.IsZero(); i++ {
+		next := s.next(prev)
+		if next.IsZero() {
+			break
+		}
+		if d := next.Sub(prev); gap == 0 || d < gap {
+			gap = d
+		}
+		prev = next
+	}
+	return gap
+}
@@ -0,0 +1,378 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+)
+
+func TestParseFrequency(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency string
+		timeZone  string
+		mode      string
+		cron      string
+		every     time.Duration
+		jitter    time.Duration
+		// from is given on the wall clock of the time zone, want are the next fire times
+		// after it, in UTC
+		from string
+		want []string
+	}{
+		{
+			name:      "minute interval",
+			frequency: "every 15 minutes",
+			mode:      constants.ScheduleModeInterval,
+			every:     15 * time.Minute,
+			from:      "2025-03-10 10:07",
+			want:      []string{"2025-03-10 10:15", "2025-03-10 10:30"},
+		},
+		{
+			name:      "hour interval",
+			frequency: "every 2 hours",
+			mode:      constants.ScheduleModeInterval,
+			every:     2 * time.Hour,
+			from:      "2025-03-10 10:07",
+			want:      []string{"2025-03-10 12:00", "2025-03-10 14:00"},
+		},
+		{
+			name:      "single unit without count",
+			frequency: "every hour",
+			mode:      constants.ScheduleModeInterval,
+			every:     time.Hour,
+			from:      "2025-03-10 10:07",
+			want:      []string{"2025-03-10 11:00", "2025-03-10 12:00"},
+		},
+		{
+			name:      "single day interval at a time is a calendar",
+			frequency: "every 1 days at 02:30",
+			mode:      constants.ScheduleModeCalendar,
+			from:      "2025-03-10 03:00",
+			want:      []string{"2025-03-11 02:30", "2025-03-12 02:30"},
+		},
+		{
+			name:      "single week interval on a day is a calendar",
+			frequency: "every week on mon at 06:00",
+			mode:      constants.ScheduleModeCalendar,
+			from:      "2025-03-12 00:00",
+			want:      []string{"2025-03-17 06:00", "2025-03-24 06:00"},
+		},
+		{
+			name:      "calendar on weekdays",
+			frequency: "weekdays at 02:30",
+			mode:      constants.ScheduleModeCalendar,
+			from:      "2025-03-14 03:00",
+			want:      []string{"2025-03-17 02:30", "2025-03-18 02:30"},
+		},
+		{
+			name:      "calendar with days and times",
+			frequency: "mon,wed,fri at 06:00,18:00",
+			mode:      constants.ScheduleModeCalendar,
+			from:      "2025-03-10 07:00",
+			want:      []string{"2025-03-10 18:00", "2025-03-12 06:00"},
+		},
+		{
+			name:      "calendar without days runs daily",
+			frequency: "at 01:00",
+			mode:      constants.ScheduleModeCalendar,
+			from:      "2025-03-10 07:00",
+			want:      []string{"2025-03-11 01:00", "2025-03-12 01:00"},
+		},
+		{
+			name:      "cron expression",
+			frequency: "0 */6 * * *",
+			mode:      constants.ScheduleModeCron,
+			cron:      "0 */6 * * *",
+			from:      "2025-03-10 07:00",
+			want:      []string{"2025-03-10 12:00", "2025-03-10 18:00"},
+		},
+		{
+			name:      "daily macro",
+			frequency: "@daily",
+			mode:      constants.ScheduleModeCron,
+			cron:      "0 0 * * *",
+			from:      "2025-03-10 07:00",
+			want:      []string{"2025-03-11 00:00", "2025-03-12 00:00"},
+		},
+		{
+			name:      "weekly macro",
+			frequency: "@weekly",
+			mode:      constants.ScheduleModeCron,
+			cron:      "0 0 * * 0",
+			from:      "2025-03-10 07:00",
+			want:      []string{"2025-03-16 00:00", "2025-03-23 00:00"},
+		},
+		{
+			name:      "legacy minutes stay cron",
+			frequency: "30-minutes",
+			mode:      constants.ScheduleModeCron,
+			cron:      "*/30 * * * *",
+			from:      "2025-03-10 07:10",
+			want:      []string{"2025-03-10 07:30", "2025-03-10 08:00"},
+		},
+		{
+			name:      "legacy days above one become an interval",
+			frequency: "2-days",
+			mode:      constants.ScheduleModeInterval,
+			every:     48 * time.Hour,
+		},
+		{
+			name:      "legacy single week stays cron",
+			frequency: "1-weeks",
+			mode:      constants.ScheduleModeCron,
+			cron:      "0 0 * * 0",
+		},
+		{
+			name:      "legacy months",
+			frequency: "3-months",
+			mode:      constants.ScheduleModeCron,
+			cron:      "0 0 1 */3 *",
+			from:      "2025-02-15 00:00",
+			want:      []string{"2025-04-01 00:00", "2025-07-01 00:00"},
+		},
+		{
+			name:      "legacy year",
+			frequency: "1-years",
+			mode:      constants.ScheduleModeCron,
+			cron:      "0 0 1 1 *",
+		},
+		{
+			name:      "jitter",
+			frequency: "every 1 hours jitter 10m",
+			mode:      constants.ScheduleModeInterval,
+			every:     time.Hour,
+			jitter:    10 * time.Minute,
+		},
+		{
+			name:      "jitter on a cron expression",
+			frequency: "0 * * * * jitter 30s",
+			mode:      constants.ScheduleModeCron,
+			cron:      "0 * * * *",
+			jitter:    30 * time.Second,
+		},
+		{
+			name:      "frequency is case insensitive",
+			frequency: "Every 2 Hours",
+			mode:      constants.ScheduleModeInterval,
+			every:     2 * time.Hour,
+		},
+		{
+			name:      "manual",
+			frequency: "manual",
+			mode:      constants.FrequencyManual,
+			from:      "2025-03-10 07:00",
+			want:      []string{},
+		},
+		{
+			name:      "once",
+			frequency: "once",
+			mode:      constants.FrequencyOnce,
+		},
+		{
+			// 02:30 does not exist on the day clocks go forward and is skipped
+			name:      "calendar skips a time missing on the DST change",
+			frequency: "daily at 02:30",
+			timeZone:  "America/New_York",
+			mode:      constants.ScheduleModeCalendar,
+			from:      "2025-03-08 03:00",
+			want:      []string{"2025-03-10 06:30", "2025-03-11 06:30"},
+		},
+		{
+			// 01:30 happens twice on the day clocks go back and fires both times
+			name:      "calendar repeats a time doubled by the DST change",
+			frequency: "daily at 01:30",
+			timeZone:  "America/New_York",
+			mode:      constants.ScheduleModeCalendar,
+			from:      "2025-11-02 00:00",
+			want:      []string{"2025-11-02 05:30", "2025-11-02 06:30"},
+		},
+		{
+			// a single day at a time is a calendar, so 02:00 stays 02:00 in summer
+			name:      "single day interval at a time follows DST",
+			frequency: "every 1 days at 02:00",
+			timeZone:  "America/New_York",
+			mode:      constants.ScheduleModeCalendar,
+			from:      "2025-07-01 00:00",
+			want:      []string{"2025-07-01 06:00", "2025-07-02 06:00"},
+		},
+		{
+			name:      "week interval on a day at midnight",
+			frequency: "every 2 weeks on mon",
+			mode:      constants.ScheduleModeInterval,
+			every:     14 * 24 * time.Hour,
+			from:      "2025-03-12 00:00",
+			want:      []string{"2025-03-17 00:00", "2025-03-31 00:00"},
+		},
+		{
+			name:      "calendar in a time zone",
+			frequency: "weekdays at 09:00",
+			timeZone:  "Asia/Kolkata",
+			mode:      constants.ScheduleModeCalendar,
+			from:      "2025-03-14 10:00",
+			want:      []string{"2025-03-17 03:30", "2025-03-18 03:30"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseFrequency(tt.frequency, tt.timeZone)
+			require.NoError(t, err)
+			require.NoError(t, ValidateFrequency(tt.frequency))
+			require.Equal(t, tt.frequency, schedule.Frequency)
+			require.Equal(t, tt.mode, schedule.Mode)
+			require.Equal(t, tt.mode, ScheduleMode(tt.frequency))
+			require.Equal(t, tt.cron, schedule.Cron)
+			require.Equal(t, tt.every, schedule.Every)
+			require.Equal(t, tt.jitter, schedule.Jitter)
+			require.Empty(t, schedule.Deprecated)
+			if tt.want != nil {
+				require.Equal(t, tt.want, nextFireTimes(t, schedule, tt.timeZone, tt.from, len(tt.want)))
+			}
+		})
+	}
+}
+
+func TestParseFrequencyErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency string
+		timeZone  string
+		err       string
+	}{
+		{"empty", "  ", "", "frequency is empty"},
+		{"zero interval", "every 0 minutes", "", "interval count must be between 1 and 1000"},
+		{"interval too long", "every 1001 days", "", "interval count must be between 1 and 1000"},
+		{"interval without unit", "every 5", "", "interval needs a unit"},
+		{"unknown interval unit", "every 5 fortnights", "", "unknown interval unit 'fortnights'"},
+		{"day interval on a day", "every 2 days on mon", "", "unexpected 'on mon'"},
+		{"minute interval at a time", "every 30 minutes at 02:00", "", "unexpected 'at 02:00'"},
+		{"week interval on two days", "every 1 weeks on mon,tue", "", "a weekly interval runs on a single day"},
+		{"interval at two times", "every 1 days at 02:00,03:00", "", "an interval runs at a single time of day"},
+		{"interval option without value", "every 1 weeks on", "", "'on' needs a value"},
+		{"unknown day", "someday at 02:00", "", "invalid days 'someday'"},
+		{"reversed day range", "fri-mon at 02:00", "", "invalid days 'fri-mon'"},
+		{"time out of range", "daily at 24:00", "", "invalid time '24:00'"},
+		{"time without leading minute zero", "daily at 02:5", "", "invalid time '02:5'"},
+		{"calendar with extra tokens", "weekdays daily at 02:00", "", "expected '[days] at HH:MM'"},
+		{"invalid cron", "61 * * * *", "", "minute"},
+		{"cron with too few fields", "* * *", "", "must have 5 fields"},
+		{"cron with too many fields", "0 0 0 * * * 2030 x", "", "7 with a second and a year, got 8"},
+		{"cron year out of range", "0 0 * * * 1900", "", "year field"},
+		{"jitter without schedule", "jitter 10m", "", "jitter needs a schedule to apply to"},
+		{"negative jitter", "every 1 hours jitter -5m", "", "must be a positive duration"},
+		{"jitter too long", "every 1 days jitter 2h", "", "jitter must be at most 1h0m0s"},
+		{"jitter as long as the gap", "every 10 minutes jitter 10m", "", "must be shorter than the 10m0s between runs"},
+		{"legacy months above a year", "13-months", "", "months must be between 1 and 12"},
+		{"legacy years above one", "2-years", "", "every 2 years cannot be scheduled"},
+		{"legacy unknown unit", "5-fortnights", "", "unknown unit 'fortnights'"},
+		{"legacy non positive", "0-days", "", "'0' must be a positive number"},
+		{"unknown time zone", "every 1 days", "Mars/Olympus", "unknown time zone 'Mars/Olympus'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFrequency(tt.frequency, tt.timeZone)
+			require.Error(t, err)
+			require.True(t, errors.Is(err, constants.ErrInvalidFrequency))
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestParseFrequencyBothDayFields(t *testing.T) {
+	const frequency = "0 0 1 * 1"
+
+	// new input restricting both day fields is refused
+	err := ValidateFrequency(frequency)
+	require.True(t, errors.Is(err, constants.ErrInvalidFrequency))
+	require.Contains(t, err.Error(), "restricts both the day of month and the day of week")
+
+	// a saved frequency is accepted as deprecated and fires as temporal runs it, on days
+	// matching both fields: the first of a month that is a Monday
+	schedule, err := ParseFrequency(frequency, "")
+	require.NoError(t, err)
+	require.Equal(t, constants.ScheduleModeCron, schedule.Mode)
+	require.Contains(t, schedule.Deprecated, "restricts both the day of month and the day of week")
+	require.Equal(t, []string{"2025-09-01 00:00", "2025-12-01 00:00"}, nextFireTimes(t, schedule, "", "2025-03-10 00:00", 2))
+
+	// restricting either one is not deprecated
+	for _, frequency := range []string{"0 0 1 * *", "0 0 * * 1", "0 0 1 * ?"} {
+		schedule, err := ParseFrequency(frequency, "")
+		require.NoError(t, err)
+		require.NoError(t, ValidateFrequency(frequency))
+		require.Empty(t, schedule.Deprecated)
+	}
+}
+
+func TestParseFrequencyDeprecatedLegacy(t *testing.T) {
+	tests := []struct {
+		frequency string
+		cron      string
+		reason    string
+		want      []string
+	}{
+		// */75 only matches minute 0, so it fired hourly as earlier releases scheduled it
+		{"75-minutes", "*/75 * * * *", "use 'every 75 minutes'", []string{"2025-03-10 08:00", "2025-03-10 09:00"}},
+		{"36-hours", "0 */36 * * *", "use 'every 36 hours'", []string{"2025-03-11 00:00", "2025-03-12 00:00"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.frequency, func(t *testing.T) {
+			// new input is refused
+			err := ValidateFrequency(tt.frequency)
+			require.True(t, errors.Is(err, constants.ErrInvalidFrequency))
+			require.Contains(t, err.Error(), tt.reason)
+
+			// a saved frequency keeps its cron form and is deprecated
+			schedule, err := ParseFrequency(tt.frequency, "")
+			require.NoError(t, err)
+			require.Equal(t, tt.cron, schedule.Cron)
+			require.Contains(t, schedule.Deprecated, tt.reason)
+			require.Equal(t, tt.want, nextFireTimes(t, schedule, "", "2025-03-10 07:10", 2))
+		})
+	}
+}
+
+func TestParseFrequencyDeprecatedInterval(t *testing.T) {
+	const frequency = "every 2 days at 02:00"
+	const reason = "'every 2 days at 02:00' keeps standard time and fires an hour off during DST"
+
+	// new input is refused
+	err := ValidateFrequency(frequency)
+	require.True(t, errors.Is(err, constants.ErrInvalidFrequency))
+	require.Contains(t, err.Error(), reason)
+
+	// a saved frequency keeps running as an interval from standard time, so 02:00 is 03:00
+	// in summer, and is deprecated
+	schedule, err := ParseFrequency(frequency, "America/New_York")
+	require.NoError(t, err)
+	require.Equal(t, constants.ScheduleModeInterval, schedule.Mode)
+	require.Equal(t, 48*time.Hour, schedule.Every)
+	require.Contains(t, schedule.Deprecated, reason)
+	require.Equal(t, []string{"2025-07-01 07:00", "2025-07-03 07:00"}, nextFireTimes(t, schedule, "America/New_York", "2025-06-30 12:00", 2))
+}
+
+// nextFireTimes returns the first n fire times of a schedule after from, a wall clock time in
+// timeZone, in UTC
+func nextFireTimes(t *testing.T, schedule *Schedule, timeZone, from string, n int) []string {
+	t.Helper()
+	loc := time.UTC
+	if timeZone != "" {
+		var err error
+		loc, err = time.LoadLocation(timeZone)
+		require.NoError(t, err)
+	}
+	at, err := time.ParseInLocation("2006-01-02 15:04", from, loc)
+	require.NoError(t, err)
+
+	times := []string{}
+	for range n {
+		if at = schedule.Next(at); at.IsZero() {
+			break
+		}
+		times = append(times, at.UTC().Format("2006-01-02 15:04"))
+	}
+	return times
+}
@@ -0,0 +1,225 @@
+package utils
+
+import (
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
+)
+
+// Window is a maintenance window in which a schedule does not fire. A recurring window runs
+// every week on Days (0 is Sunday, every day when empty) from Start to End, past midnight
+// when End is not after Start, on the wall clock of TimeZone. A one-off window runs from From
+// to To.
+type Window struct {
+	Days       []int
+	Start, End ClockTime
+	TimeZone   string
+	From, To   time.Time
+}
+
+// Blackout is a span of the wall clock of a schedule's time zone in which it does not fire:
+// From to To minutes into the day, To exclusive, on every Weekday, or on Date when that is set.
+type Blackout struct {
+	Date     time.Time // year, month and day in UTC, zero for every week
+	Weekday  int
+	From, To int
+}
+
+const minutesPerDay = 24 * 60
+
+// ParseWindow reads a maintenance window: a recurring one from days ("daily", "weekdays",
+// "weekends" or days such as "mon,wed" or "mon-fri", every day when empty) and the start and
+// end times "HH:MM", or a one-off one from from and to. timeZone is the time zone of a
+// recurring window.
+func ParseWindow(days, start, end, timeZone string, from, to *time.Time) (Window, error) {
+	recurring := start != "" || end != "" || days != ""
+	switch {
+	case recurring && (from != nil || to != nil):
+		return Window{}, fmt.Errorf("a window is either recurring, with start_time and end_time, or one-off, with starts_at and ends_at")
+	case recurring:
+		if start == "" || end == "" {
+			return Window{}, fmt.Errorf("a recurring window needs start_time and end_time")
+		}
+		window := Window{TimeZone: timeZone}
+		if days != "" && days != "daily" {
+			var err error
+			if window.Days, err = parseDays(strings.ToLower(days)); err != nil {
+				return Window{}, err
+			}
+		}
+		times, err := parseTimes(start)
+		if err != nil || len(times) != 1 {
+			return Window{}, fmt.Errorf("invalid start_time '%s', expected HH:MM", start)
+		}
+		window.Start = times[0]
+		if times, err = parseTimes(end); err != nil || len(times) != 1 {
+			return Window{}, fmt.Errorf("invalid end_time '%s', expected HH:MM", end)
+		}
+		window.End = times[0]
+		if window.Start == window.End {
+			return Window{}, fmt.Errorf("start_time and end_time must differ")
+		}
+		return window, nil
+	case from == nil || to == nil:
+		return Window{}, fmt.Errorf("a one-off window needs starts_at and ends_at")
+	case !to.After(*from):
+		return Window{}, fmt.Errorf("ends_at must be after starts_at")
+	case to.Sub(*from) > constants.MaxMaintenanceWindowSpan:
+		return Window{}, fmt.Errorf("a one-off window lasts at most %s", constants.MaxMaintenanceWindowSpan)
+	default:
+		return Window{From: *from, To: *to}, nil
+	}
+}
+
+// Recurring reports whether the window repeats every week
+func (w Window) Recurring() bool {
+	return w.From.IsZero()
+}
+
+// Contains reports whether t lies within the window and, if so, when that occurrence of the
+// window ends
+func (w Window) Contains(t time.Time) (time.Time, bool) {
+	if !w.Recurring() {
+		return w.To, !t.Before(w.From) && t.Before(w.To)
+	}
+	loc, err := time.LoadLocation(w.TimeZone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	wall := t.In(loc)
+	minute := wall.Hour()*60 + wall.Minute()
+	start, end := w.Start.Hour*60+w.Start.Minute, w.End.Hour*60+w.End.Minute
+	onDay := func(day time.Weekday) bool {
+		return len(w.Days) == 0 || slices.Contains(w.Days, int(day))
+	}
+	switch {
+	case end > start && onDay(wall.Weekday()) && minute >= start && minute < end:
+		return wallClockAfter(wall, wall.Year(), wall.Month(), wall.Day(), w.End.Hour).Add(time.Duration(w.End.Minute) * time.Minute), true
+	case end < start && onDay(wall.Weekday()) && minute >= start:
+		return wallClockAfter(wall, wall.Year(), wall.Month(), wall.Day()+1, w.End.Hour).Add(time.Duration(w.End.Minute) * time.Minute), true
+	case end < start && onDay((wall.Weekday()+6)%7) && minute < end:
+		return wallClockAfter(wall, wall.Year(), wall.Month(), wall.Day(), w.End.Hour).Add(time.Duration(w.End.Minute) * time.Minute), true
+	}
+	return time.Time{}, false
+}
+
+// Exclude keeps the schedule from firing within the given windows, by turning them into
+// blackouts on the wall clock of the schedule's time zone. A recurring window in another time
+// zone is moved by the difference between the two zones at now, so it has to be excluded
+// again once either zone changes to or from DST. One-off windows that are over by now are
+// left out.
+func (s *Schedule) Exclude(windows []Window, now time.Time) error {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return fmt.Errorf("unknown time zone '%s'", s.TimeZone)
+	}
+	s.location = loc
+	s.Blackouts = nil
+	for _, w := range windows {
+		if !w.Recurring() {
+			if w.To.After(now) {
+				s.Blackouts = append(s.Blackouts, datedBlackouts(w.From.In(loc), w.To.In(loc))...)
+			}
+			continue
+		}
+		shift := 0
+		if w.TimeZone != "" && w.TimeZone != s.TimeZone {
+			windowLoc, err := time.LoadLocation(w.TimeZone)
+			if err != nil {
+				return fmt.Errorf("unknown time zone '%s'", w.TimeZone)
+			}
+			_, scheduleOffset := now.In(loc).Zone()
+			_, windowOffset := now.In(windowLoc).Zone()
+			shift = (scheduleOffset - windowOffset) / 60
+		}
+		s.Blackouts = append(s.Blackouts, weeklyBlackouts(w, shift)...)
+	}
+	return nil
+}
+
+// weeklyBlackouts splits the occurrences of a recurring window, moved by shift minutes, into
+// blackouts of whole days of the week
+func weeklyBlackouts(w Window, shift int) []Blackout {
+	const minutesPerWeek = 7 * minutesPerDay
+	days := w.Days
+	if len(days) == 0 {
+		days = []int{0, 1, 2, 3, 4, 5, 6}
+	}
+	length := (w.End.Hour*60 + w.End.Minute) - (w.Start.Hour*60 + w.Start.Minute)
+	if length < 0 {
+		length += minutesPerDay
+	}
+
+	var blackouts []Blackout
+	for _, day := range days {
+		start := ((day*minutesPerDay+w.Start.Hour*60+w.Start.Minute+shift)%minutesPerWeek + minutesPerWeek) % minutesPerWeek
+		for remaining := length; remaining > 0; {
+			weekday, from := start/minutesPerDay, start%minutesPerDay
+			to := min(from+remaining, minutesPerDay)
+			blackouts = append(blackouts, Blackout{Weekday: weekday, From: from, To: to})
+			remaining -= to - from
+			start = (start + to - from) % minutesPerWeek
+		}
+	}
+	return blackouts
+}
+
+// datedBlackouts splits the wall-clock span from start to end into blackouts of whole dates,
+// widened to whole minutes
+func datedBlackouts(start, end time.Time) []Blackout {
+	var blackouts []Blackout
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	last := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	for ; !day.After(last); day = day.AddDate(0, 0, 1) {
+		from, to := 0, minutesPerDay
+		if day.Equal(time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)) {
+			from = start.Hour()*60 + start.Minute()
+		}
+		if day.Equal(last) {
+			to = end.Hour()*60 + end.Minute()
+			if end.Second() > 0 || end.Nanosecond() > 0 {
+				to++
+			}
+		}
+		if to > from {
+			blackouts = append(blackouts, Blackout{Date: day, From: from, To: to})
+		}
+	}
+	return blackouts
+}
+
+// BlackoutKey identifies the blackouts of a schedule, empty when it has none, so that a
+// schedule can be checked for the blackouts it was created with
+func (s *Schedule) BlackoutKey() string {
+	if len(s.Blackouts) == 0 {
+		return ""
+	}
+	h := fnv.New64a()
+	for _, b := range s.Blackouts {
+		fmt.Fprintf(h, "%s/%d/%d/%d;", b.Date.Format(time.DateOnly), b.Weekday, b.From, b.To)
+	}
+	return fmt.Sprintf("maintenance windows %016x", h.Sum64())
+}
+
+// blackedOut returns the end of the blackout t lies in, or the zero time when it lies in none
+func (s *Schedule) blackedOut(t time.Time) time.Time {
+	if len(s.Blackouts) == 0 {
+		return time.Time{}
+	}
+	wall := t.In(s.location)
+	minute := wall.Hour()*60 + wall.Minute()
+	date := time.Date(wall.Year(), wall.Month(), wall.Day(), 0, 0, 0, 0, time.UTC)
+	for _, b := range s.Blackouts {
+		if minute < b.From || minute >= b.To {
+			continue
+		}
+		if b.Date.IsZero() && b.Weekday == int(wall.Weekday()) || b.Date.Equal(date) {
+			return wallClockAfter(wall, wall.Year(), wall.Month(), wall.Day(), b.To/60).Add(time.Duration(b.To%60) * time.Minute)
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,234 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeeklyBlackouts(t *testing.T) {
+	tests := []struct {
+		name   string
+		window Window
+		shift  int
+		want   []Blackout
+	}{
+		{
+			name:   "every day",
+			window: Window{Start: ClockTime{Hour: 2}, End: ClockTime{Hour: 4}},
+			want: []Blackout{
+				{Weekday: 0, From: 120, To: 240},
+				{Weekday: 1, From: 120, To: 240},
+				{Weekday: 2, From: 120, To: 240},
+				{Weekday: 3, From: 120, To: 240},
+				{Weekday: 4, From: 120, To: 240},
+				{Weekday: 5, From: 120, To: 240},
+				{Weekday: 6, From: 120, To: 240},
+			},
+		},
+		{
+			name:   "past midnight",
+			window: Window{Days: []int{5}, Start: ClockTime{Hour: 22}, End: ClockTime{Hour: 2}},
+			want: []Blackout{
+				{Weekday: 5, From: 1320, To: 1440},
+				{Weekday: 6, From: 0, To: 120},
+			},
+		},
+		{
+			name:   "past the end of the week",
+			window: Window{Days: []int{6}, Start: ClockTime{Hour: 23, Minute: 30}, End: ClockTime{Hour: 0, Minute: 30}},
+			want: []Blackout{
+				{Weekday: 6, From: 1410, To: 1440},
+				{Weekday: 0, From: 0, To: 30},
+			},
+		},
+		{
+			name:   "shifted forward into the next day",
+			window: Window{Days: []int{1}, Start: ClockTime{Hour: 23}, End: ClockTime{Hour: 23, Minute: 45}},
+			shift:  60,
+			want:   []Blackout{{Weekday: 2, From: 0, To: 45}},
+		},
+		{
+			name:   "shifted back into the previous week",
+			window: Window{Days: []int{0}, Start: ClockTime{Hour: 0, Minute: 30}, End: ClockTime{Hour: 1, Minute: 30}},
+			shift:  -60,
+			want: []Blackout{
+				{Weekday: 6, From: 1410, To: 1440},
+				{Weekday: 0, From: 0, To: 30},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, weeklyBlackouts(tt.window, tt.shift))
+		})
+	}
+}
+
+func TestDatedBlackouts(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       []Blackout
+	}{
+		{
+			name:  "within a day",
+			start: time.Date(2025, 3, 10, 2, 0, 0, 0, time.UTC),
+			end:   time.Date(2025, 3, 10, 4, 15, 0, 0, time.UTC),
+			want:  []Blackout{{Date: day(10), From: 120, To: 255}},
+		},
+		{
+			name:  "over several days",
+			start: time.Date(2025, 3, 10, 22, 0, 0, 0, time.UTC),
+			end:   time.Date(2025, 3, 12, 1, 0, 0, 0, time.UTC),
+			want: []Blackout{
+				{Date: day(10), From: 1320, To: 1440},
+				{Date: day(11), From: 0, To: 1440},
+				{Date: day(12), From: 0, To: 60},
+			},
+		},
+		{
+			name:  "ends at midnight",
+			start: time.Date(2025, 3, 10, 22, 0, 0, 0, time.UTC),
+			end:   time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC),
+			want:  []Blackout{{Date: day(10), From: 1320, To: 1440}},
+		},
+		{
+			name:  "widened to whole minutes",
+			start: time.Date(2025, 3, 10, 2, 0, 30, 0, time.UTC),
+			end:   time.Date(2025, 3, 10, 2, 5, 1, 0, time.UTC),
+			want:  []Blackout{{Date: day(10), From: 120, To: 126}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, datedBlackouts(tt.start, tt.end))
+		})
+	}
+}
+
+func TestWindowContains(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	overnight := Window{Days: []int{5}, Start: ClockTime{Hour: 22}, End: ClockTime{Hour: 2}, TimeZone: "Europe/Berlin"}
+	oneOff := Window{From: time.Date(2025, 3, 10, 2, 0, 0, 0, time.UTC), To: time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name   string
+		window Window
+		t      time.Time
+		// end is the end of the occurrence t lies in, in UTC, empty when it lies in none
+		end string
+	}{
+		{
+			name:   "evening of the window's day",
+			window: overnight,
+			t:      time.Date(2025, 3, 14, 23, 0, 0, 0, berlin),
+			end:    "2025-03-15 01:00:00",
+		},
+		{
+			name:   "past midnight after the window's day",
+			window: overnight,
+			t:      time.Date(2025, 3, 15, 1, 59, 0, 0, berlin),
+			end:    "2025-03-15 01:00:00",
+		},
+		{
+			name:   "wall clock of the window's time zone",
+			window: overnight,
+			t:      time.Date(2025, 3, 14, 21, 30, 0, 0, time.UTC),
+			end:    "2025-03-15 01:00:00",
+		},
+		{
+			name:   "before the start",
+			window: overnight,
+			t:      time.Date(2025, 3, 14, 21, 59, 0, 0, berlin),
+		},
+		{
+			name:   "past midnight after another day",
+			window: overnight,
+			t:      time.Date(2025, 3, 14, 1, 0, 0, 0, berlin),
+		},
+		{
+			name:   "end of the window",
+			window: overnight,
+			t:      time.Date(2025, 3, 15, 2, 0, 0, 0, berlin),
+		},
+		{
+			name:   "within a one-off window",
+			window: oneOff,
+			t:      time.Date(2025, 3, 10, 2, 0, 0, 0, time.UTC),
+			end:    "2025-03-10 04:00:00",
+		},
+		{
+			name:   "end of a one-off window",
+			window: oneOff,
+			t:      time.Date(2025, 3, 10, 4, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, ok := tt.window.Contains(tt.t)
+			require.Equal(t, tt.end != "", ok)
+			if ok {
+				require.Equal(t, tt.end, end.UTC().Format(time.DateTime))
+			}
+		})
+	}
+}
+
+func TestScheduleExclude(t *testing.T) {
+	// 02:00 to 04:00 in Berlin is 01:00 to 03:00 UTC in winter and 00:00 to 02:00 in summer
+	nightly := Window{Start: ClockTime{Hour: 2}, End: ClockTime{Hour: 4}, TimeZone: "Europe/Berlin"}
+	tests := []struct {
+		name    string
+		windows []Window
+		now     time.Time
+		from    time.Time
+		want    string
+	}{
+		{
+			name:    "window in another zone in winter",
+			windows: []Window{nightly},
+			now:     time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC),
+			from:    time.Date(2025, 1, 11, 0, 30, 0, 0, time.UTC),
+			want:    "2025-01-11 03:00:00",
+		},
+		{
+			name:    "window in another zone in summer",
+			windows: []Window{nightly},
+			now:     time.Date(2025, 7, 10, 12, 0, 0, 0, time.UTC),
+			from:    time.Date(2025, 7, 10, 23, 30, 0, 0, time.UTC),
+			want:    "2025-07-11 02:00:00",
+		},
+		{
+			name: "one-off window",
+			windows: []Window{{
+				From: time.Date(2025, 1, 11, 0, 30, 0, 0, time.UTC),
+				To:   time.Date(2025, 1, 11, 2, 10, 0, 0, time.UTC),
+			}},
+			now:  time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC),
+			from: time.Date(2025, 1, 11, 0, 30, 0, 0, time.UTC),
+			want: "2025-01-11 03:00:00",
+		},
+		{
+			name: "one-off window that is over",
+			windows: []Window{{
+				From: time.Date(2025, 1, 11, 0, 30, 0, 0, time.UTC),
+				To:   time.Date(2025, 1, 11, 2, 10, 0, 0, time.UTC),
+			}},
+			now:  time.Date(2025, 1, 12, 12, 0, 0, 0, time.UTC),
+			from: time.Date(2025, 1, 11, 0, 30, 0, 0, time.UTC),
+			want: "2025-01-11 01:00:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseFrequency("every 1 hours", "UTC")
+			require.NoError(t, err)
+			require.NoError(t, schedule.Exclude(tt.windows, tt.now))
+			require.Equal(t, tt.want, schedule.Next(tt.from).UTC().Format(time.DateTime))
+		})
+	}
+}
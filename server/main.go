@@ -16,6 +16,8 @@
 // @tag.description Destination configuration endpoints
 // @tag.name Project Settings
 // @tag.description Project configuration endpoints
+// @tag.name Spec
+// @tag.description Declarative configuration endpoints
 // @tag.name Platform
 // @tag.description Platform-level operations
 // @tag.name Users
@@ -30,6 +32,9 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	// schedule time zones are validated against the embedded zone database, the runtime
+	// image ships without one
+	_ "time/tzdata"
 
 	"github.com/datazip-inc/olake-ui/server/internal/appconfig"
 	"github.com/datazip-inc/olake-ui/server/internal/constants"
@@ -76,6 +81,37 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	if cfg.ProvisioningDir != "" {
+		// users are created after startup, so the specs are applied in the background
+		go func() {
+			if err := appSvc.ETL().ProvisionFromDir(ctx, cfg.ProvisioningDir, cfg.ProvisioningUser, cfg.ProvisioningAllowDestructive); err != nil {
+				logger.Errorf("Failed to apply provisioning specs from %s: %s", cfg.ProvisioningDir, err)
+			}
+		}()
+	}
+
+	go appSvc.ETL().ReportDeprecatedFrequencies()
+
+	if cfg.ScheduleDispatchInterval > 0 {
+		go appSvc.ETL().RunScheduleDispatcher(ctx, cfg.ScheduleDispatchInterval)
+	}
+
+	if cfg.TrashRetention > 0 {
+		go appSvc.ETL().RunTrashPurger(ctx, cfg.TrashRetention)
+	}
+
+	if cfg.ReconcileInterval > 0 {
+		go appSvc.ETL().RunReconciler(ctx, cfg.ReconcileInterval, cfg.ReconcileDryRun)
+	}
+
+	if cfg.DependencyWatchInterval > 0 {
+		go appSvc.ETL().RunDependencyWatcher(ctx, cfg.DependencyWatchInterval)
+	}
+
+	if cfg.ConcurrencyCheckInterval > 0 {
+		go appSvc.ETL().RunConcurrencyController(ctx, cfg.ConcurrencyCheckInterval)
+	}
+
 	api := handlers.NewHandler(appSvc, &cfg, db)
 	server := httpserver.New(&cfg, api)
 
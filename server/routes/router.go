@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"github.com/datazip-inc/olake-ui/server/internal/constants"
 	"github.com/datazip-inc/olake-ui/server/internal/handlers"
 	"github.com/gin-gonic/gin"
 )
@@ -35,6 +36,10 @@ func RegisterRoutes(engine *gin.Engine, h *handlers.Handler) {
 	etl.POST("/project/:projectid/sources/streams", etlHandler.GetSourceCatalog)
 	etl.GET("/project/:projectid/sources/versions", etlHandler.GetSourceVersions)
 	etl.POST("/project/:projectid/sources/spec", etlHandler.GetSourceSpec)
+	etl.GET("/project/:projectid/sources/:id/labels", etlHandler.GetLabels(constants.SourceTable))
+	etl.PUT("/project/:projectid/sources/:id/labels", etlHandler.ReplaceLabels(constants.SourceTable))
+	etl.PATCH("/project/:projectid/sources/:id/labels", etlHandler.PatchLabels(constants.SourceTable))
+	etl.DELETE("/project/:projectid/sources/:id/labels/:key", etlHandler.DeleteLabel(constants.SourceTable))
 
 	// destinations routes
 	etl.GET("/project/:projectid/destinations", etlHandler.ListDestinations)
@@ -45,36 +50,98 @@ func RegisterRoutes(engine *gin.Engine, h *handlers.Handler) {
 	etl.POST("/project/:projectid/destinations/test", etlHandler.TestDestinationConnection)
 	etl.GET("/project/:projectid/destinations/versions", etlHandler.GetDestinationVersions)
 	etl.POST("/project/:projectid/destinations/spec", etlHandler.GetDestinationSpec)
+	etl.GET("/project/:projectid/destinations/:id/labels", etlHandler.GetLabels(constants.DestinationTable))
+	etl.PUT("/project/:projectid/destinations/:id/labels", etlHandler.ReplaceLabels(constants.DestinationTable))
+	etl.PATCH("/project/:projectid/destinations/:id/labels", etlHandler.PatchLabels(constants.DestinationTable))
+	etl.DELETE("/project/:projectid/destinations/:id/labels/:key", etlHandler.DeleteLabel(constants.DestinationTable))
 
 	// jobs routes
 	etl.GET("/project/:projectid/jobs", etlHandler.ListJobs)
 	etl.POST("/project/:projectid/jobs", etlHandler.CreateJob)
+	etl.POST("/project/:projectid/jobs/bulk", etlHandler.BulkJobOperation)
+	etl.POST("/project/:projectid/jobs/backfill", etlHandler.BackfillJobs)
+	etl.GET("/project/:projectid/jobs/export", etlHandler.ExportJobs)
+	etl.GET("/project/:projectid/jobs/dependencies", etlHandler.GetDependencyGraph)
+	etl.POST("/project/:projectid/jobs/schedule-preview", etlHandler.PreviewSchedule)
+	etl.GET("/project/:projectid/jobs/calendar", etlHandler.GetRunCalendar)
+	etl.POST("/project/:projectid/jobs/import", etlHandler.ImportJobs)
 	etl.GET("/project/:projectid/jobs/:id", etlHandler.GetJob)
 	etl.PUT("/project/:projectid/jobs/:id", etlHandler.UpdateJob)
 	etl.DELETE("/project/:projectid/jobs/:id", etlHandler.DeleteJob)
 	etl.POST("/project/:projectid/jobs/:id/sync", etlHandler.SyncJob)
+	etl.POST("/project/:projectid/jobs/:id/backfill", etlHandler.BackfillJob)
 	etl.POST("/project/:projectid/jobs/:id/activate", etlHandler.ActivateJob)
+	etl.POST("/project/:projectid/jobs/:id/clone", etlHandler.CloneJob)
+	etl.POST("/project/:projectid/jobs/:id/template", etlHandler.CreateJobsFromTemplate)
 	etl.GET("/project/:projectid/jobs/:id/tasks", etlHandler.GetJobTasks)
 	etl.GET("/project/:projectid/jobs/:id/cancel", etlHandler.CancelJobRun)
 	etl.POST("/project/:projectid/jobs/:id/tasks/:taskid/logs", etlHandler.GetTaskLogs)
 	etl.GET("/project/:projectid/jobs/:id/logs/download", etlHandler.DownloadTaskLogs)
 	etl.POST("/project/:projectid/jobs/:id/clear-destination", etlHandler.ClearDestination)
 	etl.GET("/project/:projectid/jobs/:id/clear-destination", etlHandler.GetClearDestinationStatus)
+	etl.GET("/project/:projectid/jobs/:id/schedule-operations", etlHandler.ListScheduleOperations)
+	etl.POST("/project/:projectid/jobs/:id/schedule-operations/retry", etlHandler.RetryScheduleOperations)
 	etl.POST("/project/:projectid/jobs/:id/stream-difference", etlHandler.GetStreamDifference)
+	etl.GET("/project/:projectid/jobs/:id/revisions", etlHandler.ListJobRevisions)
+	etl.GET("/project/:projectid/jobs/:id/revisions/diff", etlHandler.DiffJobRevisions)
+	etl.POST("/project/:projectid/jobs/:id/revisions/:revision/rollback", etlHandler.RollbackJob)
+	etl.GET("/project/:projectid/jobs/:id/state", etlHandler.GetJobState)
+	etl.GET("/project/:projectid/jobs/:id/state/history", etlHandler.ListJobStateHistory)
+	etl.POST("/project/:projectid/jobs/:id/state/history/:snapshotid/restore", etlHandler.RestoreJobState)
+	etl.POST("/project/:projectid/jobs/:id/resync", etlHandler.ResyncStreams)
+	etl.GET("/project/:projectid/jobs/:id/runs/:runid", etlHandler.GetJobRun)
+	etl.POST("/project/:projectid/jobs/:id/trigger-tokens", etlHandler.CreateTriggerToken)
+	etl.GET("/project/:projectid/jobs/:id/trigger-tokens", etlHandler.ListTriggerTokens)
+	etl.DELETE("/project/:projectid/jobs/:id/trigger-tokens/:tokenid", etlHandler.DeleteTriggerToken)
+	etl.GET("/project/:projectid/jobs/:id/dependencies", etlHandler.GetJobDependencies)
+	etl.PUT("/project/:projectid/jobs/:id/dependencies", etlHandler.SetJobDependencies)
+	etl.GET("/project/:projectid/jobs/:id/schedule-preview", etlHandler.GetJobSchedulePreview)
+	etl.GET("/project/:projectid/jobs/:id/labels", etlHandler.GetLabels(constants.JobTable))
+	etl.PUT("/project/:projectid/jobs/:id/labels", etlHandler.ReplaceLabels(constants.JobTable))
+	etl.PATCH("/project/:projectid/jobs/:id/labels", etlHandler.PatchLabels(constants.JobTable))
+	etl.DELETE("/project/:projectid/jobs/:id/labels/:key", etlHandler.DeleteLabel(constants.JobTable))
+
+	// trash routes
+	etl.GET("/project/:projectid/trash", etlHandler.ListTrash)
+	etl.POST("/project/:projectid/trash/:kind/:id/restore", etlHandler.RestoreFromTrash)
+	etl.DELETE("/project/:projectid/trash/:kind/:id", etlHandler.PurgeFromTrash)
 
 	// Project settings routes
 	etl.PUT("/project/:projectid/settings", etlHandler.UpsertProjectSettings)
 	etl.GET("/project/:projectid/settings", etlHandler.GetProjectSettings)
 
+	// maintenance window routes
+	etl.GET("/project/:projectid/maintenance-windows", etlHandler.ListMaintenanceWindows)
+	etl.POST("/project/:projectid/maintenance-windows", etlHandler.CreateMaintenanceWindow)
+	etl.PUT("/project/:projectid/maintenance-windows/:id", etlHandler.UpdateMaintenanceWindow)
+	etl.DELETE("/project/:projectid/maintenance-windows/:id", etlHandler.DeleteMaintenanceWindow)
+
+	// concurrency routes
+	etl.GET("/project/:projectid/concurrency", etlHandler.GetConcurrency)
+	etl.PUT("/project/:projectid/concurrency", etlHandler.SetConcurrencyLimits)
+	etl.DELETE("/project/:projectid/concurrency/queue/:id", etlHandler.DeleteRunQueueEntry)
+
+	// declarative spec routes
+	etl.POST("/project/:projectid/spec/plan", etlHandler.PlanSpec)
+	etl.POST("/project/:projectid/spec/apply", etlHandler.ApplySpec)
+
 	// validation routes
 	etl.POST("/project/:projectid/check-unique", etlHandler.CheckUniqueName)
 
 	// platform routes
 	etl.GET("/platform/releases", etlHandler.GetReleaseUpdates)
+	etl.POST("/platform/reconcile", etlHandler.ReconcileSchedules)
+	etl.GET("/platform/worker-pools", etlHandler.ListWorkerPools)
 
 	// module gate routes
 	etl.GET("/platform/opt/status", h.GetOptimizationStatus)
 
+	// trigger routes, authenticated with a trigger token of the job instead of a session
+	trigger := engine.Group("/trigger/v1")
+	trigger.Use(etlHandler.TriggerTokenAuth())
+	trigger.POST("/project/:projectid/jobs/:id/sync", etlHandler.TriggerSync)
+	trigger.GET("/project/:projectid/jobs/:id/runs/:runid", etlHandler.GetJobRun)
+
 	// internal routes
 	engine.POST("/internal/worker/callback/sync-telemetry", etlHandler.UpdateSyncTelemetry)
 	engine.POST("/internal/project/:projectid/jobs/:id/clear-destination/recover", etlHandler.RecoverClearDestination)
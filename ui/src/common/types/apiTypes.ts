@@ -4,6 +4,13 @@ export interface APIResponse<T> {
 	data: T
 }
 
+export interface PaginatedResponse<T> {
+	items: T[]
+	total: number
+	limit: number
+	next_cursor?: string
+}
+
 export interface SpecResponse {
 	spec?: {
 		jsonschema: object
@@ -2,10 +2,12 @@ export interface SystemSettings {
 	id: number
 	project_id: string
 	webhook_alert_url: string
+	default_time_zone?: string
 }
 
 export interface UpdateSystemSettingsRequest {
 	id?: number
 	project_id: string
 	webhook_alert_url: string
+	default_time_zone?: string
 }
@@ -1,6 +1,10 @@
 import { AxiosError } from "axios"
 
-import { SpecResponse, TestConnectionResponse } from "@/common/types"
+import {
+	PaginatedResponse,
+	SpecResponse,
+	TestConnectionResponse,
+} from "@/common/types"
 import { API_CONFIG } from "@/config"
 import { trackTestConnection } from "@/core/analytics/analyticsUtils"
 import { api } from "@/core/api"
@@ -17,11 +21,11 @@ import {
 export const destinationService = {
 	getDestinations: async () => {
 		try {
-			const response = await api.get<Entity[]>(
+			const response = await api.get<PaginatedResponse<Entity>>(
 				API_CONFIG.ENDPOINTS.ETL.DESTINATIONS(API_CONFIG.PROJECT_ID),
 				{ timeout: 0 }, // Disable timeout for this request since it can take longer
 			)
-			const destinations: Entity[] = response.data.map(item => {
+			const destinations: Entity[] = response.data.items.map(item => {
 				const config = JSON.parse(item.config)
 				return {
 					...item,
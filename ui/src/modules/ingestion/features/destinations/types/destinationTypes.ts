@@ -16,6 +16,7 @@ export interface Destination {
 	type: string
 	version: string
 	config: string | DestinationConfig
+	labels?: Record<string, string>
 }
 
 export interface ExtendedDestination extends Destination {
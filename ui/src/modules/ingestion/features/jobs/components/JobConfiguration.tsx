@@ -6,13 +6,14 @@ import { useEffect, useState } from "react"
 import { validateAlphanumericUnderscore } from "@/common/utils"
 import StepTitle from "@/modules/ingestion/common/components/StepTitle"
 
-import { DAYS, FREQUENCY_OPTIONS } from "../constants"
+import { DAYS, FREQUENCY_OPTIONS, UNSCHEDULED_FREQUENCIES } from "../constants"
 import { useJobConfigurationStore } from "../stores"
 import { JobConfigurationProps } from "../types"
 import {
 	generateCronExpression,
 	parseCronExpression,
 	isValidCronExpression,
+	isUnscheduledFrequency,
 } from "../utils"
 import AdvancedSettingsCard from "./AdvancedSettingsCard"
 import JobSourceDestinationSelection from "./JobSourceDestinationSelection"
@@ -279,6 +280,11 @@ const JobConfiguration: React.FC<JobConfigurationProps> = ({
 						</div>
 					</div>
 				)}
+				{isUnscheduledFrequency(frequency) && (
+					<span className="text-sm text-gray-600">
+						{UNSCHEDULED_FREQUENCIES[frequency]}
+					</span>
+				)}
 			</div>
 
 			{/* Source & Destination Selection */}
@@ -57,4 +57,12 @@ export const FREQUENCY_OPTIONS = [
 	{ value: "days", label: "Every Day" },
 	{ value: "weeks", label: "Every Week" },
 	{ value: "custom", label: "Custom" },
+	{ value: "manual", label: "Manual Only" },
+	{ value: "once", label: "Run Once" },
 ]
+
+// frequencies without a cron schedule, saved as they are
+export const UNSCHEDULED_FREQUENCIES: Record<string, string> = {
+	manual: "Runs only when triggered manually",
+	once: "Runs once right after saving, then pauses itself",
+}
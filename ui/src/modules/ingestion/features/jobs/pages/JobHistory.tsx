@@ -18,7 +18,7 @@ import { getStatusIcon } from "@/modules/ingestion/common/components/statusIcons
 import { getConnectorImage } from "@/modules/ingestion/common/utils"
 
 import { useJobDetails, useJobTasks } from "../hooks"
-import { JobType } from "../types"
+import { JobTask, JobType } from "../types"
 import { getJobTypeClass, getJobTypeLabel } from "../utils"
 
 interface JobHistoryNavState {
@@ -134,14 +134,17 @@ const JobHistory: React.FC = () => {
 			title: "Job Type",
 			dataIndex: "job_type",
 			key: "job_type",
-			render: (job_type: JobType) => (
+			render: (job_type: JobType, record: JobTask) => (
 				<div
 					className={clsx(
 						"flex w-fit items-center justify-center gap-1 rounded-md px-4 py-1",
 						getJobTypeClass(job_type),
 					)}
 				>
-					<span>{getJobTypeLabel(job_type)}</span>
+					<span>
+						{getJobTypeLabel(job_type)}
+						{record.ad_hoc && " (ad-hoc)"}
+					</span>
 				</div>
 			),
 		},
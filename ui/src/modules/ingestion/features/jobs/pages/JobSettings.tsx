@@ -17,7 +17,7 @@ import {
 	StreamEditDisabledModal,
 	AdvancedSettingsCard,
 } from "../components"
-import { DAYS, FREQUENCY_OPTIONS } from "../constants"
+import { DAYS, FREQUENCY_OPTIONS, UNSCHEDULED_FREQUENCIES } from "../constants"
 import {
 	useClearDestinationStatus,
 	useJobDetails,
@@ -29,6 +29,7 @@ import {
 	validateCronExpression,
 	isValidCronExpression,
 	generateCronExpression,
+	isUnscheduledFrequency,
 } from "../utils"
 
 const JobSettings: React.FC = () => {
@@ -446,6 +447,11 @@ const JobSettings: React.FC = () => {
 												</div>
 											</div>
 										)}
+										{isUnscheduledFrequency(frequency) && (
+											<div className="mt-4 text-sm text-gray-600">
+												{UNSCHEDULED_FREQUENCIES[frequency]}
+											</div>
+										)}
 									</div>
 								</div>
 								<AdvancedSettingsCard />
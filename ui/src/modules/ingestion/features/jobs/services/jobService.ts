@@ -1,3 +1,4 @@
+import { PaginatedResponse } from "@/common/types"
 import { API_CONFIG } from "@/config/apiConfig"
 import { api } from "@/core/api"
 import { StreamsDataStructure } from "@/modules/ingestion/common/types"
@@ -15,12 +16,12 @@ import {
 export const jobService = {
 	getJobs: async (showNotification?: boolean): Promise<Job[]> => {
 		try {
-			const response = await api.get<Job[]>(
+			const response = await api.get<PaginatedResponse<Job>>(
 				API_CONFIG.ENDPOINTS.ETL.JOBS(API_CONFIG.PROJECT_ID),
 				{ timeout: 0, showNotification: showNotification },
 			)
 
-			const jobs = response.data.map(job => ({
+			const jobs = response.data.items.map(job => ({
 				...job,
 				destination: {
 					...job.destination,
@@ -124,11 +125,11 @@ export const jobService = {
 
 	getJobTasks: async (id: string): Promise<JobTask[]> => {
 		try {
-			const response = await api.get<JobTask[]>(
+			const response = await api.get<PaginatedResponse<JobTask>>(
 				`${API_CONFIG.ENDPOINTS.ETL.JOBS(API_CONFIG.PROJECT_ID)}/${id}/tasks`,
 				{ timeout: 0, showNotification: true }, // Disable timeout for this request, no toast for fetching tasks
 			)
-			return response.data
+			return response.data.items
 		} catch (error) {
 			console.error("Error fetching job tasks:", error)
 			throw error
@@ -19,6 +19,9 @@ export interface Job {
 	}
 	streams_config: string
 	frequency: string
+	schedule_mode?: "cron" | "interval" | "calendar" | "manual" | "once"
+	time_zone?: string
+	effective_time_zone?: string
 	last_run_type: JobType
 	last_run_state: string
 	last_run_time: string
@@ -28,6 +31,30 @@ export interface Job {
 	updated_by: string
 	activate: boolean
 	advanced_settings?: AdvancedSettings | null
+	execution_policy?: ExecutionPolicy
+	worker_pool?: string
+	task_queue?: string
+	driver_resources?: DriverResources
+	labels?: Record<string, string>
+	maintenance_windows?: MaintenanceWindow[]
+	trigger_deferred_until?: string
+}
+export interface MaintenanceWindow {
+	id: number
+	name: string
+	scope: "project" | "source" | "job"
+	source_id?: number
+	job_id?: number
+	recurring: boolean
+	days?: string
+	start_time?: string
+	end_time?: string
+	time_zone?: string
+	starts_at?: string
+	ends_at?: string
+	active_until?: string
+	created_by?: string
+	created_at: string
 }
 export interface JobBase {
 	name: string
@@ -46,6 +73,7 @@ export interface JobBase {
 		config: string
 	}
 	frequency: string
+	time_zone?: string
 	streams_config: string
 	difference_streams?: string
 	activate?: boolean
@@ -57,6 +85,7 @@ export interface JobTask {
 	status: string
 	file_path: string
 	job_type: JobType
+	ad_hoc?: boolean
 }
 
 export interface TaskLogsResponse {
@@ -120,8 +149,113 @@ export interface JobTableProps {
 	onDelete: (id: string) => void
 	onCancelJob: (id: string) => void
 }
+export type BackfillMode = "catch_up" | "per_window"
+export interface BackfillRequest {
+	from: string
+	to: string
+	mode?: BackfillMode
+	job_ids?: number[]
+	selector?: string
+	dry_run?: boolean
+	override_maintenance_window?: boolean
+}
+export interface BackfillJobResult {
+	job_id: number
+	job_name?: string
+	missed_windows: string[]
+	enqueued: string[]
+	skipped?: string
+	error?: string
+}
+export interface BackfillResponse {
+	from: string
+	to: string
+	mode: BackfillMode
+	dry_run: boolean
+	missed: number
+	enqueued: number
+	jobs: BackfillJobResult[]
+}
+export type ConcurrencyScope = "project" | "source" | "destination"
+export interface ConcurrencyUsage {
+	id?: number
+	name?: string
+	max_concurrent_syncs: number
+	running_syncs: number
+	queued_syncs: number
+	max_concurrent_discovers: number
+	running_discovers: number
+	queued_discovers: number
+}
+export interface RunQueueItem {
+	id: number
+	kind: "sync" | "discover"
+	status: "queued" | "running"
+	job_id?: number
+	job_name?: string
+	source_id?: number
+	destination_id?: number
+	reason?: string
+	queued_at: string
+	position?: number
+}
+export interface ConcurrencyResponse {
+	project: ConcurrencyUsage
+	sources: ConcurrencyUsage[]
+	destinations: ConcurrencyUsage[]
+	queue: RunQueueItem[]
+}
+export interface ConcurrencyLimitRequest {
+	scope: ConcurrencyScope
+	id?: number
+	max_concurrent_syncs: number
+	max_concurrent_discovers: number
+}
+export interface WorkerPool {
+	name: string
+	task_queue: string
+	default: boolean
+	pollers: number
+	error?: string
+}
 export interface AdvancedSettings {
 	max_discover_threads?: number | null
+	run_timeout?: string
+	retry?: RetryPolicy | null
+	overlap_policy?: OverlapPolicy
+	catchup_window?: string
+	worker_pool?: string
+	resources?: DriverResources | null
+	env?: DriverEnvVar[]
+}
+export interface ResourceQuantities {
+	cpu?: string
+	memory?: string
+}
+export interface DriverResources {
+	requests?: ResourceQuantities | null
+	limits?: ResourceQuantities | null
+}
+export interface DriverEnvVar {
+	name: string
+	value?: string
+	secret?: boolean
+}
+export type OverlapPolicy = "skip" | "buffer_one" | "cancel_other"
+export interface RetryPolicy {
+	max_attempts: number
+	initial_interval?: string
+	backoff_coefficient?: number
+	max_interval?: string
+}
+export interface ExecutionPolicy {
+	run_timeout: string
+	max_attempts: number
+	initial_interval: string
+	backoff_coefficient: number
+	max_interval: string
+	overlap_policy: OverlapPolicy
+	catchup_window: string
 }
 
 export interface JobConfigurationProps {
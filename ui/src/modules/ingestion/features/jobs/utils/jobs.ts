@@ -4,7 +4,7 @@ import { Cron } from "croner"
 import { getConnectorInLowerCase } from "@/modules/ingestion/common/utils"
 import { CronParseResult } from "@/modules/ingestion/features/jobs/types"
 
-import { DAYS_MAP } from "../constants"
+import { DAYS_MAP, UNSCHEDULED_FREQUENCIES } from "../constants"
 import { JobType } from "../types"
 
 export const buildConnectorPayload = (
@@ -59,6 +59,10 @@ export const getDayNumber = (day: string): number => {
 	return DAYS_MAP[day as keyof typeof DAYS_MAP]
 }
 
+// manual and once jobs have no cron schedule
+export const isUnscheduledFrequency = (frequency: string): boolean =>
+	Object.prototype.hasOwnProperty.call(UNSCHEDULED_FREQUENCIES, frequency)
+
 export const generateCronExpression = (
 	frequency: string,
 	time: string,
@@ -87,6 +91,10 @@ export const generateCronExpression = (
 			const dayNumber = getDayNumber(day)
 			cronExp = `0 ${hour} * * ${dayNumber}` // Every week on specified day at specified hour
 			break
+		case "manual":
+		case "once":
+			cronExp = frequency // no cron schedule
+			break
 		default:
 			cronExp = "* * * * *" // Default to every minute if no frequency specified
 	}
@@ -103,16 +111,27 @@ export const generateCronExpression = (
 const QUARTZ_TOKEN_REGEX = /\?|(?<![A-Za-z])L(?![A-Za-z])|\dW|#/
 
 // Returns null if the cron expression is valid, or an error string if error.
+// Fields are read as the server and Temporal read them: 5 fields are minute, hour,
+// day of month, month and day of week, 6 fields add a year and 7 fields add a
+// second before and a year after.
 export const isValidCronExpression = (cron: string): string | null => {
-	const parts = cron.trim().split(" ")
-	if (parts.length !== 5 && parts.length !== 7)
-		return `Cron expression must have 5 or 7 fields, but got ${parts.length}`
+	const parts = cron.trim().split(/\s+/)
+	if (parts.length < 5 || parts.length > 7)
+		return `Cron expression must have 5, 6 or 7 fields, but got ${parts.length}`
 
 	if (QUARTZ_TOKEN_REGEX.test(cron))
 		return "Quartz-specific tokens (?, L, W, #) are not supported."
 
+	// Temporal requires both day fields to match, unlike standard cron, so the
+	// server refuses expressions that restrict both
+	const [dayOfMonth, dayOfWeek] =
+		parts.length === 7 ? [parts[3], parts[5]] : [parts[2], parts[4]]
+	if (dayOfMonth !== "*" && dayOfWeek !== "*")
+		return "Restrict only one of day of month and day of week."
+
 	try {
-		new Cron(cron)
+		// croner reads 6 fields as a leading second, so the second is made explicit
+		new Cron(parts.length === 6 ? `0 ${parts.join(" ")}` : parts.join(" "))
 		return null
 	} catch (error) {
 		return error instanceof Error ? error.message : "Invalid cron expression"
@@ -123,6 +142,9 @@ export const parseCronExpression = (
 	cronExpression: string,
 	DAYS: string[],
 ): CronParseResult => {
+	if (isUnscheduledFrequency(cronExpression)) {
+		return { frequency: cronExpression }
+	}
 	try {
 		const parts = cronExpression.split(" ")
 		if (parts.length !== 5) {
@@ -225,6 +247,9 @@ export const validateCronExpression = (cronExpression: string): boolean => {
 		message.error("Cron expression is required")
 		return false
 	}
+	if (isUnscheduledFrequency(cronExpression)) {
+		return true
+	}
 	const error = isValidCronExpression(cronExpression)
 	if (error) {
 		message.error(error)
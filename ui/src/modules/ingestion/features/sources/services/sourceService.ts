@@ -1,6 +1,10 @@
 import { AxiosError } from "axios"
 
-import { SpecResponse, TestConnectionResponse } from "@/common/types"
+import {
+	PaginatedResponse,
+	SpecResponse,
+	TestConnectionResponse,
+} from "@/common/types"
 import { API_CONFIG } from "@/config"
 import { trackTestConnection } from "@/core/analytics/analyticsUtils"
 import { api } from "@/core/api"
@@ -14,12 +18,12 @@ import {
 export const sourceService = {
 	getSources: async (): Promise<Entity[]> => {
 		try {
-			const response = await api.get<Entity[]>(
+			const response = await api.get<PaginatedResponse<Entity>>(
 				API_CONFIG.ENDPOINTS.ETL.SOURCES(API_CONFIG.PROJECT_ID),
 				{ timeout: 0 }, // Disable timeout for this request since it can take longer
 			)
 
-			return response.data.map(item => ({
+			return response.data.items.map(item => ({
 				...item,
 				config: JSON.parse(item.config),
 			}))
@@ -13,6 +13,8 @@ export interface Source {
 	type: string
 	version: string
 	config?: any
+	labels?: Record<string, string>
+	worker_pool?: string
 }
 
 export interface SourceJob {
@@ -31,6 +33,7 @@ export interface SourceData {
 	type: string
 	config: Record<string, any>
 	version?: string
+	worker_pool?: string
 }
 
 export interface DiscoverSourceStreamsParams {
@@ -41,4 +44,5 @@ export interface DiscoverSourceStreamsParams {
 	job_name: string
 	job_id?: number
 	max_discover_threads?: number | null
+	worker_pool?: string
 }
@@ -1,4 +1,4 @@
-import { SpecResponse } from "@/common/types"
+import { PaginatedResponse, SpecResponse } from "@/common/types"
 import { API_CONFIG } from "@/config"
 import { api } from "@/core/api"
 
@@ -14,11 +14,11 @@ const DESTINATION_TYPE = "iceberg"
 
 export const catalogService = {
 	getIcebergDestinations: async () => {
-		const response = await api.get<DestinationEntity[]>(
+		const response = await api.get<PaginatedResponse<DestinationEntity>>(
 			API_CONFIG.ENDPOINTS.ETL.DESTINATIONS(API_CONFIG.PROJECT_ID),
 		)
 
-		return response.data.filter(
+		return response.data.items.filter(
 			item => item.type.toLowerCase() === DESTINATION_TYPE,
 		)
 	},